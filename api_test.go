@@ -28,7 +28,7 @@ func ledgerTxoIdGen(ringHash []byte, index uint8) []byte {
 }
 
 func TestPublicParameter_TransferTxGen_TransferTxVerify(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	peerNum := 2
 	seeds := make([][]byte, peerNum)
@@ -247,7 +247,7 @@ func TestPublicParameter_TransferTxGen_TransferTxVerify(t *testing.T) {
 }
 
 func TestValueKeyGen_ValueKeyVerify(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	type args struct {
 		pp   *PublicParameter
 		seed []byte
@@ -0,0 +1,59 @@
+// Command pqringctx-vectors generates the test vectors of the MLP (Multi-Level Privacy) keys, Txos, serial numbers,
+// and transactions, which are used to check the compatibility of other implementations byte-for-byte.
+//
+// All the keys and transactions are generated from fixed seeds, with the KEM KEM_MLKEM768, so that running this command twice
+// (with the same version of pqringctx) outputs the same vectors.
+// The vectors are in JSON, with the schema defined in vectors.go.
+// The vectors are checked in as testdata/vectors.json, and the tests of this package deserialize and re-verify the checked-in bytes.
+//
+// Usage:
+//
+//	go run ./cmd/pqringctx-vectors -o cmd/pqringctx-vectors/testdata/vectors.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/pqabelian/pqringctx/pqringctxapi"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"os"
+)
+
+func main() {
+	output := flag.String("o", "", "the output file (default stdout)")
+	flag.Parse()
+
+	if err := run(*output); err != nil {
+		fmt.Fprintf(os.Stderr, "pqringctx-vectors: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// vectorsKemVersion is the KEM of the vectors.
+// KEM_MLKEM768 is used, since the transactions are generated with a deterministic randomness source,
+// which the default KEM_OQS_KYBER (liboqs) does not accept for the encapsulation.
+const vectorsKemVersion = pqringctxkem.KEM_MLKEM768
+
+// newVectorsPublicParameter returns the PublicParameter of the vectors, i.e., the default one with vectorsKemVersion.
+func newVectorsPublicParameter() *pqringctxapi.PublicParameter {
+	return pqringctxapi.InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(vectorsKemVersion, nil, ""))
+}
+
+func run(output string) error {
+	pp := newVectorsPublicParameter()
+	suite, err := generateVectors(pp)
+	if err != nil {
+		return err
+	}
+
+	serializedSuite, err := marshalVectors(suite)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(serializedSuite)
+		return err
+	}
+	return os.WriteFile(output, serializedSuite, 0644)
+}
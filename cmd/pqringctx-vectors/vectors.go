@@ -0,0 +1,576 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/pqabelian/pqringctx"
+	"github.com/pqabelian/pqringctx/pqringctxapi"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"golang.org/x/crypto/sha3"
+	"io"
+)
+
+// The schema of the vectors.
+// All the byte strings are hex-encoded, and all the serializations are those of pqringctx.
+// A key is referred to by its name, and each Txo records the name of the key that owns it.
+// All the byte strings, including the keys, the Txos, and the transactions, are recorded in full,
+// so that other implementations can deserialize and verify them.
+// A transaction is given by SerializedTxWithoutWitness and SerializedTxWitness,
+// and the serialization of the transaction with witness is SerializedTxWithoutWitness || VarInt(len(SerializedTxWitness)) || SerializedTxWitness,
+// as computed by serializeTxWithWitness.
+
+// vectorSuite is the top level of the vectors.
+type vectorSuite struct {
+	Description string `json:"description"`
+	// ParameterSeed is the parameterSeedString passed to pqringctxapi.InitializePQRingCTXWithParamKem (see newVectorsPublicParameter), where empty means the default one.
+	ParameterSeed hexBytes `json:"parameterSeed"`
+	// KemVersion is the pqringctxkem.VersionKEM of the PublicParameter, i.e., pqringctxkem.KEM_MLKEM768.
+	KemVersion pqringctxkem.VersionKEM `json:"kemVersion"`
+
+	Keys        []*keyVector        `json:"keys"`
+	CoinbaseTxs []*coinbaseTxVector `json:"coinbaseTxs"`
+	TransferTxs []*transferTxVector `json:"transferTxs"`
+}
+
+// keyVector is a coin-address key and the corresponding coin-value key.
+// For CoinAddressTypePublicKeyForRingPre, the keys are generated by pqringctx.AddressKeyGen and pqringctx.ValueKeyGen.
+// For CoinAddressTypePublicKeyForRing, the keys are generated by pqringctxapi.CoinAddressKeyForPKRingGen and pqringctxapi.CoinValueKeyGen.
+// For CoinAddressTypePublicKeyHashForSingle, the keys are generated by pqringctxapi.CoinAddressKeyForPKHSingleGen, and there is no coin-value key.
+type keyVector struct {
+	Name            string                       `json:"name"`
+	CoinAddressType pqringctxapi.CoinAddressType `json:"coinAddressType"`
+
+	AddressKeySeed              hexBytes `json:"addressKeySeed,omitempty"`
+	CoinSpendKeyRandSeed        hexBytes `json:"coinSpendKeyRandSeed,omitempty"`
+	CoinSerialNumberKeyRandSeed hexBytes `json:"coinSerialNumberKeyRandSeed,omitempty"`
+	CoinDetectorKey             hexBytes `json:"coinDetectorKey,omitempty"`
+	PublicRand                  hexBytes `json:"publicRand,omitempty"`
+	CoinValueKeyRandSeed        hexBytes `json:"coinValueKeyRandSeed,omitempty"`
+
+	CoinAddress               hexBytes `json:"coinAddress"`
+	CoinSpendSecretKey        hexBytes `json:"coinSpendSecretKey"`
+	CoinSerialNumberSecretKey hexBytes `json:"coinSerialNumberSecretKey,omitempty"`
+	CoinValuePublicKey        hexBytes `json:"coinValuePublicKey,omitempty"`
+	CoinValueSecretKey        hexBytes `json:"coinValueSecretKey,omitempty"`
+}
+
+// txoVector is a Txo, owned by the key Owner and hosting Value.
+// If the Txo is a ring member, LgrTxoId is set, the serialized LgrTxo is SerializedTxo || LgrTxoId,
+// and SerialNumber is the serial number of the LgrTxo, computed with the owner's coinSerialNumberSecretKey.
+type txoVector struct {
+	Owner         string   `json:"owner"`
+	Value         uint64   `json:"value"`
+	SerializedTxo hexBytes `json:"serializedTxo"`
+
+	LgrTxoId     hexBytes `json:"lgrTxoId,omitempty"`
+	SerialNumber hexBytes `json:"serialNumber,omitempty"`
+}
+
+// coinbaseTxVector is a CoinbaseTxMLP, generated by pqringctxapi.CoinbaseTxGenWithRand
+// with the randomness SHAKE256(RandSeed).
+type coinbaseTxVector struct {
+	Name     string                      `json:"name"`
+	TxCase   pqringctx.TxWitnessCbTxCase `json:"txCase"`
+	RandSeed string                      `json:"randSeed"`
+	Vin      uint64                      `json:"vin"`
+	TxMemo   hexBytes                    `json:"txMemo"`
+	Txos     []*txoVector                `json:"txos"`
+
+	SerializedTxWithoutWitness hexBytes `json:"serializedTxWithoutWitness"`
+	SerializedTxWitness        hexBytes `json:"serializedTxWitness"`
+}
+
+// txInputVector is an input of a TransferTxMLP, which spends Ring[Sidx].
+// SerialNumber is the serial number in the transaction, i.e., Ring[Sidx].SerialNumber.
+type txInputVector struct {
+	Ring         []*txoVector `json:"ring"`
+	Sidx         uint8        `json:"sidx"`
+	SerialNumber hexBytes     `json:"serialNumber"`
+}
+
+// transferTxVector is a TransferTxMLP, generated by pqringctxapi.TransferTxGenWithRand
+// with the randomness SHAKE256(RandSeed).
+type transferTxVector struct {
+	Name     string                      `json:"name"`
+	TxCase   pqringctx.TxWitnessTrTxCase `json:"txCase"`
+	RandSeed string                      `json:"randSeed"`
+	Inputs   []*txInputVector            `json:"inputs"`
+	Txos     []*txoVector                `json:"txos"`
+	Fee      uint64                      `json:"fee"`
+	TxMemo   hexBytes                    `json:"txMemo"`
+
+	SerializedTxWithoutWitness hexBytes `json:"serializedTxWithoutWitness"`
+	SerializedTxWitness        hexBytes `json:"serializedTxWitness"`
+}
+
+// hexBytes is a byte slice that is hex-encoded in JSON.
+type hexBytes []byte
+
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// marshalVectors encodes the input vectorSuite in indented JSON.
+func marshalVectors(suite *vectorSuite) ([]byte, error) {
+	serializedSuite, err := json.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(serializedSuite, '\n'), nil
+}
+
+// unmarshalVectors decodes the input JSON.
+func unmarshalVectors(serializedSuite []byte) (*vectorSuite, error) {
+	suite := &vectorSuite{}
+	if err := json.Unmarshal(serializedSuite, suite); err != nil {
+		return nil, err
+	}
+	return suite, nil
+}
+
+// serializeTxWithWitness returns the serialization of a transaction with witness,
+// from the serialization without witness and the serialization of the witness.
+func serializeTxWithWitness(serializedTxWithoutWitness []byte, serializedTxWitness []byte) []byte {
+	w := bytes.NewBuffer(make([]byte, 0, len(serializedTxWithoutWitness)+9+len(serializedTxWitness)))
+	w.Write(serializedTxWithoutWitness)
+	_ = pqringctx.WriteVarInt(w, uint64(len(serializedTxWitness)))
+	w.Write(serializedTxWitness)
+	return w.Bytes()
+}
+
+// vectorSeedDomain separates the seeds of the vectors from any other use of SHAKE256.
+const vectorSeedDomain = "pqringctx-vectors/"
+
+// vectorRandReader returns the deterministic randomness source SHAKE256(vectorSeedDomain || label).
+func vectorRandReader(label string) io.Reader {
+	xof := sha3.NewShake256()
+	xof.Write([]byte(vectorSeedDomain + label))
+	return xof
+}
+
+// vectorSeed returns the first length bytes of vectorRandReader(label).
+func vectorSeed(label string, length int) []byte {
+	seed := make([]byte, length)
+	_, _ = io.ReadFull(vectorRandReader(label), seed)
+	return seed
+}
+
+// Names of the keys.
+// For each CoinAddressType, there are two keys: the one spending the coins, and the one owning the other ring members.
+const (
+	keyRingPreSpender = "ringPre-spender"
+	keyRingPreDecoy   = "ringPre-decoy"
+	keyRingSpender    = "ring-spender"
+	keyRingDecoy      = "ring-decoy"
+	keySingleSpender  = "single-spender"
+	keySingleOther    = "single-other"
+)
+
+// genKeyVector generates the keys with the input name and CoinAddressType, from the seeds derived from the name.
+func genKeyVector(pp *pqringctxapi.PublicParameter, name string, coinAddressType pqringctxapi.CoinAddressType) (*keyVector, error) {
+	kv := &keyVector{
+		Name:            name,
+		CoinAddressType: coinAddressType,
+	}
+
+	var err error
+	switch coinAddressType {
+	case pqringctxapi.CoinAddressTypePublicKeyForRingPre:
+		kv.AddressKeySeed = vectorSeed("key/"+name+"/addressKeySeed", pqringctxapi.GetParamSeedBytesLen(pp))
+		kv.CoinAddress, kv.CoinSpendSecretKey, kv.CoinSerialNumberSecretKey, err = pqringctx.AddressKeyGen(pp, kv.AddressKeySeed)
+		if err != nil {
+			return nil, err
+		}
+		kv.CoinValueKeyRandSeed = vectorSeed("key/"+name+"/coinValueKeyRandSeed", pqringctxapi.GetParamSeedBytesLen(pp))
+		kv.CoinValuePublicKey, kv.CoinValueSecretKey, err = pqringctx.ValueKeyGen(pp, kv.CoinValueKeyRandSeed)
+		if err != nil {
+			return nil, err
+		}
+
+	case pqringctxapi.CoinAddressTypePublicKeyForRing:
+		kv.CoinSpendKeyRandSeed = vectorSeed("key/"+name+"/coinSpendKeyRandSeed", pqringctxapi.GetParamSeedBytesLen(pp))
+		kv.CoinSerialNumberKeyRandSeed = vectorSeed("key/"+name+"/coinSerialNumberKeyRandSeed", pqringctxapi.GetParamSeedBytesLen(pp))
+		kv.CoinDetectorKey = vectorSeed("key/"+name+"/coinDetectorKey", pqringctxapi.GetParamMACKeyBytesLen(pp))
+		kv.PublicRand = vectorSeed("key/"+name+"/publicRand", pqringctxapi.GetParamKeyGenPublicRandBytesLen(pp))
+		kv.CoinAddress, kv.CoinSpendSecretKey, kv.CoinSerialNumberSecretKey, err = pqringctxapi.CoinAddressKeyForPKRingGen(pp,
+			kv.CoinSpendKeyRandSeed, kv.CoinSerialNumberKeyRandSeed, kv.CoinDetectorKey, kv.PublicRand)
+		if err != nil {
+			return nil, err
+		}
+		kv.CoinValueKeyRandSeed = vectorSeed("key/"+name+"/coinValueKeyRandSeed", pqringctxapi.GetParamSeedBytesLen(pp))
+		kv.CoinValuePublicKey, kv.CoinValueSecretKey, err = pqringctxapi.CoinValueKeyGen(pp, kv.CoinValueKeyRandSeed)
+		if err != nil {
+			return nil, err
+		}
+
+	case pqringctxapi.CoinAddressTypePublicKeyHashForSingle:
+		kv.CoinSpendKeyRandSeed = vectorSeed("key/"+name+"/coinSpendKeyRandSeed", pqringctxapi.GetParamSeedBytesLen(pp))
+		kv.CoinDetectorKey = vectorSeed("key/"+name+"/coinDetectorKey", pqringctxapi.GetParamMACKeyBytesLen(pp))
+		kv.PublicRand = vectorSeed("key/"+name+"/publicRand", pqringctxapi.GetParamKeyGenPublicRandBytesLen(pp))
+		kv.CoinAddress, kv.CoinSpendSecretKey, err = pqringctxapi.CoinAddressKeyForPKHSingleGen(pp,
+			kv.CoinSpendKeyRandSeed, kv.CoinDetectorKey, kv.PublicRand)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("genKeyVector: unsupported coinAddressType (%d)", coinAddressType)
+	}
+
+	return kv, nil
+}
+
+// coinSpec specifies a coin (i.e., an input or an output) of a transaction.
+type coinSpec struct {
+	key   string
+	value uint64
+}
+
+// transferTxSpec specifies a TransferTxMLP, one for each TxWitnessTrTxCase.
+// Let vPublic = (the sum of pseudonym-privacy output values) + fee - (the sum of pseudonym-privacy input values),
+// then the TxWitnessTrTxCase is determined by (the number of RingCT-privacy inputs, the number of RingCT-privacy outputs, the sign of vPublic).
+type transferTxSpec struct {
+	name    string
+	txCase  pqringctx.TxWitnessTrTxCase
+	inputs  []coinSpec
+	outputs []coinSpec
+	fee     uint64
+}
+
+// transferTxSpecs are the specifications of the TransferTxMLP vectors.
+// The RingCT-privacy inputs/outputs must be put before the pseudonym-privacy ones.
+var transferTxSpecs = []*transferTxSpec{
+	{"I0C0", pqringctx.TxWitnessTrTxCaseI0C0,
+		[]coinSpec{{keySingleSpender, 100}},
+		[]coinSpec{{keySingleOther, 90}}, 10},
+	{"I0C1", pqringctx.TxWitnessTrTxCaseI0C1,
+		[]coinSpec{{keySingleSpender, 100}},
+		[]coinSpec{{keyRingSpender, 60}, {keySingleOther, 30}}, 10},
+	{"I0Cn", pqringctx.TxWitnessTrTxCaseI0Cn,
+		[]coinSpec{{keySingleSpender, 100}},
+		[]coinSpec{{keyRingSpender, 30}, {keyRingPreSpender, 30}, {keySingleOther, 30}}, 10},
+	{"I1C0", pqringctx.TxWitnessTrTxCaseI1C0,
+		[]coinSpec{{keyRingPreSpender, 100}},
+		[]coinSpec{{keySingleOther, 90}}, 10},
+	{"I1C1Exact", pqringctx.TxWitnessTrTxCaseI1C1Exact,
+		[]coinSpec{{keyRingSpender, 100}, {keySingleSpender, 20}},
+		[]coinSpec{{keyRingPreSpender, 100}, {keySingleOther, 10}}, 10},
+	{"I1C1CAdd", pqringctx.TxWitnessTrTxCaseI1C1CAdd,
+		[]coinSpec{{keyRingSpender, 100}},
+		[]coinSpec{{keyRingSpender, 60}, {keySingleOther, 30}}, 10},
+	{"I1C1IAdd", pqringctx.TxWitnessTrTxCaseI1C1IAdd,
+		[]coinSpec{{keyRingPreSpender, 100}, {keySingleSpender, 100}},
+		[]coinSpec{{keyRingSpender, 150}, {keySingleOther, 40}}, 10},
+	{"I1CnExact", pqringctx.TxWitnessTrTxCaseI1CnExact,
+		[]coinSpec{{keyRingSpender, 100}, {keySingleSpender, 20}},
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}, {keySingleOther, 10}}, 10},
+	{"I1CnCAdd", pqringctx.TxWitnessTrTxCaseI1CnCAdd,
+		[]coinSpec{{keyRingSpender, 100}},
+		[]coinSpec{{keyRingSpender, 30}, {keyRingPreSpender, 30}, {keySingleOther, 30}}, 10},
+	{"I1CnIAdd", pqringctx.TxWitnessTrTxCaseI1CnIAdd,
+		[]coinSpec{{keyRingSpender, 100}, {keySingleSpender, 100}},
+		[]coinSpec{{keyRingSpender, 80}, {keyRingPreSpender, 70}, {keySingleOther, 40}}, 10},
+	{"ImC0", pqringctx.TxWitnessTrTxCaseImC0,
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}},
+		[]coinSpec{{keySingleOther, 90}}, 10},
+	{"ImC1Exact", pqringctx.TxWitnessTrTxCaseImC1Exact,
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}, {keySingleSpender, 20}},
+		[]coinSpec{{keyRingSpender, 100}, {keySingleOther, 10}}, 10},
+	{"ImC1CAdd", pqringctx.TxWitnessTrTxCaseImC1CAdd,
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}},
+		[]coinSpec{{keyRingSpender, 60}, {keySingleOther, 30}}, 10},
+	{"ImC1IAdd", pqringctx.TxWitnessTrTxCaseImC1IAdd,
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}, {keySingleSpender, 100}},
+		[]coinSpec{{keyRingSpender, 150}, {keySingleOther, 40}}, 10},
+	{"ImCnExact", pqringctx.TxWitnessTrTxCaseImCnExact,
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}, {keySingleSpender, 20}},
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}, {keySingleOther, 10}}, 10},
+	{"ImCnCAdd", pqringctx.TxWitnessTrTxCaseImCnCAdd,
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}},
+		[]coinSpec{{keyRingSpender, 30}, {keyRingPreSpender, 30}, {keySingleOther, 30}}, 10},
+	{"ImCnIAdd", pqringctx.TxWitnessTrTxCaseImCnIAdd,
+		[]coinSpec{{keyRingSpender, 50}, {keyRingPreSpender, 50}, {keySingleSpender, 100}},
+		[]coinSpec{{keyRingSpender, 80}, {keyRingPreSpender, 70}, {keySingleOther, 40}}, 10},
+}
+
+// coinbaseTxSpec specifies a CoinbaseTxMLP, one for each TxWitnessCbTxCase.
+// The TxWitnessCbTxCase is determined by the number of RingCT-privacy outputs.
+type coinbaseTxSpec struct {
+	name    string
+	txCase  pqringctx.TxWitnessCbTxCase
+	outputs []coinSpec
+}
+
+// coinbaseTxSpecs are the specifications of the CoinbaseTxMLP vectors.
+// The RingCT-privacy outputs must be put before the pseudonym-privacy ones.
+var coinbaseTxSpecs = []*coinbaseTxSpec{
+	{"C0", pqringctx.TxWitnessCbTxCaseC0,
+		[]coinSpec{{keySingleSpender, 100}, {keySingleOther, 20}}},
+	{"C1", pqringctx.TxWitnessCbTxCaseC1,
+		[]coinSpec{{keyRingSpender, 100}, {keySingleSpender, 20}}},
+	{"Cn", pqringctx.TxWitnessCbTxCaseCn,
+		[]coinSpec{{keyRingSpender, 100}, {keyRingPreSpender, 50}, {keySingleSpender, 20}}},
+}
+
+// decoyValue is the value of the ring members owned by the decoy keys.
+const decoyValue = 7
+
+// vectorGenerator generates the vectors, and keeps the generated keys by name.
+type vectorGenerator struct {
+	pp    *pqringctxapi.PublicParameter
+	suite *vectorSuite
+	keys  map[string]*keyVector
+}
+
+// generateVectors generates all the vectors.
+func generateVectors(pp *pqringctxapi.PublicParameter) (*vectorSuite, error) {
+	g := &vectorGenerator{
+		pp: pp,
+		suite: &vectorSuite{
+			Description: "pqringctx test vectors for the MLP keys, Txos, serial numbers, and transactions, generated by cmd/pqringctx-vectors.",
+			KemVersion:  vectorsKemVersion,
+		},
+		keys: make(map[string]*keyVector),
+	}
+
+	keySpecs := []struct {
+		name            string
+		coinAddressType pqringctxapi.CoinAddressType
+	}{
+		{keyRingPreSpender, pqringctxapi.CoinAddressTypePublicKeyForRingPre},
+		{keyRingPreDecoy, pqringctxapi.CoinAddressTypePublicKeyForRingPre},
+		{keyRingSpender, pqringctxapi.CoinAddressTypePublicKeyForRing},
+		{keyRingDecoy, pqringctxapi.CoinAddressTypePublicKeyForRing},
+		{keySingleSpender, pqringctxapi.CoinAddressTypePublicKeyHashForSingle},
+		{keySingleOther, pqringctxapi.CoinAddressTypePublicKeyHashForSingle},
+	}
+	for _, keySpec := range keySpecs {
+		kv, err := genKeyVector(pp, keySpec.name, keySpec.coinAddressType)
+		if err != nil {
+			return nil, err
+		}
+		g.suite.Keys = append(g.suite.Keys, kv)
+		g.keys[kv.Name] = kv
+	}
+
+	for _, spec := range coinbaseTxSpecs {
+		cbVector, _, err := g.genCoinbaseTx(spec.name, spec.outputs)
+		if err != nil {
+			return nil, fmt.Errorf("generateVectors: %s: %v", spec.name, err)
+		}
+		if cbVector.TxCase != spec.txCase {
+			return nil, fmt.Errorf("generateVectors: %s: the generated TxWitnessCbTxCase (%d) is not the expected one (%d)", spec.name, cbVector.TxCase, spec.txCase)
+		}
+		g.suite.CoinbaseTxs = append(g.suite.CoinbaseTxs, cbVector)
+	}
+
+	for _, spec := range transferTxSpecs {
+		trVector, err := g.genTransferTx(spec)
+		if err != nil {
+			return nil, fmt.Errorf("generateVectors: %s: %v", spec.name, err)
+		}
+		g.suite.TransferTxs = append(g.suite.TransferTxs, trVector)
+	}
+
+	return g.suite, nil
+}
+
+// decoyKeyOf returns the name of the key owning the other ring members of the coins of the input key,
+// or "" if the input key is not a RingCT-privacy one.
+func (g *vectorGenerator) decoyKeyOf(keyName string) string {
+	switch g.keys[keyName].CoinAddressType {
+	case pqringctxapi.CoinAddressTypePublicKeyForRingPre:
+		return keyRingPreDecoy
+	case pqringctxapi.CoinAddressTypePublicKeyForRing:
+		return keyRingDecoy
+	default:
+		return ""
+	}
+}
+
+func (g *vectorGenerator) newTxOutputDesc(coin coinSpec) *pqringctxapi.TxOutputDescMLP {
+	kv := g.keys[coin.key]
+	return pqringctxapi.NewTxOutputDescMLP(kv.CoinAddress, kv.CoinValuePublicKey, coin.value)
+}
+
+// genTxoVectors returns the txoVectors for the input txos, which are paid to the input coins.
+func (g *vectorGenerator) genTxoVectors(txos []pqringctxapi.TxoMLP, coins []coinSpec) ([]*txoVector, error) {
+	txoVectors := make([]*txoVector, len(txos))
+	for i, txo := range txos {
+		serializedTxo, err := pqringctxapi.SerializeTxo(g.pp, txo)
+		if err != nil {
+			return nil, err
+		}
+		txoVectors[i] = &txoVector{
+			Owner:         coins[i].key,
+			Value:         coins[i].value,
+			SerializedTxo: serializedTxo,
+		}
+	}
+	return txoVectors, nil
+}
+
+// genCoinbaseTx generates a coinbase transaction paying to the input coins, with the randomness derived from name.
+// The Txos are also made LgrTxos, with the ids derived from name, and the returned vector records their serial numbers.
+func (g *vectorGenerator) genCoinbaseTx(name string, coins []coinSpec) (*coinbaseTxVector, []*pqringctxapi.LgrTxoMLP, error) {
+	pp := g.pp
+
+	vin := uint64(0)
+	txOutputDescs := make([]*pqringctxapi.TxOutputDescMLP, len(coins))
+	for i, coin := range coins {
+		vin += coin.value
+		txOutputDescs[i] = g.newTxOutputDesc(coin)
+	}
+
+	cbVector := &coinbaseTxVector{
+		Name:     name,
+		RandSeed: vectorSeedDomain + "tx/" + name,
+		Vin:      vin,
+		TxMemo:   []byte("coinbase " + name),
+	}
+	cbTx, err := pqringctxapi.CoinbaseTxGenWithRand(pp, vectorRandReader("tx/"+name), cbVector.Vin, txOutputDescs, cbVector.TxMemo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = pqringctxapi.CoinbaseTxVerify(pp, cbTx); err != nil {
+		return nil, nil, err
+	}
+	txWitness := pqringctxapi.GetCbTxTxWitness(cbTx)
+	cbVector.TxCase = txWitness.TxCase()
+
+	txos := pqringctxapi.GetCbTxTxos(cbTx)
+	cbVector.Txos, err = g.genTxoVectors(txos, coins)
+	if err != nil {
+		return nil, nil, err
+	}
+	lgrTxos := make([]*pqringctxapi.LgrTxoMLP, len(txos))
+	for i, txo := range txos {
+		id := vectorSeed(fmt.Sprintf("lgrTxoId/%s/%d", name, i), pqringctx.HashOutputBytesLen)
+		lgrTxos[i] = pqringctxapi.NewLgrTxo(txo, id)
+		cbVector.Txos[i].LgrTxoId = id
+		cbVector.Txos[i].SerialNumber, err = pqringctxapi.LedgerTxoSerialNumberGen(pp, lgrTxos[i], g.keys[coins[i].key].CoinSerialNumberSecretKey)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	serializedTxWithoutWitness, err := pp.SerializeCoinbaseTxMLP(cbTx, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	serializedTxWitness, err := pqringctxapi.SerializeTxWitnessCbTx(pp, txWitness)
+	if err != nil {
+		return nil, nil, err
+	}
+	serializedTx, err := pp.SerializeCoinbaseTxMLP(cbTx, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	cbVector.SerializedTxWithoutWitness = serializedTxWithoutWitness
+	cbVector.SerializedTxWitness = serializedTxWitness
+	if !bytes.Equal(serializedTx, serializeTxWithWitness(serializedTxWithoutWitness, serializedTxWitness)) {
+		return nil, nil, fmt.Errorf("the serialization of the coinbase transaction with witness does not match serializeTxWithWitness")
+	}
+
+	return cbVector, lgrTxos, nil
+}
+
+// genTransferTx generates the transfer transaction specified by spec,
+// where the ring members of the inputs are the Txos of a funding coinbase transaction, which is not included in the vectors.
+func (g *vectorGenerator) genTransferTx(spec *transferTxSpec) (*transferTxVector, error) {
+	pp := g.pp
+
+	//	The ring of the first input, if it is RingCT-privacy, is (the coin, a decoy coin), and the ring of any other input is (the coin).
+	//	Note that the rings are kept small, since the size of the witness grows with the ring sizes.
+	//	As the RingCT-privacy inputs are at the first positions, the funding coins are in the order of the ring members of the inputs.
+	var fundingCoins []coinSpec
+	ringIndexes := make([][]int, len(spec.inputs))
+	for i, input := range spec.inputs {
+		ringIndexes[i] = append(ringIndexes[i], len(fundingCoins))
+		fundingCoins = append(fundingCoins, input)
+		if decoyKey := g.decoyKeyOf(input.key); decoyKey != "" && i == 0 {
+			ringIndexes[i] = append(ringIndexes[i], len(fundingCoins))
+			fundingCoins = append(fundingCoins, coinSpec{decoyKey, decoyValue})
+		}
+	}
+	fundingCbVector, fundingLgrTxos, err := g.genCoinbaseTx(spec.name+"/funding", fundingCoins)
+	if err != nil {
+		return nil, err
+	}
+
+	trVector := &transferTxVector{
+		Name:     spec.name,
+		TxCase:   spec.txCase,
+		RandSeed: vectorSeedDomain + "tx/" + spec.name,
+		Fee:      spec.fee,
+		TxMemo:   []byte("transfer " + spec.name),
+	}
+
+	//	the spent coin is the first ring member
+	txInputDescs := make([]*pqringctxapi.TxInputDescMLP, len(spec.inputs))
+	for i, input := range spec.inputs {
+		inputVector := &txInputVector{}
+		ring := make([]*pqringctxapi.LgrTxoMLP, len(ringIndexes[i]))
+		for j, idx := range ringIndexes[i] {
+			ring[j] = fundingLgrTxos[idx]
+			inputVector.Ring = append(inputVector.Ring, fundingCbVector.Txos[idx])
+		}
+		inputVector.SerialNumber = inputVector.Ring[inputVector.Sidx].SerialNumber
+		trVector.Inputs = append(trVector.Inputs, inputVector)
+
+		kv := g.keys[input.key]
+		txInputDescs[i] = pqringctxapi.NewTxInputDescMLP(ring, inputVector.Sidx, kv.CoinSpendSecretKey, kv.CoinSerialNumberSecretKey,
+			kv.CoinValuePublicKey, kv.CoinValueSecretKey, kv.CoinDetectorKey, input.value)
+	}
+	txOutputDescs := make([]*pqringctxapi.TxOutputDescMLP, len(spec.outputs))
+	for i, output := range spec.outputs {
+		txOutputDescs[i] = g.newTxOutputDesc(output)
+	}
+
+	trTx, err := pqringctxapi.TransferTxGenWithRand(pp, vectorRandReader("tx/"+spec.name), txInputDescs, txOutputDescs, trVector.Fee, trVector.TxMemo)
+	if err != nil {
+		return nil, err
+	}
+	if err = pqringctxapi.TransferTxVerify(pp, trTx); err != nil {
+		return nil, err
+	}
+	txWitness := pqringctxapi.GetTrTxWitness(trTx)
+	if txWitness.TxCase() != spec.txCase {
+		return nil, fmt.Errorf("the generated TxWitnessTrTxCase (%d) is not the expected one (%d)", txWitness.TxCase(), spec.txCase)
+	}
+	trVector.Txos, err = g.genTxoVectors(pqringctxapi.GetTrTxTxos(trTx), spec.outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedTxWithoutWitness, err := pp.SerializeTransferTxMLP(trTx, false)
+	if err != nil {
+		return nil, err
+	}
+	serializedTxWitness, err := pqringctxapi.SerializeTxWitnessTrTx(pp, txWitness)
+	if err != nil {
+		return nil, err
+	}
+	serializedTx, err := pp.SerializeTransferTxMLP(trTx, true)
+	if err != nil {
+		return nil, err
+	}
+	trVector.SerializedTxWithoutWitness = serializedTxWithoutWitness
+	trVector.SerializedTxWitness = serializedTxWitness
+	if !bytes.Equal(serializedTx, serializeTxWithWitness(serializedTxWithoutWitness, serializedTxWitness)) {
+		return nil, fmt.Errorf("the serialization of the transfer transaction with witness does not match serializeTxWithWitness")
+	}
+
+	return trVector, nil
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bytes"
+	"github.com/pqabelian/pqringctx/pqringctxapi"
+	"os"
+	"sync"
+	"testing"
+)
+
+const vectorsFile = "testdata/vectors.json"
+
+var pp = newVectorsPublicParameter()
+
+// loaded caches the checked-in vectors, which are shared by the tests.
+var loaded struct {
+	once  sync.Once
+	suite *vectorSuite
+	err   error
+}
+
+func loadVectors(t *testing.T) *vectorSuite {
+	loaded.once.Do(func() {
+		var serializedSuite []byte
+		serializedSuite, loaded.err = os.ReadFile(vectorsFile)
+		if loaded.err != nil {
+			return
+		}
+		loaded.suite, loaded.err = unmarshalVectors(serializedSuite)
+	})
+	if loaded.err != nil {
+		t.Fatalf("failed to load %s: %v", vectorsFile, loaded.err)
+	}
+	return loaded.suite
+}
+
+// generated caches the re-generated vectors, for the tests that need them.
+var generated struct {
+	once  sync.Once
+	suite *vectorSuite
+	err   error
+}
+
+func generatedVectors(t *testing.T) *vectorSuite {
+	if testing.Short() {
+		t.Skip("skipping the re-generation of the vectors in short mode")
+	}
+	generated.once.Do(func() {
+		generated.suite, generated.err = generateVectors(pp)
+	})
+	if generated.err != nil {
+		t.Fatalf("generateVectors: %v", generated.err)
+	}
+	return generated.suite
+}
+
+func checkBytes(t *testing.T, name string, got []byte, want []byte) {
+	t.Helper()
+	if bytes.Equal(got, want) {
+		return
+	}
+	//	the byte strings may be large, so only the first difference is reported
+	i := 0
+	for i < len(got) && i < len(want) && got[i] == want[i] {
+		i++
+	}
+	t.Errorf("%s: got %d bytes, want %d bytes, the first difference is at offset %d", name, len(got), len(want), i)
+}
+
+// TestVectorsKemVersion checks that the vectors are for the KEM of newVectorsPublicParameter.
+func TestVectorsKemVersion(t *testing.T) {
+	suite := loadVectors(t)
+	if suite.KemVersion != vectorsKemVersion {
+		t.Fatalf("kemVersion = %d, want %d", suite.KemVersion, vectorsKemVersion)
+	}
+}
+
+// TestVectorsKeys re-generates the keys from the seeds, and checks them against the vectors.
+func TestVectorsKeys(t *testing.T) {
+	suite := loadVectors(t)
+
+	for _, want := range suite.Keys {
+		got, err := genKeyVector(pp, want.Name, want.CoinAddressType)
+		if err != nil {
+			t.Fatalf("%s: genKeyVector: %v", want.Name, err)
+		}
+		checkBytes(t, want.Name+": addressKeySeed", got.AddressKeySeed, want.AddressKeySeed)
+		checkBytes(t, want.Name+": coinSpendKeyRandSeed", got.CoinSpendKeyRandSeed, want.CoinSpendKeyRandSeed)
+		checkBytes(t, want.Name+": coinSerialNumberKeyRandSeed", got.CoinSerialNumberKeyRandSeed, want.CoinSerialNumberKeyRandSeed)
+		checkBytes(t, want.Name+": coinDetectorKey", got.CoinDetectorKey, want.CoinDetectorKey)
+		checkBytes(t, want.Name+": publicRand", got.PublicRand, want.PublicRand)
+		checkBytes(t, want.Name+": coinValueKeyRandSeed", got.CoinValueKeyRandSeed, want.CoinValueKeyRandSeed)
+
+		checkBytes(t, want.Name+": coinAddress", got.CoinAddress, want.CoinAddress)
+		checkBytes(t, want.Name+": coinSpendSecretKey", got.CoinSpendSecretKey, want.CoinSpendSecretKey)
+		checkBytes(t, want.Name+": coinSerialNumberSecretKey", got.CoinSerialNumberSecretKey, want.CoinSerialNumberSecretKey)
+		checkBytes(t, want.Name+": coinValuePublicKey", got.CoinValuePublicKey, want.CoinValuePublicKey)
+		checkBytes(t, want.Name+": coinValueSecretKey", got.CoinValueSecretKey, want.CoinValueSecretKey)
+
+		//	the checked-in keys are parsed and verified as they are
+		coinAddressType, err := pqringctxapi.ExtractCoinAddressTypeFromCoinAddress(pp, want.CoinAddress)
+		if err != nil || coinAddressType != want.CoinAddressType {
+			t.Errorf("%s: ExtractCoinAddressTypeFromCoinAddress = (%d, %v), want %d", want.Name, coinAddressType, err, want.CoinAddressType)
+		}
+		if want.CoinValuePublicKey != nil {
+			if valid, err := pqringctxapi.CoinValueKeyVerify(pp, want.CoinValuePublicKey, want.CoinValueSecretKey); !valid {
+				t.Errorf("%s: CoinValueKeyVerify: %v", want.Name, err)
+			}
+		}
+	}
+}
+
+// TestVectorsTransactions deserializes and verifies the checked-in transactions, Txos, and LgrTxos,
+// and checks that they re-serialize to the checked-in bytes.
+func TestVectorsTransactions(t *testing.T) {
+	suite := loadVectors(t)
+
+	keys := make(map[string]*keyVector)
+	for _, kv := range suite.Keys {
+		keys[kv.Name] = kv
+	}
+
+	checkTxo := func(name string, txo pqringctxapi.TxoMLP, want *txoVector) {
+		serializedTxo, err := pqringctxapi.SerializeTxo(pp, txo)
+		if err != nil {
+			t.Fatalf("%s: SerializeTxo: %v", name, err)
+		}
+		checkBytes(t, name+": serializedTxo", serializedTxo, want.SerializedTxo)
+
+		coinAddress, err := pqringctxapi.GetCoinAddressFromTxo(pp, txo)
+		if err != nil {
+			t.Fatalf("%s: GetCoinAddressFromTxo: %v", name, err)
+		}
+		checkBytes(t, name+": coinAddress", coinAddress, keys[want.Owner].CoinAddress)
+	}
+
+	checkLgrTxo := func(name string, want *txoVector) {
+		serializedLgrTxo := append(append([]byte{}, want.SerializedTxo...), want.LgrTxoId...)
+		lgrTxo, err := pp.DeserializeLgrTxoMLP(serializedLgrTxo)
+		if err != nil {
+			t.Fatalf("%s: DeserializeLgrTxoMLP: %v", name, err)
+		}
+		checkTxo(name, lgrTxo.GetTxo(), want)
+		checkBytes(t, name+": lgrTxoId", lgrTxo.GetId(), want.LgrTxoId)
+
+		reserializedLgrTxo, err := pp.SerializeLgrTxoMLP(pqringctxapi.NewLgrTxo(lgrTxo.GetTxo(), want.LgrTxoId))
+		if err != nil {
+			t.Fatalf("%s: SerializeLgrTxoMLP: %v", name, err)
+		}
+		checkBytes(t, name+": serializedLgrTxo", reserializedLgrTxo, serializedLgrTxo)
+
+		serialNumber, err := pqringctxapi.LedgerTxoSerialNumberGen(pp, lgrTxo, keys[want.Owner].CoinSerialNumberSecretKey)
+		if err != nil {
+			t.Fatalf("%s: LedgerTxoSerialNumberGen: %v", name, err)
+		}
+		checkBytes(t, name+": serialNumber", serialNumber, want.SerialNumber)
+	}
+
+	for _, cbVector := range suite.CoinbaseTxs {
+		serializedTxWithWitness := serializeTxWithWitness(cbVector.SerializedTxWithoutWitness, cbVector.SerializedTxWitness)
+		cbTx, err := pp.DeserializeCoinbaseTxMLP(serializedTxWithWitness, true)
+		if err != nil {
+			t.Fatalf("%s: DeserializeCoinbaseTxMLP: %v", cbVector.Name, err)
+		}
+		if err = pqringctxapi.CoinbaseTxVerify(pp, cbTx); err != nil {
+			t.Errorf("%s: CoinbaseTxVerify: %v", cbVector.Name, err)
+		}
+		txWitness := pqringctxapi.GetCbTxTxWitness(cbTx)
+		if txWitness.TxCase() != cbVector.TxCase {
+			t.Errorf("%s: TxCase = %d, want %d", cbVector.Name, txWitness.TxCase(), cbVector.TxCase)
+		}
+
+		serializedTx, err := pp.SerializeCoinbaseTxMLP(cbTx, true)
+		if err != nil {
+			t.Fatalf("%s: SerializeCoinbaseTxMLP: %v", cbVector.Name, err)
+		}
+		checkBytes(t, cbVector.Name+": serializedTxWithWitness", serializedTx, serializedTxWithWitness)
+		serializedTx, err = pp.SerializeCoinbaseTxMLP(cbTx, false)
+		if err != nil {
+			t.Fatalf("%s: SerializeCoinbaseTxMLP: %v", cbVector.Name, err)
+		}
+		checkBytes(t, cbVector.Name+": serializedTxWithoutWitness", serializedTx, cbVector.SerializedTxWithoutWitness)
+		serializedTxWitness, err := pqringctxapi.SerializeTxWitnessCbTx(pp, txWitness)
+		if err != nil {
+			t.Fatalf("%s: SerializeTxWitnessCbTx: %v", cbVector.Name, err)
+		}
+		checkBytes(t, cbVector.Name+": serializedTxWitness", serializedTxWitness, cbVector.SerializedTxWitness)
+
+		txos := pqringctxapi.GetCbTxTxos(cbTx)
+		if len(txos) != len(cbVector.Txos) {
+			t.Fatalf("%s: the number of txos is %d, want %d", cbVector.Name, len(txos), len(cbVector.Txos))
+		}
+		for i, txo := range txos {
+			checkTxo(cbVector.Name, txo, cbVector.Txos[i])
+			checkLgrTxo(cbVector.Name, cbVector.Txos[i])
+		}
+	}
+
+	for _, trVector := range suite.TransferTxs {
+		serializedTxWithWitness := serializeTxWithWitness(trVector.SerializedTxWithoutWitness, trVector.SerializedTxWitness)
+		trTx, err := pp.DeserializeTransferTxMLP(serializedTxWithWitness, true)
+		if err != nil {
+			t.Fatalf("%s: DeserializeTransferTxMLP: %v", trVector.Name, err)
+		}
+		if err = pqringctxapi.TransferTxVerify(pp, trTx); err != nil {
+			t.Errorf("%s: TransferTxVerify: %v", trVector.Name, err)
+		}
+		txWitness := pqringctxapi.GetTrTxWitness(trTx)
+		if txWitness.TxCase() != trVector.TxCase {
+			t.Errorf("%s: TxCase = %d, want %d", trVector.Name, txWitness.TxCase(), trVector.TxCase)
+		}
+
+		serializedTx, err := pp.SerializeTransferTxMLP(trTx, true)
+		if err != nil {
+			t.Fatalf("%s: SerializeTransferTxMLP: %v", trVector.Name, err)
+		}
+		checkBytes(t, trVector.Name+": serializedTxWithWitness", serializedTx, serializedTxWithWitness)
+		serializedTx, err = pp.SerializeTransferTxMLP(trTx, false)
+		if err != nil {
+			t.Fatalf("%s: SerializeTransferTxMLP: %v", trVector.Name, err)
+		}
+		checkBytes(t, trVector.Name+": serializedTxWithoutWitness", serializedTx, trVector.SerializedTxWithoutWitness)
+		serializedTxWitness, err := pqringctxapi.SerializeTxWitnessTrTx(pp, txWitness)
+		if err != nil {
+			t.Fatalf("%s: SerializeTxWitnessTrTx: %v", trVector.Name, err)
+		}
+		checkBytes(t, trVector.Name+": serializedTxWitness", serializedTxWitness, trVector.SerializedTxWitness)
+
+		txos := pqringctxapi.GetTrTxTxos(trTx)
+		if len(txos) != len(trVector.Txos) {
+			t.Fatalf("%s: the number of txos is %d, want %d", trVector.Name, len(txos), len(trVector.Txos))
+		}
+		for i, txo := range txos {
+			checkTxo(trVector.Name, txo, trVector.Txos[i])
+		}
+
+		txInputs := pqringctxapi.GetTrTxTxInputs(trTx)
+		if len(txInputs) != len(trVector.Inputs) {
+			t.Fatalf("%s: the number of inputs is %d, want %d", trVector.Name, len(txInputs), len(trVector.Inputs))
+		}
+		for i, txInput := range txInputs {
+			for _, ringMember := range trVector.Inputs[i].Ring {
+				checkLgrTxo(trVector.Name, ringMember)
+			}
+			checkBytes(t, trVector.Name+": serialNumber", pqringctxapi.GetTxInputSerialNumber(txInput), trVector.Inputs[i].SerialNumber)
+		}
+	}
+}
+
+// TestVectorsRegenerate re-generates all the vectors, and checks that they are the same as the checked-in ones byte-for-byte.
+func TestVectorsRegenerate(t *testing.T) {
+	want, err := os.ReadFile(vectorsFile)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", vectorsFile, err)
+	}
+	got, err := marshalVectors(generatedVectors(t))
+	if err != nil {
+		t.Fatalf("marshalVectors: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		gotLines := bytes.Split(got, []byte("\n"))
+		wantLines := bytes.Split(want, []byte("\n"))
+		line := 0
+		for line < len(gotLines) && line < len(wantLines) && bytes.Equal(gotLines[line], wantLines[line]) {
+			line++
+		}
+		t.Errorf("the re-generated vectors are different from %s from line %d; if the change is intended, re-generate it by go run ./cmd/pqringctx-vectors -o cmd/pqringctx-vectors/%s", vectorsFile, line+1, vectorsFile)
+	}
+}
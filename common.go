@@ -330,3 +330,74 @@ func readVarBytes(r io.Reader, maxAllowed uint32, fieldName string) ([]byte, err
 	}
 	return b, nil
 }
+
+// writeVarBytesFrame writes a frame of size bytes to io.Writer, in the same format as writeVarBytes,
+// where the content of the frame is written by writeContent.
+// The size must be the exact number of bytes written by writeContent, e.g., computed by the corresponding XxxSerializeSize function.
+func writeVarBytesFrame(w io.Writer, size int, writeContent func(w io.Writer) error) error {
+	err := WriteVarInt(w, uint64(size))
+	if err != nil {
+		return err
+	}
+	cw := &countingWriter{w: w}
+	err = writeContent(cw)
+	if err != nil {
+		return err
+	}
+	if cw.n != int64(size) {
+		return fmt.Errorf("writeVarBytesFrame: %d bytes are written, but the frame size is %d", cw.n, size)
+	}
+	return nil
+}
+
+// readVarBytesFrame reads a frame written by writeVarBytes or writeVarBytesFrame from io.Reader,
+// and calls readContent on the content of the frame, with the frame size.
+// readContent cannot read beyond the frame, and the bytes of the frame not read by readContent are discarded,
+// so that it accepts exactly the same inputs as readVarBytes followed by a deserialization of the returned bytes.
+func readVarBytesFrame(r io.Reader, maxAllowed uint32, fieldName string, readContent func(r io.Reader, size int) error) error {
+	count, err := ReadVarInt(r)
+	if err != nil {
+		return err
+	}
+
+	// Prevent byte array larger than the max message size.
+	if count > uint64(maxAllowed) {
+		str := fmt.Sprintf("%s is larger than the max allowed size "+
+			"[count %d, max %d]", fieldName, count, maxAllowed)
+		return errors.New(str)
+	}
+
+	return readFixedLength(r, int(count), func(r io.Reader) error {
+		return readContent(r, int(count))
+	})
+}
+
+// readFixedLength calls readContent on the next size bytes of io.Reader.
+// readContent cannot read beyond the size bytes, and the bytes not read by readContent are discarded,
+// so that it accepts exactly the same inputs as reading size bytes into a slice and then deserializing the slice.
+func readFixedLength(r io.Reader, size int, readContent func(r io.Reader) error) error {
+	lr := &io.LimitedReader{R: r, N: int64(size)}
+	err := readContent(lr)
+	if err != nil {
+		return err
+	}
+	if lr.N > 0 {
+		_, err = io.CopyN(io.Discard, lr, lr.N)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written to the underlying io.Writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
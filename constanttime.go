@@ -0,0 +1,163 @@
+package pqringctx
+
+import (
+	"math/bits"
+)
+
+//	Constant-time arithmetic	begin
+
+// ctModulus implements the arithmetic modulo an odd q (3 <= q < 2^62) in constant time,
+// i.e., without data-dependent branches, memory accesses, or divisions,
+// so that it can be applied to the coefficients derived from the secrets, e.g., the AddressSecretKeySp, the ma of the AddressSecretKeySn,
+// the randomness of the value-commitments, and the masking randomness of the signatures.
+// The reductions are implemented by Montgomery reduction with R = 2^64,
+// and all the results are in the centered scope [-(q-1)/2, (q-1)/2], which is consistent with reduceInt64.
+// Note that math/bits.Mul64, bits.Add64, and bits.Sub64 are constant-time, while bits.Div64 and the operator % are not.
+type ctModulus struct {
+	q     int64
+	qHalf int64  // (q-1)/2
+	qInv  uint64 // -q^{-1} mod 2^64
+	r     uint64 // 2^64 mod q
+	r2    uint64 // 2^128 mod q
+}
+
+// newCTModulus precomputes the constants for the constant-time arithmetic modulo the input q.
+// q is public, hence the precomputation does not need to be constant-time.
+func newCTModulus(q int64) *ctModulus {
+	if q < 3 || q&1 == 0 || q >= 1<<62 {
+		panic("newCTModulus: q should be an odd number in [3, 2^62)")
+	}
+
+	uq := uint64(q)
+	//	Newton iteration: for odd q, q*q = 1 mod 8, and each iteration doubles the number of the correct bits.
+	inv := uq
+	for i := 0; i < 5; i++ {
+		inv *= 2 - uq*inv
+	}
+
+	r := (-uq) % uq
+	_, r2 := bits.Div64(r, 0, uq)
+
+	return &ctModulus{
+		q:     q,
+		qHalf: (q - 1) >> 1,
+		qInv:  -inv,
+		r:     r,
+		r2:    r2,
+	}
+}
+
+// redc returns (hi*2^64 + lo) * 2^{-64} mod q in [0, q), for the input satisfying hi*2^64 + lo < q*2^64, i.e., hi < q.
+func (m *ctModulus) redc(hi, lo uint64) uint64 {
+	k := lo * m.qInv
+	mh, ml := bits.Mul64(k, uint64(m.q))
+	_, carry := bits.Add64(lo, ml, 0)
+	//	t < 2q < 2^64
+	t := hi + mh + carry
+	return m.ctSubQ(t)
+}
+
+// ctSubQ returns t-q if t >= q, and t otherwise, for t in [0, 2q).
+func (m *ctModulus) ctSubQ(t uint64) uint64 {
+	d, borrow := bits.Sub64(t, uint64(m.q), 0)
+	//	mask is all-ones if and only if there is no borrow, i.e., t >= q.
+	mask := borrow - 1
+	return (d & mask) | (t &^ mask)
+}
+
+// center maps x in [0, q) to the centered scope [-(q-1)/2, (q-1)/2].
+func (m *ctModulus) center(x uint64) int64 {
+	y := int64(x)
+	//	mask is all-ones if and only if y > (q-1)/2.
+	mask := (m.qHalf - y) >> 63
+	return y - (m.q & mask)
+}
+
+// canonical maps a in (-q, q) to [0, q).
+func (m *ctModulus) canonical(a int64) uint64 {
+	return uint64(a + (m.q & (a >> 63)))
+}
+
+// toUnsigned returns the representative of the input a in [0, q), where a can be any int64.
+func (m *ctModulus) toUnsigned(a int64) uint64 {
+	//	x = u * 2^64 * 2^{-64} = u mod q, noting that u * (2^64 mod q) < q * 2^64.
+	x := m.redc(bits.Mul64(uint64(a), m.r))
+	//	For negative a, u = a + 2^64, so that 2^64 mod q is subtracted.
+	return m.ctSubMod(x, m.r&uint64(a>>63))
+}
+
+// toMont returns the Montgomery form of the input a, i.e., a * 2^64 mod q in [0, q), where a can be any int64.
+func (m *ctModulus) toMont(a int64) uint64 {
+	//	x = u * 2^{128} * 2^{-64} = u * 2^64 mod q
+	x := m.redc(bits.Mul64(uint64(a), m.r2))
+	//	For negative a, u = a + 2^64, so that 2^128 mod q is subtracted.
+	return m.ctSubMod(x, m.r2&uint64(a>>63))
+}
+
+// ctSubMod returns x-y mod q in [0, q), for x and y in [0, q).
+func (m *ctModulus) ctSubMod(x, y uint64) uint64 {
+	d, borrow := bits.Sub64(x, y, 0)
+	return d + (uint64(m.q) & -borrow)
+}
+
+// montMulLazy returns a * b * 2^{-64} mod q in [0, 2q), for a < 4q and b < q,
+// i.e., the result is not fully reduced, which is left to the caller.
+// Note that a * b < 4q^2 < q * 2^64 implies hi < q, and then the result is smaller than 2q.
+func (m *ctModulus) montMulLazy(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	k := lo * m.qInv
+	mh, ml := bits.Mul64(k, uint64(m.q))
+	_, carry := bits.Add64(lo, ml, 0)
+	return hi + mh + carry
+}
+
+// ctSub2Q returns t-2q if t >= 2q, and t otherwise, for t in [0, 4q).
+func (m *ctModulus) ctSub2Q(t uint64) uint64 {
+	d, borrow := bits.Sub64(t, uint64(m.q)<<1, 0)
+	mask := borrow - 1
+	return (d & mask) | (t &^ mask)
+}
+
+// reduce returns the centered representative of the input a, which can be any int64.
+func (m *ctModulus) reduce(a int64) int64 {
+	return m.center(m.toUnsigned(a))
+}
+
+// add returns the centered representative of a+b, for a and b in (-2^62, 2^62).
+func (m *ctModulus) add(a, b int64) int64 {
+	return m.reduce(a + b)
+}
+
+// sub returns the centered representative of a-b, for a and b in (-2^62, 2^62).
+func (m *ctModulus) sub(a, b int64) int64 {
+	return m.reduce(a - b)
+}
+
+// mul returns the centered representative of a*b, for a and b in (-q, q).
+func (m *ctModulus) mul(a, b int64) int64 {
+	//	x = a * b * 2^{-64} * 2^{128} * 2^{-64} = a * b mod q
+	x := m.redc(bits.Mul64(m.canonical(a), m.canonical(b)))
+	x = m.redc(bits.Mul64(x, m.r2))
+	return m.center(x)
+}
+
+// mulAny returns the centered representative of a*b, where a and b can be any int64.
+func (m *ctModulus) mulAny(a, b int64) int64 {
+	//	a * (b * 2^64) * 2^{-64} = a * b mod q
+	return m.center(m.redc(bits.Mul64(m.toUnsigned(a), m.toMont(b))))
+}
+
+// ctAbs returns |a| without branch, for a != -2^63.
+func ctAbs(a int64) int64 {
+	mask := a >> 63
+	return (a ^ mask) - mask
+}
+
+// ctMax returns the maximum of a and b without branch, for a and b with |a-b| < 2^63.
+func ctMax(a, b int64) int64 {
+	//	mask is all-ones if and only if a < b.
+	mask := (a - b) >> 63
+	return a ^ ((a ^ b) & mask)
+}
+
+//	Constant-time arithmetic	end
@@ -0,0 +1,212 @@
+//go:build dudect
+
+package pqringctx
+
+import (
+	"flag"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// This file is a dudect-style statistical timing test harness for the constant-time arithmetic,
+// following "Dude, is my code constant time?" (Reparaz, Balasch, and Verbauwhede, DATE 2017).
+// For each target, the execution times for the inputs of two classes, i.e., fixed inputs and random inputs,
+// are measured in a randomly interleaved order, and Welch's t-test is applied to the two distributions.
+// A |t| beyond dudectThreshold is a strong evidence of timing leakage.
+//
+// It is excluded from the normal test runs, since it is time-consuming and sensitive to the machine noise.
+// Run it locally on an idle machine by
+//
+//	go test -tags dudect -run TestDudect -v -dudect.n=1000000 .
+var dudectMeasurements = flag.Int("dudect.n", 50000, "the number of the measurements for each dudect target")
+
+const (
+	// dudectThreshold is the threshold of |t| used by dudect to report a definite leakage.
+	dudectThreshold = 10
+	// dudectBatch is the number of the executions in each measurement, to amortize the resolution of the timer.
+	dudectBatch = 8
+)
+
+// dudectSink prevents the compiler from eliminating the measured computations.
+var dudectSink int64
+
+// welchTTest is an online Welch's t-test, using Welford's algorithm.
+type welchTTest struct {
+	n    [2]float64
+	mean [2]float64
+	m2   [2]float64
+}
+
+func (w *welchTTest) push(class int, x float64) {
+	w.n[class]++
+	delta := x - w.mean[class]
+	w.mean[class] += delta / w.n[class]
+	w.m2[class] += delta * (x - w.mean[class])
+}
+
+func (w *welchTTest) t() float64 {
+	if w.n[0] < 2 || w.n[1] < 2 {
+		return 0
+	}
+	var0 := w.m2[0] / (w.n[0] - 1)
+	var1 := w.m2[1] / (w.n[1] - 1)
+	den := math.Sqrt(var0/w.n[0] + var1/w.n[1])
+	if den == 0 {
+		return 0
+	}
+	return (w.mean[0] - w.mean[1]) / den
+}
+
+// dudectTarget describes a computation to be measured.
+// prepare fills the input of the given class into a fresh buffer, and run executes the computation on it.
+type dudectTarget struct {
+	name    string
+	prepare func(class int, rng *rand.Rand) interface{}
+	run     func(input interface{}) int64
+}
+
+// dudectRun measures the target and returns the maximum |t| over the cropped distributions,
+// where the measurements above the percentiles in dudectPercentiles are discarded, as dudect does, to remove the outliers.
+func dudectRun(target dudectTarget, measurements int) float64 {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	classes := make([]int, measurements)
+	inputs := make([]interface{}, measurements)
+	for i := 0; i < measurements; i++ {
+		classes[i] = rng.Intn(2)
+		inputs[i] = target.prepare(classes[i], rng)
+	}
+
+	durations := make([]float64, measurements)
+	for i := 0; i < measurements; i++ {
+		start := time.Now()
+		for j := 0; j < dudectBatch; j++ {
+			dudectSink ^= target.run(inputs[i])
+		}
+		durations[i] = float64(time.Since(start).Nanoseconds())
+	}
+
+	//	the first 10% of the measurements are used as warm-up, and are discarded.
+	warmup := measurements / 10
+	sorted := append([]float64{}, durations[warmup:]...)
+	sort.Float64s(sorted)
+
+	dudectPercentiles := []float64{1.0, 0.5, 0.75, 0.9, 0.95, 0.99}
+	maxT := 0.0
+	for _, p := range dudectPercentiles {
+		cutoff := sorted[int(p*float64(len(sorted)-1))]
+		var w welchTTest
+		for i := warmup; i < measurements; i++ {
+			if durations[i] <= cutoff {
+				w.push(classes[i], durations[i])
+			}
+		}
+		maxT = math.Max(maxT, math.Abs(w.t()))
+	}
+	return maxT
+}
+
+func TestDudect(t *testing.T) {
+	pp := initializeForTest()
+
+	randomCoeffs := func(rng *rand.Rand, n int, q int64) []int64 {
+		coeffs := make([]int64, n)
+		for i := 0; i < n; i++ {
+			coeffs[i] = rng.Int63n(q) - (q-1)/2
+		}
+		return coeffs
+	}
+	//	The fixed class uses the zero input, which is the most likely to trigger the shortcuts in a non-constant-time implementation.
+	coeffsOfClass := func(class int, rng *rand.Rand, n int, q int64) []int64 {
+		if class == 0 {
+			return make([]int64, n)
+		}
+		return randomCoeffs(rng, n, q)
+	}
+
+	targets := []dudectTarget{
+		{
+			name: "ctModulus.reduce (q_c)",
+			prepare: func(class int, rng *rand.Rand) interface{} {
+				if class == 0 {
+					return int64(0)
+				}
+				return rng.Int63() - rng.Int63()
+			},
+			run: func(input interface{}) int64 {
+				return pp.paramQCModulus.reduce(input.(int64))
+			},
+		},
+		{
+			name: "ctModulus.mul (q_c)",
+			prepare: func(class int, rng *rand.Rand) interface{} {
+				return coeffsOfClass(class, rng, 2, pp.paramQC)
+			},
+			run: func(input interface{}) int64 {
+				ab := input.([]int64)
+				return pp.paramQCModulus.mul(ab[0], ab[1])
+			},
+		},
+		{
+			name: "NTTPolyA",
+			prepare: func(class int, rng *rand.Rand) interface{} {
+				return &PolyA{coeffs: coeffsOfClass(class, rng, pp.paramDA, pp.paramQA)}
+			},
+			run: func(input interface{}) int64 {
+				return pp.NTTPolyA(input.(*PolyA)).coeffs[0]
+			},
+		},
+		{
+			name: "PolyANTTMul",
+			prepare: func(class int, rng *rand.Rand) interface{} {
+				return &PolyANTT{coeffs: coeffsOfClass(class, rng, pp.paramDA, pp.paramQA)}
+			},
+			run: func(input interface{}) int64 {
+				a := input.(*PolyANTT)
+				return pp.PolyANTTMul(a, pp.paramVectorA.polyANTTs[0]).coeffs[0]
+			},
+		},
+		{
+			name: "PolyA.infNorm",
+			prepare: func(class int, rng *rand.Rand) interface{} {
+				return &PolyA{coeffs: coeffsOfClass(class, rng, pp.paramDA, pp.paramQA)}
+			},
+			run: func(input interface{}) int64 {
+				return input.(*PolyA).infNorm()
+			},
+		},
+		{
+			name: "NTTPolyC",
+			prepare: func(class int, rng *rand.Rand) interface{} {
+				return &PolyC{coeffs: coeffsOfClass(class, rng, pp.paramDC, pp.paramQC)}
+			},
+			run: func(input interface{}) int64 {
+				return pp.NTTPolyC(input.(*PolyC)).coeffs[0]
+			},
+		},
+		{
+			name: "PolyCNTTMul",
+			prepare: func(class int, rng *rand.Rand) interface{} {
+				return &PolyCNTT{coeffs: coeffsOfClass(class, rng, pp.paramDC, pp.paramQC)}
+			},
+			run: func(input interface{}) int64 {
+				a := input.(*PolyCNTT)
+				return pp.PolyCNTTMul(a, pp.paramMatrixB[0].polyCNTTs[0]).coeffs[0]
+			},
+		},
+	}
+
+	for _, target := range targets {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			maxT := dudectRun(target, *dudectMeasurements)
+			t.Logf("%s: max |t| = %.2f over %d measurements", target.name, maxT, *dudectMeasurements)
+			if maxT > dudectThreshold {
+				t.Errorf("%s: timing leakage detected, max |t| = %.2f > %d", target.name, maxT, dudectThreshold)
+			}
+		})
+	}
+}
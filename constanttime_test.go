@@ -0,0 +1,62 @@
+package pqringctx
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// TestCTModulus checks the constant-time arithmetic against math/big, modulo q_a and q_c.
+func TestCTModulus(t *testing.T) {
+	pp := initializeForTest()
+	rng := rand.New(rand.NewSource(1))
+
+	for _, mod := range []*ctModulus{pp.paramQAModulus, pp.paramQCModulus} {
+		q := mod.q
+		bigQ := big.NewInt(q)
+		expected := func(x *big.Int) int64 {
+			x.Mod(x, bigQ)
+			return reduceInt64(x.Int64(), q)
+		}
+
+		//	the edge cases, together with random values
+		values := []int64{0, 1, -1, 2, -2, mod.qHalf, -mod.qHalf, mod.qHalf + 1, -mod.qHalf - 1, q - 1, -(q - 1), q, -q, q + 1,
+			1<<62 - 1, -(1<<62 - 1), 1<<63 - 1, -1 << 63}
+		for i := 0; i < 1000; i++ {
+			values = append(values, rng.Int63n(2*q-1)-(q-1), rng.Int63()-rng.Int63())
+		}
+
+		for _, a := range values {
+			if got, want := mod.reduce(a), expected(big.NewInt(a)); got != want {
+				t.Fatalf("reduce(%d) mod %d = %d, want %d", a, q, got, want)
+			}
+		}
+
+		for i := 0; i < 2000; i++ {
+			a := values[rng.Intn(len(values))]
+			b := values[rng.Intn(len(values))]
+			if a > -q && a < q && b > -q && b < q {
+				got := mod.mul(a, b)
+				want := expected(new(big.Int).Mul(big.NewInt(a), big.NewInt(b)))
+				if got != want {
+					t.Fatalf("mul(%d, %d) mod %d = %d, want %d", a, b, q, got, want)
+				}
+			}
+			if a > -(1<<62) && a < 1<<62 && b > -(1<<62) && b < 1<<62 {
+				if got, want := mod.add(a, b), expected(new(big.Int).Add(big.NewInt(a), big.NewInt(b))); got != want {
+					t.Fatalf("add(%d, %d) mod %d = %d, want %d", a, b, q, got, want)
+				}
+				if got, want := mod.sub(a, b), expected(new(big.Int).Sub(big.NewInt(a), big.NewInt(b))); got != want {
+					t.Fatalf("sub(%d, %d) mod %d = %d, want %d", a, b, q, got, want)
+				}
+			}
+		}
+	}
+
+	if ctAbs(-5) != 5 || ctAbs(5) != 5 || ctAbs(0) != 0 {
+		t.Fatalf("ctAbs is wrong")
+	}
+	if ctMax(3, 7) != 7 || ctMax(7, 3) != 7 || ctMax(-3, -7) != -3 {
+		t.Fatalf("ctMax is wrong")
+	}
+}
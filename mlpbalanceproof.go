@@ -177,6 +177,9 @@ func (pp *PublicParameter) genBalanceProofL0R1(msg []byte, vL uint64, cmt *Value
 	zs := make([]*PolyCVec, pp.paramK)
 
 genBalanceProofL0R1Restart:
+	if err := pp.contextErr("genBalanceProofL0R1"); err != nil {
+		return nil, err
+	}
 	for t := 0; t < pp.paramK; t++ {
 		// random y
 		tmpY, err := pp.sampleMaskingVecC()
@@ -205,16 +208,12 @@ genBalanceProofL0R1Restart:
 		return nil, err
 	}
 	ch := pp.NTTPolyC(ch_poly)
+	sigma_t_ch := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(sigma_t_ch)
 	for t := 0; t < pp.paramK; t++ {
-		zs_ntt[t] = pp.PolyCNTTVecAdd(
-			ys[t],
-			pp.PolyCNTTVecScaleMul(
-				pp.sigmaPowerPolyCNTT(ch, t),
-				cmtr,
-				pp.paramLC,
-			),
-			pp.paramLC,
-		)
+		pp.sigmaPowerPolyCNTTTo(sigma_t_ch, ch, t)
+		zs_ntt[t] = pp.PolyCNTTVecScaleMul(sigma_t_ch, cmtr, pp.paramLC)
+		pp.PolyCNTTVecAddTo(zs_ntt[t], ys[t], zs_ntt[t], pp.paramLC)
 		// check the norm
 		zs[t] = pp.NTTInvPolyCVec(zs_ntt[t])
 		if zs[t].infNorm() > boundC {
@@ -263,23 +262,34 @@ func (pp *PublicParameter) verifyBalanceProofL0R1(msg []byte, vL uint64, cmt *Va
 	mtmp := pp.intToBinary(vL)
 	//msg := pp.NTTInRQc(&Polyv2{coeffs1: mtmp})
 	msgNTT := &PolyCNTT{coeffs: mtmp}
+	//	cSubMsg = c - m does not depend on t.
+	cSubMsg := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(cSubMsg)
+	pp.PolyCNTTSubTo(cSubMsg, cmt.c, msgNTT)
+	sigma_t_ch := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(sigma_t_ch)
+	tmp := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp)
+	bScaled := pp.getPolyCNTTVec(pp.paramKC)
+	defer pp.putPolyCNTTVec(bScaled)
+	z_ntt := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(z_ntt)
 	for t := 0; t < pp.paramK; t++ {
-		sigma_t_ch := pp.sigmaPowerPolyCNTT(ch, t)
+		pp.sigmaPowerPolyCNTTTo(sigma_t_ch, ch, t)
+
+		for i := 0; i < pp.paramLC; i++ {
+			pp.NTTPolyCTo(z_ntt.polyCNTTs[i], balanceProof.zs[t].polyCs[i])
+		}
 
-		z_ntt := pp.NTTPolyCVec(balanceProof.zs[t])
+		//	w_t = B z_t - sigma^t(c) b
+		ws[t] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_ntt, pp.paramKC, pp.paramLC)
+		pp.PolyCNTTVecScaleMulTo(bScaled, sigma_t_ch, cmt.b, pp.paramKC)
+		pp.PolyCNTTVecSubTo(ws[t], ws[t], bScaled, pp.paramKC)
 
-		ws[t] = pp.PolyCNTTVecSub(
-			pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_ntt, pp.paramKC, pp.paramLC),
-			pp.PolyCNTTVecScaleMul(sigma_t_ch, cmt.b, pp.paramKC),
-			pp.paramKC,
-		)
-		deltas[t] = pp.PolyCNTTSub(
-			pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[0], z_ntt, pp.paramLC),
-			pp.PolyCNTTMul(
-				sigma_t_ch,
-				pp.PolyCNTTSub(cmt.c, msgNTT),
-			),
-		)
+		//	delta_t = <h, z_t> - sigma^t(c) (c - m)
+		deltas[t] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[0], z_ntt, pp.paramLC)
+		pp.PolyCNTTMulTo(tmp, sigma_t_ch, cSubMsg)
+		pp.PolyCNTTSubTo(deltas[t], deltas[t], tmp)
 	}
 
 	seedMsg, err := pp.collectBytesForBalanceProofL0R1Challenge(msg, vL, cmt, ws, deltas)
@@ -432,14 +442,14 @@ func (pp *PublicParameter) genBalanceProofL0Rn(msg []byte, nR uint8, vL uint64,
 		if err != nil {
 			return nil, err
 		}
-		c_hats[i] = pp.PolyCNTTAdd(
-			pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], r_hat, pp.paramLC),
-			msgNTTi,
-			//&PolyCNTT{coeffs: msg_hats[i]},
-		)
+		c_hats[i] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], r_hat, pp.paramLC)
+		pp.PolyCNTTAddTo(c_hats[i], c_hats[i], msgNTTi)
 	}
 
 genBalanceProofL0RnRestart:
+	if err := pp.contextErr("genBalanceProofL0Rn"); err != nil {
+		return nil, err
+	}
 	//e := make([]int64, pp.paramDC)
 	e, err := pp.randomDcIntegersInQcEtaF()
 	if err != nil {
@@ -452,11 +462,8 @@ genBalanceProofL0RnRestart:
 	if err != nil {
 		return nil, err
 	}
-	c_hats[n+1] = pp.PolyCNTTAdd(
-		pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[n+2], r_hat, pp.paramLC),
-		//&PolyCNTT{coeffs: msg_hats[n+1]},
-		msgNTTe,
-	)
+	c_hats[n+1] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[n+2], r_hat, pp.paramLC)
+	pp.PolyCNTTAddTo(c_hats[n+1], c_hats[n+1], msgNTTe)
 
 	////	todo_done 2022.04.03: check the scope of u_p in theory
 	////	u_p = B f + e, where e \in [-eta_f, eta_f], with eta_f < q_c/12.
@@ -622,7 +629,7 @@ func (pp *PublicParameter) verifyBalanceProofL0Rn(msg []byte, nR uint8, vL uint6
 	n1 := n
 	err = pp.rpulpVerifyMLP(msg, cmtRs, uint8(n), balanceProof.b_hat, balanceProof.c_hats, uint8(n2), uint8(n1), RpUlpTypeL0Rn, binM, 0, nR, 3, u_hats, balanceProof.rpulpproof)
 	if err != nil {
-		return err
+		return wrapTxError(err, ErrRangeProofFailed, -1, -1)
 	}
 
 	return nil
@@ -667,6 +674,9 @@ func (pp *PublicParameter) genBalanceProofL1R1(msg []byte, cmt1 *ValueCommitment
 	deltas := make([]*PolyCNTT, pp.paramK)
 
 genBalanceProofL1R1Restart:
+	if err := pp.contextErr("genBalanceProofL1R1"); err != nil {
+		return nil, err
+	}
 	for t := 0; t < pp.paramK; t++ {
 		//	y_1[t], y_2[t] \in (S_{eta_c})^{L_c}
 		tmpY1, err := pp.sampleMaskingVecC()
@@ -683,11 +693,10 @@ genBalanceProofL1R1Restart:
 		//	w_1[t] = B y_1[t], w_2[t] = B y_2[t], \delta[t] = <h, y_1[t]> - <h, y_2[t]>
 		w1s[t] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, y1s[t], pp.paramKC, pp.paramLC)
 		w2s[t] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, y2s[t], pp.paramKC, pp.paramLC)
-		deltas[t] = pp.PolyCNTTVecInnerProduct(
-			pp.paramMatrixH[0],
-			pp.PolyCNTTVecSub(y1s[t], y2s[t], pp.paramLC),
-			pp.paramLC,
-		)
+		yDiff := pp.getPolyCNTTVec(pp.paramLC)
+		defer pp.putPolyCNTTVec(yDiff)
+		pp.PolyCNTTVecSubTo(yDiff, y1s[t], y2s[t], pp.paramLC)
+		deltas[t] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[0], yDiff, pp.paramLC)
 	}
 
 	// splicing the data to be processed
@@ -717,27 +726,34 @@ genBalanceProofL1R1Restart:
 		return nil, err
 	}
 	// 2 * m - mu
-	TwoMSubMu := pp.PolyCNTTSub(
-		//  m + m
-		pp.PolyCNTTAdd(
-			msgNTT,
-			msgNTT,
-		),
-		pp.paramMu,
-	)
-
+	TwoMSubMu := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(TwoMSubMu)
+	pp.PolyCNTTAddTo(TwoMSubMu, msgNTT, msgNTT)
+	pp.PolyCNTTSubTo(TwoMSubMu, TwoMSubMu, pp.paramMu)
+
+	tmp := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp)
+	tmp1 := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp1)
+	tmp2 := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp2)
 	for t := 0; t < pp.paramK; t++ {
 		// <h , y_1[t]>
-		tmp := pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[0], y1s[t], pp.paramLC)
+		pp.PolyCNTTVecInnerProductTo(tmp, pp.paramMatrixH[0], y1s[t], pp.paramLC)
 
 		// (2 * m - mu) <h, y_1[t]>
-		tmp1 := pp.PolyCNTTMul(TwoMSubMu, tmp)
+		pp.PolyCNTTMulTo(tmp1, TwoMSubMu, tmp)
 
 		//	(<h , y_1[t]>)^2
-		tmp2 := pp.PolyCNTTMul(tmp, tmp)
+		pp.PolyCNTTMulTo(tmp2, tmp, tmp)
 
-		psi = pp.PolyCNTTSub(psi, pp.PolyCNTTMul(betas[t], pp.sigmaInvPolyCNTT(tmp1, t)))
-		psip = pp.PolyCNTTAdd(psip, pp.PolyCNTTMul(betas[t], pp.sigmaInvPolyCNTT(tmp2, t)))
+		// psi = psi - beta_t * sigma^{-t}(tmp1), psi' = psi' + beta_t * sigma^{-t}(tmp2)
+		pp.sigmaPowerPolyCNTTTo(tmp, tmp1, (pp.paramK-t)%pp.paramK)
+		pp.PolyCNTTMulTo(tmp, betas[t], tmp)
+		pp.PolyCNTTSubTo(psi, psi, tmp)
+		pp.sigmaPowerPolyCNTTTo(tmp, tmp2, (pp.paramK-t)%pp.paramK)
+		pp.PolyCNTTMulTo(tmp, betas[t], tmp)
+		pp.PolyCNTTAddTo(psip, psip, tmp)
 	}
 
 	//	seed_ch and ch
@@ -760,16 +776,20 @@ genBalanceProofL1R1Restart:
 	z2s := make([]*PolyCVec, pp.paramK)
 
 	bound := pp.paramEtaC - int64(pp.paramBetaC)
+	sigma_t_ch := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(sigma_t_ch)
 	for t := 0; t < pp.paramK; t++ {
-		sigma_t_ch := pp.sigmaPowerPolyCNTT(ch, t)
+		pp.sigmaPowerPolyCNTTTo(sigma_t_ch, ch, t)
 
-		z1s_ntt[t] = pp.PolyCNTTVecAdd(y1s[t], pp.PolyCNTTVecScaleMul(sigma_t_ch, cmtr1, pp.paramLC), pp.paramLC)
+		z1s_ntt[t] = pp.PolyCNTTVecScaleMul(sigma_t_ch, cmtr1, pp.paramLC)
+		pp.PolyCNTTVecAddTo(z1s_ntt[t], y1s[t], z1s_ntt[t], pp.paramLC)
 		z1s[t] = pp.NTTInvPolyCVec(z1s_ntt[t])
 		if z1s[t].infNorm() > bound {
 			goto genBalanceProofL1R1Restart
 		}
 
-		z2s_ntt[t] = pp.PolyCNTTVecAdd(y2s[t], pp.PolyCNTTVecScaleMul(sigma_t_ch, cmtr2, pp.paramLC), pp.paramLC)
+		z2s_ntt[t] = pp.PolyCNTTVecScaleMul(sigma_t_ch, cmtr2, pp.paramLC)
+		pp.PolyCNTTVecAddTo(z2s_ntt[t], y2s[t], z2s_ntt[t], pp.paramLC)
 		z2s[t] = pp.NTTInvPolyCVec(z2s_ntt[t])
 		if z2s[t].infNorm() > bound {
 			goto genBalanceProofL1R1Restart
@@ -827,28 +847,33 @@ func (pp *PublicParameter) verifyBalanceProofL1R1(msg []byte, cmt1 *ValueCommitm
 	z1s_ntt := make([]*PolyCNTTVec, pp.paramK)
 	z2s_ntt := make([]*PolyCNTTVec, pp.paramK)
 
+	//	cDiff = c_1 - c_2 does not depend on t.
+	cDiff := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(cDiff)
+	pp.PolyCNTTSubTo(cDiff, cmt1.c, cmt2.c)
+	tmp := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp)
+	bScaled := pp.getPolyCNTTVec(pp.paramKC)
+	defer pp.putPolyCNTTVec(bScaled)
+	zDiff := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(zDiff)
 	for t := 0; t < pp.paramK; t++ {
 		sigma_chs[t] = pp.sigmaPowerPolyCNTT(ch, t)
 
 		z1s_ntt[t] = pp.NTTPolyCVec(balanceProof.z1s[t])
-		w1s[t] = pp.PolyCNTTVecSub(
-			pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z1s_ntt[t], pp.paramKC, pp.paramLC),
-			pp.PolyCNTTVecScaleMul(sigma_chs[t], cmt1.b, pp.paramKC),
-			pp.paramKC)
+		w1s[t] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z1s_ntt[t], pp.paramKC, pp.paramLC)
+		pp.PolyCNTTVecScaleMulTo(bScaled, sigma_chs[t], cmt1.b, pp.paramKC)
+		pp.PolyCNTTVecSubTo(w1s[t], w1s[t], bScaled, pp.paramKC)
 
 		z2s_ntt[t] = pp.NTTPolyCVec(balanceProof.z2s[t])
-		w2s[t] = pp.PolyCNTTVecSub(
-			pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z2s_ntt[t], pp.paramKC, pp.paramLC),
-			pp.PolyCNTTVecScaleMul(sigma_chs[t], cmt2.b, pp.paramKC),
-			pp.paramKC)
+		w2s[t] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z2s_ntt[t], pp.paramKC, pp.paramLC)
+		pp.PolyCNTTVecScaleMulTo(bScaled, sigma_chs[t], cmt2.b, pp.paramKC)
+		pp.PolyCNTTVecSubTo(w2s[t], w2s[t], bScaled, pp.paramKC)
 
-		deltas[t] = pp.PolyCNTTSub(
-			pp.PolyCNTTVecInnerProduct(
-				pp.paramMatrixH[0],
-				pp.PolyCNTTVecSub(z1s_ntt[t], z2s_ntt[t], pp.paramLC),
-				pp.paramLC),
-			pp.PolyCNTTMul(sigma_chs[t], pp.PolyCNTTSub(cmt1.c, cmt2.c)),
-		)
+		pp.PolyCNTTVecSubTo(zDiff, z1s_ntt[t], z2s_ntt[t], pp.paramLC)
+		deltas[t] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[0], zDiff, pp.paramLC)
+		pp.PolyCNTTMulTo(tmp, sigma_chs[t], cDiff)
+		pp.PolyCNTTSubTo(deltas[t], deltas[t], tmp)
 	}
 
 	// splicing the data to be processed
@@ -871,31 +896,31 @@ func (pp *PublicParameter) verifyBalanceProofL1R1(msg []byte, cmt1 *ValueCommitm
 	// psi'
 	psip := pp.NewZeroPolyCNTT()
 	//mu := pp.paramMu
+	f_t := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(f_t)
+	tmp1 := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp1)
 	for t := 0; t < pp.paramK; t++ {
 		//	f_t = <h, z1_t> - sigma_c_t c_1
-		f_t := pp.PolyCNTTSub(
-			//	<h, z1_t>
-			pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[0], z1s_ntt[t], pp.paramLC),
-			//	sigma_c_t c_1
-			pp.PolyCNTTMul(sigma_chs[t], cmt1.c),
-		)
+		pp.PolyCNTTVecInnerProductTo(f_t, pp.paramMatrixH[0], z1s_ntt[t], pp.paramLC)
+		pp.PolyCNTTMulTo(tmp, sigma_chs[t], cmt1.c)
+		pp.PolyCNTTSubTo(f_t, f_t, tmp)
+
 		//	tmp = f_t + sigma_c_t mu
-		tmp := pp.PolyCNTTAdd(
-			f_t,
-			//	sigma_c_t mu
-			pp.PolyCNTTMul(sigma_chs[t], pp.paramMu),
-		)
+		pp.PolyCNTTMulTo(tmp, sigma_chs[t], pp.paramMu)
+		pp.PolyCNTTAddTo(tmp, f_t, tmp)
 
-		tmp = pp.PolyCNTTMul(tmp, f_t)
-		tmp = pp.sigmaInvPolyCNTT(tmp, t)
-		tmp = pp.PolyCNTTMul(betas[t], tmp)
+		pp.PolyCNTTMulTo(tmp, tmp, f_t)
+		pp.sigmaPowerPolyCNTTTo(tmp1, tmp, (pp.paramK-t)%pp.paramK)
+		pp.PolyCNTTMulTo(tmp1, betas[t], tmp1)
 
-		psip = pp.PolyCNTTAdd(psip, tmp)
+		pp.PolyCNTTAddTo(psip, psip, tmp1)
 	}
 
-	psip = pp.PolyCNTTSub(psip, pp.PolyCNTTMul(ch, balanceProof.psi))
-	psip = pp.PolyCNTTAdd(psip,
-		pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+6], z1s_ntt[0], pp.paramLC))
+	pp.PolyCNTTMulTo(tmp, ch, balanceProof.psi)
+	pp.PolyCNTTSubTo(psip, psip, tmp)
+	pp.PolyCNTTVecInnerProductTo(tmp, pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+6], z1s_ntt[0], pp.paramLC)
+	pp.PolyCNTTAddTo(psip, psip, tmp)
 
 	//	seed_ch and ch
 	preMsgAll := pp.collectBytesForBalanceProofL1R1Challenge2(preMsg, balanceProof.psi, psip)
@@ -1071,14 +1096,14 @@ func (pp *PublicParameter) genBalanceProofL1Rn(msg []byte, nR uint8, cmtL *Value
 		if err != nil {
 			return nil, err
 		}
-		c_hats[i] = pp.PolyCNTTAdd(
-			pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], r_hat, pp.paramLC),
-			msgNTTi,
-			//&PolyCNTT{coeffs: msg_hats[i]},
-		)
+		c_hats[i] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], r_hat, pp.paramLC)
+		pp.PolyCNTTAddTo(c_hats[i], c_hats[i], msgNTTi)
 	}
 
 genBalanceProofL1RnRestart:
+	if err := pp.contextErr("genBalanceProofL1Rn"); err != nil {
+		return nil, err
+	}
 	//e := make([]int64, pp.paramDC)
 	e, err := pp.randomDcIntegersInQcEtaF()
 	if err != nil {
@@ -1091,10 +1116,8 @@ genBalanceProofL1RnRestart:
 	if err != nil {
 		return nil, err
 	}
-	c_hats[n+1] = pp.PolyCNTTAdd(
-		pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[n+2], r_hat, pp.paramLC),
-		msgNTTe,
-	)
+	c_hats[n+1] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[n+2], r_hat, pp.paramLC)
+	pp.PolyCNTTAddTo(c_hats[n+1], c_hats[n+1], msgNTTe)
 
 	seedMsg, err := pp.collectBytesForBalanceProofL1RnChallenge(msg, nR, cmtL, cmtRs, vRPub, b_hat, c_hats)
 	if err != nil {
@@ -1170,8 +1193,6 @@ genBalanceProofL1RnRestart:
 		u_p:        u_p,
 		rpulpproof: rprlppi,
 	}, nil
-
-	return nil, nil
 }
 
 // verifyBalanceProofL1Rn verifies BalanceProofL1Rn.
@@ -1295,7 +1316,7 @@ func (pp *PublicParameter) verifyBalanceProofL1Rn(msg []byte, nR uint8, cmtL *Va
 	n1 := n
 	err = pp.rpulpVerifyMLP(msg, cmts, uint8(n), balanceProof.b_hat, balanceProof.c_hats, uint8(n2), uint8(n1), RpUlpTypeL1Rn, binM, 1, nR, 3, u_hats, balanceProof.rpulpproof)
 	if err != nil {
-		return err
+		return wrapTxError(err, ErrRangeProofFailed, -1, -1)
 	}
 
 	return nil
@@ -1526,14 +1547,14 @@ func (pp *PublicParameter) genBalanceProofLmRn(msg []byte, nL uint8, nR uint8, c
 		if err != nil {
 			return nil, err
 		}
-		c_hats[i] = pp.PolyCNTTAdd(
-			pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], r_hat, pp.paramLC),
-			msgNTTi,
-			//&PolyCNTT{coeffs: msg_hats[i]},
-		)
+		c_hats[i] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], r_hat, pp.paramLC)
+		pp.PolyCNTTAddTo(c_hats[i], c_hats[i], msgNTTi)
 	}
 
 genBalanceProofLmRnRestart:
+	if err := pp.contextErr("genBalanceProofLmRn"); err != nil {
+		return nil, err
+	}
 	//e := make([]int64, pp.paramDC)
 	e, err := pp.randomDcIntegersInQcEtaF()
 	if err != nil {
@@ -1546,10 +1567,8 @@ genBalanceProofLmRnRestart:
 	if err != nil {
 		return nil, err
 	}
-	c_hats[n+3] = pp.PolyCNTTAdd(
-		pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[n+4], r_hat, pp.paramLC),
-		msgNTTe,
-	)
+	c_hats[n+3] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[n+4], r_hat, pp.paramLC)
+	pp.PolyCNTTAddTo(c_hats[n+3], c_hats[n+3], msgNTTe)
 
 	seedMsg, err := pp.collectBytesForBalanceProofLmRnChallenge(msg, nL, nR, cmtLs, cmtRs, vRPub, b_hat, c_hats)
 	if err != nil {
@@ -1785,7 +1804,7 @@ func (pp *PublicParameter) verifyBalanceProofLmRn(msg []byte, nL uint8, nR uint8
 	n1 := n + 1
 	err = pp.rpulpVerifyMLP(msg, cmts, uint8(n), balanceProof.b_hat, balanceProof.c_hats, uint8(n2), uint8(n1), RpUlpTypeLmRn, binM, nL, nR, 5, u_hats, balanceProof.rpulpproof)
 	if err != nil {
-		return err
+		return wrapTxError(err, ErrRangeProofFailed, -1, -1)
 	}
 
 	return nil
@@ -1814,6 +1833,26 @@ func (pp *PublicParameter) serializeBalanceProof(balanceProof BalanceProof) ([]b
 	}
 }
 
+// writeBalanceProof writes the input BalanceProof to w, in the format of serializeBalanceProof.
+func (pp *PublicParameter) writeBalanceProof(w io.Writer, balanceProof BalanceProof) error {
+	if balanceProof == nil {
+		return fmt.Errorf("writeBalanceProof: the input BalanceProof is nil")
+	}
+
+	switch bpfInst := balanceProof.(type) {
+	case *BalanceProofL0R0:
+		return pp.writeBalanceProofL0R0(w, bpfInst)
+	case *BalanceProofL0R1:
+		return pp.writeBalanceProofL0R1(w, bpfInst)
+	case *BalanceProofL1R1:
+		return pp.writeBalanceProofL1R1(w, bpfInst)
+	case *BalanceProofLmRnGeneral:
+		return pp.writeBalanceProofLmRnGeneral(w, bpfInst)
+	default:
+		return fmt.Errorf("writeBalanceProof: the input BalanceProof is not BalanceProofL0R0, BalanceProofL0R1, BalanceProofL1R1, or BalanceProofLmRnGeneral")
+	}
+}
+
 // deserializeBalanceProof deserialize []byte to BalanceProof.
 // reviewed on 2023.12.20
 // reviewed by Alice, 2024.07.05
@@ -1822,29 +1861,35 @@ func (pp *PublicParameter) deserializeBalanceProof(serializedBpf []byte) (Balanc
 		return nil, fmt.Errorf("deserializeBalanceProof: the input serializedBpf is empty")
 	}
 
-	r := bytes.NewReader(serializedBpf)
+	return pp.readBalanceProof(bytes.NewReader(serializedBpf))
+}
 
+// readBalanceProof reads a BalanceProof from r, which was written by writeBalanceProof.
+func (pp *PublicParameter) readBalanceProof(r io.Reader) (BalanceProof, error) {
 	// balanceProofCase BalanceProofCase
-	balanceProofCase, err := r.ReadByte()
+	balanceProofCase, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
+	//	The balanceProofCase is also read by the concrete readBalanceProofXxx, so that it is put back.
+	r = io.MultiReader(bytes.NewReader([]byte{balanceProofCase}), r)
+
 	switch BalanceProofCase(balanceProofCase) {
 	case BalanceProofCaseL0R0:
-		return pp.deserializeBalanceProofL0R0(serializedBpf)
+		return pp.readBalanceProofL0R0(r)
 	case BalanceProofCaseL0R1:
-		return pp.deserializeBalanceProofL0R1(serializedBpf)
+		return pp.readBalanceProofL0R1(r)
 	case BalanceProofCaseL0Rn:
-		return pp.deserializeBalanceProofLmRnGeneral(serializedBpf)
+		return pp.readBalanceProofLmRnGeneral(r)
 	case BalanceProofCaseL1R1:
-		return pp.deserializeBalanceProofL1R1(serializedBpf)
+		return pp.readBalanceProofL1R1(r)
 	case BalanceProofCaseL1Rn:
-		return pp.deserializeBalanceProofLmRnGeneral(serializedBpf)
+		return pp.readBalanceProofLmRnGeneral(r)
 	case BalanceProofCaseLmRn:
-		return pp.deserializeBalanceProofLmRnGeneral(serializedBpf)
+		return pp.readBalanceProofLmRnGeneral(r)
 	default:
-		return nil, fmt.Errorf("deserializeBalanceProof: the extracted balanceProofCase (%d) is not suppoted", balanceProofCase)
+		return nil, fmt.Errorf("readBalanceProof: the extracted balanceProofCase (%d) is not suppoted", balanceProofCase)
 	}
 }
 
@@ -1862,37 +1907,48 @@ func (pp *PublicParameter) balanceProofL0R0SerializeSize() int {
 // reviewed on 2023.12.18
 // reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) serializeBalanceProofL0R0(bpf *BalanceProofL0R0) ([]byte, error) {
+	w := bytes.NewBuffer(make([]byte, 0, pp.balanceProofL0R0SerializeSize()))
+	err := pp.writeBalanceProofL0R0(w, bpf)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeBalanceProofL0R0 writes the input BalanceProofL0R0 to w, in the format of serializeBalanceProofL0R0.
+func (pp *PublicParameter) writeBalanceProofL0R0(w io.Writer, bpf *BalanceProofL0R0) error {
 
 	if !pp.BalanceProofL0R0SanityCheck(bpf) {
-		return nil, fmt.Errorf("serializeBalanceProofL0R0: the input bpf *BalanceProofL0R0 is not well-form")
+		return fmt.Errorf("writeBalanceProofL0R0: the input bpf *BalanceProofL0R0 is not well-form")
 	}
 
-	w := bytes.NewBuffer(make([]byte, 0, pp.balanceProofL0R0SerializeSize()))
-
 	//	balanceProofCase BalanceProofCase
-	err := w.WriteByte(byte(bpf.balanceProofCase))
+	err := binarySerializer.PutUint8(w, uint8(bpf.balanceProofCase))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeBalanceProofL0R0 deserialize the input []byte to a BalanceProofL0R0.
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) deserializeBalanceProofL0R0(serializedBpfL0R0 []byte) (*BalanceProofL0R0, error) {
+	return pp.readBalanceProofL0R0(bytes.NewReader(serializedBpfL0R0))
+}
 
-	r := bytes.NewReader(serializedBpfL0R0)
+// readBalanceProofL0R0 reads a BalanceProofL0R0 from r, which was written by writeBalanceProofL0R0.
+func (pp *PublicParameter) readBalanceProofL0R0(r io.Reader) (*BalanceProofL0R0, error) {
 
 	// balanceProofCase BalanceProofCase
-	balanceProofCase, err := r.ReadByte()
+	balanceProofCase, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	if BalanceProofCase(balanceProofCase) != BalanceProofCaseL0R0 {
-		return nil, fmt.Errorf("deserializeBalanceProofL0R0: the deserialized balanceProofCase is not BalanceProofCaseL0R0")
+		return nil, fmt.Errorf("readBalanceProofL0R0: the deserialized balanceProofCase is not BalanceProofCaseL0R0")
 	}
 
 	balanceProofL0R0 := &BalanceProofL0R0{
@@ -1900,7 +1956,7 @@ func (pp *PublicParameter) deserializeBalanceProofL0R0(serializedBpfL0R0 []byte)
 	}
 
 	if !pp.BalanceProofL0R0SanityCheck(balanceProofL0R0) {
-		return nil, fmt.Errorf("deserializeBalanceProofL0R0: the deserialized BalanceProofL0R0 is not well-form")
+		return nil, fmt.Errorf("readBalanceProofL0R0: the deserialized BalanceProofL0R0 is not well-form")
 	}
 
 	return balanceProofL0R0, nil
@@ -1923,23 +1979,31 @@ func (pp *PublicParameter) balanceProofL0R1SerializeSize() int {
 // reviewed on 2023.12.18
 // reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) serializeBalanceProofL0R1(bpf *BalanceProofL0R1) ([]byte, error) {
+	w := bytes.NewBuffer(make([]byte, 0, pp.balanceProofL0R1SerializeSize()))
+	err := pp.writeBalanceProofL0R1(w, bpf)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeBalanceProofL0R1 writes the input BalanceProofL0R1 to w, in the format of serializeBalanceProofL0R1.
+func (pp *PublicParameter) writeBalanceProofL0R1(w io.Writer, bpf *BalanceProofL0R1) error {
 
 	if !pp.BalanceProofL0R1SanityCheck(bpf) {
-		return nil, fmt.Errorf("serializeBalanceProofL0R1: the input bpf *BalanceProofL0R1 is not well-form")
+		return fmt.Errorf("writeBalanceProofL0R1: the input bpf *BalanceProofL0R1 is not well-form")
 	}
 
-	w := bytes.NewBuffer(make([]byte, 0, pp.balanceProofL0R1SerializeSize()))
-
 	//	balanceProofCase BalanceProofCase
-	err := w.WriteByte(byte(bpf.balanceProofCase))
+	err := binarySerializer.PutUint8(w, uint8(bpf.balanceProofCase))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	chseed           []byte
 	_, err = w.Write(bpf.chseed)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	zs               []*PolyCVec
@@ -1947,11 +2011,11 @@ func (pp *PublicParameter) serializeBalanceProofL0R1(bpf *BalanceProofL0R1) ([]b
 	for i := 0; i < pp.paramK; i++ {
 		err = pp.writePolyCVecEta(w, bpf.zs[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeBalanceProofL0R1 deserialize the input []byte to a BalanceProofL0R1.
@@ -1959,17 +2023,20 @@ func (pp *PublicParameter) serializeBalanceProofL0R1(bpf *BalanceProofL0R1) ([]b
 // reviewed on 2023.12.18
 // reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) deserializeBalanceProofL0R1(serializedBpfL0R1 []byte) (*BalanceProofL0R1, error) {
+	return pp.readBalanceProofL0R1(bytes.NewReader(serializedBpfL0R1))
+}
 
-	r := bytes.NewReader(serializedBpfL0R1)
+// readBalanceProofL0R1 reads a BalanceProofL0R1 from r, which was written by writeBalanceProofL0R1.
+func (pp *PublicParameter) readBalanceProofL0R1(r io.Reader) (*BalanceProofL0R1, error) {
 
 	// balanceProofCase BalanceProofCase
-	balanceProofCase, err := r.ReadByte()
+	balanceProofCase, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	if BalanceProofCase(balanceProofCase) != BalanceProofCaseL0R1 {
-		return nil, fmt.Errorf("deserializeBalanceProofL0R1: the deserialized balanceProofCase is not BalanceProofCaseL0R1")
+		return nil, fmt.Errorf("readBalanceProofL0R1: the deserialized balanceProofCase is not BalanceProofCaseL0R1")
 	}
 
 	//	chseed           []byte
@@ -1996,7 +2063,7 @@ func (pp *PublicParameter) deserializeBalanceProofL0R1(serializedBpfL0R1 []byte)
 	}
 
 	if !pp.BalanceProofL0R1SanityCheck(balanceProofL0R1) {
-		return nil, fmt.Errorf("deserializeBalanceProofL0R1: the deserialized BalanceProofL0R1 is not well-form")
+		return nil, fmt.Errorf("readBalanceProofL0R1: the deserialized BalanceProofL0R1 is not well-form")
 	}
 
 	return balanceProofL0R1, nil
@@ -2019,29 +2086,37 @@ func (pp *PublicParameter) balanceProofL1R1SerializeSize() int {
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) serializeBalanceProofL1R1(bpf *BalanceProofL1R1) ([]byte, error) {
+	w := bytes.NewBuffer(make([]byte, 0, pp.balanceProofL1R1SerializeSize()))
+	err := pp.writeBalanceProofL1R1(w, bpf)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeBalanceProofL1R1 writes the input BalanceProofL1R1 to w, in the format of serializeBalanceProofL1R1.
+func (pp *PublicParameter) writeBalanceProofL1R1(w io.Writer, bpf *BalanceProofL1R1) error {
 
 	if !pp.BalanceProofL1R1SanityCheck(bpf) {
-		return nil, fmt.Errorf("serializeBalanceProofL1R1: the input bpf *BalanceProofL1R1 is not well-form")
+		return fmt.Errorf("writeBalanceProofL1R1: the input bpf *BalanceProofL1R1 is not well-form")
 	}
 
-	w := bytes.NewBuffer(make([]byte, 0, pp.balanceProofL1R1SerializeSize()))
-
 	//	balanceProofCase BalanceProofCase
-	err := w.WriteByte(byte(bpf.balanceProofCase))
+	err := binarySerializer.PutUint8(w, uint8(bpf.balanceProofCase))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	 psi              *PolyCNTT
 	err = pp.writePolyCNTT(w, bpf.psi)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	chseed           []byte
 	_, err = w.Write(bpf.chseed)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	z1s               []*PolyCVec
@@ -2049,7 +2124,7 @@ func (pp *PublicParameter) serializeBalanceProofL1R1(bpf *BalanceProofL1R1) ([]b
 	for i := 0; i < pp.paramK; i++ {
 		err = pp.writePolyCVecEta(w, bpf.z1s[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -2058,28 +2133,31 @@ func (pp *PublicParameter) serializeBalanceProofL1R1(bpf *BalanceProofL1R1) ([]b
 	for i := 0; i < pp.paramK; i++ {
 		err = pp.writePolyCVecEta(w, bpf.z2s[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeBalanceProofL1R1 deserialize the input []byte to a BalanceProofL1R1.
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) deserializeBalanceProofL1R1(serializedBpfL1R1 []byte) (*BalanceProofL1R1, error) {
+	return pp.readBalanceProofL1R1(bytes.NewReader(serializedBpfL1R1))
+}
 
-	r := bytes.NewReader(serializedBpfL1R1)
+// readBalanceProofL1R1 reads a BalanceProofL1R1 from r, which was written by writeBalanceProofL1R1.
+func (pp *PublicParameter) readBalanceProofL1R1(r io.Reader) (*BalanceProofL1R1, error) {
 
 	// balanceProofCase BalanceProofCase
-	balanceProofCase, err := r.ReadByte()
+	balanceProofCase, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	if BalanceProofCase(balanceProofCase) != BalanceProofCaseL1R1 {
-		return nil, fmt.Errorf("deserializeBalanceProofL1R1: the deserialized balanceProofCase is not BalanceProofCaseL1R1")
+		return nil, fmt.Errorf("readBalanceProofL1R1: the deserialized balanceProofCase is not BalanceProofCaseL1R1")
 	}
 
 	//	psi              *PolyCNTT
@@ -2125,7 +2203,7 @@ func (pp *PublicParameter) deserializeBalanceProofL1R1(serializedBpfL1R1 []byte)
 	}
 
 	if !pp.BalanceProofL1R1SanityCheck(balanceProofL1R1) {
-		return nil, fmt.Errorf("deserializeBalanceProofL1R1: the deserialized BalanceProofL1R1 is not well-form")
+		return nil, fmt.Errorf("readBalanceProofL1R1: the deserialized BalanceProofL1R1 is not well-form")
 	}
 
 	return balanceProofL1R1, nil
@@ -2198,58 +2276,71 @@ func (pp *PublicParameter) serializeBalanceProofLmRnGeneral(bpf *BalanceProofLmR
 		return nil, fmt.Errorf("serializeBalanceProofLmRnGeneral: the input bpf *BalanceProofLmRn is nil")
 	}
 
+	length, err := pp.balanceProofLmRnGeneralSerializeSizeByCommNum(bpf.nL, bpf.nR)
+	if err != nil {
+		return nil, err
+	}
+
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeBalanceProofLmRnGeneral(w, bpf)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeBalanceProofLmRnGeneral writes the input BalanceProofLmRnGeneral to w, in the format of serializeBalanceProofLmRnGeneral.
+func (pp *PublicParameter) writeBalanceProofLmRnGeneral(w io.Writer, bpf *BalanceProofLmRnGeneral) error {
+
+	if bpf == nil {
+		return fmt.Errorf("writeBalanceProofLmRnGeneral: the input bpf *BalanceProofLmRn is nil")
+	}
+
 	switch bpf.balanceProofCase {
 	case BalanceProofCaseL0Rn:
 		if !pp.BalanceProofL0RnSanityCheck(bpf) {
-			return nil, fmt.Errorf("serializeBalanceProofLmRn: the input bpf *BalanceProofLmRn with BalanceProofCaseL0Rn is not well-form")
+			return fmt.Errorf("writeBalanceProofLmRnGeneral: the input bpf *BalanceProofLmRn with BalanceProofCaseL0Rn is not well-form")
 		}
 	case BalanceProofCaseL1Rn:
 		if !pp.BalanceProofL1RnSanityCheck(bpf) {
-			return nil, fmt.Errorf("serializeBalanceProofLmRn: the input bpf *BalanceProofLmRn with BalanceProofCaseL1Rn is not well-form")
+			return fmt.Errorf("writeBalanceProofLmRnGeneral: the input bpf *BalanceProofLmRn with BalanceProofCaseL1Rn is not well-form")
 		}
 	case BalanceProofCaseLmRn:
 		if !pp.BalanceProofLmRnSanityCheck(bpf) {
-			return nil, fmt.Errorf("serializeBalanceProofLmRn: the input bpf *BalanceProofLmRn with BalanceProofCaseLmRn is not well-form")
+			return fmt.Errorf("writeBalanceProofLmRnGeneral: the input bpf *BalanceProofLmRn with BalanceProofCaseLmRn is not well-form")
 		}
 	default:
-		return nil, fmt.Errorf("serializeBalanceProofLmRnGeneral: the input bpf *BalanceProofLmRn has a balanceProofCase not in (BalanceProofCaseL0Rn, BalanceProofCaseL1Rn, BalanceProofCaseLmRn)")
+		return fmt.Errorf("writeBalanceProofLmRnGeneral: the input bpf *BalanceProofLmRn has a balanceProofCase not in (BalanceProofCaseL0Rn, BalanceProofCaseL1Rn, BalanceProofCaseLmRn)")
 	}
 
-	length, err := pp.balanceProofLmRnGeneralSerializeSizeByCommNum(bpf.nL, bpf.nR)
-	if err != nil {
-		return nil, err
-	}
-
-	w := bytes.NewBuffer(make([]byte, 0, length))
-
 	//	balanceProofCase BalanceProofCase
-	err = w.WriteByte(byte(bpf.balanceProofCase))
+	err := binarySerializer.PutUint8(w, uint8(bpf.balanceProofCase))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	nL      uint8
-	err = w.WriteByte(bpf.nL)
+	err = binarySerializer.PutUint8(w, bpf.nL)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	nR      uint8
-	err = w.WriteByte(bpf.nR)
+	err = binarySerializer.PutUint8(w, bpf.nR)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	vRPub
 	err = binarySerializer.PutUint64(w, binary.LittleEndian, bpf.vRPub)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// b_hat            *PolyCNTTVec
 	err = pp.writePolyCNTTVec(w, bpf.b_hat)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	c_hats           []*PolyCNTT
@@ -2269,44 +2360,39 @@ func (pp *PublicParameter) serializeBalanceProofLmRnGeneral(bpf *BalanceProofLmR
 	//if n2 > 0xFF {
 	//	//	when calling rpulpProve, n2 will be converted into uint8.
 	//	//	we shall always guarantee this point.
-	//	return nil, fmt.Errorf("serializeBalanceProofLmRnGeneral: n2 = nL + nR  > 0xFF")
+	//	return fmt.Errorf("writeBalanceProofLmRnGeneral: n2 = nL + nR  > 0xFF")
 	//}
 
 	n2 := len(bpf.c_hats) //	Note that previous sanity checks has guaranteed the well-form of n2.
 	err = WriteVarInt(w, uint64(n2))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for i := 0; i < n2; i++ {
 		err = pp.writePolyCNTT(w, bpf.c_hats[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// u_p              []int64
 	err = pp.writeCarryVectorRProof(w, bpf.u_p)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// rpulpproof       *rpulpProofMLP
-	serializedRpulpProof, err := pp.serializeRpulpProofMLP(bpf.rpulpproof)
-	if err != nil {
-		return nil, err
-	}
-	_, err = w.Write(serializedRpulpProof)
-	if err != nil {
-		return nil, err
-	}
 	//	an assert, can be removed after test
-	serializedRpulpProofSize := pp.rpulpProofMLPSerializeSizeByCommNum(bpf.nL, bpf.nR)
-	if len(serializedRpulpProof) != serializedRpulpProofSize {
+	if bpf.rpulpproof != nil && pp.rpulpProofMLPSerializeSizeByCommNum(bpf.rpulpproof.nL, bpf.rpulpproof.nR) != pp.rpulpProofMLPSerializeSizeByCommNum(bpf.nL, bpf.nR) {
 		//	assert
-		return nil, fmt.Errorf("serializeBalanceProofLmRn: this shoudl not happen, where the size of serializedRpulpProof is not the same as expected")
+		return fmt.Errorf("writeBalanceProofLmRnGeneral: this shoudl not happen, where the size of serializedRpulpProof is not the same as expected")
+	}
+	err = pp.writeRpulpProofMLP(w, bpf.rpulpproof)
+	if err != nil {
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeBalanceProofLmRnGeneral deserialize the input []byte to a BalanceProofLmRn (general).
@@ -2321,10 +2407,13 @@ func (pp *PublicParameter) serializeBalanceProofLmRnGeneral(bpf *BalanceProofLmR
 // reviewed on 2023.12.20
 // refactored and reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) deserializeBalanceProofLmRnGeneral(serializedBpfLmRn []byte) (*BalanceProofLmRnGeneral, error) {
-	r := bytes.NewReader(serializedBpfLmRn)
+	return pp.readBalanceProofLmRnGeneral(bytes.NewReader(serializedBpfLmRn))
+}
 
+// readBalanceProofLmRnGeneral reads a BalanceProofLmRnGeneral from r, which was written by writeBalanceProofLmRnGeneral.
+func (pp *PublicParameter) readBalanceProofLmRnGeneral(r io.Reader) (*BalanceProofLmRnGeneral, error) {
 	// balanceProofCase BalanceProofCase
-	balanceProofCase, err := r.ReadByte()
+	balanceProofCase, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
@@ -2332,17 +2421,17 @@ func (pp *PublicParameter) deserializeBalanceProofLmRnGeneral(serializedBpfLmRn
 	if BalanceProofCase(balanceProofCase) != BalanceProofCaseL0Rn &&
 		BalanceProofCase(balanceProofCase) != BalanceProofCaseL1Rn &&
 		BalanceProofCase(balanceProofCase) != BalanceProofCaseLmRn {
-		return nil, fmt.Errorf("deserializeBalanceProofLmRnGeneral: the deserialized balanceProofCase is not BalanceProofCaseL0Rn, BalanceProofCaseL1Rn, or BalanceProofCaseLmRn")
+		return nil, fmt.Errorf("readBalanceProofLmRnGeneral: the deserialized balanceProofCase is not BalanceProofCaseL0Rn, BalanceProofCaseL1Rn, or BalanceProofCaseLmRn")
 	}
 
 	//	nL      uint8
-	nL, err := r.ReadByte()
+	nL, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	//	nR      uint8
-	nR, err := r.ReadByte()
+	nR, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
@@ -2381,11 +2470,11 @@ func (pp *PublicParameter) deserializeBalanceProofLmRnGeneral(serializedBpfLmRn
 	if n2 > 0xFF {
 		//	when calling rpulpProve, n2 will be converted into uint8.
 		//	we shall always guarantee this point.
-		return nil, fmt.Errorf("deserializeBalanceProofLmRnGeneral: n2 from (nL, nR) (%d) > 0xFF", n2)
+		return nil, fmt.Errorf("readBalanceProofLmRnGeneral: n2 from (nL, nR) (%d) > 0xFF", n2)
 	}
 
 	if n2Read != uint64(n2) {
-		return nil, fmt.Errorf("deserializeBalanceProofLmRnGeneral: the decoded n2 (%d) does not mathc the decoded (nL, nR) (%d, %d)", n2Read, int(nL), int(nR))
+		return nil, fmt.Errorf("readBalanceProofLmRnGeneral: the decoded n2 (%d) does not mathc the decoded (nL, nR) (%d, %d)", n2Read, int(nL), int(nR))
 	}
 
 	c_hats := make([]*PolyCNTT, n2)
@@ -2403,12 +2492,12 @@ func (pp *PublicParameter) deserializeBalanceProofLmRnGeneral(serializedBpfLmRn
 	}
 
 	// rpulpproof       *rpulpProofMLP
-	serializedRpUlpProofBytes := make([]byte, pp.rpulpProofMLPSerializeSizeByCommNum(nL, nR))
-	_, err = io.ReadFull(r, serializedRpUlpProofBytes)
-	if err != nil {
-		return nil, err
-	}
-	rpUlpProof, err := pp.deserializeRpulpProofMLP(serializedRpUlpProofBytes)
+	var rpUlpProof *RpulpProofMLP
+	err = readFixedLength(r, pp.rpulpProofMLPSerializeSizeByCommNum(nL, nR), func(r io.Reader) error {
+		var err error
+		rpUlpProof, err = pp.readRpulpProofMLP(r)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2425,7 +2514,7 @@ func (pp *PublicParameter) deserializeBalanceProofLmRnGeneral(serializedBpfLmRn
 	}
 
 	if !pp.BalanceProofSanityCheck(balanceProofLmRnGeneral) {
-		return nil, fmt.Errorf("deserializeBalanceProofLmRnGeneral: the deserialzied BalanceProofLmRnGeneral is not well-form")
+		return nil, fmt.Errorf("readBalanceProofLmRnGeneral: the deserialzied BalanceProofLmRnGeneral is not well-form")
 	}
 
 	return balanceProofLmRnGeneral, nil
@@ -2910,7 +2999,7 @@ func (pp *PublicParameter) readCarryVectorRProof(r io.Reader) ([]int64, error) {
 	var coeff int64
 	tmp := make([]byte, 3)
 	for i := 0; i < pp.paramDC; i++ {
-		_, err := r.Read(tmp)
+		_, err := io.ReadFull(r, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,102 @@
+package pqringctx
+
+import (
+	"runtime"
+	"sync"
+)
+
+//	Batch Verification	begin
+
+// batchVerifyWorkerNum returns the number of workers used by the batch verification (and the other batch processing) for n transactions,
+// namely min(GOMAXPROCS, n).
+func batchVerifyWorkerNum(n int) int {
+	workerNum := runtime.GOMAXPROCS(0)
+	if workerNum > n {
+		workerNum = n
+	}
+	if workerNum < 1 {
+		workerNum = 1
+	}
+	return workerNum
+}
+
+// runWorkerPool runs task(i) for i in [0, n) over a bounded worker pool, and returns when all the tasks finish.
+// The tasks must be safe to run concurrently.
+func runWorkerPool(n int, task func(i int)) {
+	runWorkerPoolWithWorkerNum(n, batchVerifyWorkerNum(n), task)
+}
+
+// runWorkerPoolWithWorkerNum runs task(i) for i in [0, n) over workerNum workers, and returns when all the tasks finish.
+// workerNum <= 1 means running the tasks one after another, in the calling goroutine.
+// The tasks must be safe to run concurrently.
+func runWorkerPoolWithWorkerNum(n int, workerNum int, task func(i int)) {
+	if n == 0 {
+		return
+	}
+	if workerNum > n {
+		workerNum = n
+	}
+	if workerNum <= 1 {
+		for i := 0; i < n; i++ {
+			task(i)
+		}
+		return
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workerNum)
+	for w := 0; w < workerNum; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				task(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
+
+// runBatchVerify runs verify(i) for i in [0, n) over a bounded worker pool, and returns the per-index results.
+func runBatchVerify(n int, verify func(i int) error) []error {
+	errs := make([]error, n)
+	runWorkerPool(n, func(i int) {
+		errs[i] = verify(i)
+	})
+	return errs
+}
+
+// VerifyCoinbaseTxMLPBatch verifies the input CoinbaseTxMLPs concurrently, using a bounded worker pool.
+// The returned errs has the same length as the input cbTxs, and errs[i] is the result of CoinbaseTxMLPVerify(cbTxs[i]),
+// i.e., errs[i] == nil implies that cbTxs[i] is valid.
+func (pp *PublicParameter) VerifyCoinbaseTxMLPBatch(cbTxs []*CoinbaseTxMLP) []error {
+	return runBatchVerify(len(cbTxs), func(i int) error {
+		if cbTxs[i] == nil {
+			return newTxError(ErrMalformedEncoding, -1, -1, "VerifyCoinbaseTxMLPBatch: the %d -th input CoinbaseTxMLP is nil", i)
+		}
+		return pp.CoinbaseTxMLPVerify(cbTxs[i])
+	})
+}
+
+// VerifyTransferTxMLPBatch verifies the input TransferTxMLPs concurrently, using a bounded worker pool.
+// The returned errs has the same length as the input trTxs, and errs[i] is the result of TransferTxMLPVerify(trTxs[i]),
+// i.e., errs[i] == nil implies that trTxs[i] is valid.
+// The ring members (LgrTxoMLP) that appear in multiple inputs/transactions of the batch are expanded (by expandKIDRMLP) only once.
+// Note that, as TransferTxMLPVerify, this only verifies each transaction itself,
+// and the double-spending across the transactions and the existence of the ring members on the ledger are left to the caller.
+func (pp *PublicParameter) VerifyTransferTxMLPBatch(trTxs []*TransferTxMLP) []error {
+	kidrCache := newLgrTxoKIDRCache()
+	return runBatchVerify(len(trTxs), func(i int) error {
+		if trTxs[i] == nil {
+			return newTxError(ErrMalformedEncoding, -1, -1, "VerifyTransferTxMLPBatch: the %d -th input TransferTxMLP is nil", i)
+		}
+		return pp.transferTxMLPVerify(trTxs[i], kidrCache)
+	})
+}
+
+//	Batch Verification	end
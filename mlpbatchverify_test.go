@@ -0,0 +1,90 @@
+package pqringctx
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPublicParameter_VerifyTransferTxMLPBatch(t *testing.T) {
+	InitialAddress()
+
+	inputRingPreSize := 0
+	inputRingRandSize := 2
+	inputSingleSize := 2
+
+	txInputDescMLPs, totalInputValueForRing, totalInputValueForSingle, _ := GenerateInputWithTypeSize(inputRingPreSize, inputRingRandSize, inputSingleSize)
+	fee := uint64(rand.Intn(int(totalInputValueForRing + totalInputValueForSingle)))
+	totalOutputValue := totalInputValueForRing + totalInputValueForSingle - fee
+	outputValueForRing := uint64(rand.Intn(int(totalOutputValue)))
+	outputValueForSingle := totalOutputValue - outputValueForRing
+
+	//	the transactions spend the same inputs, so that the ring members are shared in the batch
+	trTxs := make([]*TransferTxMLP, 0, 4)
+	for i := 0; i < 3; i++ {
+		txOutputDescMLPs, _ := GenerateOutput(outputValueForRing, outputValueForSingle, 0, 1, 1)
+		trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, RandomBytes(10))
+		if err != nil {
+			t.Fatalf("TransferTxMLPGen() error = %v", err)
+		}
+		trTxs = append(trTxs, trTx)
+	}
+
+	//	tampered fee
+	tampered := NewTransferTxMLP(trTxs[0].txInputs, trTxs[0].txos, trTxs[0].fee+1, trTxs[0].txMemo, trTxs[0].txWitness)
+	trTxs = append(trTxs, tampered, nil)
+
+	errs := pp.VerifyTransferTxMLPBatch(trTxs)
+	if len(errs) != len(trTxs) {
+		t.Fatalf("VerifyTransferTxMLPBatch() returns %d results, want %d", len(errs), len(trTxs))
+	}
+	for i := 0; i < 3; i++ {
+		if errs[i] != nil {
+			t.Errorf("VerifyTransferTxMLPBatch() errs[%d] = %v, want nil", i, errs[i])
+		}
+	}
+	if errs[3] == nil {
+		t.Errorf("VerifyTransferTxMLPBatch() errs[3] = nil, want error for the tampered transaction")
+	}
+	if errs[4] == nil {
+		t.Errorf("VerifyTransferTxMLPBatch() errs[4] = nil, want error for the nil transaction")
+	}
+
+	//	consistent with TransferTxMLPVerify
+	for i := 0; i < 4; i++ {
+		err := pp.TransferTxMLPVerify(trTxs[i])
+		if (err == nil) != (errs[i] == nil) {
+			t.Errorf("TransferTxMLPVerify() error = %v, while VerifyTransferTxMLPBatch() errs[%d] = %v", err, i, errs[i])
+		}
+	}
+
+	if len(pp.VerifyTransferTxMLPBatch(nil)) != 0 {
+		t.Errorf("VerifyTransferTxMLPBatch(nil) shall return an empty result")
+	}
+}
+
+func TestPublicParameter_VerifyCoinbaseTxMLPBatch(t *testing.T) {
+	InitialAddress()
+
+	cbTxs := make([]*CoinbaseTxMLP, 0, 3)
+	for i := 0; i < 2; i++ {
+		txOutputDescMLPs, _ := GenerateOutput(300, 212, 0, 2, 1)
+		cbTx, err := pp.CoinbaseTxMLPGen(512, txOutputDescMLPs, RandomBytes(10))
+		if err != nil {
+			t.Fatalf("CoinbaseTxMLPGen() error = %v", err)
+		}
+		cbTxs = append(cbTxs, cbTx)
+	}
+	//	tampered vin
+	cbTxs = append(cbTxs, NewCoinbaseTxMLP(cbTxs[0].vin+1, cbTxs[0].txos, cbTxs[0].txMemo, cbTxs[0].txWitness))
+
+	errs := pp.VerifyCoinbaseTxMLPBatch(cbTxs)
+	if len(errs) != len(cbTxs) {
+		t.Fatalf("VerifyCoinbaseTxMLPBatch() returns %d results, want %d", len(errs), len(cbTxs))
+	}
+	if errs[0] != nil || errs[1] != nil {
+		t.Errorf("VerifyCoinbaseTxMLPBatch() errs = %v, want nil for the first two", errs)
+	}
+	if errs[2] == nil {
+		t.Errorf("VerifyCoinbaseTxMLPBatch() errs[2] = nil, want error for the tampered transaction")
+	}
+}
@@ -3,6 +3,7 @@ package pqringctx
 import (
 	"bytes"
 	"errors"
+	"io"
 )
 
 // ValueCommitment
@@ -25,33 +26,46 @@ func (pp *PublicParameter) ValueCommitmentSerializeSize() int {
 // moved from serialization.go on 2024.06.21
 // reviewed by Alice, 2024.06.22
 func (pp *PublicParameter) SerializeValueCommitment(vcmt *ValueCommitment) ([]byte, error) {
+	length := pp.ValueCommitmentSerializeSize()
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeValueCommitment(w, vcmt)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeValueCommitment writes the input ValueCommitment to w, in the format of SerializeValueCommitment.
+func (pp *PublicParameter) writeValueCommitment(w io.Writer, vcmt *ValueCommitment) error {
 	var err error
 
 	if !pp.ValueCommitmentSanityCheck(vcmt) {
-		return nil, errors.New("SerializeValueCommitment: the input ValueCommitment is not well-form")
+		return errors.New("writeValueCommitment: the input ValueCommitment is not well-form")
 	}
 
-	length := pp.ValueCommitmentSerializeSize()
-	w := bytes.NewBuffer(make([]byte, 0, length))
 	for i := 0; i < pp.paramKC; i++ {
 		err = pp.writePolyCNTT(w, vcmt.b.polyCNTTs[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 	err = pp.writePolyCNTT(w, vcmt.c)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return w.Bytes(), nil
+	return nil
 }
 
 // DeserializeValueCommitment
 // moved from serialization.go on 2024.06.21
 // reviewed by Alice, 2024.06.22
 func (pp *PublicParameter) DeserializeValueCommitment(serializedValueCommitment []byte) (*ValueCommitment, error) {
+	return pp.readValueCommitment(bytes.NewReader(serializedValueCommitment))
+}
+
+// readValueCommitment reads a ValueCommitment from r, which was written by writeValueCommitment.
+func (pp *PublicParameter) readValueCommitment(r io.Reader) (*ValueCommitment, error) {
 	var err error
-	r := bytes.NewReader(serializedValueCommitment)
 
 	b := pp.NewPolyCNTTVec(pp.paramKC)
 	var c *PolyCNTT
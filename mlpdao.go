@@ -116,6 +116,44 @@ func NewLgrTxoMLP(txo TxoMLP, id []byte) *LgrTxoMLP {
 
 //	New functions for TxInputDesc and TxOutputDesc 	end
 
+//	Get functions for TxInputDesc, TxOutputDesc, and LgrTxo	begin
+
+// GetCoinAddress returns the coinAddress of TxOutputDescMLP.
+func (txOutputDesc *TxOutputDescMLP) GetCoinAddress() []byte {
+	return txOutputDesc.coinAddress
+}
+
+// GetValue returns the value of TxOutputDescMLP.
+func (txOutputDesc *TxOutputDescMLP) GetValue() uint64 {
+	return txOutputDesc.value
+}
+
+// GetLgrTxoToSpend returns the LgrTxoMLP to be consumed, i.e., lgrTxoList[sidx].
+// Note that it returns nil if sidx is out of the range of lgrTxoList.
+func (txInputDesc *TxInputDescMLP) GetLgrTxoToSpend() *LgrTxoMLP {
+	if int(txInputDesc.sidx) >= len(txInputDesc.lgrTxoList) {
+		return nil
+	}
+	return txInputDesc.lgrTxoList[txInputDesc.sidx]
+}
+
+// GetValue returns the value of TxInputDescMLP.
+func (txInputDesc *TxInputDescMLP) GetValue() uint64 {
+	return txInputDesc.value
+}
+
+// GetTxo returns the txo of LgrTxoMLP.
+func (lgrTxo *LgrTxoMLP) GetTxo() TxoMLP {
+	return lgrTxo.txo
+}
+
+// GetId returns the id of LgrTxoMLP.
+func (lgrTxo *LgrTxoMLP) GetId() []byte {
+	return lgrTxo.id
+}
+
+//	Get functions for TxInputDesc, TxOutputDesc, and LgrTxo	end
+
 // New and Get functions for Transactions	begin
 
 // NewCoinbaseTxMLP constructs a new CoinbaseTxMLP from the input (vin uint64, txos []TxoMLP, txMemo []byte, txWitness *TxWitnessCbTx).
@@ -193,4 +231,9 @@ func (txInput *TxInputMLP) GetSerialNumber() []byte {
 	return txInput.serialNumber
 }
 
+// GetLgrTxoList returns the lgrTxoList, i.e., the ring, of TxInputMLP.
+func (txInput *TxInputMLP) GetLgrTxoList() []*LgrTxoMLP {
+	return txInput.lgrTxoList
+}
+
 //	New and Get functions for Transactions	end
@@ -0,0 +1,140 @@
+package pqringctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+//	Errors	begin
+
+// ErrorCode classifies the failures of the sanity-check, (de)serialization, and verification of the MLP transactions,
+// so that the caller, e.g., a mempool, can tell a malformed transaction from one with an invalid proof or signature.
+// Each ErrorCode is an error itself, and serves as the sentinel for errors.Is, e.g., errors.Is(err, ErrBalanceProofFailed).
+type ErrorCode int
+
+const (
+	// ErrMalformedEncoding denotes that the (serialized) transaction is not well-form.
+	ErrMalformedEncoding ErrorCode = iota + 1
+	// ErrRingMemberInvalid denotes that the ring of an input is not well-form, or contains an unexpected member.
+	ErrRingMemberInvalid
+	// ErrSerialNumberMismatch denotes that the serial number of an input is different from the computed one.
+	ErrSerialNumberMismatch
+	// ErrDoubleSpending denotes that two inputs of the transaction have the same serial number.
+	ErrDoubleSpending
+	// ErrSignatureInvalid denotes that the (elr or simple) signature of an input is invalid.
+	ErrSignatureInvalid
+	// ErrBalanceProofFailed denotes that the balance proof is invalid.
+	ErrBalanceProofFailed
+	// ErrRangeProofFailed denotes that the range proof, which is a part of the balance proof, is invalid.
+	ErrRangeProofFailed
+)
+
+// String returns the name of the ErrorCode.
+func (code ErrorCode) String() string {
+	switch code {
+	case ErrMalformedEncoding:
+		return "malformed encoding"
+	case ErrRingMemberInvalid:
+		return "ring member invalid"
+	case ErrSerialNumberMismatch:
+		return "serial number mismatch"
+	case ErrDoubleSpending:
+		return "double spending"
+	case ErrSignatureInvalid:
+		return "signature invalid"
+	case ErrBalanceProofFailed:
+		return "balance proof failed"
+	case ErrRangeProofFailed:
+		return "range proof failed"
+	default:
+		return fmt.Sprintf("unknown error code (%d)", int(code))
+	}
+}
+
+// Error implements error.
+func (code ErrorCode) Error() string {
+	return code.String()
+}
+
+// TxError is the error returned by the sanity-check, deserialization, and verification of the MLP transactions.
+// It carries the ErrorCode and the index of the related input or output (-1 if not applicable),
+// and can be obtained by errors.As.
+// Note that Error() returns the message of the underlying error, so that the messages stay the same as before.
+type TxError struct {
+	Code        ErrorCode
+	InputIndex  int
+	OutputIndex int
+	Err         error
+}
+
+// Error implements error.
+func (e *TxError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is the ErrorCode of the TxError.
+func (e *TxError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == e.Code
+}
+
+// GetErrorCode returns the ErrorCode carried by the input err, and 0 if there is none.
+func GetErrorCode(err error) ErrorCode {
+	var txErr *TxError
+	if errors.As(err, &txErr) {
+		return txErr.Code
+	}
+	return 0
+}
+
+// newTxError returns a TxError with the input code and indices, and the message formatted by fmt.Errorf.
+func newTxError(code ErrorCode, inputIndex int, outputIndex int, format string, a ...interface{}) *TxError {
+	return &TxError{
+		Code:        code,
+		InputIndex:  inputIndex,
+		OutputIndex: outputIndex,
+		Err:         fmt.Errorf(format, a...),
+	}
+}
+
+// wrapTxError wraps the input err into a TxError with the input code and indices.
+// If err already carries a TxError, its ErrorCode is kept, and only its unknown (-1) indices are filled in by the input ones.
+// It returns nil if the input err is nil, and returns err unchanged if it is due to the cancellation of the context (see WithContext),
+// since such an error does not imply that the transaction is invalid.
+func wrapTxError(err error, code ErrorCode, inputIndex int, outputIndex int) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var txErr *TxError
+	if errors.As(err, &txErr) {
+		if (inputIndex < 0 || txErr.InputIndex >= 0) && (outputIndex < 0 || txErr.OutputIndex >= 0) {
+			return err
+		}
+		code = txErr.Code
+		if txErr.InputIndex >= 0 {
+			inputIndex = txErr.InputIndex
+		}
+		if txErr.OutputIndex >= 0 {
+			outputIndex = txErr.OutputIndex
+		}
+	}
+
+	return &TxError{
+		Code:        code,
+		InputIndex:  inputIndex,
+		OutputIndex: outputIndex,
+		Err:         err,
+	}
+}
+
+//	Errors	end
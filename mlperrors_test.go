@@ -0,0 +1,129 @@
+package pqringctx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPublicParameter_TransferTxMLPVerify_ErrorCode(t *testing.T) {
+	InitialAddress()
+
+	genTrTx := func(t *testing.T, inputRingRandSize int, outputRingRandNum int, fee uint64) *TransferTxMLP {
+		txInputDescs, totalInputValueForRing, _, _ := GenerateInputWithTypeSize(0, inputRingRandSize, 0)
+		if totalInputValueForRing < fee+uint64(outputRingRandNum) {
+			t.Skipf("the generated input value (%d) is too small", totalInputValueForRing)
+		}
+		txOutputDescs := GenerateOutputWithValues(nil, SplitNum(totalInputValueForRing-fee, outputRingRandNum), nil)
+		trTx, err := pp.TransferTxMLPGen(txInputDescs, txOutputDescs, fee, RandomBytes(10))
+		if err != nil {
+			t.Fatalf("TransferTxMLPGen: %v", err)
+		}
+		if err = pp.TransferTxMLPVerify(trTx); err != nil {
+			t.Fatalf("TransferTxMLPVerify: %v", err)
+		}
+		return trTx
+	}
+
+	checkTxError := func(t *testing.T, err error, code ErrorCode, inputIndex int) {
+		if !errors.Is(err, code) {
+			t.Fatalf("the error (%v) is not %v", err, code)
+		}
+		var txErr *TxError
+		if !errors.As(err, &txErr) {
+			t.Fatalf("the error (%v) is not a TxError", err)
+		}
+		if txErr.InputIndex != inputIndex {
+			t.Fatalf("the error (%v) has InputIndex %d, rather than %d", err, txErr.InputIndex, inputIndex)
+		}
+		if GetErrorCode(err) != code {
+			t.Fatalf("GetErrorCode returns %v, rather than %v", GetErrorCode(err), code)
+		}
+	}
+
+	t.Run("malformed encoding", func(t *testing.T) {
+		trTx := genTrTx(t, 2, 1, 10)
+		serializedTrTx, err := pp.SerializeTransferTxMLP(trTx, true)
+		if err != nil {
+			t.Fatalf("SerializeTransferTxMLP: %v", err)
+		}
+		_, err = pp.DeserializeTransferTxMLP(serializedTrTx[:len(serializedTrTx)-1], true)
+		checkTxError(t, err, ErrMalformedEncoding, -1)
+	})
+
+	t.Run("serial number mismatch and double spending", func(t *testing.T) {
+		trTx := genTrTx(t, 2, 1, 10)
+		serialNumber := trTx.txInputs[0].serialNumber
+
+		//	the serial numbers are also authenticated by the signatures, so that only the first input is reported as mismatching.
+		trTx.txInputs[0].serialNumber = make([]byte, len(serialNumber))
+		checkTxError(t, pp.TransferTxMLPVerify(trTx), ErrSerialNumberMismatch, 0)
+
+		trTx.txInputs[0].serialNumber = serialNumber
+		trTx.txInputs[1].serialNumber = serialNumber
+		checkTxError(t, pp.TransferTxMLPVerify(trTx), ErrDoubleSpending, 1)
+	})
+
+	t.Run("signature invalid", func(t *testing.T) {
+		trTx := genTrTx(t, 2, 1, 10)
+		trTx.txMemo[0] ^= 1
+		checkTxError(t, pp.TransferTxMLPVerify(trTx), ErrSignatureInvalid, 0)
+	})
+
+	t.Run("balance proof failed", func(t *testing.T) {
+		//	1 RingCT-Privacy input, 1 RingCT-Privacy output, and vPublic = 0, so that BalanceProofL1R1 is used.
+		trTx := genTrTx(t, 1, 1, 0)
+		bpf, ok := trTx.txWitness.balanceProof.(*BalanceProofL1R1)
+		if !ok {
+			t.Fatalf("the balance proof is not BalanceProofL1R1")
+		}
+		bpf.chseed[0] ^= 1
+		checkTxError(t, pp.TransferTxMLPVerify(trTx), ErrBalanceProofFailed, -1)
+	})
+
+	t.Run("range proof failed", func(t *testing.T) {
+		//	1 RingCT-Privacy input and 2 RingCT-Privacy outputs, so that BalanceProofLmRnGeneral (L1Rn) is used.
+		trTx := genTrTx(t, 1, 2, 10)
+		bpf, ok := trTx.txWitness.balanceProof.(*BalanceProofLmRnGeneral)
+		if !ok {
+			t.Fatalf("the balance proof is not BalanceProofLmRnGeneral")
+		}
+		if bpf.u_p[0] != 0 {
+			bpf.u_p[0] = -bpf.u_p[0]
+		} else {
+			bpf.u_p[0] = 1
+		}
+		err := pp.TransferTxMLPVerify(trTx)
+		checkTxError(t, err, ErrRangeProofFailed, -1)
+		if errors.Is(err, ErrBalanceProofFailed) {
+			t.Fatalf("the error (%v) is both %v and %v", err, ErrRangeProofFailed, ErrBalanceProofFailed)
+		}
+	})
+}
+
+func TestPublicParameter_CoinbaseTxMLPSanityCheck_ErrorCode(t *testing.T) {
+	InitialAddress()
+
+	txOutputDescs := GenerateOutputWithValues(nil, []uint64{100}, []uint64{50})
+	cbTx, err := pp.CoinbaseTxMLPGen(150, txOutputDescs, RandomBytes(10))
+	if err != nil {
+		t.Fatalf("CoinbaseTxMLPGen: %v", err)
+	}
+	if err = pp.CoinbaseTxMLPSanityCheck(cbTx, true); err != nil {
+		t.Fatalf("CoinbaseTxMLPSanityCheck: %v", err)
+	}
+
+	if err = pp.CoinbaseTxMLPSanityCheck(nil, true); !errors.Is(err, ErrMalformedEncoding) {
+		t.Fatalf("the error (%v) for a nil CoinbaseTxMLP is not %v", err, ErrMalformedEncoding)
+	}
+
+	//	the public value of the pseudonym-privacy output exceeds vin
+	cbTx.vin = 10
+	err = pp.CoinbaseTxMLPSanityCheck(cbTx, true)
+	if !errors.Is(err, ErrMalformedEncoding) {
+		t.Fatalf("the error (%v) for a CoinbaseTxMLP with vin < vOutPublic is not %v", err, ErrMalformedEncoding)
+	}
+	var txErr *TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("the error (%v) is not a TxError", err)
+	}
+}
@@ -0,0 +1,854 @@
+package pqringctx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+//	JSON Encoding	begin
+//
+// The MLP transactions and their components are encoded in JSON by the XxxToJSON/XxxFromJSON functions below,
+// e.g., for RPC and block explorers.
+// The schema is stable, namely, fields are only added in a backward-compatible manner, and is as follows,
+// where <hex> denotes a lowercase hex string, and the heavy components (polynomials, commitments, signatures, and proofs)
+// are given as <hex> of their binary serialization, which is the same as that in SerializeTransferTxMLP.
+//
+//	TxoMLP:
+//	{
+//	  "coinAddressType": <number>,                  // CoinAddressType, which determines the other fields
+//	  "addressPublicKeyForRing": <hex>,             // CoinAddressTypePublicKeyForRingPre and CoinAddressTypePublicKeyForRing only
+//	  "addressPublicKeyForSingleHash": <hex>,       // CoinAddressTypePublicKeyHashForSingle only
+//	  "publicRand": <hex>,                          // CoinAddressTypePublicKeyForRing and CoinAddressTypePublicKeyHashForSingle only
+//	  "detectorTag": <hex>,                         // CoinAddressTypePublicKeyForRing and CoinAddressTypePublicKeyHashForSingle only
+//	  "valueCommitment": <hex>,                     // CoinAddressTypePublicKeyForRingPre and CoinAddressTypePublicKeyForRing only
+//	  "vct": <hex>,                                 // CoinAddressTypePublicKeyForRingPre and CoinAddressTypePublicKeyForRing only
+//	  "ctKem": <hex>,                               // CoinAddressTypePublicKeyForRingPre and CoinAddressTypePublicKeyForRing only
+//	  "value": <number>                             // CoinAddressTypePublicKeyHashForSingle only
+//	}
+//
+//	TxInputMLP:
+//	{
+//	  "ringMembers": [ { "id": <hex>, "txo": TxoMLP }, ... ],
+//	  "serialNumber": <hex>
+//	}
+//
+//	TxWitnessCbTx:
+//	{
+//	  "txCase": <number>,                           // TxWitnessCbTxCase
+//	  "vL": <number>,
+//	  "outForRing": <number>,
+//	  "outForSingle": <number>,
+//	  "balanceProofCase": <number>,                 // BalanceProofCase
+//	  "balanceProof": <hex>
+//	}
+//
+//	TxWitnessTrTx:
+//	{
+//	  "txCase": <number>,                           // TxWitnessTrTxCase
+//	  "inForRing": <number>,
+//	  "inForSingle": <number>,
+//	  "inForSingleDistinct": <number>,
+//	  "inRingSizes": [ <number>, ... ],             // length inForRing
+//	  "outForRing": <number>,
+//	  "outForSingle": <number>,
+//	  "vPublic": <number>,                          // signed
+//	  "maPs": [ <hex>, ... ],                       // length inForRing, the key-images
+//	  "cmtsInP": [ <hex>, ... ],                    // length inForRing
+//	  "elrSigs": [ <hex>, ... ],                    // length inForRing
+//	  "addressPublicKeyForSingles": [ <hex>, ... ], // length inForSingleDistinct
+//	  "simpleSigs": [ <hex>, ... ],                 // length inForSingleDistinct
+//	  "balanceProofCase": <number>,                 // BalanceProofCase
+//	  "balanceProof": <hex>
+//	}
+//
+//	CoinbaseTxMLP:
+//	{
+//	  "vin": <number>,
+//	  "txos": [ TxoMLP, ... ],
+//	  "txMemo": <hex>,
+//	  "txWitness": TxWitnessCbTx                    // only if withWitness
+//	}
+//
+//	TransferTxMLP:
+//	{
+//	  "txInputs": [ TxInputMLP, ... ],
+//	  "txos": [ TxoMLP, ... ],
+//	  "fee": <number>,
+//	  "txMemo": <hex>,
+//	  "txWitness": TxWitnessTrTx                    // only if withWitness
+//	}
+//
+// Note that the uint64 values (e.g., value, vin, and fee) are encoded as JSON numbers,
+// so that the JSON decoders which use float64 for numbers (e.g., JavaScript) shall take care of the values above 2^53.
+
+// hexBytes is a []byte encoded as a hex string in JSON.
+type hexBytes []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	decoded, err := hex.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// checkHexBytesLen checks whether the input hexBytes, which is the field name of a JSON object, has the expected length.
+func checkHexBytesLen(name string, b hexBytes, expectedLen int) error {
+	if len(b) != expectedLen {
+		return fmt.Errorf("the %s has length %d, but %d is expected", name, len(b), expectedLen)
+	}
+	return nil
+}
+
+type txoMLPJSON struct {
+	CoinAddressType               CoinAddressType `json:"coinAddressType"`
+	AddressPublicKeyForRing       hexBytes        `json:"addressPublicKeyForRing,omitempty"`
+	AddressPublicKeyForSingleHash hexBytes        `json:"addressPublicKeyForSingleHash,omitempty"`
+	PublicRand                    hexBytes        `json:"publicRand,omitempty"`
+	DetectorTag                   hexBytes        `json:"detectorTag,omitempty"`
+	ValueCommitment               hexBytes        `json:"valueCommitment,omitempty"`
+	Vct                           hexBytes        `json:"vct,omitempty"`
+	CtKem                         hexBytes        `json:"ctKem,omitempty"`
+	Value                         *uint64         `json:"value,omitempty"`
+}
+
+type lgrTxoMLPJSON struct {
+	Id  hexBytes    `json:"id"`
+	Txo *txoMLPJSON `json:"txo"`
+}
+
+type txInputMLPJSON struct {
+	RingMembers  []*lgrTxoMLPJSON `json:"ringMembers"`
+	SerialNumber hexBytes         `json:"serialNumber"`
+}
+
+type txWitnessCbTxJSON struct {
+	TxCase           TxWitnessCbTxCase `json:"txCase"`
+	VL               uint64            `json:"vL"`
+	OutForRing       uint8             `json:"outForRing"`
+	OutForSingle     uint8             `json:"outForSingle"`
+	BalanceProofCase BalanceProofCase  `json:"balanceProofCase"`
+	BalanceProof     hexBytes          `json:"balanceProof"`
+}
+
+type txWitnessTrTxJSON struct {
+	TxCase                     TxWitnessTrTxCase `json:"txCase"`
+	InForRing                  uint8             `json:"inForRing"`
+	InForSingle                uint8             `json:"inForSingle"`
+	InForSingleDistinct        uint8             `json:"inForSingleDistinct"`
+	InRingSizes                []int             `json:"inRingSizes"` //	[]uint8 would be encoded as a base64 string
+	OutForRing                 uint8             `json:"outForRing"`
+	OutForSingle               uint8             `json:"outForSingle"`
+	VPublic                    int64             `json:"vPublic"`
+	MaPs                       []hexBytes        `json:"maPs"`
+	CmtsInP                    []hexBytes        `json:"cmtsInP"`
+	ElrSigs                    []hexBytes        `json:"elrSigs"`
+	AddressPublicKeyForSingles []hexBytes        `json:"addressPublicKeyForSingles"`
+	SimpleSigs                 []hexBytes        `json:"simpleSigs"`
+	BalanceProofCase           BalanceProofCase  `json:"balanceProofCase"`
+	BalanceProof               hexBytes          `json:"balanceProof"`
+}
+
+type coinbaseTxMLPJSON struct {
+	Vin       uint64             `json:"vin"`
+	Txos      []*txoMLPJSON      `json:"txos"`
+	TxMemo    hexBytes           `json:"txMemo"`
+	TxWitness *txWitnessCbTxJSON `json:"txWitness,omitempty"`
+}
+
+type transferTxMLPJSON struct {
+	TxInputs  []*txInputMLPJSON  `json:"txInputs"`
+	Txos      []*txoMLPJSON      `json:"txos"`
+	Fee       uint64             `json:"fee"`
+	TxMemo    hexBytes           `json:"txMemo"`
+	TxWitness *txWitnessTrTxJSON `json:"txWitness,omitempty"`
+}
+
+// TxoMLPToJSON encodes the input TxoMLP in JSON.
+func (pp *PublicParameter) TxoMLPToJSON(txoMLP TxoMLP) ([]byte, error) {
+	txoJSON, err := pp.txoMLPToJSON(txoMLP)
+	if err != nil {
+		return nil, fmt.Errorf("TxoMLPToJSON: %v", err)
+	}
+	return json.Marshal(txoJSON)
+}
+
+// TxoMLPFromJSON decodes the input JSON, which is generated by TxoMLPToJSON, to a TxoMLP.
+func (pp *PublicParameter) TxoMLPFromJSON(data []byte) (TxoMLP, error) {
+	var txoJSON txoMLPJSON
+	if err := json.Unmarshal(data, &txoJSON); err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxoMLPFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	txoMLP, err := pp.txoMLPFromJSON(&txoJSON)
+	if err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxoMLPFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	return txoMLP, nil
+}
+
+// TxInputMLPToJSON encodes the input TxInputMLP in JSON.
+func (pp *PublicParameter) TxInputMLPToJSON(txInput *TxInputMLP) ([]byte, error) {
+	txInputJSON, err := pp.txInputMLPToJSON(txInput)
+	if err != nil {
+		return nil, fmt.Errorf("TxInputMLPToJSON: %v", err)
+	}
+	return json.Marshal(txInputJSON)
+}
+
+// TxInputMLPFromJSON decodes the input JSON, which is generated by TxInputMLPToJSON, to a TxInputMLP.
+func (pp *PublicParameter) TxInputMLPFromJSON(data []byte) (*TxInputMLP, error) {
+	var txInputJSON txInputMLPJSON
+	if err := json.Unmarshal(data, &txInputJSON); err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxInputMLPFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	txInput, err := pp.txInputMLPFromJSON(&txInputJSON)
+	if err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxInputMLPFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	return txInput, nil
+}
+
+// TxWitnessCbTxToJSON encodes the input TxWitnessCbTx in JSON.
+func (pp *PublicParameter) TxWitnessCbTxToJSON(txWitness *TxWitnessCbTx) ([]byte, error) {
+	txWitnessJSON, err := pp.txWitnessCbTxToJSON(txWitness)
+	if err != nil {
+		return nil, fmt.Errorf("TxWitnessCbTxToJSON: %v", err)
+	}
+	return json.Marshal(txWitnessJSON)
+}
+
+// TxWitnessCbTxFromJSON decodes the input JSON, which is generated by TxWitnessCbTxToJSON, to a TxWitnessCbTx.
+func (pp *PublicParameter) TxWitnessCbTxFromJSON(data []byte) (*TxWitnessCbTx, error) {
+	var txWitnessJSON txWitnessCbTxJSON
+	if err := json.Unmarshal(data, &txWitnessJSON); err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxWitnessCbTxFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	txWitness, err := pp.txWitnessCbTxFromJSON(&txWitnessJSON)
+	if err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxWitnessCbTxFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	return txWitness, nil
+}
+
+// TxWitnessTrTxToJSON encodes the input TxWitnessTrTx in JSON.
+func (pp *PublicParameter) TxWitnessTrTxToJSON(txWitness *TxWitnessTrTx) ([]byte, error) {
+	txWitnessJSON, err := pp.txWitnessTrTxToJSON(txWitness)
+	if err != nil {
+		return nil, fmt.Errorf("TxWitnessTrTxToJSON: %v", err)
+	}
+	return json.Marshal(txWitnessJSON)
+}
+
+// TxWitnessTrTxFromJSON decodes the input JSON, which is generated by TxWitnessTrTxToJSON, to a TxWitnessTrTx.
+func (pp *PublicParameter) TxWitnessTrTxFromJSON(data []byte) (*TxWitnessTrTx, error) {
+	var txWitnessJSON txWitnessTrTxJSON
+	if err := json.Unmarshal(data, &txWitnessJSON); err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxWitnessTrTxFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	txWitness, err := pp.txWitnessTrTxFromJSON(&txWitnessJSON)
+	if err != nil {
+		return nil, wrapTxError(fmt.Errorf("TxWitnessTrTxFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+	return txWitness, nil
+}
+
+// CoinbaseTxMLPToJSON encodes the input CoinbaseTxMLP in JSON, where the txWitness is included only if withWitness is true.
+func (pp *PublicParameter) CoinbaseTxMLPToJSON(cbTx *CoinbaseTxMLP, withWitness bool) ([]byte, error) {
+	err := pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness)
+	if err != nil {
+		return nil, fmt.Errorf("CoinbaseTxMLPToJSON: the input cbTx *CoinbaseTxMLP is not well-form: %w", err)
+	}
+
+	cbTxJSON := &coinbaseTxMLPJSON{
+		Vin:    cbTx.vin,
+		Txos:   make([]*txoMLPJSON, len(cbTx.txos)),
+		TxMemo: cbTx.txMemo,
+	}
+	for i := 0; i < len(cbTx.txos); i++ {
+		cbTxJSON.Txos[i], err = pp.txoMLPToJSON(cbTx.txos[i])
+		if err != nil {
+			return nil, fmt.Errorf("CoinbaseTxMLPToJSON: txos[%d]: %v", i, err)
+		}
+	}
+	if withWitness {
+		cbTxJSON.TxWitness, err = pp.txWitnessCbTxToJSON(cbTx.txWitness)
+		if err != nil {
+			return nil, fmt.Errorf("CoinbaseTxMLPToJSON: txWitness: %v", err)
+		}
+	}
+
+	return json.Marshal(cbTxJSON)
+}
+
+// CoinbaseTxMLPFromJSON decodes the input JSON, which is generated by CoinbaseTxMLPToJSON, to a CoinbaseTxMLP.
+// The txWitness is decoded only if withWitness is true, and must be present in that case.
+// As DeserializeCoinbaseTxMLP, the returned error is a TxError with ErrMalformedEncoding.
+func (pp *PublicParameter) CoinbaseTxMLPFromJSON(data []byte, withWitness bool) (*CoinbaseTxMLP, error) {
+	var cbTxJSON coinbaseTxMLPJSON
+	if err := json.Unmarshal(data, &cbTxJSON); err != nil {
+		return nil, wrapTxError(fmt.Errorf("CoinbaseTxMLPFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+
+	if len(cbTxJSON.Txos) > int(pp.paramJ)+int(pp.paramJSingle) {
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPFromJSON: the outputNum (%d) exceeds the allowed maximum value (%d)", len(cbTxJSON.Txos), int(pp.paramJ)+int(pp.paramJSingle))
+	}
+	txos := make([]TxoMLP, len(cbTxJSON.Txos))
+	for i := 0; i < len(cbTxJSON.Txos); i++ {
+		txo, err := pp.txoMLPFromJSON(cbTxJSON.Txos[i])
+		if err != nil {
+			return nil, newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPFromJSON: txos[%d]: %v", i, err)
+		}
+		txos[i] = txo
+	}
+
+	var txWitness *TxWitnessCbTx
+	if withWitness {
+		var err error
+		txWitness, err = pp.txWitnessCbTxFromJSON(cbTxJSON.TxWitness)
+		if err != nil {
+			return nil, newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPFromJSON: txWitness: %v", err)
+		}
+	}
+
+	cbTx := &CoinbaseTxMLP{
+		vin:       cbTxJSON.Vin,
+		txos:      txos,
+		txMemo:    cbTxJSON.TxMemo,
+		txWitness: txWitness,
+	}
+
+	err := pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness)
+	if err != nil {
+		return nil, fmt.Errorf("CoinbaseTxMLPFromJSON: the decoded CoinbaseTxMLP is not well-form: %w", err)
+	}
+
+	return cbTx, nil
+}
+
+// TransferTxMLPToJSON encodes the input TransferTxMLP in JSON, where the txWitness is included only if withWitness is true.
+func (pp *PublicParameter) TransferTxMLPToJSON(trTx *TransferTxMLP, withWitness bool) ([]byte, error) {
+	err := pp.TransferTxMLPSanityCheck(trTx, withWitness)
+	if err != nil {
+		return nil, fmt.Errorf("TransferTxMLPToJSON: the input trTx *TransferTxMLP is not well-form: %w", err)
+	}
+
+	trTxJSON := &transferTxMLPJSON{
+		TxInputs: make([]*txInputMLPJSON, len(trTx.txInputs)),
+		Txos:     make([]*txoMLPJSON, len(trTx.txos)),
+		Fee:      trTx.fee,
+		TxMemo:   trTx.txMemo,
+	}
+	for i := 0; i < len(trTx.txInputs); i++ {
+		trTxJSON.TxInputs[i], err = pp.txInputMLPToJSON(trTx.txInputs[i])
+		if err != nil {
+			return nil, fmt.Errorf("TransferTxMLPToJSON: txInputs[%d]: %v", i, err)
+		}
+	}
+	for i := 0; i < len(trTx.txos); i++ {
+		trTxJSON.Txos[i], err = pp.txoMLPToJSON(trTx.txos[i])
+		if err != nil {
+			return nil, fmt.Errorf("TransferTxMLPToJSON: txos[%d]: %v", i, err)
+		}
+	}
+	if withWitness {
+		trTxJSON.TxWitness, err = pp.txWitnessTrTxToJSON(trTx.txWitness)
+		if err != nil {
+			return nil, fmt.Errorf("TransferTxMLPToJSON: txWitness: %v", err)
+		}
+	}
+
+	return json.Marshal(trTxJSON)
+}
+
+// TransferTxMLPFromJSON decodes the input JSON, which is generated by TransferTxMLPToJSON, to a TransferTxMLP.
+// The txWitness is decoded only if withWitness is true, and must be present in that case.
+// As DeserializeTransferTxMLP, the returned error is a TxError with ErrMalformedEncoding.
+func (pp *PublicParameter) TransferTxMLPFromJSON(data []byte, withWitness bool) (*TransferTxMLP, error) {
+	var trTxJSON transferTxMLPJSON
+	if err := json.Unmarshal(data, &trTxJSON); err != nil {
+		return nil, wrapTxError(fmt.Errorf("TransferTxMLPFromJSON: %v", err), ErrMalformedEncoding, -1, -1)
+	}
+
+	if len(trTxJSON.TxInputs) > int(pp.paramI)+int(pp.paramISingle) {
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPFromJSON: the inputNum (%d) exceeds the allowed maximum value (%d)", len(trTxJSON.TxInputs), int(pp.paramI)+int(pp.paramISingle))
+	}
+	txInputs := make([]*TxInputMLP, len(trTxJSON.TxInputs))
+	for i := 0; i < len(trTxJSON.TxInputs); i++ {
+		txInput, err := pp.txInputMLPFromJSON(trTxJSON.TxInputs[i])
+		if err != nil {
+			return nil, newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPFromJSON: txInputs[%d]: %v", i, err)
+		}
+		txInputs[i] = txInput
+	}
+
+	if len(trTxJSON.Txos) > int(pp.paramJ)+int(pp.paramJSingle) {
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPFromJSON: the outputNum (%d) exceeds the allowed maximum value (%d)", len(trTxJSON.Txos), int(pp.paramJ)+int(pp.paramJSingle))
+	}
+	txos := make([]TxoMLP, len(trTxJSON.Txos))
+	for i := 0; i < len(trTxJSON.Txos); i++ {
+		txo, err := pp.txoMLPFromJSON(trTxJSON.Txos[i])
+		if err != nil {
+			return nil, newTxError(ErrMalformedEncoding, -1, i, "TransferTxMLPFromJSON: txos[%d]: %v", i, err)
+		}
+		txos[i] = txo
+	}
+
+	var txWitness *TxWitnessTrTx
+	if withWitness {
+		var err error
+		txWitness, err = pp.txWitnessTrTxFromJSON(trTxJSON.TxWitness)
+		if err != nil {
+			return nil, newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPFromJSON: txWitness: %v", err)
+		}
+	}
+
+	trTx := &TransferTxMLP{
+		txInputs:  txInputs,
+		txos:      txos,
+		fee:       trTxJSON.Fee,
+		txMemo:    trTxJSON.TxMemo,
+		txWitness: txWitness,
+	}
+
+	err := pp.TransferTxMLPSanityCheck(trTx, withWitness)
+	if err != nil {
+		return nil, fmt.Errorf("TransferTxMLPFromJSON: the decoded TransferTxMLP is not well-form, %w", err)
+	}
+
+	return trTx, nil
+}
+
+// txoMLPToJSON converts the input TxoMLP to its JSON object.
+func (pp *PublicParameter) txoMLPToJSON(txoMLP TxoMLP) (*txoMLPJSON, error) {
+	if !pp.TxoMLPSanityCheck(txoMLP) {
+		return nil, fmt.Errorf("the input TxoMLP is not well-form")
+	}
+
+	switch txoInst := txoMLP.(type) {
+	case *TxoRCTPre:
+		serializedApk, err := pp.serializeAddressPublicKeyForRing(txoInst.addressPublicKeyForRing)
+		if err != nil {
+			return nil, err
+		}
+		serializedCmt, err := pp.SerializeValueCommitment(txoInst.valueCommitment)
+		if err != nil {
+			return nil, err
+		}
+		return &txoMLPJSON{
+			CoinAddressType:         txoInst.coinAddressType,
+			AddressPublicKeyForRing: serializedApk,
+			ValueCommitment:         serializedCmt,
+			Vct:                     txoInst.vct,
+			CtKem:                   txoInst.ctKemSerialized,
+		}, nil
+
+	case *TxoRCT:
+		serializedApk, err := pp.serializeAddressPublicKeyForRing(txoInst.addressPublicKeyForRing)
+		if err != nil {
+			return nil, err
+		}
+		serializedCmt, err := pp.SerializeValueCommitment(txoInst.valueCommitment)
+		if err != nil {
+			return nil, err
+		}
+		return &txoMLPJSON{
+			CoinAddressType:         txoInst.coinAddressType,
+			AddressPublicKeyForRing: serializedApk,
+			PublicRand:              txoInst.publicRand,
+			DetectorTag:             txoInst.detectorTag,
+			ValueCommitment:         serializedCmt,
+			Vct:                     txoInst.vct,
+			CtKem:                   txoInst.ctKemSerialized,
+		}, nil
+
+	case *TxoSDN:
+		value := txoInst.value
+		return &txoMLPJSON{
+			CoinAddressType:               txoInst.coinAddressType,
+			AddressPublicKeyForSingleHash: txoInst.addressPublicKeyForSingleHash,
+			PublicRand:                    txoInst.publicRand,
+			DetectorTag:                   txoInst.detectorTag,
+			Value:                         &value,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("the input TxoMLP is not TxoRCTPre, TxoRCT, or TxoSDN")
+	}
+}
+
+// txoMLPFromJSON converts the input JSON object to a TxoMLP.
+func (pp *PublicParameter) txoMLPFromJSON(txoJSON *txoMLPJSON) (TxoMLP, error) {
+	if txoJSON == nil {
+		return nil, fmt.Errorf("the txo is missing")
+	}
+
+	var txoMLP TxoMLP
+	switch txoJSON.CoinAddressType {
+	case CoinAddressTypePublicKeyForRingPre, CoinAddressTypePublicKeyForRing:
+		if len(txoJSON.AddressPublicKeyForSingleHash) != 0 || txoJSON.Value != nil {
+			return nil, fmt.Errorf("the txo with coinAddressType %d has the fields for pseudonym-privacy", txoJSON.CoinAddressType)
+		}
+		if err := checkHexBytesLen("addressPublicKeyForRing", txoJSON.AddressPublicKeyForRing, pp.addressPublicKeyForRingSerializeSize()); err != nil {
+			return nil, err
+		}
+		apk, err := pp.deserializeAddressPublicKeyForRing(txoJSON.AddressPublicKeyForRing)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkHexBytesLen("valueCommitment", txoJSON.ValueCommitment, pp.ValueCommitmentSerializeSize()); err != nil {
+			return nil, err
+		}
+		cmt, err := pp.DeserializeValueCommitment(txoJSON.ValueCommitment)
+		if err != nil {
+			return nil, err
+		}
+
+		if txoJSON.CoinAddressType == CoinAddressTypePublicKeyForRingPre {
+			if len(txoJSON.PublicRand) != 0 || len(txoJSON.DetectorTag) != 0 {
+				return nil, fmt.Errorf("the txo with coinAddressType %d has publicRand or detectorTag", txoJSON.CoinAddressType)
+			}
+			txoMLP = &TxoRCTPre{
+				coinAddressType:         txoJSON.CoinAddressType,
+				addressPublicKeyForRing: apk,
+				valueCommitment:         cmt,
+				vct:                     txoJSON.Vct,
+				ctKemSerialized:         txoJSON.CtKem,
+			}
+		} else {
+			txoMLP = &TxoRCT{
+				coinAddressType:         txoJSON.CoinAddressType,
+				addressPublicKeyForRing: apk,
+				publicRand:              txoJSON.PublicRand,
+				detectorTag:             txoJSON.DetectorTag,
+				valueCommitment:         cmt,
+				vct:                     txoJSON.Vct,
+				ctKemSerialized:         txoJSON.CtKem,
+			}
+		}
+
+	case CoinAddressTypePublicKeyHashForSingle:
+		if len(txoJSON.AddressPublicKeyForRing) != 0 || len(txoJSON.ValueCommitment) != 0 || len(txoJSON.Vct) != 0 || len(txoJSON.CtKem) != 0 {
+			return nil, fmt.Errorf("the txo with coinAddressType %d has the fields for RingCT-privacy", txoJSON.CoinAddressType)
+		}
+		if txoJSON.Value == nil {
+			return nil, fmt.Errorf("the txo with coinAddressType %d has no value", txoJSON.CoinAddressType)
+		}
+		txoMLP = &TxoSDN{
+			coinAddressType:               txoJSON.CoinAddressType,
+			addressPublicKeyForSingleHash: txoJSON.AddressPublicKeyForSingleHash,
+			publicRand:                    txoJSON.PublicRand,
+			detectorTag:                   txoJSON.DetectorTag,
+			value:                         *txoJSON.Value,
+		}
+
+	default:
+		return nil, fmt.Errorf("the coinAddressType (%d) is not supported", txoJSON.CoinAddressType)
+	}
+
+	if !pp.TxoMLPSanityCheck(txoMLP) {
+		return nil, fmt.Errorf("the decoded TxoMLP is not well-form")
+	}
+	return txoMLP, nil
+}
+
+// txInputMLPToJSON converts the input TxInputMLP to its JSON object.
+func (pp *PublicParameter) txInputMLPToJSON(txInput *TxInputMLP) (*txInputMLPJSON, error) {
+	if !pp.TxInputMLPSanityCheck(txInput) {
+		return nil, fmt.Errorf("the input TxInputMLP is not well-form")
+	}
+
+	txInputJSON := &txInputMLPJSON{
+		RingMembers:  make([]*lgrTxoMLPJSON, len(txInput.lgrTxoList)),
+		SerialNumber: txInput.serialNumber,
+	}
+	for i := 0; i < len(txInput.lgrTxoList); i++ {
+		txoJSON, err := pp.txoMLPToJSON(txInput.lgrTxoList[i].txo)
+		if err != nil {
+			return nil, fmt.Errorf("ringMembers[%d]: %v", i, err)
+		}
+		txInputJSON.RingMembers[i] = &lgrTxoMLPJSON{
+			Id:  txInput.lgrTxoList[i].id,
+			Txo: txoJSON,
+		}
+	}
+	return txInputJSON, nil
+}
+
+// txInputMLPFromJSON converts the input JSON object to a TxInputMLP.
+func (pp *PublicParameter) txInputMLPFromJSON(txInputJSON *txInputMLPJSON) (*TxInputMLP, error) {
+	if txInputJSON == nil {
+		return nil, fmt.Errorf("the txInput is missing")
+	}
+	if len(txInputJSON.RingMembers) > int(pp.paramRingSizeMax) {
+		return nil, fmt.Errorf("the ringSize (%d) exceeds the allowed maximum value (%d)", len(txInputJSON.RingMembers), pp.paramRingSizeMax)
+	}
+
+	lgrTxoList := make([]*LgrTxoMLP, len(txInputJSON.RingMembers))
+	for i := 0; i < len(txInputJSON.RingMembers); i++ {
+		if txInputJSON.RingMembers[i] == nil {
+			return nil, fmt.Errorf("ringMembers[%d] is missing", i)
+		}
+		txo, err := pp.txoMLPFromJSON(txInputJSON.RingMembers[i].Txo)
+		if err != nil {
+			return nil, fmt.Errorf("ringMembers[%d]: %v", i, err)
+		}
+		lgrTxoList[i] = &LgrTxoMLP{
+			txo: txo,
+			id:  txInputJSON.RingMembers[i].Id,
+		}
+	}
+
+	txInput := &TxInputMLP{
+		lgrTxoList:   lgrTxoList,
+		serialNumber: txInputJSON.SerialNumber,
+	}
+	if !pp.TxInputMLPSanityCheck(txInput) {
+		return nil, fmt.Errorf("the decoded TxInputMLP is not well-form")
+	}
+	return txInput, nil
+}
+
+// txWitnessCbTxToJSON converts the input TxWitnessCbTx to its JSON object.
+func (pp *PublicParameter) txWitnessCbTxToJSON(txWitness *TxWitnessCbTx) (*txWitnessCbTxJSON, error) {
+	if !pp.TxWitnessCbTxSanityCheck(txWitness) {
+		return nil, fmt.Errorf("the input TxWitnessCbTx is not well-form")
+	}
+
+	serializedBpf, err := pp.serializeBalanceProof(txWitness.balanceProof)
+	if err != nil {
+		return nil, err
+	}
+	return &txWitnessCbTxJSON{
+		TxCase:           txWitness.txCase,
+		VL:               txWitness.vL,
+		OutForRing:       txWitness.outForRing,
+		OutForSingle:     txWitness.outForSingle,
+		BalanceProofCase: txWitness.balanceProof.BalanceProofCase(),
+		BalanceProof:     serializedBpf,
+	}, nil
+}
+
+// txWitnessCbTxFromJSON converts the input JSON object to a TxWitnessCbTx.
+func (pp *PublicParameter) txWitnessCbTxFromJSON(txWitnessJSON *txWitnessCbTxJSON) (*TxWitnessCbTx, error) {
+	if txWitnessJSON == nil {
+		return nil, fmt.Errorf("the txWitness is missing")
+	}
+
+	bpfLen, err := pp.balanceProofCbTxSerializeSize(txWitnessJSON.OutForRing)
+	if err != nil {
+		return nil, err
+	}
+	balanceProof, err := pp.balanceProofFromJSON(txWitnessJSON.BalanceProofCase, txWitnessJSON.BalanceProof, bpfLen)
+	if err != nil {
+		return nil, err
+	}
+
+	txWitness := &TxWitnessCbTx{
+		txCase:       txWitnessJSON.TxCase,
+		vL:           txWitnessJSON.VL,
+		outForRing:   txWitnessJSON.OutForRing,
+		outForSingle: txWitnessJSON.OutForSingle,
+		balanceProof: balanceProof,
+	}
+	if !pp.TxWitnessCbTxSanityCheck(txWitness) {
+		return nil, fmt.Errorf("the decoded TxWitnessCbTx is not well-form")
+	}
+	return txWitness, nil
+}
+
+// txWitnessTrTxToJSON converts the input TxWitnessTrTx to its JSON object.
+func (pp *PublicParameter) txWitnessTrTxToJSON(txWitness *TxWitnessTrTx) (*txWitnessTrTxJSON, error) {
+	if !pp.TxWitnessTrTxSanityCheck(txWitness) {
+		return nil, fmt.Errorf("the input TxWitnessTrTx is not well-form")
+	}
+
+	inForRing := int(txWitness.inForRing)
+	inForSingleDistinct := int(txWitness.inForSingleDistinct)
+	txWitnessJSON := &txWitnessTrTxJSON{
+		TxCase:                     txWitness.txCase,
+		InForRing:                  txWitness.inForRing,
+		InForSingle:                txWitness.inForSingle,
+		InForSingleDistinct:        txWitness.inForSingleDistinct,
+		InRingSizes:                make([]int, inForRing),
+		OutForRing:                 txWitness.outForRing,
+		OutForSingle:               txWitness.outForSingle,
+		VPublic:                    txWitness.vPublic,
+		MaPs:                       make([]hexBytes, inForRing),
+		CmtsInP:                    make([]hexBytes, inForRing),
+		ElrSigs:                    make([]hexBytes, inForRing),
+		AddressPublicKeyForSingles: make([]hexBytes, inForSingleDistinct),
+		SimpleSigs:                 make([]hexBytes, inForSingleDistinct),
+		BalanceProofCase:           txWitness.balanceProof.BalanceProofCase(),
+	}
+
+	var err error
+	for i := 0; i < inForRing; i++ {
+		txWitnessJSON.InRingSizes[i] = int(txWitness.inRingSizes[i])
+
+		w := bytes.NewBuffer(make([]byte, 0, pp.PolyANTTSerializeSize()))
+		err = pp.writePolyANTT(w, txWitness.ma_ps[i])
+		if err != nil {
+			return nil, err
+		}
+		txWitnessJSON.MaPs[i] = w.Bytes()
+
+		txWitnessJSON.CmtsInP[i], err = pp.SerializeValueCommitment(txWitness.cmts_in_p[i])
+		if err != nil {
+			return nil, err
+		}
+
+		txWitnessJSON.ElrSigs[i], err = pp.serializeElrSignatureMLP(txWitness.elrSigs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 0; i < inForSingleDistinct; i++ {
+		txWitnessJSON.AddressPublicKeyForSingles[i], err = pp.serializeAddressPublicKeyForSingle(txWitness.addressPublicKeyForSingles[i])
+		if err != nil {
+			return nil, err
+		}
+
+		txWitnessJSON.SimpleSigs[i], err = pp.serializeSimpleSignature(txWitness.simpleSigs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	txWitnessJSON.BalanceProof, err = pp.serializeBalanceProof(txWitness.balanceProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return txWitnessJSON, nil
+}
+
+// txWitnessTrTxFromJSON converts the input JSON object to a TxWitnessTrTx.
+func (pp *PublicParameter) txWitnessTrTxFromJSON(txWitnessJSON *txWitnessTrTxJSON) (*TxWitnessTrTx, error) {
+	if txWitnessJSON == nil {
+		return nil, fmt.Errorf("the txWitness is missing")
+	}
+
+	inForRing := int(txWitnessJSON.InForRing)
+	inForSingleDistinct := int(txWitnessJSON.InForSingleDistinct)
+	if len(txWitnessJSON.InRingSizes) != inForRing || len(txWitnessJSON.MaPs) != inForRing ||
+		len(txWitnessJSON.CmtsInP) != inForRing || len(txWitnessJSON.ElrSigs) != inForRing {
+		return nil, fmt.Errorf("the lengths of inRingSizes, maPs, cmtsInP, and elrSigs do not match inForRing (%d)", inForRing)
+	}
+	if len(txWitnessJSON.AddressPublicKeyForSingles) != inForSingleDistinct || len(txWitnessJSON.SimpleSigs) != inForSingleDistinct {
+		return nil, fmt.Errorf("the lengths of addressPublicKeyForSingles and simpleSigs do not match inForSingleDistinct (%d)", inForSingleDistinct)
+	}
+
+	inRingSizes := make([]uint8, inForRing)
+	ma_ps := make([]*PolyANTT, inForRing)
+	cmts_in_p := make([]*ValueCommitment, inForRing)
+	elrSigs := make([]*ElrSignatureMLP, inForRing)
+	var err error
+	for i := 0; i < inForRing; i++ {
+		if txWitnessJSON.InRingSizes[i] < 0 || txWitnessJSON.InRingSizes[i] > int(pp.paramRingSizeMax) {
+			return nil, fmt.Errorf("inRingSizes[%d] (%d) is not in the allowed scope [0, %d]", i, txWitnessJSON.InRingSizes[i], pp.paramRingSizeMax)
+		}
+		inRingSizes[i] = uint8(txWitnessJSON.InRingSizes[i])
+
+		if err = checkHexBytesLen(fmt.Sprintf("maPs[%d]", i), txWitnessJSON.MaPs[i], pp.PolyANTTSerializeSize()); err != nil {
+			return nil, err
+		}
+		ma_ps[i], err = pp.readPolyANTT(bytes.NewReader(txWitnessJSON.MaPs[i]))
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkHexBytesLen(fmt.Sprintf("cmtsInP[%d]", i), txWitnessJSON.CmtsInP[i], pp.ValueCommitmentSerializeSize()); err != nil {
+			return nil, err
+		}
+		cmts_in_p[i], err = pp.DeserializeValueCommitment(txWitnessJSON.CmtsInP[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkHexBytesLen(fmt.Sprintf("elrSigs[%d]", i), txWitnessJSON.ElrSigs[i], pp.elrSignatureMLPSerializeSize(inRingSizes[i])); err != nil {
+			return nil, err
+		}
+		elrSigs[i], err = pp.deserializeElrSignatureMLP(txWitnessJSON.ElrSigs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	addressPublicKeyForSingles := make([]*AddressPublicKeyForSingle, inForSingleDistinct)
+	simpleSigs := make([]*SimpleSignatureMLP, inForSingleDistinct)
+	for i := 0; i < inForSingleDistinct; i++ {
+		if err = checkHexBytesLen(fmt.Sprintf("addressPublicKeyForSingles[%d]", i), txWitnessJSON.AddressPublicKeyForSingles[i], pp.addressPublicKeyForSingleSerializeSize()); err != nil {
+			return nil, err
+		}
+		addressPublicKeyForSingles[i], err = pp.deserializeAddressPublicKeyForSingle(txWitnessJSON.AddressPublicKeyForSingles[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkHexBytesLen(fmt.Sprintf("simpleSigs[%d]", i), txWitnessJSON.SimpleSigs[i], pp.simpleSignatureSerializeSize()); err != nil {
+			return nil, err
+		}
+		simpleSigs[i], err = pp.deserializeSimpleSignature(txWitnessJSON.SimpleSigs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bpfLen, err := pp.balanceProofTrTxSerializeSize(txWitnessJSON.InForRing, txWitnessJSON.OutForRing, txWitnessJSON.VPublic)
+	if err != nil {
+		return nil, err
+	}
+	balanceProof, err := pp.balanceProofFromJSON(txWitnessJSON.BalanceProofCase, txWitnessJSON.BalanceProof, bpfLen)
+	if err != nil {
+		return nil, err
+	}
+
+	txWitness := &TxWitnessTrTx{
+		txCase:                     txWitnessJSON.TxCase,
+		inForRing:                  txWitnessJSON.InForRing,
+		inForSingle:                txWitnessJSON.InForSingle,
+		inForSingleDistinct:        txWitnessJSON.InForSingleDistinct,
+		inRingSizes:                inRingSizes,
+		outForRing:                 txWitnessJSON.OutForRing,
+		outForSingle:               txWitnessJSON.OutForSingle,
+		vPublic:                    txWitnessJSON.VPublic,
+		ma_ps:                      ma_ps,
+		cmts_in_p:                  cmts_in_p,
+		elrSigs:                    elrSigs,
+		addressPublicKeyForSingles: addressPublicKeyForSingles,
+		simpleSigs:                 simpleSigs,
+		balanceProof:               balanceProof,
+	}
+	if !pp.TxWitnessTrTxSanityCheck(txWitness) {
+		return nil, fmt.Errorf("the decoded TxWitnessTrTx is not well-form")
+	}
+	return txWitness, nil
+}
+
+// balanceProofFromJSON deserializes the input serializedBpf, which shall have the length expectedLen,
+// and checks that the obtained BalanceProof has the input balanceProofCase.
+func (pp *PublicParameter) balanceProofFromJSON(balanceProofCase BalanceProofCase, serializedBpf hexBytes, expectedLen int) (BalanceProof, error) {
+	if err := checkHexBytesLen("balanceProof", serializedBpf, expectedLen); err != nil {
+		return nil, err
+	}
+	balanceProof, err := pp.deserializeBalanceProof(serializedBpf)
+	if err != nil {
+		return nil, err
+	}
+	if balanceProof.BalanceProofCase() != balanceProofCase {
+		return nil, fmt.Errorf("the balanceProofCase (%d) does not match the balanceProof (%d)", balanceProofCase, balanceProof.BalanceProofCase())
+	}
+	return balanceProof, nil
+}
+
+//	JSON Encoding	end
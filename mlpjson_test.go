@@ -0,0 +1,217 @@
+package pqringctx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPublicParameter_TransferTxMLPToJSON_TransferTxMLPFromJSON(t *testing.T) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+
+	for _, withWitness := range []bool{true, false} {
+		data, err := pp.TransferTxMLPToJSON(trTx, withWitness)
+		if err != nil {
+			t.Fatalf("TransferTxMLPToJSON(withWitness = %v) error = %v", withWitness, err)
+		}
+		decoded, err := pp.TransferTxMLPFromJSON(data, withWitness)
+		if err != nil {
+			t.Fatalf("TransferTxMLPFromJSON(withWitness = %v) error = %v", withWitness, err)
+		}
+
+		serialized, err := pp.SerializeTransferTxMLP(trTx, withWitness)
+		if err != nil {
+			t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+		}
+		serializedDecoded, err := pp.SerializeTransferTxMLP(decoded, withWitness)
+		if err != nil {
+			t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+		}
+		if !bytes.Equal(serialized, serializedDecoded) {
+			t.Errorf("TransferTxMLPFromJSON(withWitness = %v) does not recover the TransferTxMLP", withWitness)
+		}
+		if withWitness {
+			if err = pp.TransferTxMLPVerify(decoded); err != nil {
+				t.Errorf("TransferTxMLPVerify() on the decoded TransferTxMLP error = %v", err)
+			}
+		}
+	}
+
+	//	the schema
+	data, err := pp.TransferTxMLPToJSON(trTx, true)
+	if err != nil {
+		t.Fatalf("TransferTxMLPToJSON() error = %v", err)
+	}
+	var trTxJSON struct {
+		TxInputs []struct {
+			RingMembers []struct {
+				Id string `json:"id"`
+			} `json:"ringMembers"`
+			SerialNumber string `json:"serialNumber"`
+		} `json:"txInputs"`
+		Fee       uint64 `json:"fee"`
+		TxMemo    string `json:"txMemo"`
+		TxWitness struct {
+			TxCase           TxWitnessTrTxCase `json:"txCase"`
+			InRingSizes      []int             `json:"inRingSizes"`
+			BalanceProofCase BalanceProofCase  `json:"balanceProofCase"`
+		} `json:"txWitness"`
+	}
+	if err = json.Unmarshal(data, &trTxJSON); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if trTxJSON.Fee != fee || trTxJSON.TxMemo != hex.EncodeToString([]byte("memo")) {
+		t.Errorf("TransferTxMLPToJSON() has fee = %d and txMemo = %q", trTxJSON.Fee, trTxJSON.TxMemo)
+	}
+	if trTxJSON.TxWitness.TxCase != trTx.txWitness.txCase || trTxJSON.TxWitness.BalanceProofCase != trTx.txWitness.balanceProof.BalanceProofCase() {
+		t.Errorf("TransferTxMLPToJSON() has txCase = %d and balanceProofCase = %d", trTxJSON.TxWitness.TxCase, trTxJSON.TxWitness.BalanceProofCase)
+	}
+	if len(trTxJSON.TxInputs) != len(trTx.txInputs) {
+		t.Fatalf("TransferTxMLPToJSON() has %d txInputs, want %d", len(trTxJSON.TxInputs), len(trTx.txInputs))
+	}
+	for i, txInput := range trTx.txInputs {
+		if trTxJSON.TxInputs[i].SerialNumber != hex.EncodeToString(txInput.serialNumber) {
+			t.Errorf("TransferTxMLPToJSON() txInputs[%d] has a wrong serialNumber", i)
+		}
+		for j, lgrTxo := range txInput.lgrTxoList {
+			if trTxJSON.TxInputs[i].RingMembers[j].Id != hex.EncodeToString(lgrTxo.id) {
+				t.Errorf("TransferTxMLPToJSON() txInputs[%d].ringMembers[%d] has a wrong id", i, j)
+			}
+		}
+	}
+}
+
+func TestPublicParameter_CoinbaseTxMLPToJSON_CoinbaseTxMLPFromJSON(t *testing.T) {
+	InitialAddress()
+
+	//	the outputs cover TxoRCTPre, TxoRCT, and TxoSDN
+	txOutputDescMLPs, _ := GenerateOutput(300, 212, 1, 1, 1)
+	cbTx, err := pp.CoinbaseTxMLPGen(512, txOutputDescMLPs, []byte("memo"))
+	if err != nil {
+		t.Fatalf("CoinbaseTxMLPGen() error = %v", err)
+	}
+
+	data, err := pp.CoinbaseTxMLPToJSON(cbTx, true)
+	if err != nil {
+		t.Fatalf("CoinbaseTxMLPToJSON() error = %v", err)
+	}
+	decoded, err := pp.CoinbaseTxMLPFromJSON(data, true)
+	if err != nil {
+		t.Fatalf("CoinbaseTxMLPFromJSON() error = %v", err)
+	}
+	serialized, err := pp.SerializeCoinbaseTxMLP(cbTx, true)
+	if err != nil {
+		t.Fatalf("SerializeCoinbaseTxMLP() error = %v", err)
+	}
+	serializedDecoded, err := pp.SerializeCoinbaseTxMLP(decoded, true)
+	if err != nil {
+		t.Fatalf("SerializeCoinbaseTxMLP() error = %v", err)
+	}
+	if !bytes.Equal(serialized, serializedDecoded) {
+		t.Errorf("CoinbaseTxMLPFromJSON() does not recover the CoinbaseTxMLP")
+	}
+	if err = pp.CoinbaseTxMLPVerify(decoded); err != nil {
+		t.Errorf("CoinbaseTxMLPVerify() on the decoded CoinbaseTxMLP error = %v", err)
+	}
+
+	for i, txo := range cbTx.txos {
+		txoData, err := pp.TxoMLPToJSON(txo)
+		if err != nil {
+			t.Fatalf("TxoMLPToJSON() error = %v", err)
+		}
+		decodedTxo, err := pp.TxoMLPFromJSON(txoData)
+		if err != nil {
+			t.Fatalf("TxoMLPFromJSON() error = %v", err)
+		}
+		if decodedTxo.CoinAddressType() != txo.CoinAddressType() {
+			t.Errorf("TxoMLPFromJSON() txos[%d] has coinAddressType %d, want %d", i, decodedTxo.CoinAddressType(), txo.CoinAddressType())
+		}
+	}
+
+	//	the fields of another coinAddressType are rejected
+	if _, err = pp.TxoMLPFromJSON([]byte(`{"coinAddressType": 2, "vct": "00", "value": 1}`)); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("TxoMLPFromJSON() with the fields of another coinAddressType error = %v, want %v", err, ErrMalformedEncoding)
+	}
+	//	the txWitness is required if withWitness is true
+	withoutWitness, err := pp.CoinbaseTxMLPToJSON(cbTx, false)
+	if err != nil {
+		t.Fatalf("CoinbaseTxMLPToJSON() error = %v", err)
+	}
+	if _, err = pp.CoinbaseTxMLPFromJSON(withoutWitness, true); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("CoinbaseTxMLPFromJSON() without txWitness error = %v, want %v", err, ErrMalformedEncoding)
+	}
+}
+
+func TestPublicParameter_TransferTxMLPFromJSON_Malformed(t *testing.T) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+	data, err := pp.TransferTxMLPToJSON(trTx, true)
+	if err != nil {
+		t.Fatalf("TransferTxMLPToJSON() error = %v", err)
+	}
+
+	//	tamper returns data with the input change applied on its generic JSON form.
+	tamper := func(change func(raw map[string]interface{})) []byte {
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		change(raw)
+		tampered, err := json.Marshal(raw)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		return tampered
+	}
+	txInput := func(raw map[string]interface{}, i int) map[string]interface{} {
+		return raw["txInputs"].([]interface{})[i].(map[string]interface{})
+	}
+	txWitness := func(raw map[string]interface{}) map[string]interface{} {
+		return raw["txWitness"].(map[string]interface{})
+	}
+
+	//	a balanceProofCase different from that of the balanceProof, which is BalanceProofCaseLmRn for the sample transaction
+	mismatchedBalanceProofCase := BalanceProofCaseL1Rn
+	if trTx.txWitness.balanceProof.BalanceProofCase() == mismatchedBalanceProofCase {
+		mismatchedBalanceProofCase = BalanceProofCaseLmRn
+	}
+
+	tests := []struct {
+		name        string
+		data        []byte
+		withWitness bool
+	}{
+		{"not JSON", []byte("txInputs"), false},
+		{"truncated", data[:len(data)/2], true},
+		{"wrong type", []byte(`{"txInputs": 1}`), false},
+		{"invalid hex", tamper(func(raw map[string]interface{}) { raw["txMemo"] = "0g" }), false},
+		{"null txInput", tamper(func(raw map[string]interface{}) { raw["txInputs"].([]interface{})[0] = nil }), false},
+		{"null ring member", tamper(func(raw map[string]interface{}) { txInput(raw, 0)["ringMembers"].([]interface{})[0] = nil }), false},
+		{"null txo of a ring member", tamper(func(raw map[string]interface{}) {
+			txInput(raw, 0)["ringMembers"].([]interface{})[0].(map[string]interface{})["txo"] = nil
+		}), false},
+		{"null txo", tamper(func(raw map[string]interface{}) { raw["txos"].([]interface{})[0] = nil }), false},
+		{"missing txWitness", tamper(func(raw map[string]interface{}) { delete(raw, "txWitness") }), true},
+		{"mismatched balanceProofCase", tamper(func(raw map[string]interface{}) { txWitness(raw)["balanceProofCase"] = mismatchedBalanceProofCase }), true},
+		{"too long balanceProof", tamper(func(raw map[string]interface{}) {
+			txWitness(raw)["balanceProof"] = txWitness(raw)["balanceProof"].(string) + "00"
+		}), true},
+		{"inRingSizes inconsistent with the rings", tamper(func(raw map[string]interface{}) { txWitness(raw)["inRingSizes"].([]interface{})[0] = 3 }), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := pp.TransferTxMLPFromJSON(tt.data, tt.withWitness); !errors.Is(err, ErrMalformedEncoding) {
+				t.Errorf("TransferTxMLPFromJSON() error = %v, want %v", err, ErrMalformedEncoding)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package pqringctx
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/pqabelian/pqringctx/internal"
+)
+
+// This file implements the hierarchical deterministic derivation of the keys,
+// so that a wallet can back up one master seed and regenerate all its coinAddresses and coinValueKeys.
+// The derivation is a two-level tree built on KMAC256:
+//	accountSeed = KMAC256(masterSeed, "Account" || account)
+//	material    = KMAC256(accountSeed, label || index)
+// where the label separates the purposes (coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, publicRand)
+// and the coinAddress types (RingCT-privacy and pseudonym-privacy).
+// The coinDetectorKey and the coinValueKey are derived at the account level,
+// so that one coinDetectorKey (resp. coinValueSecretKey) can detect (resp. receive) all the coins of an account.
+
+const (
+	// CoinKeyMasterSeedBytesLenMin is the minimum length of the master seed for key derivation.
+	CoinKeyMasterSeedBytesLenMin = 32
+	// coinKeyAccountSeedBytesLen is the length of the account-level seed.
+	coinKeyAccountSeedBytesLen = 64
+)
+
+const keyDerivationCustomizationString = "PQRINGCTX-KEY-DERIVATION"
+
+// labels for the derived materials
+const (
+	keyDerivationLabelAccount                     = "Account"
+	keyDerivationLabelCoinDetectorKey             = "CoinDetectorKey"
+	keyDerivationLabelCoinValueKeyRandSeed        = "CoinValueKeyRandSeed"
+	keyDerivationLabelRingSpendKeyRandSeed        = "PKRing/CoinSpendKeyRandSeed"
+	keyDerivationLabelRingSerialNumberKeyRandSeed = "PKRing/CoinSerialNumberKeyRandSeed"
+	keyDerivationLabelRingPublicRand              = "PKRing/PublicRand"
+	keyDerivationLabelSingleSpendKeyRandSeed      = "PKHSingle/CoinSpendKeyRandSeed"
+	keyDerivationLabelSinglePublicRand            = "PKHSingle/PublicRand"
+)
+
+// keyDerivationKMAC outputs KMAC256(key, label || index) with outputLen bytes,
+// where index is encoded in 4 bytes big-endian.
+func keyDerivationKMAC(key []byte, label string, index uint32, outputLen int) []byte {
+	msg := make([]byte, len(label)+4)
+	copy(msg, label)
+	binary.BigEndian.PutUint32(msg[len(label):], index)
+
+	kmac256 := internal.NewKMAC256(key, outputLen, []byte(keyDerivationCustomizationString))
+	kmac256.Write(msg)
+	return kmac256.Sum(nil)
+}
+
+// deriveAccountSeed derives the seed for the input account from the input masterSeed.
+func deriveAccountSeed(masterSeed []byte, account uint32) ([]byte, error) {
+	if len(masterSeed) < CoinKeyMasterSeedBytesLenMin {
+		return nil, fmt.Errorf("deriveAccountSeed: the input masterSeed's length (%d) is smaller than the allowed minimum value (%d)", len(masterSeed), CoinKeyMasterSeedBytesLenMin)
+	}
+	return keyDerivationKMAC(masterSeed, keyDerivationLabelAccount, account, coinKeyAccountSeedBytesLen), nil
+}
+
+// CoinDetectorKeyDerive derives the coinDetectorKey of the input account from the input masterSeed.
+// All the coinAddresses derived for the account are tagged by this coinDetectorKey.
+func (pp *PublicParameter) CoinDetectorKeyDerive(masterSeed []byte, account uint32) (coinDetectorKey []byte, err error) {
+	accountSeed, err := deriveAccountSeed(masterSeed, account)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeBytes(accountSeed)
+	return keyDerivationKMAC(accountSeed, keyDerivationLabelCoinDetectorKey, 0, pp.GetParamMACKeyBytesLen()), nil
+}
+
+// CoinValueKeyRandSeedDerive derives the randSeed of CoinValueKeyGen for the input account from the input masterSeed.
+func (pp *PublicParameter) CoinValueKeyRandSeedDerive(masterSeed []byte, account uint32) (randSeed []byte, err error) {
+	accountSeed, err := deriveAccountSeed(masterSeed, account)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeBytes(accountSeed)
+	return keyDerivationKMAC(accountSeed, keyDerivationLabelCoinValueKeyRandSeed, 0, pp.GetParamSeedBytesLen()), nil
+}
+
+// CoinAddressKeyForPKRingRandSeedsDerive derives the inputs of CoinAddressKeyForPKRingGen,
+// for the input (account, index) path from the input masterSeed.
+func (pp *PublicParameter) CoinAddressKeyForPKRingRandSeedsDerive(masterSeed []byte, account uint32, index uint32) (coinSpendKeyRandSeed []byte, coinSerialNumberKeyRandSeed []byte,
+	coinDetectorKey []byte, publicRand []byte, err error) {
+	accountSeed, err := deriveAccountSeed(masterSeed, account)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	defer wipeBytes(accountSeed)
+
+	coinSpendKeyRandSeed = keyDerivationKMAC(accountSeed, keyDerivationLabelRingSpendKeyRandSeed, index, pp.GetParamSeedBytesLen())
+	coinSerialNumberKeyRandSeed = keyDerivationKMAC(accountSeed, keyDerivationLabelRingSerialNumberKeyRandSeed, index, pp.GetParamSeedBytesLen())
+	coinDetectorKey = keyDerivationKMAC(accountSeed, keyDerivationLabelCoinDetectorKey, 0, pp.GetParamMACKeyBytesLen())
+	publicRand = keyDerivationKMAC(accountSeed, keyDerivationLabelRingPublicRand, index, pp.GetParamKeyGenPublicRandBytesLen())
+
+	return coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, coinDetectorKey, publicRand, nil
+}
+
+// CoinAddressKeyForPKHSingleRandSeedsDerive derives the inputs of CoinAddressKeyForPKHSingleGen,
+// for the input (account, index) path from the input masterSeed.
+// Note that the pseudonym-privacy keys are separated from the RingCT-privacy keys with the same path.
+func (pp *PublicParameter) CoinAddressKeyForPKHSingleRandSeedsDerive(masterSeed []byte, account uint32, index uint32) (coinSpendKeyRandSeed []byte,
+	coinDetectorKey []byte, publicRand []byte, err error) {
+	accountSeed, err := deriveAccountSeed(masterSeed, account)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer wipeBytes(accountSeed)
+
+	coinSpendKeyRandSeed = keyDerivationKMAC(accountSeed, keyDerivationLabelSingleSpendKeyRandSeed, index, pp.GetParamSeedBytesLen())
+	coinDetectorKey = keyDerivationKMAC(accountSeed, keyDerivationLabelCoinDetectorKey, 0, pp.GetParamMACKeyBytesLen())
+	publicRand = keyDerivationKMAC(accountSeed, keyDerivationLabelSinglePublicRand, index, pp.GetParamKeyGenPublicRandBytesLen())
+
+	return coinSpendKeyRandSeed, coinDetectorKey, publicRand, nil
+}
+
+// CoinAddressKeyForPKRingDerive generates coinAddress, coinSpendSecretKey, and coinSerialNumberSecretKey
+// for the input (account, index) path from the input masterSeed,
+// i.e., CoinAddressKeyForPKRingGen on the inputs derived by CoinAddressKeyForPKRingRandSeedsDerive.
+func (pp *PublicParameter) CoinAddressKeyForPKRingDerive(masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey []byte,
+	coinSerialNumberSecretKey []byte, err error) {
+	coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, coinDetectorKey, publicRand, err := pp.CoinAddressKeyForPKRingRandSeedsDerive(masterSeed, account, index)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer wipeBytes(coinSpendKeyRandSeed)
+	defer wipeBytes(coinSerialNumberKeyRandSeed)
+	return pp.CoinAddressKeyForPKRingGen(coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, coinDetectorKey, publicRand)
+}
+
+// CoinAddressKeyForPKHSingleDerive generates coinAddress and coinSpendSecretKey
+// for the input (account, index) path from the input masterSeed,
+// i.e., CoinAddressKeyForPKHSingleGen on the inputs derived by CoinAddressKeyForPKHSingleRandSeedsDerive.
+func (pp *PublicParameter) CoinAddressKeyForPKHSingleDerive(masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey []byte, err error) {
+	coinSpendKeyRandSeed, coinDetectorKey, publicRand, err := pp.CoinAddressKeyForPKHSingleRandSeedsDerive(masterSeed, account, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer wipeBytes(coinSpendKeyRandSeed)
+	return pp.CoinAddressKeyForPKHSingleGen(coinSpendKeyRandSeed, coinDetectorKey, publicRand)
+}
+
+// CoinValueKeyDerive generates coinValuePublicKey and coinValueSecretKey for the input account from the input masterSeed,
+// i.e., CoinValueKeyGen on the randSeed derived by CoinValueKeyRandSeedDerive.
+func (pp *PublicParameter) CoinValueKeyDerive(masterSeed []byte, account uint32) (coinValuePublicKey []byte, coinValueSecretKey []byte, err error) {
+	randSeed, err := pp.CoinValueKeyRandSeedDerive(masterSeed, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer wipeBytes(randSeed)
+	return pp.CoinValueKeyGen(randSeed)
+}
@@ -0,0 +1,92 @@
+package pqringctx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPublicParameter_CoinAddressKeyDerive(t *testing.T) {
+	pp := initializeForTest()
+
+	masterSeed := RandomBytes(64)
+
+	coinDetectorKey, err := pp.CoinDetectorKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coinAddresses := make(map[string]struct{})
+	for index := uint32(0); index < 3; index++ {
+		coinAddress, coinSpendSecretKey, coinSerialNumberSecretKey, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, 0, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err := pp.CoinAddressKeyForPKRingVerify(coinAddress, coinSpendSecretKey, coinSerialNumberSecretKey, coinDetectorKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !valid {
+			t.Fatalf("the derived RingCT-privacy key with index %d is invalid", index)
+		}
+
+		//	deterministic
+		coinAddressAgain, coinSpendSecretKeyAgain, coinSerialNumberSecretKeyAgain, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, 0, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(coinAddress, coinAddressAgain) || !bytes.Equal(coinSpendSecretKey, coinSpendSecretKeyAgain) ||
+			!bytes.Equal(coinSerialNumberSecretKey, coinSerialNumberSecretKeyAgain) {
+			t.Fatalf("the derivation of RingCT-privacy key with index %d is not deterministic", index)
+		}
+
+		coinAddressSingle, coinSpendSecretKeySingle, err := pp.CoinAddressKeyForPKHSingleDerive(masterSeed, 0, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err = pp.CoinAddressKeyForPKHSingleVerify(coinAddressSingle, coinSpendSecretKeySingle, coinDetectorKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !valid {
+			t.Fatalf("the derived pseudonym-privacy key with index %d is invalid", index)
+		}
+
+		coinAddresses[string(coinAddress)] = struct{}{}
+		coinAddresses[string(coinAddressSingle)] = struct{}{}
+	}
+	if len(coinAddresses) != 6 {
+		t.Fatalf("the derived coinAddresses are not distinct")
+	}
+
+	//	another account uses another coinDetectorKey
+	coinAddress, _, _, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	detected, err := pp.DetectCoinAddress(coinAddress, coinDetectorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detected {
+		t.Fatalf("the coinAddress of account 1 is detected by the coinDetectorKey of account 0")
+	}
+
+	coinValuePublicKey, coinValueSecretKey, err := pp.CoinValueKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid, hints := pp.CoinValueKeyVerify(coinValuePublicKey, coinValueSecretKey); !valid {
+		t.Fatal(hints)
+	}
+	coinValuePublicKeyAgain, _, err := pp.CoinValueKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(coinValuePublicKey, coinValuePublicKeyAgain) {
+		t.Fatalf("the derivation of coinValueKey is not deterministic")
+	}
+
+	if _, _, err = pp.CoinAddressKeyForPKHSingleDerive(masterSeed[:CoinKeyMasterSeedBytesLenMin-1], 0, 0); err == nil {
+		t.Fatalf("a too short masterSeed is accepted")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"io"
 )
 
 // AddressSecretKeySp namely SpendKey.
@@ -48,6 +49,37 @@ type AddressSecretKeyForSingle struct {
 	*AddressSecretKeySp
 }
 
+// Destroy zeroes the AddressSecretKeySp, so that the SpendKey does not linger in memory.
+// The AddressSecretKeySp must not be used after Destroy.
+func (askSp *AddressSecretKeySp) Destroy() {
+	if askSp != nil {
+		askSp.s.wipe()
+	}
+}
+
+// Destroy zeroes the AddressSecretKeySn, so that the SerialNumberKey does not linger in memory.
+// The AddressSecretKeySn must not be used after Destroy.
+func (askSn *AddressSecretKeySn) Destroy() {
+	if askSn != nil {
+		askSn.ma.wipe()
+	}
+}
+
+// Destroy zeroes both the AddressSecretKeySp and the AddressSecretKeySn of the AddressSecretKeyForRing.
+func (ask *AddressSecretKeyForRing) Destroy() {
+	if ask != nil {
+		ask.AddressSecretKeySp.Destroy()
+		ask.AddressSecretKeySn.Destroy()
+	}
+}
+
+// Destroy zeroes the AddressSecretKeySp of the AddressSecretKeyForSingle.
+func (ask *AddressSecretKeyForSingle) Destroy() {
+	if ask != nil {
+		ask.AddressSecretKeySp.Destroy()
+	}
+}
+
 // CoinAddressKeyForPKRingGen generates coinAddress, coinSpendKey, and coinSnKey
 // for the key which will be used to host the coins with full-privacy.
 // Note that keys are purely in cryptography, we export bytes,
@@ -72,6 +104,7 @@ func (pp *PublicParameter) CoinAddressKeyForPKRingGen(coinSpendKeyRandSeed []byt
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	defer ask.Destroy()
 
 	serializedAPK, err := pp.serializeAddressPublicKeyForRing(apk)
 	if err != nil {
@@ -82,10 +115,12 @@ func (pp *PublicParameter) CoinAddressKeyForPKRingGen(coinSpendKeyRandSeed []byt
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	defer wipeBytes(serializedASKSp)
 	serializedASKSn, err := pp.serializeAddressSecretKeySn(ask.AddressSecretKeySn)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	defer wipeBytes(serializedASKSn)
 
 	coinAddress = make([]byte, 1+len(serializedAPK)+len(publicRand)+pp.GetParamMACOutputBytesLen())
 	coinAddress[0] = byte(CoinAddressTypePublicKeyForRing)
@@ -121,11 +156,13 @@ func (pp *PublicParameter) CoinAddressKeyForPKRingGenSerialNumberKeyPart(coinSer
 	if err != nil {
 		return nil, err
 	}
+	defer askSn.Destroy()
 
 	serializedASKSn, err := pp.serializeAddressSecretKeySn(askSn)
 	if err != nil {
 		return nil, err
 	}
+	defer wipeBytes(serializedASKSn)
 
 	coinSerialNumberSecretKey = make([]byte, 1+len(serializedASKSn))
 	coinSerialNumberSecretKey[0] = byte(CoinAddressTypePublicKeyForRing)
@@ -269,6 +306,7 @@ func (pp *PublicParameter) CoinAddressKeyForPKRingVerify(coinAddress []byte, coi
 		AddressSecretKeySp: askSp,
 		AddressSecretKeySn: askSn,
 	}
+	defer ask.Destroy()
 	valid, hints := pp.addressKeyForRingVerify(apk, ask)
 	if valid {
 		return true, nil
@@ -350,6 +388,7 @@ func (pp *PublicParameter) CoinAddressKeyForPKHSingleGen(coinSpendKeyRandSeed []
 	if err != nil {
 		return nil, nil, err
 	}
+	defer ask.Destroy()
 
 	serializedAPK, err := pp.serializeAddressPublicKeyForSingle(apk)
 	if err != nil {
@@ -360,6 +399,7 @@ func (pp *PublicParameter) CoinAddressKeyForPKHSingleGen(coinSpendKeyRandSeed []
 	if err != nil {
 		return nil, nil, err
 	}
+	defer wipeBytes(serializedASKSp)
 
 	apkHash, err := Hash(serializedAPK)
 	if err != nil {
@@ -486,6 +526,7 @@ func (pp *PublicParameter) CoinAddressKeyForPKHSingleVerify(coinAddress []byte,
 	ask := &AddressSecretKeyForSingle{
 		AddressSecretKeySp: askSp,
 	}
+	defer ask.Destroy()
 
 	valid, hints := pp.addressKeyForSingleVerify(apk, ask)
 	if valid {
@@ -815,7 +856,7 @@ func (pp *PublicParameter) DetectCoinAddress(coinAddress []byte, coinDetectorKey
 // reviewed on 2023.12.07
 // reviewed on 2023.12.30
 // reviewed by Alice, 2024.06.24
-// todo: erase the memory?
+// The local copies of the seeds and the temporaries derived from the secret keys are wiped before return.
 func (pp *PublicParameter) addressKeyForRingGen(coinSpendKeyRandSeed []byte, coinSerialNumberKeyRandSeed []byte) (apk *AddressPublicKeyForRing, ask *AddressSecretKeyForRing, err error) {
 	// check the validity of the length of seed
 	if coinSpendKeyRandSeed != nil && len(coinSpendKeyRandSeed) != pp.paramKeyGenSeedBytesLen {
@@ -827,6 +868,7 @@ func (pp *PublicParameter) addressKeyForRingGen(coinSpendKeyRandSeed []byte, coi
 	} else {
 		copy(localCoinSpendKeyRandSeed, coinSpendKeyRandSeed)
 	}
+	defer wipeBytes(localCoinSpendKeyRandSeed)
 
 	if coinSerialNumberKeyRandSeed != nil && len(coinSerialNumberKeyRandSeed) != pp.paramKeyGenSeedBytesLen {
 		return nil, nil, fmt.Errorf("addressKeyForRingGen: the length of coinSerialNumberKeyRandSeed (%d) is invalid", len(coinSerialNumberKeyRandSeed))
@@ -837,6 +879,7 @@ func (pp *PublicParameter) addressKeyForRingGen(coinSpendKeyRandSeed []byte, coi
 	} else {
 		copy(localCoinSerialNumberKeyRandSeed, coinSerialNumberKeyRandSeed)
 	}
+	defer wipeBytes(localCoinSerialNumberKeyRandSeed)
 
 	// this temporary byte slice is for protect seed unmodified
 	//tmp := make([]byte, pp.paramKeyGenSeedBytesLen)
@@ -857,10 +900,13 @@ func (pp *PublicParameter) addressKeyForRingGen(coinSpendKeyRandSeed []byte, coi
 
 	// t = A * s, will be as a part of public key
 	s_ntt := pp.NTTPolyAVec(s)
+	defer s_ntt.wipe()
 	t := pp.PolyANTTMatrixMulVector(pp.paramMatrixA, s_ntt, pp.paramKA, pp.paramLA)
 
 	// e = <a,s>+ma
-	e := pp.PolyANTTAdd(pp.PolyANTTVecInnerProduct(pp.paramVectorA, s_ntt, pp.paramLA), ma)
+	as := pp.PolyANTTVecInnerProduct(pp.paramVectorA, s_ntt, pp.paramLA)
+	defer as.wipe()
+	e := pp.PolyANTTAdd(as, ma)
 
 	apk = &AddressPublicKeyForRing{
 		t: t,
@@ -871,8 +917,6 @@ func (pp *PublicParameter) addressKeyForRingGen(coinSpendKeyRandSeed []byte, coi
 		AddressSecretKeySn: &AddressSecretKeySn{ma: ma},
 	}
 
-	// todo: erase the memory?
-
 	return apk, ask, nil
 }
 
@@ -892,6 +936,7 @@ func (pp *PublicParameter) addressKeyForRingGenSerialNumberKeyPart(coinSerialNum
 	} else {
 		copy(localCoinSerialNumberKeyRandSeed, coinSerialNumberKeyRandSeed)
 	}
+	defer wipeBytes(localCoinSerialNumberKeyRandSeed)
 
 	//// this temporary byte slice is for protect seed unmodified
 	////tmp := make([]byte, pp.paramKeyGenSeedBytesLen)
@@ -926,8 +971,6 @@ func (pp *PublicParameter) addressKeyForRingGenSerialNumberKeyPart(coinSerialNum
 	//	AddressSecretKeySn: &AddressSecretKeySn{ma: ma},
 	//}
 
-	// todo: erase the memory?
-
 	return &AddressSecretKeySn{ma: ma}, nil
 }
 
@@ -948,6 +991,7 @@ func (pp *PublicParameter) addressKeyForRingVerify(apk *AddressPublicKeyForRing,
 
 	// compute t = A * s
 	s_ntt := pp.NTTPolyAVec(ask.s)
+	defer s_ntt.wipe()
 	t := pp.PolyANTTMatrixMulVector(pp.paramMatrixA, s_ntt, pp.paramKA, pp.paramLA)
 
 	// compute e = <a,s>+ma
@@ -970,7 +1014,7 @@ func (pp *PublicParameter) addressKeyForRingVerify(apk *AddressPublicKeyForRing,
 // reviewed on 2023.12.14
 // reviewed on 2023.12.30
 // reviewed by Alice, 2024.06.24
-// todo: erase the memory?
+// The local copy of the seed and the temporaries derived from the secret key are wiped before return.
 func (pp *PublicParameter) addressKeyForSingleGen(coinSpendKeyRandSeed []byte) (apk *AddressPublicKeyForSingle, ask *AddressSecretKeyForSingle, err error) {
 	// check the validity of the length of seed
 	if coinSpendKeyRandSeed != nil && len(coinSpendKeyRandSeed) != pp.paramKeyGenSeedBytesLen {
@@ -982,6 +1026,7 @@ func (pp *PublicParameter) addressKeyForSingleGen(coinSpendKeyRandSeed []byte) (
 	} else {
 		copy(localCoinSpendKeyRandSeed, coinSpendKeyRandSeed)
 	}
+	defer wipeBytes(localCoinSpendKeyRandSeed)
 
 	//// this temporary byte slice is for protect seed unmodified
 	//tmp := make([]byte, pp.paramKeyGenSeedBytesLen)
@@ -1002,6 +1047,7 @@ func (pp *PublicParameter) addressKeyForSingleGen(coinSpendKeyRandSeed []byte) (
 
 	// t = A * s, will be as a part of public key
 	s_ntt := pp.NTTPolyAVec(s)
+	defer s_ntt.wipe()
 	t := pp.PolyANTTMatrixMulVector(pp.paramMatrixA, s_ntt, pp.paramKA, pp.paramLA)
 
 	//// e = <a,s>+ma
@@ -1036,6 +1082,7 @@ func (pp *PublicParameter) addressKeyForSingleVerify(apk *AddressPublicKeyForSin
 
 	// compute t = A * s
 	s_ntt := pp.NTTPolyAVec(ask.s)
+	defer s_ntt.wipe()
 	t := pp.PolyANTTMatrixMulVector(pp.paramMatrixA, s_ntt, pp.paramKA, pp.paramLA)
 
 	//// compute e = <a,s>+ma
@@ -1065,26 +1112,35 @@ func (pp *PublicParameter) addressPublicKeyForRingSerializeSize() int {
 // reviewed on 2023.12.30
 // reviewed by Alice, 2024.06.25
 func (pp *PublicParameter) serializeAddressPublicKeyForRing(apk *AddressPublicKeyForRing) ([]byte, error) {
+	length := pp.addressPublicKeyForRingSerializeSize()
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeAddressPublicKeyForRing(w, apk)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeAddressPublicKeyForRing writes the input AddressPublicKeyForRing to w, in the format of serializeAddressPublicKeyForRing.
+func (pp *PublicParameter) writeAddressPublicKeyForRing(w io.Writer, apk *AddressPublicKeyForRing) error {
 	var err error
 
 	if !pp.AddressPublicKeyForRingSanityCheck(apk) {
-		return nil, fmt.Errorf("serializeAddressPublicKeyForRing: the input AddressPublicKeyForRing is not well-form")
+		return fmt.Errorf("writeAddressPublicKeyForRing: the input AddressPublicKeyForRing is not well-form")
 	}
 
-	length := pp.addressPublicKeyForRingSerializeSize()
-	w := bytes.NewBuffer(make([]byte, 0, length))
 	for i := 0; i < pp.paramKA; i++ {
 		err = pp.writePolyANTT(w, apk.t.polyANTTs[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 	err = pp.writePolyANTT(w, apk.e)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeAddressPublicKeyForRing deserialize the input []byte to an AddressPublicKeyForRing.
@@ -1092,8 +1148,12 @@ func (pp *PublicParameter) serializeAddressPublicKeyForRing(apk *AddressPublicKe
 // reviewed on 2023.12.30
 // reviewed by Alice, 2024.06.24
 func (pp *PublicParameter) deserializeAddressPublicKeyForRing(serializedAPKForRing []byte) (*AddressPublicKeyForRing, error) {
+	return pp.readAddressPublicKeyForRing(bytes.NewReader(serializedAPKForRing))
+}
+
+// readAddressPublicKeyForRing reads an AddressPublicKeyForRing from r, which was written by writeAddressPublicKeyForRing.
+func (pp *PublicParameter) readAddressPublicKeyForRing(r io.Reader) (*AddressPublicKeyForRing, error) {
 	var err error
-	r := bytes.NewReader(serializedAPKForRing)
 
 	t := pp.NewPolyANTTVec(pp.paramKA)
 	var e *PolyANTT
@@ -1126,22 +1186,29 @@ func (pp *PublicParameter) addressPublicKeyForSingleSerializeSize() int {
 // reviewed on 2023.12.30
 // reviewed by Alice, 2024.06.24
 func (pp *PublicParameter) serializeAddressPublicKeyForSingle(apk *AddressPublicKeyForSingle) ([]byte, error) {
-	var err error
+	length := pp.addressPublicKeyForSingleSerializeSize()
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeAddressPublicKeyForSingle(w, apk)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
 
+// writeAddressPublicKeyForSingle writes the input AddressPublicKeyForSingle to w, in the format of serializeAddressPublicKeyForSingle.
+func (pp *PublicParameter) writeAddressPublicKeyForSingle(w io.Writer, apk *AddressPublicKeyForSingle) error {
 	if !pp.AddressPublicKeyForSingleSanityCheck(apk) {
-		return nil, fmt.Errorf("serializeAddressPublicKeyForSingle: the input AddressPublicKeyForSingle is not well-form")
+		return fmt.Errorf("writeAddressPublicKeyForSingle: the input AddressPublicKeyForSingle is not well-form")
 	}
 
-	length := pp.addressPublicKeyForSingleSerializeSize()
-	w := bytes.NewBuffer(make([]byte, 0, length))
 	for i := 0; i < pp.paramKA; i++ {
-		err = pp.writePolyANTT(w, apk.t.polyANTTs[i])
+		err := pp.writePolyANTT(w, apk.t.polyANTTs[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeAddressPublicKeyForSingle deserialize the input []byte to an AddressPublicKeyForSingle.
@@ -1149,8 +1216,12 @@ func (pp *PublicParameter) serializeAddressPublicKeyForSingle(apk *AddressPublic
 // reviewed on 2023.12.30
 // reviewed by Alice, 2024.06.24
 func (pp *PublicParameter) deserializeAddressPublicKeyForSingle(serializedAPKForSingle []byte) (*AddressPublicKeyForSingle, error) {
+	return pp.readAddressPublicKeyForSingle(bytes.NewReader(serializedAPKForSingle))
+}
+
+// readAddressPublicKeyForSingle reads an AddressPublicKeyForSingle from r, which was written by writeAddressPublicKeyForSingle.
+func (pp *PublicParameter) readAddressPublicKeyForSingle(r io.Reader) (*AddressPublicKeyForSingle, error) {
 	var err error
-	r := bytes.NewReader(serializedAPKForSingle)
 
 	t := pp.NewPolyANTTVec(pp.paramKA)
 
@@ -1291,6 +1362,7 @@ func (pp *PublicParameter) coinSpendSecretKeyForPKRingParse(coinSpendSecretKey [
 
 	serializedASKSpLen := pp.addressSecretKeySpSerializeSize()
 	serializedASKSp := make([]byte, serializedASKSpLen)
+	defer wipeBytes(serializedASKSp)
 	switch coinAddressType {
 	case CoinAddressTypePublicKeyForRingPre:
 		if len(coinSpendSecretKey) != serializedASKSpLen {
@@ -1335,6 +1407,7 @@ func (pp *PublicParameter) coinSerialNumberSecretKeyForPKRingParse(coinSerialNum
 
 	serializedASKSnLen := pp.addressSecretKeySnSerializeSize()
 	serializedASKSn := make([]byte, serializedASKSnLen)
+	defer wipeBytes(serializedASKSn)
 	switch coinAddressType {
 	case CoinAddressTypePublicKeyForRingPre:
 		if len(coinSerialNumberSecretKey) != serializedASKSnLen {
@@ -1381,6 +1454,7 @@ func (pp *PublicParameter) coinSpendSecretKeyForPKHSingleParse(coinSpendSecretKe
 	serializedAPK := make([]byte, serializedAPKLen)
 	serializedASKSpLen := pp.addressSecretKeySpSerializeSize()
 	serializedASKSp := make([]byte, serializedASKSpLen)
+	defer wipeBytes(serializedASKSp)
 
 	switch coinAddressType {
 	case CoinAddressTypePublicKeyForRingPre:
@@ -5,7 +5,7 @@ import (
 )
 
 func TestPublicParameter_CoinAddressKeyForPKRingGen_CoinAddressKeyForPKRingVerify(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	for i := 0; i < 100; i++ {
 		coinSpendKeyRandSeed := RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -32,7 +32,7 @@ func TestPublicParameter_CoinAddressKeyForPKRingGen_CoinAddressKeyForPKRingVerif
 }
 
 func TestPublicParameter_CoinAddressKeyForPKHSingleGen_CoinAddressKeyForPKHSingleVerify(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	for i := 0; i < 100; i++ {
 		coinSpendKeyRandSeed := RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -57,7 +57,7 @@ func TestPublicParameter_CoinAddressKeyForPKHSingleGen_CoinAddressKeyForPKHSingl
 }
 
 func TestPublicParameter_CoinValueKeyGen_CoinValueKeyVerify(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	for i := 0; i < 100; i++ {
 		randSeed := RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -81,8 +81,9 @@ func TestPublicParameter_CoinValueKeyGen_CoinValueKeyVerify(t *testing.T) {
 		}
 	}
 }
+
 func TestPublicParameter_MLPKey_Sizes(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	coinAddressTypes := []CoinAddressType{
 		CoinAddressTypePublicKeyForRingPre,
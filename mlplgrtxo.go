@@ -3,6 +3,8 @@ package pqringctx
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"sync"
 )
 
 //	LgrTxoMLP	begin
@@ -54,26 +56,35 @@ func (pp *PublicParameter) SerializeLgrTxoMLP(lgrTxo *LgrTxoMLP) ([]byte, error)
 		return nil, err
 	}
 	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeLgrTxoMLP(w, lgrTxo)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeLgrTxoMLP writes the input LgrTxoMLP to w, in the format of SerializeLgrTxoMLP.
+func (pp *PublicParameter) writeLgrTxoMLP(w io.Writer, lgrTxo *LgrTxoMLP) error {
+
+	if !pp.LgrTxoMLPSanityCheck(lgrTxo) {
+		return fmt.Errorf("writeLgrTxoMLP: the input LgrTxoMLP is not well-form")
+	}
 
 	//	txo: fixed length
 	//  It is fixed length in pqringct, but not anymore in pqringctx.
 	//	To keep back-compatible with pqringct, here we still use w.Write, as in pqringct.
-	serializedTxo, err := pp.SerializeTxoMLP(lgrTxo.txo)
-	if err != nil {
-		return nil, err
-	}
-	_, err = w.Write(serializedTxo)
+	err := pp.writeTxoMLP(w, lgrTxo.txo)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	id: fixed-length
 	_, err = w.Write(lgrTxo.id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // DeserializeLgrTxoMLP deserialize the input []byte to a LgrTxoMLP.
@@ -88,28 +99,31 @@ func (pp *PublicParameter) DeserializeLgrTxoMLP(serializedLgrTxo []byte) (*LgrTx
 		return nil, fmt.Errorf("DeserializeLgrTxoMLP: the input serializedLgrTxo is empty")
 	}
 
-	r := bytes.NewReader(serializedLgrTxo)
+	return pp.readLgrTxoMLP(bytes.NewReader(serializedLgrTxo), len(serializedLgrTxo))
+}
 
+// readLgrTxoMLP reads a LgrTxoMLP of size bytes from r, which was written by writeLgrTxoMLP.
+func (pp *PublicParameter) readLgrTxoMLP(r io.Reader, size int) (*LgrTxoMLP, error) {
 	// To be compatible with pqringct, we have to use this way to determine the bytes for the serializedTxo.
 	// Note that this is based on the fact that pp.LgrTxoMLPIdSerializeSize() is a fixed length.
-	serializedTxoLen := len(serializedLgrTxo) - pp.LgrTxoMLPIdSerializeSize()
+	serializedTxoLen := size - pp.LgrTxoMLPIdSerializeSize()
 
 	if serializedTxoLen <= 0 {
-		return nil, fmt.Errorf("DeserializeLgrTxoMLP: the input serializedLgrTxo has an incorrect length")
+		return nil, fmt.Errorf("readLgrTxoMLP: the input serializedLgrTxo has an incorrect length")
 	}
 
-	serializedTxo := make([]byte, serializedTxoLen)
-	_, err := r.Read(serializedTxo)
-	if err != nil {
-		return nil, err
-	}
-	txo, err := pp.DeserializeTxoMLP(serializedTxo)
+	var txo TxoMLP
+	err := readFixedLength(r, serializedTxoLen, func(r io.Reader) error {
+		var err error
+		txo, err = pp.readTxoMLP(r, serializedTxoLen)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	id := make([]byte, pp.LgrTxoMLPIdSerializeSize())
-	_, err = r.Read(id)
+	_, err = io.ReadFull(r, id)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +169,7 @@ func (pp *PublicParameter) LedgerTxoSerialNumberGen(lgrTxo *LgrTxoMLP, coinSeria
 		}
 
 		ma_p = pp.PolyANTTAdd(askSn.ma, m_r)
+		askSn.Destroy()
 
 	} else {
 
@@ -244,16 +259,8 @@ func (pp *PublicParameter) expandKIDRMLP(lgrtxo *LgrTxoMLP) (*PolyANTT, error) {
 	if err != nil {
 		return nil, err
 	}
-	seed, err := Hash(serializedLgrTxo)
-	if err != nil {
-		return nil, err
-	}
 
-	coeffs, err := pp.randomDaIntegersInQa(seed)
-	if err != nil {
-		return nil, err
-	}
-	return &PolyANTT{coeffs}, nil
+	return pp.expandKIDRMLPFromSerialized(serializedLgrTxo)
 
 	//bitNum := 38
 	//bound := pp.paramQA
@@ -275,6 +282,76 @@ func (pp *PublicParameter) expandKIDRMLP(lgrtxo *LgrTxoMLP) (*PolyANTT, error) {
 	//return &PolyANTT{coeffs: coeffs}, nil
 }
 
+// expandKIDRMLPFromSerialized expands the input serializedLgrTxo to m_r.
+// It is the part of expandKIDRMLP after the serialization, so that the callers who already have the serializedLgrTxo
+// (e.g., lgrTxoKIDRCache) do not need to serialize the LgrTxoMLP again.
+// Note that this must keep consistent with expandKIDRMLP.
+func (pp *PublicParameter) expandKIDRMLPFromSerialized(serializedLgrTxo []byte) (*PolyANTT, error) {
+	seed, err := Hash(serializedLgrTxo)
+	if err != nil {
+		return nil, err
+	}
+
+	coeffs, err := pp.randomDaIntegersInQa(seed)
+	if err != nil {
+		return nil, err
+	}
+	return &PolyANTT{coeffs}, nil
+}
+
+// lgrTxoKIDRCache caches the m_r = expandKIDRMLP(lgrTxo) of LgrTxoMLPs, keyed by the serialized LgrTxoMLP,
+// so that the same ring member appearing in multiple inputs/transactions is expanded only once.
+// Note that the key is the whole serialized LgrTxoMLP rather than the LgrTxoMLP.id,
+// since the (txo, id) pairs in an unverified transaction are not trusted.
+// It is safe for concurrent use. The cached PolyANTTs are shared, and must be used as read-only.
+type lgrTxoKIDRCache struct {
+	mu   sync.RWMutex
+	m_rs map[string]*PolyANTT
+}
+
+// newLgrTxoKIDRCache creates an empty lgrTxoKIDRCache.
+func newLgrTxoKIDRCache() *lgrTxoKIDRCache {
+	return &lgrTxoKIDRCache{
+		m_rs: make(map[string]*PolyANTT),
+	}
+}
+
+// expandKIDRMLPWithCache returns expandKIDRMLP(lgrtxo), using the input cache.
+// If the input cache is nil, it is the same as expandKIDRMLP.
+func (pp *PublicParameter) expandKIDRMLPWithCache(lgrtxo *LgrTxoMLP, cache *lgrTxoKIDRCache) (*PolyANTT, error) {
+	if cache == nil {
+		return pp.expandKIDRMLP(lgrtxo)
+	}
+
+	if !pp.LgrTxoMLPSanityCheck(lgrtxo) {
+		return nil, fmt.Errorf("expandKIDRMLPWithCache: the input LgrTxoMLP is not well-form")
+	}
+
+	serializedLgrTxo, err := pp.SerializeLgrTxoMLP(lgrtxo)
+	if err != nil {
+		return nil, err
+	}
+	key := string(serializedLgrTxo)
+
+	cache.mu.RLock()
+	m_r, exists := cache.m_rs[key]
+	cache.mu.RUnlock()
+	if exists {
+		return m_r, nil
+	}
+
+	m_r, err = pp.expandKIDRMLPFromSerialized(serializedLgrTxo)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	cache.m_rs[key] = m_r
+	cache.mu.Unlock()
+
+	return m_r, nil
+}
+
 //	LgrTxo SerialNumber	end
 
 //	Sanity-Check functions	begin
@@ -3,6 +3,7 @@ package pqringctx
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"math/big"
 )
 
@@ -118,18 +119,22 @@ func (pp *PublicParameter) rpulpProveMLP(message []byte, cmts []*ValueCommitment
 	// c_waves[i] = <h_i, r_i> + m_i
 	c_waves := make([]*PolyCNTT, n)
 	for i := uint8(0); i < n; i++ {
-		tmp := pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], cmt_rs[i], pp.paramLC)
-		c_waves[i] = pp.PolyCNTTAdd(tmp, &PolyCNTT{coeffs: msg_hats[i]})
+		c_waves[i] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], cmt_rs[i], pp.paramLC)
+		pp.PolyCNTTAddTo(c_waves[i], c_waves[i], &PolyCNTT{coeffs: msg_hats[i]})
 	}
 
 rpUlpProveMLPRestart:
+	if err := pp.contextErr("rpulpProveMLP"); err != nil {
+		return nil, err
+	}
 	tmpg, err := pp.samplePloyCWithLowZeros()
 	if err != nil {
 		return nil, err
 	}
 	g := pp.NTTPolyC(tmpg)
 	// c_hat(n2+1)
-	c_hat_g := pp.PolyCNTTAdd(pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+5], r_hat, pp.paramLC), g)
+	c_hat_g := pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+5], r_hat, pp.paramLC)
+	pp.PolyCNTTAddTo(c_hat_g, c_hat_g, g)
 
 	cmt_ys := make([][]*PolyCNTTVec, pp.paramK)
 	ys := make([]*PolyCNTTVec, pp.paramK)
@@ -159,12 +164,18 @@ rpUlpProveMLPRestart:
 	//	\tilde{\delta}^(t)_i, \hat{\delta}^(t)_i,
 	delta_waves := make([][]*PolyCNTT, pp.paramK)
 	delta_hats := make([][]*PolyCNTT, pp.paramK)
+	hDiff := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(hDiff)
+	yDiff := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(yDiff)
 	for t := 0; t < pp.paramK; t++ {
 		delta_waves[t] = make([]*PolyCNTT, n)
 		delta_hats[t] = make([]*PolyCNTT, n)
 		for i := uint8(0); i < n; i++ {
-			delta_waves[t][i] = pp.PolyCNTTVecInnerProduct(pp.PolyCNTTVecSub(pp.paramMatrixH[i+1], pp.paramMatrixH[0], pp.paramLC), cmt_ys[t][i], pp.paramLC)
-			delta_hats[t][i] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], pp.PolyCNTTVecSub(ys[t], cmt_ys[t][i], pp.paramLC), pp.paramLC)
+			pp.PolyCNTTVecSubTo(hDiff, pp.paramMatrixH[i+1], pp.paramMatrixH[0], pp.paramLC)
+			delta_waves[t][i] = pp.PolyCNTTVecInnerProduct(hDiff, cmt_ys[t][i], pp.paramLC)
+			pp.PolyCNTTVecSubTo(yDiff, ys[t], cmt_ys[t][i], pp.paramLC)
+			delta_hats[t][i] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], yDiff, pp.paramLC)
 		}
 	}
 
@@ -184,46 +195,43 @@ rpUlpProveMLPRestart:
 	psi := pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+6], r_hat, pp.paramLC)
 	psip := pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+6], ys[0], pp.paramLC)
 
+	hy := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(hy)
+	tmp := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp)
+	tmp1 := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp1)
+	tmp2 := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp2)
 	for t := 0; t < pp.paramK; t++ {
-		tmp1 := pp.NewZeroPolyCNTT()
-		tmp2 := pp.NewZeroPolyCNTT()
+		tmp1.wipe()
+		tmp2.wipe()
 		// sum(0->n1-1)
 		for i := uint8(0); i < n1; i++ {
 			// <h_i , y_t>
-			tmp := pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], ys[t], pp.paramLC)
-
-			tmp1 = pp.PolyCNTTAdd(
-				tmp1,
-				// alpha[i] * (2 * m_i - mu) <h_i , y_t>
-				pp.PolyCNTTMul(
-					alphas[i],
-					// (2 * m_i - mu) <h_i , y_t>
-					pp.PolyCNTTMul(
-						// 2 * m_i - mu
-						pp.PolyCNTTSub(
-							//  m_i+m_i
-							pp.PolyCNTTAdd(
-								&PolyCNTT{coeffs: msg_hats[i]},
-								&PolyCNTT{coeffs: msg_hats[i]},
-							),
-							pp.paramMu,
-						),
-						tmp,
-					),
-				),
-			)
-			tmp2 = pp.PolyCNTTAdd(
-				tmp2,
-				// alpha[i] * <h_i , y_t> * <h_i , y_t>
-				pp.PolyCNTTMul(
-					alphas[i],
-					pp.PolyCNTTMul(tmp, tmp),
-				),
-			)
-		}
-
-		psi = pp.PolyCNTTSub(psi, pp.PolyCNTTMul(betas[t], pp.sigmaInvPolyCNTT(tmp1, t)))
-		psip = pp.PolyCNTTAdd(psip, pp.PolyCNTTMul(betas[t], pp.sigmaInvPolyCNTT(tmp2, t)))
+			pp.PolyCNTTVecInnerProductTo(hy, pp.paramMatrixH[i+1], ys[t], pp.paramLC)
+
+			// alpha[i] * (2 * m_i - mu) <h_i , y_t>
+			msg_hat := &PolyCNTT{coeffs: msg_hats[i]}
+			pp.PolyCNTTAddTo(tmp, msg_hat, msg_hat)
+			pp.PolyCNTTSubTo(tmp, tmp, pp.paramMu)
+			pp.PolyCNTTMulTo(tmp, tmp, hy)
+			pp.PolyCNTTMulTo(tmp, alphas[i], tmp)
+			pp.PolyCNTTAddTo(tmp1, tmp1, tmp)
+
+			// alpha[i] * <h_i , y_t> * <h_i , y_t>
+			pp.PolyCNTTMulTo(tmp, hy, hy)
+			pp.PolyCNTTMulTo(tmp, alphas[i], tmp)
+			pp.PolyCNTTAddTo(tmp2, tmp2, tmp)
+		}
+
+		// psi = psi - beta_t * sigma^{-t}(tmp1), psi' = psi' + beta_t * sigma^{-t}(tmp2)
+		pp.sigmaPowerPolyCNTTTo(tmp, tmp1, (pp.paramK-t)%pp.paramK)
+		pp.PolyCNTTMulTo(tmp, betas[t], tmp)
+		pp.PolyCNTTSubTo(psi, psi, tmp)
+		pp.sigmaPowerPolyCNTTTo(tmp, tmp2, (pp.paramK-t)%pp.paramK)
+		pp.PolyCNTTMulTo(tmp, betas[t], tmp)
+		pp.PolyCNTTAddTo(psip, psip, tmp)
 	}
 	//fmt.Printf("Prove\n")
 	//fmt.Printf("psip = %v\n", psip)
@@ -287,11 +295,14 @@ rpUlpProveMLPRestart:
 	//	fmt.Println("PHI NEW:") // remove this line after test
 
 	phi := pp.NewZeroPolyCNTT() // tSum
+	jSum := tmp1
+	tauSum := tmp2
 	for t := 0; t < pp.paramK; t++ {
 
-		jSum := pp.NewZeroPolyCNTT()
+		jSum.wipe()
 		for j := uint8(0); j < n2; j++ {
-			jSum = pp.PolyCNTTAdd(jSum, pp.PolyCNTTMul(p[t][j], &PolyCNTT{coeffs: msg_hats[j]}))
+			pp.PolyCNTTMulTo(tmp, p[t][j], &PolyCNTT{coeffs: msg_hats[j]})
+			pp.PolyCNTTAddTo(jSum, jSum, tmp)
 		}
 
 		//		fmt.Println("jSum:", jSum) // remove this line after test
@@ -304,13 +315,17 @@ rpUlpProveMLPRestart:
 		inprd.Mod(inprd, bigQc)
 		constPoly.coeffs[0] = reduceInt64(inprd.Int64(), pp.paramQC)
 
-		tauItemConst := pp.PolyCNTTSub(jSum, pp.NTTPolyC(constPoly))
+		//	tauItemConst is computed in jSum
+		pp.NTTPolyCTo(tmp, constPoly)
+		tauItemConst := jSum
+		pp.PolyCNTTSubTo(tauItemConst, jSum, tmp)
 
 		//		fmt.Println("tauItemConst:", tauItemConst) // remove this line after test
 
-		tauSum := pp.NewZeroPolyCNTT()
+		tauSum.wipe()
 		for tau := 0; tau < pp.paramK; tau++ {
-			tauSum = pp.PolyCNTTAdd(tauSum, pp.sigmaPowerPolyCNTT(tauItemConst, tau))
+			pp.sigmaPowerPolyCNTTTo(tmp, tauItemConst, tau)
+			pp.PolyCNTTAddTo(tauSum, tauSum, tmp)
 		}
 
 		//		fmt.Println("tauSum:", tauSum) // remove this line after test
@@ -318,14 +333,16 @@ rpUlpProveMLPRestart:
 		xtPoly := pp.NewZeroPolyC()
 		xtPoly.coeffs[t] = pp.paramKInv
 
-		tItem := pp.PolyCNTTMul(pp.NTTPolyC(xtPoly), tauSum)
+		//	tItem is computed in tmp
+		pp.NTTPolyCTo(tmp, xtPoly)
+		pp.PolyCNTTMulTo(tmp, tmp, tauSum)
 
-		phi = pp.PolyCNTTAdd(phi, tItem)
+		pp.PolyCNTTAddTo(phi, phi, tmp)
 	}
 
 	//	fmt.Println("phi first:", phi) // remove this line after test
 
-	phi = pp.PolyCNTTAdd(phi, g)
+	pp.PolyCNTTAddTo(phi, phi, g)
 
 	//	fmt.Println("phi:", phi) // remove this line after test
 
@@ -395,17 +412,18 @@ rpUlpProveMLPRestart:
 
 	//	As JSums are not related to xi, we pre-compute them here.
 	jSums := make([]*PolyCNTTVec, pp.paramK)
+	tmpVec := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(tmpVec)
 	for t := 0; t < pp.paramK; t++ {
-		jSum := pp.NewZeroPolyCNTTVec(pp.paramLC)
+		jSums[t] = pp.getPolyCNTTVec(pp.paramLC)
+		defer pp.putPolyCNTTVec(jSums[t])
 		for j := uint8(0); j < n2; j++ {
-			jSum = pp.PolyCNTTVecAdd(
-				jSum,
-				pp.PolyCNTTVecScaleMul(p[t][j], pp.paramMatrixH[j+1], pp.paramLC),
-				pp.paramLC)
+			pp.PolyCNTTVecScaleMulTo(tmpVec, p[t][j], pp.paramMatrixH[j+1], pp.paramLC)
+			pp.PolyCNTTVecAddTo(jSums[t], jSums[t], tmpVec, pp.paramLC)
 		}
-		jSums[t] = jSum
 	}
 
+	tauItem := tmp1
 	for xi := 0; xi < pp.paramK; xi++ {
 		phips[xi] = pp.NewZeroPolyCNTT()
 
@@ -440,14 +458,11 @@ rpUlpProveMLPRestart:
 			//fmt.Println("tauSumByJSum:", tauSumOld)
 			////	remove after test	end
 
-			tauSum := pp.NewZeroPolyCNTT()
+			tauSum.wipe()
 			for tau := 0; tau < pp.paramK; tau++ {
-				tauSum = pp.PolyCNTTAdd(
-					tauSum,
-					pp.sigmaPowerPolyCNTT(
-						pp.PolyCNTTVecInnerProduct(jSums[t], ys[(xi-tau+pp.paramK)%pp.paramK], pp.paramLC),
-						tau),
-				)
+				pp.PolyCNTTVecInnerProductTo(tauItem, jSums[t], ys[(xi-tau+pp.paramK)%pp.paramK], pp.paramLC)
+				pp.sigmaPowerPolyCNTTTo(tmp, tauItem, tau)
+				pp.PolyCNTTAddTo(tauSum, tauSum, tmp)
 			}
 			//fmt.Println("tauSumByJSums:", tauSum) // remove this line after test
 
@@ -456,17 +471,18 @@ rpUlpProveMLPRestart:
 			xtPoly := pp.NewZeroPolyC()
 			xtPoly.coeffs[t] = pp.paramKInv
 
-			tItem := pp.PolyCNTTMul(pp.NTTPolyC(xtPoly), tauSum)
+			//	tItem is computed in tmp
+			pp.NTTPolyCTo(tmp, xtPoly)
+			pp.PolyCNTTMulTo(tmp, tmp, tauSum)
 			//			fmt.Println("tItem:", tItem)
 
-			phips[xi] = pp.PolyCNTTAdd(phips[xi], tItem)
+			pp.PolyCNTTAddTo(phips[xi], phips[xi], tmp)
 		}
 
 		//		fmt.Println("phips[xi] first:", phips[xi])
 
-		phips[xi] = pp.PolyCNTTAdd(
-			phips[xi],
-			pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+5], ys[xi], pp.paramLC))
+		pp.PolyCNTTVecInnerProductTo(tmp, pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+5], ys[xi], pp.paramLC)
+		pp.PolyCNTTAddTo(phips[xi], phips[xi], tmp)
 
 		//		fmt.Println("phips[xi]:", phips[xi])
 	}
@@ -492,30 +508,29 @@ rpUlpProveMLPRestart:
 	}
 	ch := pp.NTTPolyC(ch_ploy)
 	// z = y + sigma^t(c) * r
-	cmt_zs_ntt := make([][]*PolyCNTTVec, pp.paramK)
-	zs_ntt := make([]*PolyCNTTVec, pp.paramK)
 	cmt_zs := make([][]*PolyCVec, pp.paramK)
 	zs := make([]*PolyCVec, pp.paramK)
 	zBound := pp.paramEtaC - int64(pp.paramBetaC)
 
+	//	z_ntt is the NTT form of each z, which is a temporary.
+	z_ntt := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(z_ntt)
 	for t := 0; t < pp.paramK; t++ {
-		cmt_zs_ntt[t] = make([]*PolyCNTTVec, n)
 		cmt_zs[t] = make([]*PolyCVec, n)
 		sigma_t_ch := pp.sigmaPowerPolyCNTT(ch, t)
 		for i := uint8(0); i < n; i++ {
-			cmt_zs_ntt[t][i] = pp.PolyCNTTVecAdd(
-				cmt_ys[t][i],
-				pp.PolyCNTTVecScaleMul(sigma_t_ch, cmt_rs[i], pp.paramLC),
-				pp.paramLC)
+			pp.PolyCNTTVecScaleMulTo(z_ntt, sigma_t_ch, cmt_rs[i], pp.paramLC)
+			pp.PolyCNTTVecAddTo(z_ntt, cmt_ys[t][i], z_ntt, pp.paramLC)
 
-			cmt_zs[t][i] = pp.NTTInvPolyCVec(cmt_zs_ntt[t][i])
+			cmt_zs[t][i] = pp.NTTInvPolyCVec(z_ntt)
 			if cmt_zs[t][i].infNorm() > zBound {
 				goto rpUlpProveMLPRestart
 			}
 		}
 
-		zs_ntt[t] = pp.PolyCNTTVecAdd(ys[t], pp.PolyCNTTVecScaleMul(sigma_t_ch, r_hat, pp.paramLC), pp.paramLC)
-		zs[t] = pp.NTTInvPolyCVec(zs_ntt[t])
+		pp.PolyCNTTVecScaleMulTo(z_ntt, sigma_t_ch, r_hat, pp.paramLC)
+		pp.PolyCNTTVecAddTo(z_ntt, ys[t], z_ntt, pp.paramLC)
+		zs[t] = pp.NTTInvPolyCVec(z_ntt)
 		if zs[t].infNorm() > zBound {
 			goto rpUlpProveMLPRestart
 		}
@@ -656,6 +671,9 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 	cmt_zs_ntt := make([][]*PolyCNTTVec, pp.paramK)
 	zs_ntt := make([]*PolyCNTTVec, pp.paramK)
 
+	//	bScaled is sigma^t(c) * b, which is a temporary.
+	bScaled := pp.getPolyCNTTVec(pp.paramKC)
+	defer pp.putPolyCNTTVec(bScaled)
 	for t := 0; t < pp.paramK; t++ {
 		sigma_chs[t] = pp.sigmaPowerPolyCNTT(ch, t)
 
@@ -665,42 +683,42 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 		for i := uint8(0); i < n; i++ {
 			cmt_zs_ntt[t][i] = pp.NTTPolyCVec(rpulppi.cmt_zs[t][i])
 
-			cmt_ws[t][i] = pp.PolyCNTTVecSub(
-				pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, cmt_zs_ntt[t][i], pp.paramKC, pp.paramLC),
-				pp.PolyCNTTVecScaleMul(sigma_chs[t], cmts[i].b, pp.paramKC),
-				pp.paramKC)
+			cmt_ws[t][i] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, cmt_zs_ntt[t][i], pp.paramKC, pp.paramLC)
+			pp.PolyCNTTVecScaleMulTo(bScaled, sigma_chs[t], cmts[i].b, pp.paramKC)
+			pp.PolyCNTTVecSubTo(cmt_ws[t][i], cmt_ws[t][i], bScaled, pp.paramKC)
 		}
 
 		zs_ntt[t] = pp.NTTPolyCVec(rpulppi.zs[t])
-		ws[t] = pp.PolyCNTTVecSub(
-			pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, zs_ntt[t], pp.paramKC, pp.paramLC),
-			pp.PolyCNTTVecScaleMul(sigma_chs[t], b_hat, pp.paramKC),
-			pp.paramKC)
+		ws[t] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, zs_ntt[t], pp.paramKC, pp.paramLC)
+		pp.PolyCNTTVecScaleMulTo(bScaled, sigma_chs[t], b_hat, pp.paramKC)
+		pp.PolyCNTTVecSubTo(ws[t], ws[t], bScaled, pp.paramKC)
 	}
 
 	//	\tilde{\delta}^(t)_i, \hat{\delta}^(t)_i,
 	delta_waves := make([][]*PolyCNTT, pp.paramK)
 	delta_hats := make([][]*PolyCNTT, pp.paramK)
+	hDiff := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(hDiff)
+	zDiff := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(zDiff)
+	tmp := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp)
 	for t := 0; t < pp.paramK; t++ {
 		delta_waves[t] = make([]*PolyCNTT, n)
 		delta_hats[t] = make([]*PolyCNTT, n)
 
 		for i := uint8(0); i < n; i++ {
-			delta_waves[t][i] = pp.PolyCNTTSub(
-				pp.PolyCNTTVecInnerProduct(
-					pp.PolyCNTTVecSub(pp.paramMatrixH[i+1], pp.paramMatrixH[0], pp.paramLC),
-					cmt_zs_ntt[t][i],
-					pp.paramLC),
-				pp.PolyCNTTMul(sigma_chs[t], pp.PolyCNTTSub(rpulppi.c_waves[i], cmts[i].c)),
-			)
+			pp.PolyCNTTVecSubTo(hDiff, pp.paramMatrixH[i+1], pp.paramMatrixH[0], pp.paramLC)
+			delta_waves[t][i] = pp.PolyCNTTVecInnerProduct(hDiff, cmt_zs_ntt[t][i], pp.paramLC)
+			pp.PolyCNTTSubTo(tmp, rpulppi.c_waves[i], cmts[i].c)
+			pp.PolyCNTTMulTo(tmp, sigma_chs[t], tmp)
+			pp.PolyCNTTSubTo(delta_waves[t][i], delta_waves[t][i], tmp)
 
-			delta_hats[t][i] = pp.PolyCNTTSub(
-				pp.PolyCNTTVecInnerProduct(
-					pp.paramMatrixH[i+1],
-					pp.PolyCNTTVecSub(zs_ntt[t], cmt_zs_ntt[t][i], pp.paramLC),
-					pp.paramLC),
-				pp.PolyCNTTMul(sigma_chs[t], pp.PolyCNTTSub(c_hats[i], rpulppi.c_waves[i])),
-			)
+			pp.PolyCNTTVecSubTo(zDiff, zs_ntt[t], cmt_zs_ntt[t][i], pp.paramLC)
+			delta_hats[t][i] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], zDiff, pp.paramLC)
+			pp.PolyCNTTSubTo(tmp, c_hats[i], rpulppi.c_waves[i])
+			pp.PolyCNTTMulTo(tmp, sigma_chs[t], tmp)
+			pp.PolyCNTTSubTo(delta_hats[t][i], delta_hats[t][i], tmp)
 		}
 	}
 
@@ -721,44 +739,44 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 	// psi'
 	psip := pp.NewZeroPolyCNTT()
 	//mu := pp.paramMu
+	f_t_i := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(f_t_i)
+	tmp1 := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp1)
+	tmp2 := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp2)
 	for t := 0; t < pp.paramK; t++ {
 
-		tmp1 := pp.NewZeroPolyCNTT()
-		tmp2 := pp.NewZeroPolyCNTT()
+		tmp1.wipe()
+		tmp2.wipe()
 
 		for i := uint8(0); i < n1; i++ {
-			f_t_i := pp.PolyCNTTSub(
-				//<h_i,z_t>
-				pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[i+1], zs_ntt[t], pp.paramLC),
-				// sigma_c_t
-				pp.PolyCNTTMul(sigma_chs[t], c_hats[i]),
-			)
+			//	f_t_i = <h_i,z_t> - sigma_c_t
+			pp.PolyCNTTVecInnerProductTo(f_t_i, pp.paramMatrixH[i+1], zs_ntt[t], pp.paramLC)
+			pp.PolyCNTTMulTo(tmp, sigma_chs[t], c_hats[i])
+			pp.PolyCNTTSubTo(f_t_i, f_t_i, tmp)
 
-			tmp := pp.PolyCNTTMul(alphas[i], f_t_i)
+			pp.PolyCNTTMulTo(tmp, alphas[i], f_t_i)
 
-			tmp1 = pp.PolyCNTTAdd(
-				tmp1,
-				pp.PolyCNTTMul(tmp, f_t_i),
-			)
+			pp.PolyCNTTAddTo(tmp2, tmp2, tmp)
 
-			tmp2 = pp.PolyCNTTAdd(
-				tmp2,
-				tmp,
-			)
+			pp.PolyCNTTMulTo(tmp, tmp, f_t_i)
+			pp.PolyCNTTAddTo(tmp1, tmp1, tmp)
 		}
-		tmp2 = pp.PolyCNTTMul(tmp2, pp.paramMu)
-		tmp2 = pp.PolyCNTTMul(tmp2, sigma_chs[t])
+		pp.PolyCNTTMulTo(tmp2, tmp2, pp.paramMu)
+		pp.PolyCNTTMulTo(tmp2, tmp2, sigma_chs[t])
 
-		tmp1 = pp.PolyCNTTAdd(tmp1, tmp2)
-		tmp1 = pp.sigmaInvPolyCNTT(tmp1, t)
-		tmp1 = pp.PolyCNTTMul(betas[t], tmp1)
+		pp.PolyCNTTAddTo(tmp1, tmp1, tmp2)
+		pp.sigmaPowerPolyCNTTTo(tmp, tmp1, (pp.paramK-t)%pp.paramK)
+		pp.PolyCNTTMulTo(tmp, betas[t], tmp)
 
-		psip = pp.PolyCNTTAdd(psip, tmp1)
+		pp.PolyCNTTAddTo(psip, psip, tmp)
 	}
 
-	psip = pp.PolyCNTTSub(psip, pp.PolyCNTTMul(ch, rpulppi.psi))
-	psip = pp.PolyCNTTAdd(psip,
-		pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+6], zs_ntt[0], pp.paramLC))
+	pp.PolyCNTTMulTo(tmp, ch, rpulppi.psi)
+	pp.PolyCNTTSubTo(psip, psip, tmp)
+	pp.PolyCNTTVecInnerProductTo(tmp, pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+6], zs_ntt[0], pp.paramLC)
+	pp.PolyCNTTAddTo(psip, psip, tmp)
 	//fmt.Printf("Verify\n")
 	//fmt.Printf("psip = %v\n", psip)
 	//	p^(t)_j:
@@ -825,12 +843,15 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 
 	//fmt.Println("NEW phip in Verify::") // remove this line after test
 	phip := pp.NewZeroPolyCNTT()
+	jSum := tmp1
+	tauSum := tmp2
 	for t := 0; t < pp.paramK; t++ {
 		//fmt.Println("t:", t) // remove this line after test
 
-		jSum := pp.NewZeroPolyCNTT()
+		jSum.wipe()
 		for j := uint8(0); j < n2; j++ {
-			jSum = pp.PolyCNTTAdd(jSum, pp.PolyCNTTMul(p[t][j], c_hats[j]))
+			pp.PolyCNTTMulTo(tmp, p[t][j], c_hats[j])
+			pp.PolyCNTTAddTo(jSum, jSum, tmp)
 		}
 
 		//fmt.Println("jSum:", jSum) // remove this line after test
@@ -842,12 +863,16 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 		inprd.Mod(inprd, bigQc)
 		constPoly.coeffs[0] = reduceInt64(inprd.Int64(), pp.paramQC)
 
-		tauItemConst := pp.PolyCNTTSub(jSum, pp.NTTPolyC(constPoly))
+		//	tauItemConst is computed in jSum
+		pp.NTTPolyCTo(tmp, constPoly)
+		tauItemConst := jSum
+		pp.PolyCNTTSubTo(tauItemConst, jSum, tmp)
 		//fmt.Println("tauItem:", tauItemConst) // remove this line after test
 
-		tauSum := pp.NewZeroPolyCNTT()
+		tauSum.wipe()
 		for tau := 0; tau < pp.paramK; tau++ {
-			tauSum = pp.PolyCNTTAdd(tauSum, pp.sigmaPowerPolyCNTT(tauItemConst, tau))
+			pp.sigmaPowerPolyCNTTTo(tmp, tauItemConst, tau)
+			pp.PolyCNTTAddTo(tauSum, tauSum, tmp)
 		}
 
 		//fmt.Println("tauSum:", tauSum) // remove this line after test
@@ -855,11 +880,13 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 		xtPoly := pp.NewZeroPolyC()
 		xtPoly.coeffs[t] = pp.paramKInv
 
-		tItem := pp.PolyCNTTMul(pp.NTTPolyC(xtPoly), tauSum)
+		//	tItem is computed in tmp
+		pp.NTTPolyCTo(tmp, xtPoly)
+		pp.PolyCNTTMulTo(tmp, tmp, tauSum)
 
 		//fmt.Println("tItem:", tItem) // remove this line after test
 
-		phip = pp.PolyCNTTAdd(phip, tItem)
+		pp.PolyCNTTAddTo(phip, phip, tmp)
 	}
 
 	//fmt.Println("phip:", phip) // remove this line after test
@@ -936,17 +963,18 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 
 	//	As jSums are not related to xi, we pre-compute them here.
 	jSums := make([]*PolyCNTTVec, pp.paramK)
+	tmpVec := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(tmpVec)
 	for t := 0; t < pp.paramK; t++ {
-		jSum := pp.NewZeroPolyCNTTVec(pp.paramLC)
+		jSums[t] = pp.getPolyCNTTVec(pp.paramLC)
+		defer pp.putPolyCNTTVec(jSums[t])
 		for j := uint8(0); j < n2; j++ {
-			jSum = pp.PolyCNTTVecAdd(
-				jSum,
-				pp.PolyCNTTVecScaleMul(p[t][j], pp.paramMatrixH[j+1], pp.paramLC),
-				pp.paramLC)
+			pp.PolyCNTTVecScaleMulTo(tmpVec, p[t][j], pp.paramMatrixH[j+1], pp.paramLC)
+			pp.PolyCNTTVecAddTo(jSums[t], jSums[t], tmpVec, pp.paramLC)
 		}
-		jSums[t] = jSum
 	}
 
+	tauItem := tmp1
 	for xi := 0; xi < pp.paramK; xi++ {
 		phips[xi] = pp.NewZeroPolyCNTT()
 
@@ -988,14 +1016,11 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 
 			//fmt.Println("jSum:", jSum) // remove this line after test
 
-			tauSum := pp.NewZeroPolyCNTT()
+			tauSum.wipe()
 			for tau := 0; tau < pp.paramK; tau++ {
-				tauSum = pp.PolyCNTTAdd(
-					tauSum,
-					pp.sigmaPowerPolyCNTT(
-						pp.PolyCNTTVecInnerProduct(jSums[t], zs_ntt[(xi-tau+pp.paramK)%pp.paramK], pp.paramLC),
-						tau),
-				)
+				pp.PolyCNTTVecInnerProductTo(tauItem, jSums[t], zs_ntt[(xi-tau+pp.paramK)%pp.paramK], pp.paramLC)
+				pp.sigmaPowerPolyCNTTTo(tmp, tauItem, tau)
+				pp.PolyCNTTAddTo(tauSum, tauSum, tmp)
 			}
 
 			//fmt.Println("tauSumByJSums: ", tauSum)                                                      //	remove this line after test
@@ -1006,22 +1031,22 @@ func (pp *PublicParameter) rpulpVerifyMLP(message []byte,
 			xtPoly := pp.NewZeroPolyC()
 			xtPoly.coeffs[t] = pp.paramKInv
 
-			tItem := pp.PolyCNTTMul(pp.NTTPolyC(xtPoly), tauSum)
+			//	tItem is computed in tmp
+			pp.NTTPolyCTo(tmp, xtPoly)
+			pp.PolyCNTTMulTo(tmp, tmp, tauSum)
 
 			//fmt.Println("tItem:", tItem) // remove this line after test
 
-			phips[xi] = pp.PolyCNTTAdd(phips[xi], tItem)
+			pp.PolyCNTTAddTo(phips[xi], phips[xi], tmp)
 		}
 
 		//fmt.Println("phips[xi] first:", phips[xi]) // remove this line after test
 
-		phips[xi] = pp.PolyCNTTAdd(
-			phips[xi],
-			pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+5], zs_ntt[xi], pp.paramLC))
+		pp.PolyCNTTVecInnerProductTo(tmp, pp.paramMatrixH[int(pp.paramI)+int(pp.paramJ)+5], zs_ntt[xi], pp.paramLC)
+		pp.PolyCNTTAddTo(phips[xi], phips[xi], tmp)
 
-		phips[xi] = pp.PolyCNTTSub(
-			phips[xi],
-			pp.PolyCNTTMul(sigma_chs[xi], constTerm))
+		pp.PolyCNTTMulTo(tmp, sigma_chs[xi], constTerm)
+		pp.PolyCNTTSubTo(phips[xi], phips[xi], tmp)
 
 		//fmt.Println("phips[xi]:", phips[xi]) // remove this line after test
 	}
@@ -1778,30 +1803,44 @@ func (pp *PublicParameter) rpulpProofMLPSerializeSizeByCommNum(nL uint8, nR uint
 // reviewed by Alice, 2024.06.30
 func (pp *PublicParameter) serializeRpulpProofMLP(prf *RpulpProofMLP) ([]byte, error) {
 
-	if !pp.RpulpProofSanityCheck(prf) {
-		return nil, fmt.Errorf("SerializeRpulpProofMLP: the input rpulpProofMLP is not well-form")
+	if prf == nil {
+		return nil, fmt.Errorf("SerializeRpulpProofMLP: the input rpulpProofMLP is nil")
 	}
 
-	var err error
 	length := pp.rpulpProofMLPSerializeSizeByCommNum(prf.nL, prf.nR)
 	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeRpulpProofMLP(w, prf)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeRpulpProofMLP writes the input RpulpProofMLP to w, in the format of serializeRpulpProofMLP.
+func (pp *PublicParameter) writeRpulpProofMLP(w io.Writer, prf *RpulpProofMLP) error {
+
+	if !pp.RpulpProofSanityCheck(prf) {
+		return fmt.Errorf("writeRpulpProofMLP: the input rpulpProofMLP is not well-form")
+	}
+
+	var err error
 
 	// rpUlpType RpUlpTypeMLP
-	err = w.WriteByte(byte(prf.rpUlpType))
+	err = binarySerializer.PutUint8(w, uint8(prf.rpUlpType))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// nL        uint8
-	err = w.WriteByte(prf.nL)
+	err = binarySerializer.PutUint8(w, prf.nL)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// nR        uint8
-	err = w.WriteByte(prf.nR)
+	err = binarySerializer.PutUint8(w, prf.nR)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	n := int(prf.nL) + int(prf.nR)
@@ -1810,32 +1849,32 @@ func (pp *PublicParameter) serializeRpulpProofMLP(prf *RpulpProofMLP) ([]byte, e
 	for i := 0; i < n; i++ {
 		err = pp.writePolyCNTT(w, prf.c_waves[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//c_hat_g *PolyCNTT
 	err = pp.writePolyCNTT(w, prf.c_hat_g)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//psi     *PolyCNTT
 	err = pp.writePolyCNTT(w, prf.psi)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//phi     *PolyCNTT
 	err = pp.writePolyCNTT(w, prf.phi)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//chseed  []byte
 	_, err = w.Write(prf.chseed)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//cmt_zs  [][]*PolyCVec eta: dimension [paramK][n]
@@ -1843,7 +1882,7 @@ func (pp *PublicParameter) serializeRpulpProofMLP(prf *RpulpProofMLP) ([]byte, e
 		for j := 0; j < n; j++ {
 			err = pp.writePolyCVecEta(w, prf.cmt_zs[i][j])
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
@@ -1852,11 +1891,11 @@ func (pp *PublicParameter) serializeRpulpProofMLP(prf *RpulpProofMLP) ([]byte, e
 	for i := 0; i < pp.paramK; i++ {
 		err = pp.writePolyCVecEta(w, prf.zs[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeRpulpProofMLP deserialize the input serializedRpulpProofMLP to a RpulpProofMLP.
@@ -1866,23 +1905,26 @@ func (pp *PublicParameter) serializeRpulpProofMLP(prf *RpulpProofMLP) ([]byte, e
 // reviewed on 2024.01.01, by Alice
 // reviewed by Alice, 2024.06.30
 func (pp *PublicParameter) deserializeRpulpProofMLP(serializedRpulpProofMLP []byte) (*RpulpProofMLP, error) {
+	return pp.readRpulpProofMLP(bytes.NewReader(serializedRpulpProofMLP))
+}
 
-	r := bytes.NewReader(serializedRpulpProofMLP)
+// readRpulpProofMLP reads a RpulpProofMLP from r, which was written by writeRpulpProofMLP.
+func (pp *PublicParameter) readRpulpProofMLP(r io.Reader) (*RpulpProofMLP, error) {
 
 	// rpUlpType RpUlpTypeMLP
-	rpUlpType, err := r.ReadByte()
+	rpUlpType, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	// nL        uint8
-	nL, err := r.ReadByte()
+	nL, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	// nR        uint8
-	nR, err := r.ReadByte()
+	nR, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
@@ -1918,7 +1960,7 @@ func (pp *PublicParameter) deserializeRpulpProofMLP(serializedRpulpProofMLP []by
 
 	//chseed  []byte
 	chseed := make([]byte, HashOutputBytesLen)
-	_, err = r.Read(chseed)
+	_, err = io.ReadFull(r, chseed)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,273 @@
+package pqringctx
+
+import (
+	"fmt"
+)
+
+//	Scanner	begin
+
+// Scanner detects and decodes the Txos owned by a wallet, from a batch of transactions.
+// It is configured with
+// (1) coinDetectorKeys, each optionally with the (coinValuePublicKey, coinValueSecretKey) pair of the coinAddresses it detects,
+// which suits the keys derived by CoinAddressKeyForPKRingDerive/CoinAddressKeyForPKHSingleDerive, and
+// (2) coinAddresses, each with its (coinValuePublicKey, coinValueSecretKey) pair.
+// A Txo is owned if its coinAddress is one of the configured coinAddresses,
+// or its coinAddress (with a detector tag) is detected by one of the configured coinDetectorKeys.
+// Note that TxoRCTPre does not have a detector tag, and hence is matched only by the exact coinAddress.
+// A Scanner must not be configured while it is scanning, and the scanning methods can be called concurrently.
+type Scanner struct {
+	pp *PublicParameter
+
+	coinDetectorKeys []*scannerCoinDetectorKey
+	//	the configured coinAddresses, keyed by string(coinAddress)
+	coinAddresses map[string]*scannerCoinValueKey
+}
+
+// scannerCoinValueKey is a (coinValuePublicKey, coinValueSecretKey) pair, which is nil for pseudonym-privacy coinAddresses.
+type scannerCoinValueKey struct {
+	coinValuePublicKey []byte
+	coinValueSecretKey []byte
+}
+
+type scannerCoinDetectorKey struct {
+	coinDetectorKey []byte
+	coinValueKey    *scannerCoinValueKey
+}
+
+// OwnedTxoMLP is an owned Txo found by Scanner.
+// TxIndex is the index of the transaction in the scanned batch, and TxoIndex is the index of the Txo in the transaction's txos.
+type OwnedTxoMLP struct {
+	TxIndex     int
+	TxoIndex    int
+	CoinAddress []byte
+	Txo         TxoMLP
+	Value       uint64
+}
+
+// NewScanner creates a Scanner without any key.
+func (pp *PublicParameter) NewScanner() *Scanner {
+	return &Scanner{
+		pp:            pp,
+		coinAddresses: make(map[string]*scannerCoinValueKey),
+	}
+}
+
+// newScannerCoinValueKey checks the input (coinValuePublicKey, coinValueSecretKey) pair, which could be both nil.
+func (s *Scanner) newScannerCoinValueKey(coinValuePublicKey []byte, coinValueSecretKey []byte) (*scannerCoinValueKey, error) {
+	if coinValuePublicKey == nil && coinValueSecretKey == nil {
+		return nil, nil
+	}
+	if valid, hints := s.pp.CoinValueKeyVerify(coinValuePublicKey, coinValueSecretKey); !valid {
+		return nil, fmt.Errorf("newScannerCoinValueKey: the input (coinValuePublicKey, coinValueSecretKey) pair is invalid: %s", hints)
+	}
+	return &scannerCoinValueKey{
+		coinValuePublicKey: coinValuePublicKey,
+		coinValueSecretKey: coinValueSecretKey,
+	}, nil
+}
+
+// AddCoinDetectorKey adds a coinDetectorKey, together with the (coinValuePublicKey, coinValueSecretKey) pair
+// used to decode the RingCT-privacy Txos detected by it.
+// The (coinValuePublicKey, coinValueSecretKey) pair could be both nil, e.g., when the coinDetectorKey is used only for pseudonym-privacy coinAddresses,
+// and then the RingCT-privacy Txos detected by it (and not matched by a configured coinAddress) are ignored.
+func (s *Scanner) AddCoinDetectorKey(coinDetectorKey []byte, coinValuePublicKey []byte, coinValueSecretKey []byte) error {
+	if len(coinDetectorKey) != s.pp.GetParamMACKeyBytesLen() {
+		return fmt.Errorf("Scanner.AddCoinDetectorKey: the input coinDetectorKey's length (%d) is incorrect", len(coinDetectorKey))
+	}
+	coinValueKey, err := s.newScannerCoinValueKey(coinValuePublicKey, coinValueSecretKey)
+	if err != nil {
+		return err
+	}
+
+	s.coinDetectorKeys = append(s.coinDetectorKeys, &scannerCoinDetectorKey{
+		coinDetectorKey: coinDetectorKey,
+		coinValueKey:    coinValueKey,
+	})
+	return nil
+}
+
+// AddCoinAddress adds a coinAddress, together with its (coinValuePublicKey, coinValueSecretKey) pair.
+// The (coinValuePublicKey, coinValueSecretKey) pair is required for the RingCT-privacy coinAddresses,
+// and should be nil for the pseudonym-privacy coinAddresses.
+func (s *Scanner) AddCoinAddress(coinAddress []byte, coinValuePublicKey []byte, coinValueSecretKey []byte) error {
+	coinAddressType, err := s.pp.ExtractCoinAddressTypeFromCoinAddress(coinAddress)
+	if err != nil {
+		return err
+	}
+
+	coinValueKey, err := s.newScannerCoinValueKey(coinValuePublicKey, coinValueSecretKey)
+	if err != nil {
+		return err
+	}
+	switch coinAddressType {
+	case CoinAddressTypePublicKeyForRingPre, CoinAddressTypePublicKeyForRing:
+		if coinValueKey == nil {
+			return fmt.Errorf("Scanner.AddCoinAddress: the input coinAddress is for RingCT-privacy, but the (coinValuePublicKey, coinValueSecretKey) pair is nil")
+		}
+	case CoinAddressTypePublicKeyHashForSingle:
+		if coinValueKey != nil {
+			return fmt.Errorf("Scanner.AddCoinAddress: the input coinAddress is for pseudonym-privacy, but the (coinValuePublicKey, coinValueSecretKey) pair is not nil")
+		}
+	default:
+		return fmt.Errorf("Scanner.AddCoinAddress: the coinAddressType (%d) of the input coinAddress is not supported", coinAddressType)
+	}
+
+	s.coinAddresses[string(coinAddress)] = coinValueKey
+	return nil
+}
+
+// scanTxo checks whether the input txo is owned, and if true, decodes its value.
+// If the txo is owned but its value cannot be decoded, e.g., the value ciphertext does not match the configured (coinValuePublicKey, coinValueSecretKey) pair,
+// it returns owned = true together with the error, so that the caller does not take the txo as not owned.
+// Note that a RingCT-privacy Txo detected by a coinDetectorKey without (coinValuePublicKey, coinValueSecretKey) pair is ignored (see AddCoinDetectorKey).
+func (s *Scanner) scanTxo(txo TxoMLP) (owned bool, coinAddress []byte, value uint64, err error) {
+	coinAddress, err = s.pp.GetCoinAddressFromTxoMLP(txo)
+	if err != nil {
+		return false, nil, 0, err
+	}
+
+	coinValueKey, owned := s.coinAddresses[string(coinAddress)]
+	if !owned && txo.CoinAddressType() != CoinAddressTypePublicKeyForRingPre {
+		for _, detectorKey := range s.coinDetectorKeys {
+			detected, err := s.pp.DetectCoinAddress(coinAddress, detectorKey.coinDetectorKey)
+			if err != nil {
+				return false, nil, 0, err
+			}
+			if detected {
+				owned = true
+				coinValueKey = detectorKey.coinValueKey
+				break
+			}
+		}
+	}
+	if !owned {
+		return false, nil, 0, nil
+	}
+
+	switch txo.CoinAddressType() {
+	case CoinAddressTypePublicKeyForRingPre, CoinAddressTypePublicKeyForRing:
+		if coinValueKey == nil {
+			return false, nil, 0, nil
+		}
+		value, _, err = s.pp.ExtractValueAndRandFromTxoMLP(txo, coinValueKey.coinValuePublicKey, coinValueKey.coinValueSecretKey)
+		if err != nil {
+			return true, coinAddress, 0, fmt.Errorf("the txo is owned, but its value cannot be extracted: %w", err)
+		}
+	default:
+		_, value, err = s.pp.PseudonymTxoCoinParse(txo)
+		if err != nil {
+			return true, coinAddress, 0, fmt.Errorf("the txo is owned, but its value cannot be parsed: %w", err)
+		}
+	}
+
+	return true, coinAddress, value, nil
+}
+
+// scanTxs scans the txos of n transactions concurrently, where txosOf(i) returns the txos of the i-th transaction.
+// The returned OwnedTxoMLPs are in the order of (TxIndex, TxoIndex).
+// The returned errs has length n, and errs[i] is the first error in scanning the i-th transaction, as runBatchVerify.
+// A txo that fails to be scanned does not stop the scanning of the other txos of the same transaction,
+// and the owned Txos decoded successfully are returned even if errs[i] != nil.
+func (s *Scanner) scanTxs(n int, txosOf func(i int) ([]TxoMLP, error)) ([]*OwnedTxoMLP, []error) {
+	ownedTxosOfTx := make([][]*OwnedTxoMLP, n)
+	errs := make([]error, n)
+	runWorkerPool(n, func(i int) {
+		txos, err := txosOf(i)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		for j, txo := range txos {
+			owned, coinAddress, value, err := s.scanTxo(txo)
+			if err != nil {
+				if errs[i] == nil {
+					errs[i] = fmt.Errorf("the %d -th txo: %w", j, err)
+				}
+				continue
+			}
+			if owned {
+				ownedTxosOfTx[i] = append(ownedTxosOfTx[i], &OwnedTxoMLP{
+					TxIndex:     i,
+					TxoIndex:    j,
+					CoinAddress: coinAddress,
+					Txo:         txo,
+					Value:       value,
+				})
+			}
+		}
+	})
+
+	ownedTxos := make([]*OwnedTxoMLP, 0)
+	for i := 0; i < n; i++ {
+		ownedTxos = append(ownedTxos, ownedTxosOfTx[i]...)
+	}
+	return ownedTxos, errs
+}
+
+// wrapScanErrors prefixes the non-nil errors in errs with the input function name and the transaction index.
+func wrapScanErrors(funcName string, errs []error) []error {
+	for i, err := range errs {
+		if err != nil {
+			errs[i] = fmt.Errorf("%s: the %d -th transaction: %w", funcName, i, err)
+		}
+	}
+	return errs
+}
+
+// ScanCoinbaseTxs returns the owned Txos in the input CoinbaseTxMLPs.
+// The returned errs has the same length as the input cbTxs, and errs[i] != nil reports that cbTxs[i] is not fully scanned,
+// e.g., cbTxs[i] is nil, or it has an owned Txo whose value cannot be decoded.
+// The owned Txos of the other transactions (and the decoded owned Txos of cbTxs[i]) are still returned.
+func (s *Scanner) ScanCoinbaseTxs(cbTxs []*CoinbaseTxMLP) (ownedTxos []*OwnedTxoMLP, errs []error) {
+	ownedTxos, errs = s.scanTxs(len(cbTxs), func(i int) ([]TxoMLP, error) {
+		if cbTxs[i] == nil {
+			return nil, fmt.Errorf("the input CoinbaseTxMLP is nil")
+		}
+		return cbTxs[i].txos, nil
+	})
+	return ownedTxos, wrapScanErrors("Scanner.ScanCoinbaseTxs", errs)
+}
+
+// ScanTransferTxs returns the owned Txos in the input TransferTxMLPs.
+// The returned errs is as that of ScanCoinbaseTxs.
+func (s *Scanner) ScanTransferTxs(trTxs []*TransferTxMLP) (ownedTxos []*OwnedTxoMLP, errs []error) {
+	ownedTxos, errs = s.scanTxs(len(trTxs), func(i int) ([]TxoMLP, error) {
+		if trTxs[i] == nil {
+			return nil, fmt.Errorf("the input TransferTxMLP is nil")
+		}
+		return trTxs[i].txos, nil
+	})
+	return ownedTxos, wrapScanErrors("Scanner.ScanTransferTxs", errs)
+}
+
+// ScanSerializedCoinbaseTxs returns the owned Txos in the input serialized CoinbaseTxMLPs,
+// which are deserialized by DeserializeCoinbaseTxMLP with the input withWitness.
+// The returned errs is as that of ScanCoinbaseTxs, where errs[i] also reports the failure of deserializing serializedCbTxs[i].
+// Note that the Txos in the returned OwnedTxoMLPs are deserialized from the input.
+func (s *Scanner) ScanSerializedCoinbaseTxs(serializedCbTxs [][]byte, withWitness bool) (ownedTxos []*OwnedTxoMLP, errs []error) {
+	ownedTxos, errs = s.scanTxs(len(serializedCbTxs), func(i int) ([]TxoMLP, error) {
+		cbTx, err := s.pp.DeserializeCoinbaseTxMLP(serializedCbTxs[i], withWitness)
+		if err != nil {
+			return nil, err
+		}
+		return cbTx.txos, nil
+	})
+	return ownedTxos, wrapScanErrors("Scanner.ScanSerializedCoinbaseTxs", errs)
+}
+
+// ScanSerializedTransferTxs returns the owned Txos in the input serialized TransferTxMLPs,
+// which are deserialized by DeserializeTransferTxMLP with the input withWitness.
+// The returned errs is as that of ScanSerializedCoinbaseTxs.
+// Note that the Txos in the returned OwnedTxoMLPs are deserialized from the input.
+func (s *Scanner) ScanSerializedTransferTxs(serializedTrTxs [][]byte, withWitness bool) (ownedTxos []*OwnedTxoMLP, errs []error) {
+	ownedTxos, errs = s.scanTxs(len(serializedTrTxs), func(i int) ([]TxoMLP, error) {
+		trTx, err := s.pp.DeserializeTransferTxMLP(serializedTrTxs[i], withWitness)
+		if err != nil {
+			return nil, err
+		}
+		return trTx.txos, nil
+	})
+	return ownedTxos, wrapScanErrors("Scanner.ScanSerializedTransferTxs", errs)
+}
+
+//	Scanner	end
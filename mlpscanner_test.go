@@ -0,0 +1,158 @@
+package pqringctx
+
+import (
+	"bytes"
+	"testing"
+)
+
+// checkScanErrors fails the test if any of the errs returned by the scanning is not nil.
+func checkScanErrors(t *testing.T, errs []error, n int) {
+	t.Helper()
+	if len(errs) != n {
+		t.Fatalf("the number of errs (%d) is not %d", len(errs), n)
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("the %d -th transaction: %v", i, err)
+		}
+	}
+}
+
+func TestScanner(t *testing.T) {
+	pp := initializeForTest()
+
+	masterSeed := RandomBytes(64)
+	coinDetectorKey, err := pp.CoinDetectorKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinValuePublicKey, coinValueSecretKey, err := pp.CoinValueKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coinAddressRing, _, _, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddressSingle, _, err := pp.CoinAddressKeyForPKHSingleDerive(masterSeed, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddressRingPre, _, _, err := AddressKeyGen(pp, RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinValuePublicKeyPre, coinValueSecretKeyPre, err := ValueKeyGen(pp, RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//	the keys of others
+	otherMasterSeed := RandomBytes(64)
+	otherCoinValuePublicKey, _, err := pp.CoinValueKeyDerive(otherMasterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCoinAddressRing, _, _, err := pp.CoinAddressKeyForPKRingDerive(otherMasterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCoinAddressSingle, _, err := pp.CoinAddressKeyForPKHSingleDerive(otherMasterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txOutputDescs := []*TxOutputDescMLP{
+		NewTxOutputDescMLP(coinAddressRingPre, coinValuePublicKeyPre, 100),
+		NewTxOutputDescMLP(otherCoinAddressRing, otherCoinValuePublicKey, 200),
+		NewTxOutputDescMLP(coinAddressRing, coinValuePublicKey, 300),
+		NewTxOutputDescMLP(otherCoinAddressSingle, nil, 400),
+		NewTxOutputDescMLP(coinAddressSingle, nil, 500),
+	}
+	cbTx, err := pp.CoinbaseTxMLPGen(1500, txOutputDescs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scanner := pp.NewScanner()
+	if err = scanner.AddCoinDetectorKey(coinDetectorKey, coinValuePublicKey, coinValueSecretKey); err != nil {
+		t.Fatal(err)
+	}
+	if err = scanner.AddCoinAddress(coinAddressRingPre, coinValuePublicKeyPre, coinValueSecretKeyPre); err != nil {
+		t.Fatal(err)
+	}
+	if err = scanner.AddCoinAddress(coinAddressSingle, coinValuePublicKey, coinValueSecretKey); err == nil {
+		t.Fatalf("a pseudonym-privacy coinAddress with value keys is accepted")
+	}
+
+	checkOwnedTxos := func(ownedTxos []*OwnedTxoMLP, txIndex int) {
+		expectedTxoIndexes := []int{0, 2, 4}
+		expectedValues := []uint64{100, 300, 500}
+		expectedCoinAddresses := [][]byte{coinAddressRingPre, coinAddressRing, coinAddressSingle}
+		if len(ownedTxos) != len(expectedTxoIndexes) {
+			t.Fatalf("the number of owned txos (%d) is not %d", len(ownedTxos), len(expectedTxoIndexes))
+		}
+		for i, ownedTxo := range ownedTxos {
+			if ownedTxo.TxIndex != txIndex || ownedTxo.TxoIndex != expectedTxoIndexes[i] ||
+				ownedTxo.Value != expectedValues[i] || !bytes.Equal(ownedTxo.CoinAddress, expectedCoinAddresses[i]) {
+				t.Fatalf("the %d -th owned txo (%d, %d, %d) is not as expected", i, ownedTxo.TxIndex, ownedTxo.TxoIndex, ownedTxo.Value)
+			}
+		}
+	}
+
+	ownedTxos, errs := scanner.ScanCoinbaseTxs([]*CoinbaseTxMLP{cbTx})
+	checkScanErrors(t, errs, 1)
+	checkOwnedTxos(ownedTxos, 0)
+
+	serializedCbTx, err := pp.SerializeCoinbaseTxMLP(cbTx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownedTxos, errs = scanner.ScanSerializedCoinbaseTxs([][]byte{serializedCbTx, serializedCbTx}, true)
+	checkScanErrors(t, errs, 2)
+	if len(ownedTxos) != 6 {
+		t.Fatalf("the number of owned txos (%d) is not 6", len(ownedTxos))
+	}
+	checkOwnedTxos(ownedTxos[3:], 1)
+
+	//	a malformed transaction is reported by its own error, and the others are still scanned
+	ownedTxos, errs = scanner.ScanSerializedCoinbaseTxs([][]byte{serializedCbTx[:len(serializedCbTx)/2], serializedCbTx}, true)
+	if len(errs) != 2 || errs[0] == nil || errs[1] != nil {
+		t.Fatalf("the errs (%v) of scanning a malformed transaction and a valid one are not as expected", errs)
+	}
+	checkOwnedTxos(ownedTxos, 1)
+
+	//	the scanning does not depend on the validity of the transactions
+	trTx := NewTransferTxMLP(nil, cbTx.txos, 0, nil, nil)
+	ownedTxos, errs = scanner.ScanTransferTxs([]*TransferTxMLP{NewTransferTxMLP(nil, nil, 0, nil, nil), trTx})
+	checkScanErrors(t, errs, 2)
+	checkOwnedTxos(ownedTxos, 1)
+
+	ownedTxos, errs = scanner.ScanTransferTxs([]*TransferTxMLP{nil, trTx})
+	if len(errs) != 2 || errs[0] == nil || errs[1] != nil {
+		t.Fatalf("the errs (%v) of scanning a nil TransferTxMLP and a valid one are not as expected", errs)
+	}
+	checkOwnedTxos(ownedTxos, 1)
+
+	//	an owned Txo whose value cannot be extracted is reported as an error, rather than taken as not owned
+	if err = scanner.AddCoinAddress(otherCoinAddressRing, coinValuePublicKey, coinValueSecretKey); err != nil {
+		t.Fatal(err)
+	}
+	ownedTxos, errs = scanner.ScanCoinbaseTxs([]*CoinbaseTxMLP{cbTx})
+	if len(errs) != 1 || errs[0] == nil {
+		t.Fatalf("the owned Txo whose value cannot be extracted is not reported")
+	}
+	checkOwnedTxos(ownedTxos, 0)
+
+	//	a scanner with only the coinDetectorKey does not find the TxoRCTPre
+	scanner = pp.NewScanner()
+	if err = scanner.AddCoinDetectorKey(coinDetectorKey, coinValuePublicKey, coinValueSecretKey); err != nil {
+		t.Fatal(err)
+	}
+	ownedTxos, errs = scanner.ScanCoinbaseTxs([]*CoinbaseTxMLP{cbTx})
+	checkScanErrors(t, errs, 1)
+	if len(ownedTxos) != 2 {
+		t.Fatalf("the number of owned txos (%d) is not 2", len(ownedTxos))
+	}
+}
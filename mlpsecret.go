@@ -0,0 +1,144 @@
+package pqringctx
+
+import "runtime"
+
+//	Secret	begin
+
+// SecretBytes holds secret key material, such as a coinSpendSecretKey, a coinSerialNumberSecretKey, a coinValueSecretKey, or a key-generation seed,
+// so that the holder, e.g., a long-lived wallet daemon, can wipe it from memory by Destroy once it is not needed anymore.
+// Note that SecretBytes does not copy the wrapped buffer, i.e., Destroy zeroes the buffer passed to NewSecretBytes.
+type SecretBytes struct {
+	b []byte
+}
+
+// NewSecretBytes wraps the input b into a SecretBytes, which takes over the ownership of b.
+func NewSecretBytes(b []byte) *SecretBytes {
+	return &SecretBytes{b: b}
+}
+
+// Bytes returns the wrapped buffer, which is nil after Destroy.
+func (s *SecretBytes) Bytes() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.b
+}
+
+// Len returns the length of the wrapped buffer, which is 0 after Destroy.
+func (s *SecretBytes) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.b)
+}
+
+// Destroy zeroes the wrapped buffer and drops it.
+// It is safe to call Destroy on a nil or an already destroyed SecretBytes.
+func (s *SecretBytes) Destroy() {
+	if s == nil {
+		return
+	}
+	wipeBytes(s.b)
+	s.b = nil
+}
+
+// CoinAddressKeyForPKRingGenSecret is the same as CoinAddressKeyForPKRingGen,
+// except that it returns the coinSpendSecretKey and coinSerialNumberSecretKey as SecretBytes.
+func (pp *PublicParameter) CoinAddressKeyForPKRingGenSecret(coinSpendKeyRandSeed []byte, coinSerialNumberKeyRandSeed []byte,
+	coinDetectorKey []byte, publicRand []byte) (coinAddress []byte, coinSpendSecretKey *SecretBytes, coinSerialNumberSecretKey *SecretBytes, err error) {
+	coinAddress, serializedCoinSpendSecretKey, serializedCoinSerialNumberSecretKey, err := pp.CoinAddressKeyForPKRingGen(coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, coinDetectorKey, publicRand)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return coinAddress, NewSecretBytes(serializedCoinSpendSecretKey), NewSecretBytes(serializedCoinSerialNumberSecretKey), nil
+}
+
+// CoinAddressKeyForPKHSingleGenSecret is the same as CoinAddressKeyForPKHSingleGen,
+// except that it returns the coinSpendSecretKey as SecretBytes.
+func (pp *PublicParameter) CoinAddressKeyForPKHSingleGenSecret(coinSpendKeyRandSeed []byte, coinDetectorKey []byte, publicRand []byte) (coinAddress []byte, coinSpendSecretKey *SecretBytes, err error) {
+	coinAddress, serializedCoinSpendSecretKey, err := pp.CoinAddressKeyForPKHSingleGen(coinSpendKeyRandSeed, coinDetectorKey, publicRand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coinAddress, NewSecretBytes(serializedCoinSpendSecretKey), nil
+}
+
+// CoinValueKeyGenSecret is the same as CoinValueKeyGen, except that it returns the coinValueSecretKey as SecretBytes.
+func (pp *PublicParameter) CoinValueKeyGenSecret(randSeed []byte) (coinValuePublicKey []byte, coinValueSecretKey *SecretBytes, err error) {
+	coinValuePublicKey, serializedCoinValueSecretKey, err := pp.CoinValueKeyGen(randSeed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coinValuePublicKey, NewSecretBytes(serializedCoinValueSecretKey), nil
+}
+
+// CoinAddressKeyForPKRingDeriveSecret is the same as CoinAddressKeyForPKRingDerive,
+// except that it returns the coinSpendSecretKey and coinSerialNumberSecretKey as SecretBytes.
+func (pp *PublicParameter) CoinAddressKeyForPKRingDeriveSecret(masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey *SecretBytes,
+	coinSerialNumberSecretKey *SecretBytes, err error) {
+	coinAddress, serializedCoinSpendSecretKey, serializedCoinSerialNumberSecretKey, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, account, index)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return coinAddress, NewSecretBytes(serializedCoinSpendSecretKey), NewSecretBytes(serializedCoinSerialNumberSecretKey), nil
+}
+
+// CoinAddressKeyForPKHSingleDeriveSecret is the same as CoinAddressKeyForPKHSingleDerive,
+// except that it returns the coinSpendSecretKey as SecretBytes.
+func (pp *PublicParameter) CoinAddressKeyForPKHSingleDeriveSecret(masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey *SecretBytes, err error) {
+	coinAddress, serializedCoinSpendSecretKey, err := pp.CoinAddressKeyForPKHSingleDerive(masterSeed, account, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coinAddress, NewSecretBytes(serializedCoinSpendSecretKey), nil
+}
+
+// CoinValueKeyDeriveSecret is the same as CoinValueKeyDerive, except that it returns the coinValueSecretKey as SecretBytes.
+func (pp *PublicParameter) CoinValueKeyDeriveSecret(masterSeed []byte, account uint32) (coinValuePublicKey []byte, coinValueSecretKey *SecretBytes, err error) {
+	coinValuePublicKey, serializedCoinValueSecretKey, err := pp.CoinValueKeyDerive(masterSeed, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	return coinValuePublicKey, NewSecretBytes(serializedCoinValueSecretKey), nil
+}
+
+// NewTxInputDescMLPSecret is the same as NewTxInputDescMLP, except that it takes the secret keys as SecretBytes,
+// so that the TxInputDescMLP can be passed to TransferTxMLPGen.
+// Note that the TxInputDescMLP refers to the buffers of the SecretBytes,
+// so that the SecretBytes should be destroyed only after the transaction is generated.
+func NewTxInputDescMLPSecret(lgrTxoList []*LgrTxoMLP, sidx uint8, coinSpendSecretKey *SecretBytes,
+	coinSerialNumberSecretKey *SecretBytes, coinValuePublicKey []byte, coinValueSecretKey *SecretBytes, coinDetectorKey []byte, value uint64) *TxInputDescMLP {
+	return NewTxInputDescMLP(lgrTxoList, sidx, coinSpendSecretKey.Bytes(), coinSerialNumberSecretKey.Bytes(), coinValuePublicKey, coinValueSecretKey.Bytes(), coinDetectorKey, value)
+}
+
+// TransferTxMLPProposalSignSecret is the same as TransferTxMLPProposalSign, except that it takes the secret keys as SecretBytes.
+func (pp *PublicParameter) TransferTxMLPProposalSignSecret(proposal *TransferTxMLPProposal, coinSpendSecretKeys []*SecretBytes, coinSerialNumberSecretKeys []*SecretBytes) (*TransferTxMLP, error) {
+	serializedCoinSpendSecretKeys := make([][]byte, len(coinSpendSecretKeys))
+	for i, coinSpendSecretKey := range coinSpendSecretKeys {
+		serializedCoinSpendSecretKeys[i] = coinSpendSecretKey.Bytes()
+	}
+	serializedCoinSerialNumberSecretKeys := make([][]byte, len(coinSerialNumberSecretKeys))
+	for i, coinSerialNumberSecretKey := range coinSerialNumberSecretKeys {
+		serializedCoinSerialNumberSecretKeys[i] = coinSerialNumberSecretKey.Bytes()
+	}
+	return pp.TransferTxMLPProposalSign(proposal, serializedCoinSpendSecretKeys, serializedCoinSerialNumberSecretKeys)
+}
+
+// wipeBytes zeroes the input b.
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	//	keep b alive until here, so that the zeroing is not optimized away as dead stores.
+	runtime.KeepAlive(b)
+}
+
+// wipeInt64s zeroes the input coeffs.
+func wipeInt64s(coeffs []int64) {
+	for i := range coeffs {
+		coeffs[i] = 0
+	}
+	runtime.KeepAlive(coeffs)
+}
+
+//	Secret	end
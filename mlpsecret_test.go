@@ -0,0 +1,147 @@
+package pqringctx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSecretBytes_Destroy(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	secret := NewSecretBytes(b)
+	if secret.Len() != 4 || !bytes.Equal(secret.Bytes(), []byte{1, 2, 3, 4}) {
+		t.Fatalf("the SecretBytes does not hold the wrapped buffer")
+	}
+
+	secret.Destroy()
+	if !bytes.Equal(b, make([]byte, 4)) {
+		t.Fatalf("Destroy does not zero the wrapped buffer: %v", b)
+	}
+	if secret.Len() != 0 || secret.Bytes() != nil {
+		t.Fatalf("the SecretBytes still holds the buffer after Destroy")
+	}
+
+	//	idempotent, and safe on nil
+	secret.Destroy()
+	var nilSecret *SecretBytes
+	nilSecret.Destroy()
+	if nilSecret.Len() != 0 || nilSecret.Bytes() != nil {
+		t.Fatalf("the nil SecretBytes is not empty")
+	}
+}
+
+func TestAddressSecretKey_Destroy(t *testing.T) {
+	pp := initializeForTest()
+
+	coinSpendKeyRandSeed := RandomBytes(pp.paramKeyGenSeedBytesLen)
+	coinSerialNumberKeyRandSeed := RandomBytes(pp.paramKeyGenSeedBytesLen)
+	coinDetectorKey := RandomBytes(pp.GetParamMACKeyBytesLen())
+	publicRand := RandomBytes(pp.GetParamKeyGenPublicRandBytesLen())
+	copiedCoinSpendKeyRandSeed := append([]byte{}, coinSpendKeyRandSeed...)
+	copiedCoinSerialNumberKeyRandSeed := append([]byte{}, coinSerialNumberKeyRandSeed...)
+
+	_, coinSpendSecretKey, coinSerialNumberSecretKey, err := pp.CoinAddressKeyForPKRingGen(coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, coinDetectorKey, publicRand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//	only the local copies of the seeds are wiped
+	if !bytes.Equal(coinSpendKeyRandSeed, copiedCoinSpendKeyRandSeed) || !bytes.Equal(coinSerialNumberKeyRandSeed, copiedCoinSerialNumberKeyRandSeed) {
+		t.Fatalf("CoinAddressKeyForPKRingGen modifies the input seeds")
+	}
+	_, regeneratedCoinSpendSecretKey, _, err := pp.CoinAddressKeyForPKRingGen(coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, coinDetectorKey, publicRand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(coinSpendSecretKey, regeneratedCoinSpendSecretKey) {
+		t.Fatalf("CoinAddressKeyForPKRingGen is not deterministic on the same seeds")
+	}
+
+	//	the parsing does not modify the input keys, and Destroy zeroes the parsed ones
+	copiedCoinSpendSecretKey := append([]byte{}, coinSpendSecretKey...)
+	askSp, err := pp.coinSpendSecretKeyForPKRingParse(coinSpendSecretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	askSn, err := pp.coinSerialNumberSecretKeyForPKRingParse(coinSerialNumberSecretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(coinSpendSecretKey, copiedCoinSpendSecretKey) {
+		t.Fatalf("coinSpendSecretKeyForPKRingParse modifies the input coinSpendSecretKey")
+	}
+
+	ask := &AddressSecretKeyForRing{AddressSecretKeySp: askSp, AddressSecretKeySn: askSn}
+	ask.Destroy()
+	for i, polyA := range askSp.s.polyAs {
+		for _, coeff := range polyA.coeffs {
+			if coeff != 0 {
+				t.Fatalf("askSp.s.polyAs[%d] is not zeroed by Destroy", i)
+			}
+		}
+	}
+	for _, coeff := range askSn.ma.coeffs {
+		if coeff != 0 {
+			t.Fatalf("askSn.ma is not zeroed by Destroy")
+		}
+	}
+
+	//	safe on nil
+	var nilAsk *AddressSecretKeyForRing
+	nilAsk.Destroy()
+	(&AddressSecretKeyForSingle{}).Destroy()
+}
+
+func TestPublicParameter_KeyDeriveSecret(t *testing.T) {
+	pp := initializeForTest()
+	masterSeed := RandomBytes(64)
+
+	coinAddress, coinSpendSecretKey, coinSerialNumberSecretKey, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddressSecret, coinSpendSecretKeySecret, coinSerialNumberSecretKeySecret, err := pp.CoinAddressKeyForPKRingDeriveSecret(masterSeed, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(coinAddress, coinAddressSecret) || !bytes.Equal(coinSpendSecretKey, coinSpendSecretKeySecret.Bytes()) || !bytes.Equal(coinSerialNumberSecretKey, coinSerialNumberSecretKeySecret.Bytes()) {
+		t.Fatalf("CoinAddressKeyForPKRingDeriveSecret is different from CoinAddressKeyForPKRingDerive")
+	}
+
+	coinAddressSingle, coinSpendSecretKeySingle, err := pp.CoinAddressKeyForPKHSingleDerive(masterSeed, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddressSingleSecret, coinSpendSecretKeySingleSecret, err := pp.CoinAddressKeyForPKHSingleDeriveSecret(masterSeed, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(coinAddressSingle, coinAddressSingleSecret) || !bytes.Equal(coinSpendSecretKeySingle, coinSpendSecretKeySingleSecret.Bytes()) {
+		t.Fatalf("CoinAddressKeyForPKHSingleDeriveSecret is different from CoinAddressKeyForPKHSingleDerive")
+	}
+
+	coinValuePublicKey, coinValueSecretKey, err := pp.CoinValueKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinValuePublicKeySecret, coinValueSecretKeySecret, err := pp.CoinValueKeyDeriveSecret(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(coinValuePublicKey, coinValuePublicKeySecret) || !bytes.Equal(coinValueSecretKey, coinValueSecretKeySecret.Bytes()) {
+		t.Fatalf("CoinValueKeyDeriveSecret is different from CoinValueKeyDerive")
+	}
+
+	//	the TxInputDescMLP refers to the buffers of the SecretBytes, which are zeroed by Destroy
+	txInputDesc := NewTxInputDescMLPSecret(nil, 0, coinSpendSecretKeySecret, coinSerialNumberSecretKeySecret, coinValuePublicKeySecret, coinValueSecretKeySecret, nil, 0)
+	if !bytes.Equal(txInputDesc.coinSpendSecretKey, coinSpendSecretKey) || !bytes.Equal(txInputDesc.coinSerialNumberSecretKey, coinSerialNumberSecretKey) ||
+		!bytes.Equal(txInputDesc.coinValueSecretKey, coinValueSecretKeySecret.Bytes()) {
+		t.Fatalf("NewTxInputDescMLPSecret does not hold the secret keys")
+	}
+	for _, secret := range []*SecretBytes{coinSpendSecretKeySecret, coinSerialNumberSecretKeySecret, coinValueSecretKeySecret} {
+		secret.Destroy()
+	}
+	for _, b := range [][]byte{txInputDesc.coinSpendSecretKey, txInputDesc.coinSerialNumberSecretKey, txInputDesc.coinValueSecretKey} {
+		if !bytes.Equal(b, make([]byte, len(b))) {
+			t.Fatalf("Destroy does not zero the secret key referred by the TxInputDescMLP")
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package pqringctx
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
 	"fmt"
@@ -17,8 +18,9 @@ import (
 // todo: review by 2024.07
 func (pp *PublicParameter) CoinbaseTxMLPSerializeSize(cbTx *CoinbaseTxMLP, withWitness bool) (int, error) {
 
-	if !pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness) {
-		return 0, fmt.Errorf("CoinbaseTxMLPSerializeSize: the input cbTx *CoinbaseTxMLP is not well-form")
+	err := pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness)
+	if err != nil {
+		return 0, fmt.Errorf("CoinbaseTxMLPSerializeSize: the input cbTx *CoinbaseTxMLP is not well-form: %w", err)
 	}
 
 	var length int
@@ -73,50 +75,73 @@ func (pp *PublicParameter) SerializeCoinbaseTxMLP(cbTx *CoinbaseTxMLP, withWitne
 		return nil, err
 	}
 	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeCoinbaseTxMLP(w, cbTx, withWitness)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// WriteCoinbaseTxMLP writes the input CoinbaseTxMLP to w, in the same format as SerializeCoinbaseTxMLP,
+// without materializing the serialized CoinbaseTxMLP or its components as []byte.
+// The writes to w are buffered, and are flushed before WriteCoinbaseTxMLP returns.
+func (pp *PublicParameter) WriteCoinbaseTxMLP(w io.Writer, cbTx *CoinbaseTxMLP, withWitness bool) error {
+	err := pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness)
+	if err != nil {
+		return fmt.Errorf("WriteCoinbaseTxMLP: the input cbTx *CoinbaseTxMLP is not well-form: %w", err)
+	}
+
+	return writeBuffered(w, func(w io.Writer) error {
+		return pp.writeCoinbaseTxMLP(w, cbTx, withWitness)
+	})
+}
 
+// writeCoinbaseTxMLP writes the input CoinbaseTxMLP to w, in the format of SerializeCoinbaseTxMLP.
+// The caller must have checked the input cbTx by CoinbaseTxMLPSanityCheck, e.g., by CoinbaseTxMLPSerializeSize.
+func (pp *PublicParameter) writeCoinbaseTxMLP(w io.Writer, cbTx *CoinbaseTxMLP, withWitness bool) error {
 	// vin     uint64
-	binarySerializer.PutUint64(w, binary.LittleEndian, cbTx.vin)
+	err := binarySerializer.PutUint64(w, binary.LittleEndian, cbTx.vin)
+	if err != nil {
+		return err
+	}
 
 	//	txos []*txo
 	outputNum := len(cbTx.txos)
 	err = WriteVarInt(w, uint64(outputNum))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for i := 0; i < outputNum; i++ {
-		serializedTxo, err := pp.SerializeTxoMLP(cbTx.txos[i])
+		err = pp.writeTxoMLPFrame(w, cbTx.txos[i])
 		if err != nil {
-			return nil, err
-		}
-		err = writeVarBytes(w, serializedTxo)
-		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//	TxMemo []byte
 	err = writeVarBytes(w, cbTx.txMemo)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txWitness *TxWitnessCbTx
 	if withWitness {
 		if cbTx.txWitness == nil {
-			return nil, fmt.Errorf("SerializeCoinbaseTxMLP: withWitness = true while cbTx.txWitness is nil")
+			return fmt.Errorf("writeCoinbaseTxMLP: withWitness = true while cbTx.txWitness is nil")
 		}
-		serializedTxWitness, err := pp.SerializeTxWitnessCbTx(cbTx.txWitness)
+		witnessLen, err := pp.TxWitnessCbTxSerializeSize(cbTx.txWitness.outForRing)
 		if err != nil {
-			return nil, err
+			return err
 		}
-
-		err = writeVarBytes(w, serializedTxWitness)
+		err = writeVarBytesFrame(w, witnessLen, func(w io.Writer) error {
+			return pp.writeTxWitnessCbTx(w, cbTx.txWitness)
+		})
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // DeserializeCoinbaseTxMLP deserialize []byte to CoinbaseTxMLP.
@@ -125,62 +150,71 @@ func (pp *PublicParameter) SerializeCoinbaseTxMLP(cbTx *CoinbaseTxMLP, withWitne
 // todo: review by 2024.07
 func (pp *PublicParameter) DeserializeCoinbaseTxMLP(serializedCoinbaseTxMLP []byte, withWitness bool) (*CoinbaseTxMLP, error) {
 	if len(serializedCoinbaseTxMLP) == 0 {
-		return nil, fmt.Errorf("DeserializeCoinbaseTxMLP: the input serializedTransferTxMLP is empty")
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "DeserializeCoinbaseTxMLP: the input serializedTransferTxMLP is empty")
 	}
 
-	r := bytes.NewReader(serializedCoinbaseTxMLP)
+	return pp.readCoinbaseTxMLP(bytes.NewReader(serializedCoinbaseTxMLP), withWitness)
+}
 
+// ReadCoinbaseTxMLP reads a CoinbaseTxMLP from r, which was written by WriteCoinbaseTxMLP or SerializeCoinbaseTxMLP,
+// without materializing the serialized CoinbaseTxMLP or its components as []byte.
+// ReadCoinbaseTxMLP does not read beyond the CoinbaseTxMLP, so that it issues many small reads, and r shall be buffered, e.g., by bufio.Reader.
+// As DeserializeCoinbaseTxMLP, the returned error wraps ErrMalformedEncoding if the read bytes are not a well-form CoinbaseTxMLP.
+func (pp *PublicParameter) ReadCoinbaseTxMLP(r io.Reader, withWitness bool) (*CoinbaseTxMLP, error) {
+	return pp.readCoinbaseTxMLP(r, withWitness)
+}
+
+// readCoinbaseTxMLP reads a CoinbaseTxMLP from r, which was written by writeCoinbaseTxMLP.
+func (pp *PublicParameter) readCoinbaseTxMLP(r io.Reader, withWitness bool) (*CoinbaseTxMLP, error) {
 	// vin     uint64
 	vin, err := binarySerializer.Uint64(r, littleEndian)
 	if err != nil {
-		return nil, err
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
 
 	//	txos      []TxoMLP
 	outputNum, err := ReadVarInt(r)
 	if err != nil {
-		return nil, err
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
 	if outputNum > uint64(pp.paramJ)+uint64(pp.paramJSingle) {
-		return nil, fmt.Errorf("DeserializeCoinbaseTxMLP: the outputNum (%d) exceeds the allowed maximum value (%d)", outputNum, uint64(pp.paramJ)+uint64(pp.paramJSingle))
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "readCoinbaseTxMLP: the outputNum (%d) exceeds the allowed maximum value (%d)", outputNum, uint64(pp.paramJ)+uint64(pp.paramJSingle))
 	}
 	txos := make([]TxoMLP, outputNum)
 	for i := 0; i < int(outputNum); i++ {
-		serializedTxo, err := readVarBytes(r, MaxAllowedTxoMLPSize, "CoinbaseTxMLP.txos")
+		txos[i], err = pp.readTxoMLPFrame(r, "CoinbaseTxMLP.txos")
 		if err != nil {
-			return nil, err
-		}
-		txos[i], err = pp.DeserializeTxoMLP(serializedTxo)
-		if err != nil {
-			return nil, err
+			return nil, wrapTxError(err, ErrMalformedEncoding, -1, i)
 		}
 	}
 
 	//	txMemo    []byte
 	txMemo, err := readVarBytes(r, MaxAllowedTxMemoMLPSize, "CoinbaseTxMLP.txMemo")
 	if err != nil {
-		return nil, err
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
 
 	//	txWitness *TxWitnessCbTx
 	var txWitness *TxWitnessCbTx
 	if withWitness {
-		serializedTxWitness, err := readVarBytes(r, MaxAllowedTxWitnessCbTxSize, "CoinbaseTxMLP.txWitness")
-		if err != nil {
-			return nil, err
-		}
-
-		txWitness, err = pp.DeserializeTxWitnessCbTx(serializedTxWitness)
+		err = readVarBytesFrame(r, MaxAllowedTxWitnessCbTxSize, "CoinbaseTxMLP.txWitness", func(r io.Reader, serializedTxWitnessLen int) error {
+			var err error
+			txWitness, err = pp.readTxWitnessCbTx(r)
+			if err != nil {
+				return err
+			}
+			//	an assert/double-check
+			expectedTxWitnessLen, err := pp.TxWitnessCbTxSerializeSize(txWitness.outForRing)
+			if err != nil {
+				return err
+			}
+			if serializedTxWitnessLen != expectedTxWitnessLen {
+				return newTxError(ErrMalformedEncoding, -1, -1, "readCoinbaseTxMLP: serializedTxWitness from serializedCoinbaseTxMLP has length %d, while the obtained txWitness has length %d", serializedTxWitnessLen, expectedTxWitnessLen)
+			}
+			return nil
+		})
 		if err != nil {
-			return nil, err
-		}
-		//	an assert/double-check
-		expectedTxWitnessLen, err1 := pp.TxWitnessCbTxSerializeSize(txWitness.outForRing)
-		if err1 != nil {
-			return nil, err
-		}
-		if len(serializedTxWitness) != expectedTxWitnessLen {
-			return nil, fmt.Errorf("DeserializeCoinbaseTxMLP: serializedTxWitness from serializedCoinbaseTxMLP has length %d, while the obtained txWitness has length %d", len(serializedTxWitness), expectedTxWitnessLen)
+			return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 		}
 	} else {
 		txWitness = nil
@@ -193,8 +227,9 @@ func (pp *PublicParameter) DeserializeCoinbaseTxMLP(serializedCoinbaseTxMLP []by
 		txWitness: txWitness,
 	}
 
-	if !pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness) {
-		return nil, fmt.Errorf("DeserializeCoinbaseTxMLP: the deserialzed CoinbaseTxMLP is not well-form")
+	err = pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness)
+	if err != nil {
+		return nil, fmt.Errorf("readCoinbaseTxMLP: the deserialzed CoinbaseTxMLP is not well-form: %w", err)
 	}
 
 	return cbTx, nil
@@ -254,22 +289,33 @@ func (pp *PublicParameter) serializeTxInputMLP(txInput *TxInputMLP) ([]byte, err
 		return nil, err
 	}
 	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeTxInputMLP(w, txInput)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
 
+// writeTxInputMLP writes the input TxInputMLP to w, in the format of serializeTxInputMLP.
+// The caller must have checked the input txInput by TxInputMLPSanityCheck, e.g., by TxInputMLPSerializeSize.
+func (pp *PublicParameter) writeTxInputMLP(w io.Writer, txInput *TxInputMLP) error {
 	//	lgrTxoList   []*LgrTxoMLP
 	// err = WriteVarInt(w, uint64(len(txInput.lgrTxoList)))
 	ringSize := len(txInput.lgrTxoList) // previous sanity-checks guarantee this is in scope of uint8.
-	err = w.WriteByte(uint8(ringSize))
+	err := binarySerializer.PutUint8(w, uint8(ringSize))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for i := 0; i < ringSize; i++ {
-		serializedLgrTxo, err := pp.SerializeLgrTxoMLP(txInput.lgrTxoList[i])
+		lgrTxoLen, err := pp.lgrTxoMLPSerializeSize(txInput.lgrTxoList[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
-		err = writeVarBytes(w, serializedLgrTxo)
+		err = writeVarBytesFrame(w, lgrTxoLen, func(w io.Writer) error {
+			return pp.writeLgrTxoMLP(w, txInput.lgrTxoList[i])
+		})
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -277,10 +323,10 @@ func (pp *PublicParameter) serializeTxInputMLP(txInput *TxInputMLP) ([]byte, err
 	//err = writeVarBytes(w, txInput.serialNumber)
 	_, err = w.Write(txInput.serialNumber)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeTxInputMLP deserializes the input []byte to a TxInputMLP.
@@ -290,25 +336,28 @@ func (pp *PublicParameter) serializeTxInputMLP(txInput *TxInputMLP) ([]byte, err
 // reviewed by Alice, 2024.07.07
 // todo: review by 2024.07
 func (pp *PublicParameter) deserializeTxInputMLP(serializedTxInputMLP []byte) (*TxInputMLP, error) {
+	return pp.readTxInputMLP(bytes.NewReader(serializedTxInputMLP))
+}
 
-	r := bytes.NewReader(serializedTxInputMLP)
+// readTxInputMLP reads a TxInputMLP from r, which was written by writeTxInputMLP.
+func (pp *PublicParameter) readTxInputMLP(r io.Reader) (*TxInputMLP, error) {
 
 	//	lgrTxoList   []*LgrTxoMLP
-	ringSize, err := r.ReadByte()
+	ringSize, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 	if ringSize > pp.paramRingSizeMax {
-		return nil, fmt.Errorf("deserializeTxInputMLP: the deserialzied ringSize (%d) exceeds the allowed maximum value (%d)", ringSize, pp.paramRingSizeMax)
+		return nil, fmt.Errorf("readTxInputMLP: the deserialzied ringSize (%d) exceeds the allowed maximum value (%d)", ringSize, pp.paramRingSizeMax)
 	}
 
 	lgrTxoList := make([]*LgrTxoMLP, ringSize)
 	for i := uint8(0); i < ringSize; i++ {
-		serializedLgrTxo, err := readVarBytes(r, MaxAllowedLgrTxoMLPSize, "TxInputMLP.lgrTxoList[]")
-		if err != nil {
-			return nil, err
-		}
-		lgrTxoList[i], err = pp.DeserializeLgrTxoMLP(serializedLgrTxo)
+		err = readVarBytesFrame(r, MaxAllowedLgrTxoMLPSize, "TxInputMLP.lgrTxoList[]", func(r io.Reader, serializedLgrTxoLen int) error {
+			var err error
+			lgrTxoList[i], err = pp.readLgrTxoMLP(r, serializedLgrTxoLen)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -329,7 +378,7 @@ func (pp *PublicParameter) deserializeTxInputMLP(serializedTxInputMLP []byte) (*
 	}
 
 	if !pp.TxInputMLPSanityCheck(txInputMLP) {
-		return nil, fmt.Errorf("deserializeTxInputMLP: the deserialized TxInputMLP is not well-form")
+		return nil, fmt.Errorf("readTxInputMLP: the deserialized TxInputMLP is not well-form")
 	}
 
 	return txInputMLP, nil
@@ -342,7 +391,7 @@ func (pp *PublicParameter) deserializeTxInputMLP(serializedTxInputMLP []byte) (*
 func (pp *PublicParameter) TransferTxMLPSerializeSize(trTx *TransferTxMLP, withWitness bool) (int, error) {
 	err := pp.TransferTxMLPSanityCheck(trTx, withWitness)
 	if err != nil {
-		return 0, fmt.Errorf("TransferTxMLPSerializeSize: the input trTx *TransferTxMLP is not well-form: %s", err)
+		return 0, fmt.Errorf("TransferTxMLPSerializeSize: the input trTx *TransferTxMLP is not well-form: %w", err)
 	}
 	// This sanity-check can guarantee the following codes run normally.
 
@@ -415,21 +464,46 @@ func (pp *PublicParameter) SerializeTransferTxMLP(trTx *TransferTxMLP, withWitne
 	}
 
 	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeTransferTxMLP(w, trTx, withWitness)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
 
+// WriteTransferTxMLP writes the input TransferTxMLP to w, in the same format as SerializeTransferTxMLP,
+// without materializing the serialized TransferTxMLP or its components (in particular, the witness) as []byte.
+// The writes to w are buffered, and are flushed before WriteTransferTxMLP returns.
+func (pp *PublicParameter) WriteTransferTxMLP(w io.Writer, trTx *TransferTxMLP, withWitness bool) error {
+	err := pp.TransferTxMLPSanityCheck(trTx, withWitness)
+	if err != nil {
+		return fmt.Errorf("WriteTransferTxMLP: the input trTx *TransferTxMLP is not well-form: %w", err)
+	}
+
+	return writeBuffered(w, func(w io.Writer) error {
+		return pp.writeTransferTxMLP(w, trTx, withWitness)
+	})
+}
+
+// writeTransferTxMLP writes the input TransferTxMLP to w, in the format of SerializeTransferTxMLP.
+// The caller must have checked the input trTx by TransferTxMLPSanityCheck, e.g., by TransferTxMLPSerializeSize.
+func (pp *PublicParameter) writeTransferTxMLP(w io.Writer, trTx *TransferTxMLP, withWitness bool) error {
 	//	txInputs  []*TxInputMLP
 	inputNum := len(trTx.txInputs)
-	err = WriteVarInt(w, uint64(inputNum))
+	err := WriteVarInt(w, uint64(inputNum))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for i := 0; i < inputNum; i++ {
-		serializedTxInput, err := pp.serializeTxInputMLP(trTx.txInputs[i])
+		txInputLen, err := pp.TxInputMLPSerializeSize(trTx.txInputs[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
-		err = writeVarBytes(w, serializedTxInput)
+		err = writeVarBytesFrame(w, txInputLen, func(w io.Writer) error {
+			return pp.writeTxInputMLP(w, trTx.txInputs[i])
+		})
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -437,49 +511,46 @@ func (pp *PublicParameter) SerializeTransferTxMLP(trTx *TransferTxMLP, withWitne
 	outputNum := len(trTx.txos)
 	err = WriteVarInt(w, uint64(outputNum))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for i := 0; i < outputNum; i++ {
-		serializedTxo, err := pp.SerializeTxoMLP(trTx.txos[i])
-		if err != nil {
-			return nil, err
-		}
-		err = writeVarBytes(w, serializedTxo)
+		err = pp.writeTxoMLPFrame(w, trTx.txos[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//	fee       uint64
 	err = binarySerializer.PutUint64(w, binary.LittleEndian, trTx.fee)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txMemo    []byte
 	err = writeVarBytes(w, trTx.txMemo)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txWitness *TxWitnessTrTx
 	if withWitness {
 		if trTx.txWitness == nil {
-			return nil, fmt.Errorf("SerializeTransferTxMLP: withWitness = true while trTx.txWitness is nil")
+			return fmt.Errorf("writeTransferTxMLP: withWitness = true while trTx.txWitness is nil")
 		}
 
-		serializedWitness, err := pp.SerializeTxWitnessTrTx(trTx.txWitness)
+		witnessLen, err := pp.TxWitnessTrTxSerializeSize(trTx.txWitness.inForRing, trTx.txWitness.inForSingleDistinct, trTx.txWitness.outForRing, trTx.txWitness.inRingSizes, trTx.txWitness.vPublic)
 		if err != nil {
-			return nil, err
+			return err
 		}
-
-		err = writeVarBytes(w, serializedWitness)
+		err = writeVarBytesFrame(w, witnessLen, func(w io.Writer) error {
+			return pp.writeTxWitnessTrTx(w, trTx.txWitness)
+		})
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // DeserializeTransferTxMLP deserialize []byte to TransferTxMLP.
@@ -488,83 +559,92 @@ func (pp *PublicParameter) SerializeTransferTxMLP(trTx *TransferTxMLP, withWitne
 // todo: review by 2024.07
 func (pp *PublicParameter) DeserializeTransferTxMLP(serializedTransferTxMLP []byte, withWitness bool) (*TransferTxMLP, error) {
 	if len(serializedTransferTxMLP) == 0 {
-		return nil, fmt.Errorf("DeserializeTransferTxMLP: the input serializedTransferTxMLP is empty")
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "DeserializeTransferTxMLP: the input serializedTransferTxMLP is empty")
 	}
 
-	r := bytes.NewReader(serializedTransferTxMLP)
+	return pp.readTransferTxMLP(bytes.NewReader(serializedTransferTxMLP), withWitness)
+}
+
+// ReadTransferTxMLP reads a TransferTxMLP from r, which was written by WriteTransferTxMLP or SerializeTransferTxMLP,
+// without materializing the serialized TransferTxMLP or its components (in particular, the witness) as []byte.
+// ReadTransferTxMLP does not read beyond the TransferTxMLP, so that it issues many small reads, and r shall be buffered, e.g., by bufio.Reader.
+// As DeserializeTransferTxMLP, the returned error wraps ErrMalformedEncoding if the read bytes are not a well-form TransferTxMLP.
+func (pp *PublicParameter) ReadTransferTxMLP(r io.Reader, withWitness bool) (*TransferTxMLP, error) {
+	return pp.readTransferTxMLP(r, withWitness)
+}
 
+// readTransferTxMLP reads a TransferTxMLP from r, which was written by writeTransferTxMLP.
+func (pp *PublicParameter) readTransferTxMLP(r io.Reader, withWitness bool) (*TransferTxMLP, error) {
 	//	txInputs  []*TxInputMLP
 	inputNum, err := ReadVarInt(r)
 	if err != nil {
-		return nil, err
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
 	if inputNum > uint64(pp.paramI)+uint64(pp.paramISingle) {
-		return nil, fmt.Errorf("DeserializeTransferTxMLP: the inputNum (%d) exceeds the allowed maximum value (%d)", inputNum, uint64(pp.paramI)+uint64(pp.paramISingle))
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "readTransferTxMLP: the inputNum (%d) exceeds the allowed maximum value (%d)", inputNum, uint64(pp.paramI)+uint64(pp.paramISingle))
 	}
 
 	txInputs := make([]*TxInputMLP, inputNum)
 	for i := 0; i < int(inputNum); i++ {
-		serializedTxInput, err := readVarBytes(r, MaxAllowedTxInputMLPSize, "TransferTxMLP.txInputs")
-		if err != nil {
-			return nil, err
-		}
-		txInputs[i], err = pp.deserializeTxInputMLP(serializedTxInput)
+		err = readVarBytesFrame(r, MaxAllowedTxInputMLPSize, "TransferTxMLP.txInputs", func(r io.Reader, _ int) error {
+			var err error
+			txInputs[i], err = pp.readTxInputMLP(r)
+			return err
+		})
 		if err != nil {
-			return nil, err
+			return nil, wrapTxError(err, ErrMalformedEncoding, i, -1)
 		}
 	}
 
 	//	txos      []TxoMLP
 	outputNum, err := ReadVarInt(r)
 	if err != nil {
-		return nil, err
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
 	if outputNum > uint64(pp.paramJ)+uint64(pp.paramJSingle) {
-		return nil, fmt.Errorf("DeserializeTransferTxMLP: the outputNum (%d) exceeds the allowed maximum value (%d)", outputNum, uint64(pp.paramJ)+uint64(pp.paramJSingle))
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "readTransferTxMLP: the outputNum (%d) exceeds the allowed maximum value (%d)", outputNum, uint64(pp.paramJ)+uint64(pp.paramJSingle))
 	}
 	txos := make([]TxoMLP, outputNum)
 	for i := 0; i < int(outputNum); i++ {
-		serializedTxo, err := readVarBytes(r, MaxAllowedTxoMLPSize, "TransferTxMLP.txos")
+		txos[i], err = pp.readTxoMLPFrame(r, "TransferTxMLP.txos")
 		if err != nil {
-			return nil, err
-		}
-		txos[i], err = pp.DeserializeTxoMLP(serializedTxo)
-		if err != nil {
-			return nil, err
+			return nil, wrapTxError(err, ErrMalformedEncoding, -1, i)
 		}
 	}
 
 	//	fee       uint64
 	fee, err := binarySerializer.Uint64(r, littleEndian)
 	if err != nil {
-		return nil, err
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
 
 	//	txMemo    []byte
 	txMemo, err := readVarBytes(r, MaxAllowedTxMemoMLPSize, "TransferTxMLP.txMemo")
 	if err != nil {
-		return nil, err
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
 
 	//	txWitness *TxWitnessTrTx
 	var txWitness *TxWitnessTrTx
 	if withWitness {
-		serializedTxWitness, err := readVarBytes(r, MaxAllowedTxWitnessTrTxSize, "TransferTxMLP.txWitness")
+		err = readVarBytesFrame(r, MaxAllowedTxWitnessTrTxSize, "TransferTxMLP.txWitness", func(r io.Reader, serializedTxWitnessLen int) error {
+			var err error
+			txWitness, err = pp.readTxWitnessTrTx(r)
+			if err != nil {
+				return err
+			}
+			//	an assert/double-check
+			expectedTxWitnessLen, err := pp.TxWitnessTrTxSerializeSize(txWitness.inForRing, txWitness.inForSingleDistinct, txWitness.outForRing, txWitness.inRingSizes, txWitness.vPublic)
+			if err != nil {
+				return err
+			}
+			if serializedTxWitnessLen != expectedTxWitnessLen {
+				return newTxError(ErrMalformedEncoding, -1, -1, "readTransferTxMLP: readed serializedWitness from serializedTransferTxMLP has length %d, while the obtained txWitness has length %d", serializedTxWitnessLen, expectedTxWitnessLen)
+			}
+			return nil
+		})
 		if err != nil {
-			return nil, err
-		}
-
-		txWitness, err = pp.DeserializeTxWitnessTrTx(serializedTxWitness)
-		if err != nil {
-			return nil, err
-		}
-		//	an assert/double-check
-		expectedTxWitnessLen, err1 := pp.TxWitnessTrTxSerializeSize(txWitness.inForRing, txWitness.inForSingleDistinct, txWitness.outForRing, txWitness.inRingSizes, txWitness.vPublic)
-		if err1 != nil {
-			return nil, err1
-		}
-		if len(serializedTxWitness) != expectedTxWitnessLen {
-			return nil, fmt.Errorf("DeserializeTransferTxMLP: readed serializedWitness from serializedTransferTxMLP has length %d, while the obtained txWitness has length %d", len(serializedTxWitness), expectedTxWitnessLen)
+			return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 		}
 	}
 
@@ -578,10 +658,21 @@ func (pp *PublicParameter) DeserializeTransferTxMLP(serializedTransferTxMLP []by
 
 	err = pp.TransferTxMLPSanityCheck(transferTxMLP, withWitness)
 	if err != nil {
-		return nil, fmt.Errorf("DeserializeTransferTxMLP: the deserialized TransferTxMLP is not well-form, %s", err)
+		return nil, fmt.Errorf("readTransferTxMLP: the deserialized TransferTxMLP is not well-form, %w", err)
 	}
 
 	return transferTxMLP, nil
 }
 
+// writeBuffered calls writeContent on a bufio.Writer over w, and flushes it,
+// so that the many small writes of the serialization are not passed to w one by one.
+func writeBuffered(w io.Writer, writeContent func(w io.Writer) error) error {
+	bw := bufio.NewWriter(w)
+	err := writeContent(bw)
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
 //	Tx Serialization	end
@@ -0,0 +1,248 @@
+package pqringctx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestPublicParameter_WriteTransferTxMLP_ReadTransferTxMLP(t *testing.T) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+
+	for _, withWitness := range []bool{true, false} {
+		serialized, err := pp.SerializeTransferTxMLP(trTx, withWitness)
+		if err != nil {
+			t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+		}
+
+		//	two transactions back to back, to check that ReadTransferTxMLP does not read beyond the first one
+		var buf bytes.Buffer
+		for i := 0; i < 2; i++ {
+			if err = pp.WriteTransferTxMLP(&buf, trTx, withWitness); err != nil {
+				t.Fatalf("WriteTransferTxMLP(withWitness = %v) error = %v", withWitness, err)
+			}
+		}
+		if !bytes.Equal(buf.Bytes(), append(append([]byte{}, serialized...), serialized...)) {
+			t.Fatalf("WriteTransferTxMLP(withWitness = %v) does not match SerializeTransferTxMLP", withWitness)
+		}
+
+		r := iotest.OneByteReader(&buf)
+		for i := 0; i < 2; i++ {
+			decoded, err := pp.ReadTransferTxMLP(r, withWitness)
+			if err != nil {
+				t.Fatalf("ReadTransferTxMLP(withWitness = %v) error = %v", withWitness, err)
+			}
+			serializedDecoded, err := pp.SerializeTransferTxMLP(decoded, withWitness)
+			if err != nil {
+				t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+			}
+			if !bytes.Equal(serialized, serializedDecoded) {
+				t.Errorf("ReadTransferTxMLP(withWitness = %v) does not recover the TransferTxMLP", withWitness)
+			}
+		}
+		if buf.Len() != 0 {
+			t.Errorf("ReadTransferTxMLP(withWitness = %v) leaves %d bytes unread", withWitness, buf.Len())
+		}
+
+		//	truncated input
+		_, err = pp.ReadTransferTxMLP(bytes.NewReader(serialized[:len(serialized)-1]), withWitness)
+		if !errors.Is(err, ErrMalformedEncoding) {
+			t.Errorf("ReadTransferTxMLP(withWitness = %v) with a truncated input error = %v, want %v", withWitness, err, ErrMalformedEncoding)
+		}
+	}
+
+	//	the witness
+	serializedWitness, err := pp.SerializeTxWitnessTrTx(trTx.txWitness)
+	if err != nil {
+		t.Fatalf("SerializeTxWitnessTrTx() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err = pp.WriteTxWitnessTrTx(&buf, trTx.txWitness); err != nil {
+		t.Fatalf("WriteTxWitnessTrTx() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), serializedWitness) {
+		t.Fatalf("WriteTxWitnessTrTx() does not match SerializeTxWitnessTrTx")
+	}
+	decodedWitness, err := pp.ReadTxWitnessTrTx(iotest.OneByteReader(&buf))
+	if err != nil {
+		t.Fatalf("ReadTxWitnessTrTx() error = %v", err)
+	}
+	serializedDecodedWitness, err := pp.SerializeTxWitnessTrTx(decodedWitness)
+	if err != nil {
+		t.Fatalf("SerializeTxWitnessTrTx() error = %v", err)
+	}
+	if !bytes.Equal(serializedWitness, serializedDecodedWitness) {
+		t.Errorf("ReadTxWitnessTrTx() does not recover the TxWitnessTrTx")
+	}
+}
+
+func TestPublicParameter_WriteCoinbaseTxMLP_ReadCoinbaseTxMLP(t *testing.T) {
+	InitialAddress()
+
+	//	the outputs cover TxoRCTPre, TxoRCT, and TxoSDN
+	txOutputDescMLPs, _ := GenerateOutput(300, 212, 1, 1, 1)
+	cbTx, err := pp.CoinbaseTxMLPGen(512, txOutputDescMLPs, []byte("memo"))
+	if err != nil {
+		t.Fatalf("CoinbaseTxMLPGen() error = %v", err)
+	}
+
+	serialized, err := pp.SerializeCoinbaseTxMLP(cbTx, true)
+	if err != nil {
+		t.Fatalf("SerializeCoinbaseTxMLP() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err = pp.WriteCoinbaseTxMLP(&buf, cbTx, true); err != nil {
+		t.Fatalf("WriteCoinbaseTxMLP() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), serialized) {
+		t.Fatalf("WriteCoinbaseTxMLP() does not match SerializeCoinbaseTxMLP")
+	}
+	decoded, err := pp.ReadCoinbaseTxMLP(iotest.OneByteReader(&buf), true)
+	if err != nil {
+		t.Fatalf("ReadCoinbaseTxMLP() error = %v", err)
+	}
+	if err = pp.CoinbaseTxMLPVerify(decoded); err != nil {
+		t.Errorf("CoinbaseTxMLPVerify() on the read CoinbaseTxMLP error = %v", err)
+	}
+
+	//	the Txos, back to back
+	buf.Reset()
+	for _, txo := range cbTx.txos {
+		if err = pp.WriteTxoMLP(&buf, txo); err != nil {
+			t.Fatalf("WriteTxoMLP() error = %v", err)
+		}
+	}
+	r := iotest.OneByteReader(&buf)
+	for i, txo := range cbTx.txos {
+		decodedTxo, err := pp.ReadTxoMLP(r)
+		if err != nil {
+			t.Fatalf("ReadTxoMLP() error = %v", err)
+		}
+		serializedTxo, _ := pp.SerializeTxoMLP(txo)
+		serializedDecodedTxo, err := pp.SerializeTxoMLP(decodedTxo)
+		if err != nil {
+			t.Fatalf("SerializeTxoMLP() error = %v", err)
+		}
+		if !bytes.Equal(serializedTxo, serializedDecodedTxo) {
+			t.Errorf("ReadTxoMLP() does not recover txos[%d]", i)
+		}
+	}
+	if _, err = pp.ReadTxoMLP(r); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadTxoMLP() at the end of input error = %v, want %v", err, io.EOF)
+	}
+
+	//	the witness
+	serializedWitness, err := pp.SerializeTxWitnessCbTx(cbTx.txWitness)
+	if err != nil {
+		t.Fatalf("SerializeTxWitnessCbTx() error = %v", err)
+	}
+	buf.Reset()
+	if err = pp.WriteTxWitnessCbTx(&buf, cbTx.txWitness); err != nil {
+		t.Fatalf("WriteTxWitnessCbTx() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), serializedWitness) {
+		t.Fatalf("WriteTxWitnessCbTx() does not match SerializeTxWitnessCbTx")
+	}
+	if _, err = pp.ReadTxWitnessCbTx(&buf); err != nil {
+		t.Errorf("ReadTxWitnessCbTx() error = %v", err)
+	}
+}
+
+// failingWriter accepts n bytes, and then fails every write with err.
+type failingWriter struct {
+	n   int
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) <= w.n {
+		w.n -= len(p)
+		return len(p), nil
+	}
+	written := w.n
+	w.n = 0
+	return written, w.err
+}
+
+func TestPublicParameter_WriteTransferTxMLP_WriterError(t *testing.T) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+	size, err := pp.TransferTxMLPSerializeSize(trTx, true)
+	if err != nil {
+		t.Fatalf("TransferTxMLPSerializeSize() error = %v", err)
+	}
+
+	errWrite := errors.New("write failure")
+	for _, n := range []int{0, 1, size / 2, size - 1} {
+		err = pp.WriteTransferTxMLP(&failingWriter{n: n, err: errWrite}, trTx, true)
+		if !errors.Is(err, errWrite) {
+			t.Errorf("WriteTransferTxMLP() with a writer failing after %d bytes error = %v, want %v", n, err, errWrite)
+		}
+	}
+	if err = pp.WriteTransferTxMLP(&failingWriter{n: size, err: errWrite}, trTx, true); err != nil {
+		t.Errorf("WriteTransferTxMLP() with a writer accepting %d bytes error = %v", size, err)
+	}
+}
+
+func TestPublicParameter_ReadTransferTxMLP_Malformed(t *testing.T) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+	serialized, err := pp.SerializeTransferTxMLP(trTx, true)
+	if err != nil {
+		t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+	}
+
+	//	the input ends at any position, including inside the VarInt and the frame of the first txInput
+	for _, n := range []int{0, 1, 2, 3, 4, len(serialized) / 3, len(serialized) / 2, len(serialized) - 1} {
+		_, err = pp.ReadTransferTxMLP(iotest.OneByteReader(bytes.NewReader(serialized[:n])), true)
+		if !errors.Is(err, ErrMalformedEncoding) {
+			t.Errorf("ReadTransferTxMLP() on the first %d bytes error = %v, want %v", n, err, ErrMalformedEncoding)
+		}
+	}
+
+	//	the error of the reader is returned, rather than being reported as a truncation
+	errRead := errors.New("read failure")
+	r := io.MultiReader(bytes.NewReader(serialized[:len(serialized)/2]), iotest.ErrReader(errRead))
+	if _, err = pp.ReadTransferTxMLP(r, true); !errors.Is(err, errRead) {
+		t.Errorf("ReadTransferTxMLP() with a failing reader error = %v, want %v", err, errRead)
+	}
+
+	//	a frame larger than the allowed maximum is rejected before it is read
+	oversized := append([]byte{}, serialized[:1]...)
+	oversized = append(oversized, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f)
+	if _, err = pp.ReadTransferTxMLP(bytes.NewReader(oversized), true); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("ReadTransferTxMLP() with an oversized txInput frame error = %v, want %v", err, ErrMalformedEncoding)
+	}
+
+	//	a frame longer than its content is handled as DeserializeTransferTxMLP does
+	frameSize, err := ReadVarInt(bytes.NewReader(serialized[1:]))
+	if err != nil {
+		t.Fatalf("ReadVarInt() error = %v", err)
+	}
+	frameStart := 1 + VarIntSerializeSize(frameSize)
+	var longerFrame bytes.Buffer
+	longerFrame.Write(serialized[:1])
+	if err = WriteVarInt(&longerFrame, frameSize+1); err != nil {
+		t.Fatalf("WriteVarInt() error = %v", err)
+	}
+	longerFrame.Write(serialized[frameStart : frameStart+int(frameSize)])
+	longerFrame.WriteByte(0)
+	longerFrame.Write(serialized[frameStart+int(frameSize):])
+	_, errReadLongerFrame := pp.ReadTransferTxMLP(bytes.NewReader(longerFrame.Bytes()), true)
+	_, errDeserializeLongerFrame := pp.DeserializeTransferTxMLP(longerFrame.Bytes(), true)
+	if (errReadLongerFrame == nil) != (errDeserializeLongerFrame == nil) {
+		t.Errorf("ReadTransferTxMLP() error = %v, but DeserializeTransferTxMLP() error = %v on the same input", errReadLongerFrame, errDeserializeLongerFrame)
+	}
+}
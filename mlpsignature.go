@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io"
 )
 
 // ElrSignatureMLP defines the data structure for ELRSSignature.
@@ -69,6 +70,7 @@ func (pp *PublicParameter) elrSignatureMLPSign(
 	if err != nil {
 		return nil, err
 	}
+	defer askSp.Destroy()
 
 	//	rc is the NTT form of a well-form randomness for value-commitment
 	if !pp.ValueCommitmentRandomnessNTTSanityCheck(rc) {
@@ -90,6 +92,7 @@ func (pp *PublicParameter) elrSignatureMLPSign(
 	}
 
 	m_a := pp.PolyANTTSub(ma_p, m_r)
+	defer m_a.wipe()
 	askSn, err := pp.newAddressSecretKeySnFromPolyANTT(m_a)
 	if err != nil {
 		return nil, err
@@ -141,30 +144,60 @@ func (pp *PublicParameter) elrSignatureMLPSign(
 	w_cps := make([][]*PolyCNTTVec, ringLen)
 	delta_cs := make([][]*PolyCNTT, ringLen)
 
+	//	The randomness of the ring members is sampled one after another, in the order of the ring members,
+	//	so that the signature does not depend on the number of ring workers.
 	for j := uint8(0); j < ringLen; j++ {
 		if j == sindex {
 			continue
 		}
-		seeds[j] = RandomBytes(HashOutputBytesLen) // we use Hash to generate seed for challenge
-
-		tmpA, err := pp.expandChallengeA(seeds[j])
-		if err != nil {
+		if err = pp.contextErr("elrSignatureMLPSign"); err != nil {
 			return nil, err
 		}
-		da := pp.NTTPolyA(tmpA)
-
-		tmpC, err := pp.expandChallengeC(seeds[j])
+		seeds[j], err = pp.randomBytes(HashOutputBytesLen) // we use Hash to generate seed for challenge
 		if err != nil {
 			return nil, err
 		}
-		dc := pp.NTTPolyC(tmpC)
 
 		// sample randomness for z_a_j
 		z_as[j], err = pp.sampleResponseA()
 		if err != nil {
 			return nil, err
 		}
-		z_as_ntt[j] = pp.NTTPolyAVec(z_as[j])
+
+		z_cs[j] = make([]*PolyCVec, pp.paramK)
+		z_cps[j] = make([]*PolyCVec, pp.paramK)
+		for tao := 0; tao < pp.paramK; tao++ {
+			z_cs[j][tao], err = pp.sampleResponseC()
+			if err != nil {
+				return nil, err
+			}
+			z_cps[j][tao], err = pp.sampleResponseC()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = pp.runRingWorkers(int(ringLen), func(jInt int) error {
+		j := uint8(jInt)
+		if j == sindex {
+			return nil
+		}
+		if err := pp.contextErr("elrSignatureMLPSign"); err != nil {
+			return err
+		}
+
+		tmpA, err := pp.expandChallengeA(seeds[j])
+		if err != nil {
+			return err
+		}
+		da := pp.NTTPolyA(tmpA)
+
+		tmpC, err := pp.expandChallengeC(seeds[j])
+		if err != nil {
+			return err
+		}
+		dc := pp.NTTPolyC(tmpC)
 
 		// lgrTxoList[j].txo.addressPublicKeyForRing.t
 		// lgrTxoList[j].txo.addressPublicKeyForRing.e
@@ -184,87 +217,30 @@ func (pp *PublicParameter) elrSignatureMLPSign(
 			b_j = txoInst.valueCommitment.b
 			c_j = txoInst.valueCommitment.c
 		case *TxoSDN:
-			return nil, fmt.Errorf("elrsMLPSign: lgrTxoList[%d].txo is a TxoSDN", j)
+			return fmt.Errorf("elrsMLPSign: lgrTxoList[%d].txo is a TxoSDN", j)
 		default:
-			return nil, fmt.Errorf("elrsMLPSign: lgrTxoList[%d].txo is not TxoRCTPre, TxoRCT, or TxoSDN", j)
+			return fmt.Errorf("elrsMLPSign: lgrTxoList[%d].txo is not TxoRCTPre, TxoRCT, or TxoSDN", j)
 		}
-		// w_a_j = A*z_a_j - d_a_j*t_j
-		w_as[j] = pp.PolyANTTVecSub(
-			pp.PolyANTTMatrixMulVector(pp.paramMatrixA, z_as_ntt[j], pp.paramKA, pp.paramLA),
-			pp.PolyANTTVecScaleMul(da, t_j, pp.paramKA),
-			pp.paramKA,
-		)
-		// delta_a_j = <a,z_a_j> - d_a_j * (e_j + expandKIDR(txo[j]) - m_a_p)
+
 		lgrTxoH, err := pp.expandKIDRMLP(lgrTxoList[j])
 		if err != nil {
-			return nil, err
+			return err
 		}
-		delta_as[j] = pp.PolyANTTSub(
-			pp.PolyANTTVecInnerProduct(pp.paramVectorA, z_as_ntt[j], pp.paramLA),
-			pp.PolyANTTMul(
-				da,
-				pp.PolyANTTSub(
-					pp.PolyANTTAdd(
-						e_j,
-						lgrTxoH,
-					),
-					ma_p,
-				),
-			),
-		)
 
+		z_as_ntt[j] = pp.NTTPolyAVec(z_as[j])
 		z_cs_ntt[j] = make([]*PolyCNTTVec, pp.paramK)
 		z_cps_ntt[j] = make([]*PolyCNTTVec, pp.paramK)
-		z_cs[j] = make([]*PolyCVec, pp.paramK)
-		z_cps[j] = make([]*PolyCVec, pp.paramK)
-
-		w_cs[j] = make([]*PolyCNTTVec, pp.paramK)
-		w_cps[j] = make([]*PolyCNTTVec, pp.paramK)
-
-		delta_cs[j] = make([]*PolyCNTT, pp.paramK)
 		for tao := 0; tao < pp.paramK; tao++ {
-			z_cs[j][tao], err = pp.sampleResponseC()
-			if err != nil {
-				return nil, err
-			}
-			z_cps[j][tao], err = pp.sampleResponseC()
-			if err != nil {
-				return nil, err
-			}
-
 			z_cs_ntt[j][tao] = pp.NTTPolyCVec(z_cs[j][tao])
 			z_cps_ntt[j][tao] = pp.NTTPolyCVec(z_cps[j][tao])
-			sigmataodc := pp.sigmaPowerPolyCNTT(dc, tao)
-			w_cs[j][tao] = pp.PolyCNTTVecSub(
-				pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_cs_ntt[j][tao], pp.paramKC, pp.paramLC),
-				pp.PolyCNTTVecScaleMul(
-					sigmataodc,
-					b_j,
-					pp.paramKC,
-				),
-				pp.paramKC,
-			)
-			w_cps[j][tao] = pp.PolyCNTTVecSub(
-				pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_cps_ntt[j][tao], pp.paramKC, pp.paramLC),
-				pp.PolyCNTTVecScaleMul(
-					sigmataodc,
-					cmt_p.b,
-					pp.paramKC,
-				),
-				pp.paramKC,
-			)
-			delta_cs[j][tao] = pp.PolyCNTTSub(
-				pp.PolyCNTTVecInnerProduct(
-					pp.paramMatrixH[0],
-					pp.PolyCNTTVecSub(z_cs_ntt[j][tao], z_cps_ntt[j][tao], pp.paramLC),
-					pp.paramLC,
-				),
-				pp.PolyCNTTMul(
-					sigmataodc,
-					pp.PolyCNTTSub(c_j, cmt_p.c),
-				),
-			)
 		}
+
+		w_as[j], delta_as[j], w_cs[j], w_cps[j], delta_cs[j] = pp.elrSignatureMLPMemberCommit(
+			ma_p, cmt_p, da, dc, t_j, e_j, lgrTxoH, b_j, c_j, z_as_ntt[j], z_cs_ntt[j], z_cps_ntt[j])
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	z_cs_ntt[sindex] = make([]*PolyCNTTVec, pp.paramK)
@@ -276,13 +252,37 @@ func (pp *PublicParameter) elrSignatureMLPSign(
 	w_cps[sindex] = make([]*PolyCNTTVec, pp.paramK)
 	delta_cs[sindex] = make([]*PolyCNTT, pp.paramK)
 
+	//	wipeAttempt wipes the masking randomness of an attempt, together with the responses if the attempt is rejected,
+	//	since both are related to the secrets (sa, rc, rc_p).
+	wipeAttempt := func(y_a *PolyANTTVec, y_cs []*PolyCNTTVec, y_cps []*PolyCNTTVec, rejected bool) {
+		y_a.wipe()
+		for tao := 0; tao < pp.paramK; tao++ {
+			y_cs[tao].wipe()
+			y_cps[tao].wipe()
+		}
+		if rejected {
+			z_as_ntt[sindex].wipe()
+			z_as[sindex].wipe()
+			for tao := 0; tao < pp.paramK; tao++ {
+				z_cs_ntt[sindex][tao].wipe()
+				z_cps_ntt[sindex][tao].wipe()
+				z_cs[sindex][tao].wipe()
+				z_cps[sindex][tao].wipe()
+			}
+		}
+	}
+
 elrSignatureMLPSignRestart:
+	if err := pp.contextErr("elrSignatureMLPSign"); err != nil {
+		return nil, err
+	}
 	// randomness y_a_j_bar
 	tmpYa, err := pp.sampleMaskingVecA()
 	if err != nil {
 		return nil, err
 	}
 	y_a := pp.NTTPolyAVec(tmpYa)
+	tmpYa.wipe()
 	w_as[sindex] = pp.PolyANTTMatrixMulVector(pp.paramMatrixA, y_a, pp.paramKA, pp.paramLA)
 	delta_as[sindex] = pp.PolyANTTVecInnerProduct(pp.paramVectorA, y_a, pp.paramLA)
 
@@ -301,6 +301,8 @@ elrSignatureMLPSignRestart:
 
 		y_cs[tao] = pp.NTTPolyCVec(tmpYc)
 		y_cps[tao] = pp.NTTPolyCVec(tmpYcp)
+		tmpYc.wipe()
+		tmpYcp.wipe()
 		w_cs[sindex][tao] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, y_cs[tao], pp.paramKC, pp.paramLC)
 		w_cps[sindex][tao] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, y_cps[tao], pp.paramKC, pp.paramLC)
 		delta_cs[sindex][tao] = pp.PolyCNTTVecInnerProduct(
@@ -344,21 +346,27 @@ elrSignatureMLPSignRestart:
 	dA := pp.NTTPolyA(tmpA)
 	dC := pp.NTTPolyC(tmpC)
 
-	z_as_ntt[sindex] = pp.PolyANTTVecAdd(y_a, pp.PolyANTTVecScaleMul(dA, sa, pp.paramLA), pp.paramLA)
+	dA_sa := pp.PolyANTTVecScaleMul(dA, sa, pp.paramLA)
+	z_as_ntt[sindex] = pp.PolyANTTVecAdd(y_a, dA_sa, pp.paramLA)
 	z_as[sindex] = pp.NTTInvPolyAVec(z_as_ntt[sindex])
+	dA_sa.wipe()
 
 	for tao := 0; tao < pp.paramK; tao++ {
 		sigmaTaoDc := pp.sigmaPowerPolyCNTT(dC, tao)
+		dC_rc := pp.PolyCNTTVecScaleMul(sigmaTaoDc, rc, pp.paramLC)
+		dC_rc_p := pp.PolyCNTTVecScaleMul(sigmaTaoDc, rc_p, pp.paramLC)
 		z_cs_ntt[sindex][tao] = pp.PolyCNTTVecAdd(
 			y_cs[tao],
-			pp.PolyCNTTVecScaleMul(sigmaTaoDc, rc, pp.paramLC),
+			dC_rc,
 			pp.paramLC,
 		)
 		z_cps_ntt[sindex][tao] = pp.PolyCNTTVecAdd(
 			y_cps[tao],
-			pp.PolyCNTTVecScaleMul(sigmaTaoDc, rc_p, pp.paramLC),
+			dC_rc_p,
 			pp.paramLC,
 		)
+		dC_rc.wipe()
+		dC_rc_p.wipe()
 
 		z_cs[sindex][tao] = pp.NTTInvPolyCVec(z_cs_ntt[sindex][tao])
 		z_cps[sindex][tao] = pp.NTTInvPolyCVec(z_cps_ntt[sindex][tao])
@@ -366,18 +374,21 @@ elrSignatureMLPSignRestart:
 
 	// This line code is put here, rather than earlier before the computation of (z_cs, z_cps), is to defend against time-based side-channel.
 	if z_as[sindex].infNorm() > pp.paramEtaA-int64(pp.paramBetaA) {
+		wipeAttempt(y_a, y_cs, y_cps, true)
 		goto elrSignatureMLPSignRestart
 	}
 
 	boundC := pp.paramEtaC - int64(pp.paramBetaC)
 	for tao := 0; tao < pp.paramK; tao++ {
 		if (z_cs[sindex][tao].infNorm() > boundC) || (z_cps[sindex][tao].infNorm() > boundC) {
+			wipeAttempt(y_a, y_cs, y_cps, true)
 			goto elrSignatureMLPSignRestart
 		}
 		//if z_cps[sindex][tao].infNorm() > boundC {
 		//	goto ELRSSignRestart
 		//}
 	}
+	wipeAttempt(y_a, y_cs, y_cps, false)
 
 	return &ElrSignatureMLP{
 		ringSize: ringLen,
@@ -388,6 +399,74 @@ elrSignatureMLPSignRestart:
 	}, nil
 }
 
+// elrSignatureMLPMemberCommit computes, for a ring member with the address public key (t_j, e_j), m_r = lgrTxoH, and the value commitment (b_j, c_j),
+// the commitments (w_a, delta_a, w_cs, w_cps, delta_cs) from the challenges (da, dc) and the responses (z_a_ntt, z_cs_ntt, z_cps_ntt),
+// namely
+// w_a = A z_a - d_a t_j, delta_a = <a, z_a> - d_a (e_j + m_r - m_a_p),
+// w_c[tao] = B z_c[tao] - sigma^tao(d_c) b_j, w_cp[tao] = B z_cp[tao] - sigma^tao(d_c) b_p,
+// delta_c[tao] = <h, z_c[tao] - z_cp[tao]> - sigma^tao(d_c) (c_j - c_p).
+// It is shared by elrSignatureMLPSign (for the ring members other than the signer) and elrSignatureMLPVerify (for all the ring members),
+// and only reads its inputs, so that it can run for the ring members concurrently.
+func (pp *PublicParameter) elrSignatureMLPMemberCommit(ma_p *PolyANTT, cmt_p *ValueCommitment, da *PolyANTT, dc *PolyCNTT,
+	t_j *PolyANTTVec, e_j *PolyANTT, lgrTxoH *PolyANTT, b_j *PolyCNTTVec, c_j *PolyCNTT,
+	z_a_ntt *PolyANTTVec, z_cs_ntt []*PolyCNTTVec, z_cps_ntt []*PolyCNTTVec) (w_a *PolyANTTVec, delta_a *PolyANTT, w_cs []*PolyCNTTVec, w_cps []*PolyCNTTVec, delta_cs []*PolyCNTT) {
+	// w_a_j = A*z_a_j - d_a_j*t_j
+	w_a = pp.PolyANTTVecSub(
+		pp.PolyANTTMatrixMulVector(pp.paramMatrixA, z_a_ntt, pp.paramKA, pp.paramLA),
+		pp.PolyANTTVecScaleMul(da, t_j, pp.paramKA),
+		pp.paramKA,
+	)
+	// delta_a_j = <a,z_a_j> - d_a_j * (e_j + expandKIDR(txo[j]) - m_a_p)
+	delta_a = pp.PolyANTTSub(
+		pp.PolyANTTVecInnerProduct(pp.paramVectorA, z_a_ntt, pp.paramLA),
+		pp.PolyANTTMul(
+			da,
+			pp.PolyANTTSub(
+				pp.PolyANTTAdd(
+					e_j,
+					lgrTxoH,
+				),
+				ma_p,
+			),
+		),
+	)
+
+	w_cs = make([]*PolyCNTTVec, pp.paramK)
+	w_cps = make([]*PolyCNTTVec, pp.paramK)
+	delta_cs = make([]*PolyCNTT, pp.paramK)
+
+	//	cDiff = c_j - c_p does not depend on tao.
+	cDiff := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(cDiff)
+	pp.PolyCNTTSubTo(cDiff, c_j, cmt_p.c)
+	sigmataodc := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(sigmataodc)
+	tmp := pp.getPolyCNTT()
+	defer pp.putPolyCNTT(tmp)
+	bScaled := pp.getPolyCNTTVec(pp.paramKC)
+	defer pp.putPolyCNTTVec(bScaled)
+	zDiff := pp.getPolyCNTTVec(pp.paramLC)
+	defer pp.putPolyCNTTVec(zDiff)
+	for tao := 0; tao < pp.paramK; tao++ {
+		pp.sigmaPowerPolyCNTTTo(sigmataodc, dc, tao)
+
+		w_cs[tao] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_cs_ntt[tao], pp.paramKC, pp.paramLC)
+		pp.PolyCNTTVecScaleMulTo(bScaled, sigmataodc, b_j, pp.paramKC)
+		pp.PolyCNTTVecSubTo(w_cs[tao], w_cs[tao], bScaled, pp.paramKC)
+
+		w_cps[tao] = pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_cps_ntt[tao], pp.paramKC, pp.paramLC)
+		pp.PolyCNTTVecScaleMulTo(bScaled, sigmataodc, cmt_p.b, pp.paramKC)
+		pp.PolyCNTTVecSubTo(w_cps[tao], w_cps[tao], bScaled, pp.paramKC)
+
+		pp.PolyCNTTVecSubTo(zDiff, z_cs_ntt[tao], z_cps_ntt[tao], pp.paramLC)
+		delta_cs[tao] = pp.PolyCNTTVecInnerProduct(pp.paramMatrixH[0], zDiff, pp.paramLC)
+		pp.PolyCNTTMulTo(tmp, sigmataodc, cDiff)
+		pp.PolyCNTTSubTo(delta_cs[tao], delta_cs[tao], tmp)
+	}
+
+	return w_a, delta_a, w_cs, w_cps, delta_cs
+}
+
 // collectBytesForElrSignatureMLPChallenge collect preMsg in elrSignatureMLPSign, for the Fiat-Shamir transform.
 // Note that this is almost the same as pqringct.collectBytesForElrsChallenge.
 // todo_DONE: the paper is not accurate, use the following params
@@ -513,11 +592,12 @@ func (pp *PublicParameter) collectBytesForElrSignatureMLPChallenge(
 }
 
 // elrSignatureMLPVerify() verify the validity of a given (message, signature) pair.
+// The input kidrCache could be nil. If it is not nil, the m_r of the ring members are obtained from/shared via the cache.
 // reviewed by Alice, 2024.07.02
-func (pp *PublicParameter) elrSignatureMLPVerify(lgrTxoList []*LgrTxoMLP, ma_p *PolyANTT, cmt_p *ValueCommitment, extTrTxCon []byte, sig *ElrSignatureMLP) error {
+func (pp *PublicParameter) elrSignatureMLPVerify(lgrTxoList []*LgrTxoMLP, ma_p *PolyANTT, cmt_p *ValueCommitment, extTrTxCon []byte, sig *ElrSignatureMLP, kidrCache *lgrTxoKIDRCache) error {
 
 	if !pp.LgrTxoRingForRingSanityCheck(lgrTxoList) {
-		return fmt.Errorf("elrSignatureMLPVerify: the input lgrTxoList []*LgrTxoMLP is not well-form")
+		return newTxError(ErrRingMemberInvalid, -1, -1, "elrSignatureMLPVerify: the input lgrTxoList []*LgrTxoMLP is not well-form")
 	}
 	ringLen := uint8(len(lgrTxoList)) // well-form LgrTxoRing has a valid length in scope uint8
 
@@ -547,7 +627,11 @@ func (pp *PublicParameter) elrSignatureMLPVerify(lgrTxoList []*LgrTxoMLP, ma_p *
 	w_cs := make([][]*PolyCNTTVec, ringLen)
 	w_cps := make([][]*PolyCNTTVec, ringLen)
 	delta_cs := make([][]*PolyCNTT, ringLen)
-	for j := uint8(0); j < ringLen; j++ {
+	err := pp.runRingWorkers(int(ringLen), func(jInt int) error {
+		j := uint8(jInt)
+		if err := pp.contextErr("elrSignatureMLPVerify"); err != nil {
+			return err
+		}
 		tmpDA, err := pp.expandChallengeA(sig.seeds[j])
 		if err != nil {
 			return err
@@ -578,75 +662,31 @@ func (pp *PublicParameter) elrSignatureMLPVerify(lgrTxoList []*LgrTxoMLP, ma_p *
 			b_j = txoInst.valueCommitment.b
 			c_j = txoInst.valueCommitment.c
 		case *TxoSDN:
-			return fmt.Errorf("elrSignatureMLPVerify: lgrTxoList[%d].txo is a TxoSDN", j)
+			return newTxError(ErrRingMemberInvalid, -1, -1, "elrSignatureMLPVerify: lgrTxoList[%d].txo is a TxoSDN", j)
 		default:
-			return fmt.Errorf("elrSignatureMLPVerify: lgrTxoList[%d].txo is not TxoRCTPre, TxoRCT, or TxoSDN", j)
+			return newTxError(ErrRingMemberInvalid, -1, -1, "elrSignatureMLPVerify: lgrTxoList[%d].txo is not TxoRCTPre, TxoRCT, or TxoSDN", j)
 		}
 
-		z_a_ntt := pp.NTTPolyAVec(sig.z_as[j])
-		// w_a_j = A*z_a_j - d_a_j*t_j
-		w_as[j] = pp.PolyANTTVecSub(
-			pp.PolyANTTMatrixMulVector(pp.paramMatrixA, z_a_ntt, pp.paramKA, pp.paramLA),
-			pp.PolyANTTVecScaleMul(da, t_j, pp.paramKA),
-			pp.paramKA,
-		)
-		// theta_a_j = <a,z_a_j> - d_a_j * (e_j + expandKIDR(txo[j]) - m_a_p)
-		lgrTxoH, err := pp.expandKIDRMLP(lgrTxoList[j])
+		// m_r_j = expandKIDR(txo[j])
+		lgrTxoH, err := pp.expandKIDRMLPWithCache(lgrTxoList[j], kidrCache)
 		if err != nil {
-			return err
+			return wrapTxError(err, ErrRingMemberInvalid, -1, -1)
 		}
-		delta_as[j] = pp.PolyANTTSub(
-			pp.PolyANTTVecInnerProduct(pp.paramVectorA, z_a_ntt, pp.paramLA),
-			pp.PolyANTTMul(
-				da,
-				pp.PolyANTTSub(
-					pp.PolyANTTAdd(
-						e_j,
-						lgrTxoH,
-					),
-					ma_p,
-				),
-			),
-		)
 
-		w_cs[j] = make([]*PolyCNTTVec, pp.paramK)
-		w_cps[j] = make([]*PolyCNTTVec, pp.paramK)
-		delta_cs[j] = make([]*PolyCNTT, pp.paramK)
+		z_a_ntt := pp.NTTPolyAVec(sig.z_as[j])
+		z_cs_ntt := make([]*PolyCNTTVec, pp.paramK)
+		z_cps_ntt := make([]*PolyCNTTVec, pp.paramK)
 		for tao := 0; tao < pp.paramK; tao++ {
-			z_c_ntt := pp.NTTPolyCVec(sig.z_cs[j][tao])
-			z_cp_ntt := pp.NTTPolyCVec(sig.z_cps[j][tao])
-			sigmataodc := pp.sigmaPowerPolyCNTT(dc, tao)
-
-			w_cs[j][tao] = pp.PolyCNTTVecSub(
-				pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_c_ntt, pp.paramKC, pp.paramLC),
-				pp.PolyCNTTVecScaleMul(
-					sigmataodc,
-					b_j,
-					pp.paramKC,
-				),
-				pp.paramKC,
-			)
-			w_cps[j][tao] = pp.PolyCNTTVecSub(
-				pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, z_cp_ntt, pp.paramKC, pp.paramLC),
-				pp.PolyCNTTVecScaleMul(
-					sigmataodc,
-					cmt_p.b,
-					pp.paramKC,
-				),
-				pp.paramKC,
-			)
-			delta_cs[j][tao] = pp.PolyCNTTSub(
-				pp.PolyCNTTVecInnerProduct(
-					pp.paramMatrixH[0],
-					pp.PolyCNTTVecSub(z_c_ntt, z_cp_ntt, pp.paramLC),
-					pp.paramLC,
-				),
-				pp.PolyCNTTMul(
-					sigmataodc,
-					pp.PolyCNTTSub(c_j, cmt_p.c),
-				),
-			)
+			z_cs_ntt[tao] = pp.NTTPolyCVec(sig.z_cs[j][tao])
+			z_cps_ntt[tao] = pp.NTTPolyCVec(sig.z_cps[j][tao])
 		}
+
+		w_as[j], delta_as[j], w_cs[j], w_cps[j], delta_cs[j] = pp.elrSignatureMLPMemberCommit(
+			ma_p, cmt_p, da, dc, t_j, e_j, lgrTxoH, b_j, c_j, z_a_ntt, z_cs_ntt, z_cps_ntt)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	preMsg, err := pp.collectBytesForElrSignatureMLPChallenge(lgrTxoList, ma_p, cmt_p, extTrTxCon, w_as, delta_as, w_cs, w_cps, delta_cs)
@@ -688,24 +728,39 @@ func (pp *PublicParameter) elrSignatureMLPSerializeSize(ringSize uint8) int {
 // reviewed on 2023.12.19
 // reviewed by Alice, 2024.07.02
 func (pp *PublicParameter) serializeElrSignatureMLP(sig *ElrSignatureMLP) ([]byte, error) {
+	if sig == nil {
+		return nil, fmt.Errorf("serializeElrSignatureMLP: the input sig *ElrSignatureMLP is nil")
+	}
+
+	length := pp.elrSignatureMLPSerializeSize(sig.ringSize)
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeElrSignatureMLP(w, sig)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeElrSignatureMLP writes the input ElrSignatureMLP to w, in the format of serializeElrSignatureMLP.
+func (pp *PublicParameter) writeElrSignatureMLP(w io.Writer, sig *ElrSignatureMLP) error {
 
 	if !pp.ElrSignatureMLPSanityCheck(sig) {
-		return nil, fmt.Errorf("serializeElrSignatureMLP: the input sig *ElrSignatureMLP is not well-form")
+		return fmt.Errorf("writeElrSignatureMLP: the input sig *ElrSignatureMLP is not well-form")
 	}
 
 	ringSize := sig.ringSize
 
-	length := pp.elrSignatureMLPSerializeSize(ringSize)
-	w := bytes.NewBuffer(make([]byte, 0, length))
-
 	//	ringSize
-	err := w.WriteByte(ringSize)
+	err := binarySerializer.PutUint8(w, ringSize)
+	if err != nil {
+		return err
+	}
 
 	// seeds [][]byte
 	for i := uint8(0); i < ringSize; i++ {
 		_, err = w.Write(sig.seeds[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -713,7 +768,7 @@ func (pp *PublicParameter) serializeElrSignatureMLP(sig *ElrSignatureMLP) ([]byt
 	for i := uint8(0); i < ringSize; i++ {
 		err = pp.writePolyAVecEta(w, sig.z_as[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -722,7 +777,7 @@ func (pp *PublicParameter) serializeElrSignatureMLP(sig *ElrSignatureMLP) ([]byt
 		for t := 0; t < pp.paramK; t++ {
 			err = pp.writePolyCVecEta(w, sig.z_cs[i][t])
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
@@ -732,12 +787,12 @@ func (pp *PublicParameter) serializeElrSignatureMLP(sig *ElrSignatureMLP) ([]byt
 		for t := 0; t < pp.paramK; t++ {
 			err = pp.writePolyCVecEta(w, sig.z_cps[i][t])
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeElrSignatureMLP deserialize the input []byte to an ElrSignatureMLP.
@@ -748,9 +803,12 @@ func (pp *PublicParameter) deserializeElrSignatureMLP(serializedSig []byte) (*El
 		return nil, fmt.Errorf("deserializeElrSignatureMLP: the input serializedSig is nil/empty")
 	}
 
-	r := bytes.NewReader(serializedSig)
+	return pp.readElrSignatureMLP(bytes.NewReader(serializedSig))
+}
 
-	ringSize, err := r.ReadByte()
+// readElrSignatureMLP reads an ElrSignatureMLP from r, which was written by writeElrSignatureMLP.
+func (pp *PublicParameter) readElrSignatureMLP(r io.Reader) (*ElrSignatureMLP, error) {
+	ringSize, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
@@ -763,7 +821,7 @@ func (pp *PublicParameter) deserializeElrSignatureMLP(serializedSig []byte) (*El
 	//	seeds [][]byte
 	for i := uint8(0); i < ringSize; i++ {
 		seeds[i] = make([]byte, HashOutputBytesLen)
-		_, err = r.Read(seeds[i])
+		_, err = io.ReadFull(r, seeds[i])
 		if err != nil {
 			return nil, err
 		}
@@ -835,6 +893,7 @@ func (pp *PublicParameter) simpleSignatureSign(t *PolyANTTVec, extTrTxCon []byte
 	ask := &AddressSecretKeyForSingle{
 		askSp,
 	}
+	defer ask.Destroy()
 
 	apk := &AddressPublicKeyForSingle{
 		t: t,
@@ -847,12 +906,16 @@ func (pp *PublicParameter) simpleSignatureSign(t *PolyANTTVec, extTrTxCon []byte
 	//	Sanity-checks 	end
 
 simpleSignatureSignRestart:
+	if err := pp.contextErr("simpleSignatureSign"); err != nil {
+		return nil, err
+	}
 	// randomness y
 	tmpY, err := pp.sampleMaskingVecA()
 	if err != nil {
 		return nil, err
 	}
 	y := pp.NTTPolyAVec(tmpY)
+	tmpY.wipe()
 
 	// w = A y
 	w := pp.PolyANTTMatrixMulVector(pp.paramMatrixA, y, pp.paramKA, pp.paramLA)
@@ -873,10 +936,16 @@ simpleSignatureSignRestart:
 	ch := pp.NTTPolyA(ch_poly)
 
 	//	z = y + d s
-	z_ntt := pp.PolyANTTVecAdd(y, pp.PolyANTTVecScaleMul(ch, s, pp.paramLA), pp.paramLA)
+	ch_s := pp.PolyANTTVecScaleMul(ch, s, pp.paramLA)
+	z_ntt := pp.PolyANTTVecAdd(y, ch_s, pp.paramLA)
 	z := pp.NTTInvPolyAVec(z_ntt)
+	//	the masking randomness y and the secret-related ch_s are wiped, and so is the rejected response z.
+	y.wipe()
+	ch_s.wipe()
+	z_ntt.wipe()
 
 	if z.infNorm() > pp.paramEtaA-int64(pp.paramBetaA) {
+		z.wipe()
 		goto simpleSignatureSignRestart
 	}
 
@@ -951,27 +1020,35 @@ func (pp *PublicParameter) simpleSignatureSerializeSize() int {
 // serializeSimpleSignature serializes the input SimpleSignatureMLP into []byte.
 // reviewed by Alice, 2024.07.02
 func (pp *PublicParameter) serializeSimpleSignature(sig *SimpleSignatureMLP) ([]byte, error) {
+	length := pp.simpleSignatureSerializeSize()
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeSimpleSignature(w, sig)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeSimpleSignature writes the input SimpleSignatureMLP to w, in the format of serializeSimpleSignature.
+func (pp *PublicParameter) writeSimpleSignature(w io.Writer, sig *SimpleSignatureMLP) error {
 
 	if !pp.SimpleSignatureSanityCheck(sig) {
-		return nil, fmt.Errorf("serializeSimpleSignature: the input sig *SimpleSignatureMLP is not well-form")
+		return fmt.Errorf("writeSimpleSignature: the input sig *SimpleSignatureMLP is not well-form")
 	}
 
-	length := pp.simpleSignatureSerializeSize()
-	w := bytes.NewBuffer(make([]byte, 0, length))
-
 	// seed_ch []byte
 	_, err := w.Write(sig.seed_ch)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// z       *PolyAVec
 	err = pp.writePolyAVecEta(w, sig.z)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeElrSignatureMLP deserialize the input []byte to an ElrSignatureMLP.
@@ -981,11 +1058,14 @@ func (pp *PublicParameter) deserializeSimpleSignature(serializedSig []byte) (*Si
 		return nil, fmt.Errorf("deserializeSimpleSignature: the input serializedSig is nil/empty")
 	}
 
-	r := bytes.NewReader(serializedSig)
+	return pp.readSimpleSignature(bytes.NewReader(serializedSig))
+}
 
+// readSimpleSignature reads a SimpleSignatureMLP from r, which was written by writeSimpleSignature.
+func (pp *PublicParameter) readSimpleSignature(r io.Reader) (*SimpleSignatureMLP, error) {
 	//	seed_ch []byte
 	seed_ch := make([]byte, HashOutputBytesLen)
-	_, err := r.Read(seed_ch)
+	_, err := io.ReadFull(r, seed_ch)
 	if err != nil {
 		return nil, err
 	}
@@ -223,8 +223,9 @@ func (pp *PublicParameter) CoinbaseTxMLPGen(vin uint64, txOutputDescMLPs []*TxOu
 // todo: review by 2024.07
 func (pp *PublicParameter) CoinbaseTxMLPVerify(cbTx *CoinbaseTxMLP) error {
 
-	if !pp.CoinbaseTxMLPSanityCheck(cbTx, true) {
-		return fmt.Errorf("CoinbaseTxMLPVerify: the input cbTx *CoinbaseTxMLP is not well-form")
+	err := pp.CoinbaseTxMLPSanityCheck(cbTx, true)
+	if err != nil {
+		return fmt.Errorf("CoinbaseTxMLPVerify: the input cbTx *CoinbaseTxMLP is not well-form: %w", err)
 	}
 
 	// As it has passed the above sanity-check, here only needs to collect the cmts_out.
@@ -261,10 +262,14 @@ func (pp *PublicParameter) CoinbaseTxMLPVerify(cbTx *CoinbaseTxMLP) error {
 		return err
 	}
 
+	if err = pp.contextErr("CoinbaseTxMLPVerify"); err != nil {
+		return err
+	}
+
 	//	verify the witness
 	err = pp.verifyBalanceProofCbTx(cbTxConDigest, cbTx.txWitness.vL, cbTx.txWitness.outForRing, cmts_out, cbTx.txWitness.txCase, cbTx.txWitness.balanceProof)
 	if err != nil {
-		return err
+		return wrapTxError(err, ErrBalanceProofFailed, -1, -1)
 	}
 
 	return nil
@@ -577,31 +582,53 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 
 	trTx := &TransferTxMLP{}
 	trTx.txInputs = make([]*TxInputMLP, inputNum)
-	trTx.txos = make([]TxoMLP, outputNum)
 	trTx.fee = fee
 	trTx.txMemo = txMemo
 	// trTx.txWitness
 
 	//	fill trTx.txos
-	cmts_out := make([]*ValueCommitment, outForRing)
-	cmtrs_out := make([]*PolyCNTTVec, outForRing)
-	values_out := make([]uint64, outForRing)
+	txos, cmts_out, cmtrs_out, values_out, err := pp.transferTxMLPTxosGen(txOutputDescs, outForRing)
+	if err != nil {
+		return nil, err
+	}
+	trTx.txos = txos
+
+	//	fill trTx.txInputs and trTx.txWitness
+	err = pp.transferTxMLPInputsAndWitnessGen(trTx, txInputDescs, inForRing, inForSingle, coinAddressForSingleDistinctList, coinAddressSpendSecretKeyMap,
+		cmtrs_in, outForRing, outForSingle, cmts_out, cmtrs_out, values_out, vPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	return trTx, nil
+
+}
+
+// transferTxMLPTxosGen generates the Txos for the input txOutputDescs, where the first outForRing ones are for RingCT-Privacy,
+// and returns the value-commitments and their openings for the RingCT-Privacy ones.
+// It is shared by TransferTxMLPGen and TransferTxMLPProposalGen, and the caller has checked the input txOutputDescs.
+func (pp *PublicParameter) transferTxMLPTxosGen(txOutputDescs []*TxOutputDescMLP, outForRing int) (txos []TxoMLP, cmts_out []*ValueCommitment, cmtrs_out []*PolyCNTTVec, values_out []uint64, err error) {
+	outputNum := len(txOutputDescs)
+	txos = make([]TxoMLP, outputNum)
+	cmts_out = make([]*ValueCommitment, outForRing)
+	cmtrs_out = make([]*PolyCNTTVec, outForRing)
+	values_out = make([]uint64, outForRing)
 
 	for j := 0; j < outputNum; j++ {
 		txOutputDescItem := txOutputDescs[j]
 
 		coinAddressType, err := pp.ExtractCoinAddressTypeFromCoinAddress(txOutputDescItem.coinAddress)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 
 		switch coinAddressType {
 		case CoinAddressTypePublicKeyForRingPre:
 			txoRCTPre, cmtr, err := pp.txoRCTPreGen(txOutputDescItem.coinAddress, txOutputDescItem.coinValuePublicKey, txOutputDescItem.value)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, nil, err
 			}
-			trTx.txos[j] = txoRCTPre
+			txos[j] = txoRCTPre
 			cmts_out[j] = txoRCTPre.valueCommitment
 			cmtrs_out[j] = cmtr
 			values_out[j] = txOutputDescItem.value
@@ -609,9 +636,9 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		case CoinAddressTypePublicKeyForRing:
 			txoRCT, cmtr, err := pp.txoRCTGen(txOutputDescItem.coinAddress, txOutputDescItem.coinValuePublicKey, txOutputDescItem.value)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, nil, err
 			}
-			trTx.txos[j] = txoRCT
+			txos[j] = txoRCT
 			cmts_out[j] = txoRCT.valueCommitment
 			cmtrs_out[j] = cmtr
 			values_out[j] = txOutputDescItem.value
@@ -619,18 +646,32 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		case CoinAddressTypePublicKeyHashForSingle:
 			txoSDN, err := pp.txoSDNGen(txOutputDescItem.coinAddress, txOutputDescItem.value)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, nil, err
 			}
-			trTx.txos[j] = txoSDN
+			txos[j] = txoSDN
 			//cmts_out[j] = txoRCT.valueCommitment
 			//cmtrs_out[j] = cmtr
 			//values_out[j] = txOutputDescItem.value
 
 		default:
-			return nil, fmt.Errorf("TransferTxMLPGen: the %d -th coinAddresses of the input txOutputDescMLPs (%d) is not supported", j, coinAddressType)
+			return nil, nil, nil, nil, fmt.Errorf("transferTxMLPTxosGen: the %d -th coinAddresses of the input txOutputDescMLPs (%d) is not supported", j, coinAddressType)
 		}
 	}
 
+	return txos, cmts_out, cmtrs_out, values_out, nil
+}
+
+// transferTxMLPInputsAndWitnessGen fills the txInputs and txWitness of the input trTx, whose txos, fee, and txMemo have been filled,
+// namely, it computes the serial numbers, and generates the signatures and the balance proof on the resulting transaction content.
+// It is shared by TransferTxMLPGen and TransferTxMLPProposalSign, and the caller has checked the inputs,
+// where cmtrs_in are the openings of the coins-to-spend in the first inForRing txInputDescs,
+// coinAddressForSingleDistinctList are the distinct coinAddresses of the remaining txInputDescs, and coinAddressSpendSecretKeyMap maps them to the coinSpendSecretKeys.
+func (pp *PublicParameter) transferTxMLPInputsAndWitnessGen(trTx *TransferTxMLP, txInputDescs []*TxInputDescMLP, inForRing int, inForSingle int,
+	coinAddressForSingleDistinctList [][]byte, coinAddressSpendSecretKeyMap map[string][]byte, cmtrs_in []*PolyCNTTVec,
+	outForRing int, outForSingle int, cmts_out []*ValueCommitment, cmtrs_out []*PolyCNTTVec, values_out []uint64, vPublic int64) error {
+	inputNum := len(txInputDescs)
+	inForSingleDistinct := len(coinAddressForSingleDistinctList)
+
 	//	fill trTx.txInputs
 	ma_ps := make([]*PolyANTT, inForRing)
 	cmts_in_p := make([]*ValueCommitment, inForRing)
@@ -646,18 +687,19 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		// m_a = m'_a + m_r
 		m_r, err := pp.expandKIDRMLP(txInputDescItem.lgrTxoList[txInputDescItem.sidx])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		askSn, err := pp.coinSerialNumberSecretKeyForPKRingParse(txInputDescItem.coinSerialNumberSecretKey)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		ma_ps[i] = pp.PolyANTTAdd(askSn.ma, m_r)
+		askSn.Destroy()
 
 		sn, err := pp.ledgerTxoSerialNumberComputeMLP(ma_ps[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		trTx.txInputs[i] = NewTxInputMLP(txInputDescItem.lgrTxoList, sn)
@@ -667,7 +709,7 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		// msgs_in
 		cmtr_p_poly, err := pp.sampleValueCmtRandomness()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		values_in[i] = txInputDescItem.value //	this has been checked during the sanity-check steps
@@ -691,7 +733,7 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		// m'_a = m_a + m_r = m_r, since m_a is empty.
 		m_r, err := pp.expandKIDRMLP(txInputDescItem.lgrTxoList[txInputDescItem.sidx])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		//askSn, err := pp.coinSerialNumberSecretKeyForPKRingParse(txInputDescItem.coinSerialNumberSecretKey)
@@ -699,7 +741,7 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 
 		sn, err := pp.ledgerTxoSerialNumberComputeMLP(m_r)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		trTx.txInputs[i] = NewTxInputMLP(txInputDescItem.lgrTxoList, sn)
@@ -708,18 +750,18 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 	// trTxCon
 	trTxCon, err := pp.SerializeTransferTxMLP(trTx, false)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	// extTrTxCon = trTxCon || cmt_p[0] || cmt_p[inForRing]
 	extTrTxConOriginal, err := pp.extendSerializedTransferTxContent(trTxCon, cmts_in_p)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// use extTrTxConDigest
 	extTrTxConDigest, err := Hash(extTrTxConOriginal)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	elrSignatureSign
@@ -729,7 +771,7 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		txInputDescItem := txInputDescs[i]
 		askSp, err := pp.coinSpendSecretKeyForPKRingParse(txInputDescItem.coinSpendSecretKey)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		askSp_ntt := pp.NTTPolyAVec(askSp.s)
 
@@ -739,8 +781,10 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		inRingSizes[i] = uint8(len(txInputDescItem.lgrTxoList)) // Note that the previous sanity-checks guarantee len(txInputDescItem.lgrTxoList) in the scope of uint8.
 		elrSigs[i], err = pp.elrSignatureMLPSign(txInputDescItem.lgrTxoList, ma_ps[i], cmts_in_p[i], extTrTxConDigest,
 			txInputDescItem.sidx, askSp_ntt, cmtrs_in[i], cmtrs_in_p[i])
+		askSp.Destroy()
+		askSp_ntt.wipe()
 		if err != nil {
-			return nil, fmt.Errorf("TransferTxMLPGen: fail to generate the extend linkable ring signature for the %d -th coin to spend", i)
+			return fmt.Errorf("transferTxMLPInputsAndWitnessGen: fail to generate the extend linkable ring signature for the %d -th coin to spend: %w", i, err)
 		}
 	}
 
@@ -753,26 +797,28 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		coinSpendSecretKey, exists := coinAddressSpendSecretKeyMap[coinAddressString]
 		if !exists {
 			// just assert
-			return nil, fmt.Errorf("TransferTxMLPGen: This should not happen, where a coinAddress with CoinAddressTypePublicKeyHashForSingle does not have corresponding coinSpendSecretKey")
+			return fmt.Errorf("transferTxMLPInputsAndWitnessGen: This should not happen, where a coinAddress with CoinAddressTypePublicKeyHashForSingle does not have corresponding coinSpendSecretKey")
 		}
 		apkForSingle, askSp, err := pp.coinSpendSecretKeyForPKHSingleParse(coinSpendSecretKey)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		addressPublicKeyForSingles[i] = apkForSingle
 
 		askSp_ntt := pp.NTTPolyAVec(askSp.s)
 		simpleSigs[i], err = pp.simpleSignatureSign(apkForSingle.t, extTrTxConDigest, askSp_ntt)
+		askSp.Destroy()
+		askSp_ntt.wipe()
 		if err != nil {
-			return nil, fmt.Errorf("TransferTxMLPGen: fail to generate the simple signature for the %d -th coinAddress with CoinAddressTypePublicKeyHashForSingle", i)
+			return fmt.Errorf("transferTxMLPInputsAndWitnessGen: fail to generate the simple signature for the %d -th coinAddress with CoinAddressTypePublicKeyHashForSingle: %w", i, err)
 		}
 	}
 
 	//	balance proof
 	txCase, balanceProof, err := pp.genBalanceProofTrTx(extTrTxConDigest, uint8(inForRing), uint8(outForRing), cmts_in_p, cmts_out, vPublic, cmtrs_in_p, values_in, cmtrs_out, values_out)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	trTx.txWitness = &TxWitnessTrTx{
@@ -792,8 +838,7 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 		balanceProof:               balanceProof,
 	}
 
-	return trTx, nil
-
+	return nil
 }
 
 // TransferTxMLPVerify verifies TransferTxMLP.
@@ -803,10 +848,17 @@ func (pp *PublicParameter) TransferTxMLPGen(txInputDescs []*TxInputDescMLP, txOu
 // todo: review by 2024.07
 // todo: multi-round review
 func (pp *PublicParameter) TransferTxMLPVerify(trTx *TransferTxMLP) error {
+	return pp.transferTxMLPVerify(trTx, nil)
+}
+
+// transferTxMLPVerify implements TransferTxMLPVerify.
+// The input kidrCache could be nil. If it is not nil, the m_r of the LgrTxoMLPs are obtained from/shared via the cache,
+// which is used by VerifyTransferTxMLPBatch to avoid re-expanding the ring members that appear in multiple transactions.
+func (pp *PublicParameter) transferTxMLPVerify(trTx *TransferTxMLP, kidrCache *lgrTxoKIDRCache) error {
 
 	err := pp.TransferTxMLPSanityCheck(trTx, true)
 	if err != nil {
-		return fmt.Errorf("TransferTxMLPVerify: the input trTx *TransferTxMLP is not well-form: %s", err)
+		return fmt.Errorf("TransferTxMLPVerify: the input trTx *TransferTxMLP is not well-form: %w", err)
 	}
 
 	//	collect cmts_out
@@ -845,6 +897,8 @@ func (pp *PublicParameter) TransferTxMLPVerify(trTx *TransferTxMLP) error {
 	//	which will be used later to check whether the spent Pseudonym-Privacy Txos have corresponding addressPublicKeys.
 	//	Also guarantee there is not addressPublicKey in txWitness.addressPublicKeyForSingles.
 	addressPublicKeyForSingleMap := make(map[string]int)
+	addressPublicKeyForSingleHashStrings := make([]string, trTx.txWitness.inForSingleDistinct) // This is used to locate the input for a failed simpleSignature.
+	addressPublicKeyForSingleInputIndexMap := make(map[string]int)
 	if trTx.txWitness.inForSingleDistinct > 0 {
 		for i := 0; i < int(trTx.txWitness.inForSingleDistinct); i++ {
 			serializedApk, err := pp.serializeAddressPublicKeyForSingle(trTx.txWitness.addressPublicKeyForSingles[i])
@@ -857,9 +911,10 @@ func (pp *PublicParameter) TransferTxMLPVerify(trTx *TransferTxMLP) error {
 			}
 			apkHashString := hex.EncodeToString(apkHash)
 			if _, exists := addressPublicKeyForSingleMap[apkHashString]; exists {
-				return fmt.Errorf("TransferTxMLPVerify: there are repated addressPublicKeyForSingles in trTx.txWitness.addressPublicKeyForSingles")
+				return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPVerify: there are repated addressPublicKeyForSingles in trTx.txWitness.addressPublicKeyForSingles")
 			} else {
 				addressPublicKeyForSingleMap[apkHashString] = 0 // the count = 0 will be used later to count the appearing times
+				addressPublicKeyForSingleHashStrings[i] = apkHashString
 			}
 		}
 
@@ -880,11 +935,14 @@ func (pp *PublicParameter) TransferTxMLPVerify(trTx *TransferTxMLP) error {
 	//		Note that the simpleSignature will be checked later using the distinct txWitness.addressPublicKeyForSingles.
 	spentCoinSerialNumberMap := make(map[string]int) // There should not be double spending in one transaction.
 	for i := 0; i < len(trTx.txInputs); i++ {
+		if err = pp.contextErr("TransferTxMLPVerify"); err != nil {
+			return err
+		}
 
 		//	serialNumber (double-spending) check inside the transaction
 		snString := hex.EncodeToString(trTx.txInputs[i].serialNumber)
 		if index, exists := spentCoinSerialNumberMap[snString]; exists {
-			return fmt.Errorf("TransferTxMLPVerify: double-spending detected, the %d-th txInput and the %d -th txInput", i, index)
+			return newTxError(ErrDoubleSpending, i, -1, "TransferTxMLPVerify: double-spending detected, the %d-th txInput and the %d -th txInput", i, index)
 		}
 		spentCoinSerialNumberMap[snString] = i
 
@@ -898,30 +956,30 @@ func (pp *PublicParameter) TransferTxMLPVerify(trTx *TransferTxMLP) error {
 				return err
 			}
 			if bytes.Compare(snFromKeyImg, trTx.txInputs[i].serialNumber) != 0 {
-				return fmt.Errorf("TransferTxMLPVerify: for the %d -th input, the computed serialNumber is different from trTx.txInputs[%d].serialNumber",
+				return newTxError(ErrSerialNumberMismatch, i, -1, "TransferTxMLPVerify: for the %d -th input, the computed serialNumber is different from trTx.txInputs[%d].serialNumber",
 					i, i)
 			}
 
 			//	elrSignature
-			err = pp.elrSignatureMLPVerify(trTx.txInputs[i].lgrTxoList, trTx.txWitness.ma_ps[i], trTx.txWitness.cmts_in_p[i], extTrTxConDigest, trTx.txWitness.elrSigs[i])
+			err = pp.elrSignatureMLPVerify(trTx.txInputs[i].lgrTxoList, trTx.txWitness.ma_ps[i], trTx.txWitness.cmts_in_p[i], extTrTxConDigest, trTx.txWitness.elrSigs[i], kidrCache)
 			if err != nil {
-				return err
+				return wrapTxError(err, ErrSignatureInvalid, i, -1)
 			}
 
 		} else {
 			//	i-th serial number
 			// Note that for CoinAddressTypePublicKeyHashForSingle,
 			// m'_a = m_a + m_r = m_r, since m_a is empty.
-			m_r, err := pp.expandKIDRMLP(trTx.txInputs[i].lgrTxoList[0])
+			m_r, err := pp.expandKIDRMLPWithCache(trTx.txInputs[i].lgrTxoList[0], kidrCache)
 			if err != nil {
-				return err
+				return wrapTxError(err, ErrRingMemberInvalid, i, -1)
 			}
 			snFromLgrTxo, err := pp.ledgerTxoSerialNumberComputeMLP(m_r)
 			if err != nil {
 				return err
 			}
 			if bytes.Compare(snFromLgrTxo, trTx.txInputs[i].serialNumber) != 0 {
-				return fmt.Errorf("TransferTxMLPVerify: for the %d -th input, the computed serialNumber is different from trTx.txInputs[%d].serialNumber",
+				return newTxError(ErrSerialNumberMismatch, i, -1, "TransferTxMLPVerify: for the %d -th input, the computed serialNumber is different from trTx.txInputs[%d].serialNumber",
 					i, i)
 			}
 
@@ -932,12 +990,15 @@ func (pp *PublicParameter) TransferTxMLPVerify(trTx *TransferTxMLP) error {
 				apkHashString := hex.EncodeToString(txoInst.addressPublicKeyForSingleHash)
 				if count, exists := addressPublicKeyForSingleMap[apkHashString]; exists {
 					addressPublicKeyForSingleMap[apkHashString] = count + 1
+					if count == 0 {
+						addressPublicKeyForSingleInputIndexMap[apkHashString] = i
+					}
 				} else {
-					return fmt.Errorf("TransferTxMLPVerify: the %d -th input is pseudonym-privacy, but there is not corresponding public key in trTx.txWitness.addressPublicKeyForSingles", i)
+					return newTxError(ErrSignatureInvalid, i, -1, "TransferTxMLPVerify: the %d -th input is pseudonym-privacy, but there is not corresponding public key in trTx.txWitness.addressPublicKeyForSingles", i)
 				}
 
 			default:
-				return fmt.Errorf("TransferTxMLPVerify: (should not happen) the %d -th input should be a TxoSDN, but it is not", i)
+				return newTxError(ErrRingMemberInvalid, i, -1, "TransferTxMLPVerify: (should not happen) the %d -th input should be a TxoSDN, but it is not", i)
 			}
 		}
 	}
@@ -945,20 +1006,23 @@ func (pp *PublicParameter) TransferTxMLPVerify(trTx *TransferTxMLP) error {
 	//	To guarantee that there are no dummy addressPublicKeyForSingles in trTx.txWitness.addressPublicKeyForSingles
 	for apkHashString, count := range addressPublicKeyForSingleMap {
 		if count == 0 {
-			return fmt.Errorf("TransferTxMLPVerify: the addressPublicKeyForSingle (with Hash = %s) in trTx.txWitness.addressPublicKeyForSingles does not have corresponding spent-coin", apkHashString)
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPVerify: the addressPublicKeyForSingle (with Hash = %s) in trTx.txWitness.addressPublicKeyForSingles does not have corresponding spent-coin", apkHashString)
 		}
 	}
 	//	verify the simpleSignatures
 	for i := 0; i < len(trTx.txWitness.addressPublicKeyForSingles); i++ {
 		err = pp.simpleSignatureVerify(trTx.txWitness.addressPublicKeyForSingles[i].t, extTrTxConDigest, trTx.txWitness.simpleSigs[i])
 		if err != nil {
-			return err
+			return wrapTxError(err, ErrSignatureInvalid, addressPublicKeyForSingleInputIndexMap[addressPublicKeyForSingleHashStrings[i]], -1)
 		}
 	}
 
+	if err = pp.contextErr("TransferTxMLPVerify"); err != nil {
+		return err
+	}
 	err = pp.verifyBalanceProofTrTx(extTrTxConDigest, trTx.txWitness.inForRing, trTx.txWitness.outForRing, trTx.txWitness.cmts_in_p, cmts_out, trTx.txWitness.vPublic, trTx.txWitness.txCase, trTx.txWitness.balanceProof)
 	if err != nil {
-		return err
+		return wrapTxError(err, ErrBalanceProofFailed, -1, -1)
 	}
 
 	return nil
@@ -1183,8 +1247,6 @@ func (pp *PublicParameter) verifyBalanceProofCbTx(cbTxCon []byte, vL uint64, out
 	default:
 		return fmt.Errorf("verifyBalanceProofCbTx: the input balanceProof is not BalanceProofL0R0, BalanceProofL0R1, or BalanceProofLmRn")
 	}
-
-	return nil
 }
 
 // extendSerializedTransferTxContent extend the serialized TransferTxMLP Content by appending the cmt_ps.
@@ -1734,41 +1796,43 @@ func (pp *PublicParameter) verifyBalanceProofTrTx(extTrTxCon []byte, inForRing u
 // (3) 0-value-coin-rule is obeyed;
 // (4) cbTx.txMemo has the size in the allowed scope;
 // (5) cbTx.txWitness is well-form.
+// It returns nil if cbTx is well-form, and otherwise a *TxError (wrapping ErrMalformedEncoding) describing the failure,
+// as TransferTxMLPSanityCheck.
 // added by Alice, 2024.07.06
 // todo: review by 2024.07
-func (pp *PublicParameter) CoinbaseTxMLPSanityCheck(cbTx *CoinbaseTxMLP, withWitness bool) bool {
+func (pp *PublicParameter) CoinbaseTxMLPSanityCheck(cbTx *CoinbaseTxMLP, withWitness bool) error {
 	if cbTx == nil {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: the input cbTx *CoinbaseTxMLP is nil")
 	}
 
 	V := (uint64(1) << pp.paramN) - 1
 
 	if cbTx.vin > V {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: the input cbTx.vin (%v) exceeds the allowed maximum value (%v)", cbTx.vin, V)
 	}
 
 	if cbTx.vin == 0 {
 		//	The special case for 0-value coin applies.
 		if len(cbTx.txos) != 1 {
-			return false
+			return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: the input cbTx.vin is 0, but cbTx.txos has size (%d) other than 1", len(cbTx.txos))
 		}
 
 		switch txoInst := cbTx.txos[0].(type) {
 		case *TxoSDN:
 			if !pp.TxoSDNSanityCheck(txoInst) {
-				return false
+				return newTxError(ErrMalformedEncoding, -1, 0, "CoinbaseTxMLPSanityCheck: the input cbTx.txos[0] is not well-form")
 			}
 
 			if txoInst.value != 0 {
-				return false
+				return newTxError(ErrMalformedEncoding, -1, 0, "CoinbaseTxMLPSanityCheck: the input cbTx.vin is 0, but cbTx.txos[0] has value (%v)", txoInst.value)
 			}
 		default:
-			return false
+			return newTxError(ErrMalformedEncoding, -1, 0, "CoinbaseTxMLPSanityCheck: the input cbTx.vin is 0, but cbTx.txos[0] is not TxoSDN")
 		}
 	}
 
 	if len(cbTx.txos) == 0 || len(cbTx.txos) > int(pp.paramJ)+int(pp.paramJSingle) {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: the input cbTx.txos has size (%d) not in [1, pp.paramJ + pp.paramJSingle]", len(cbTx.txos))
 	}
 
 	vOutPublic := uint64(0)
@@ -1776,7 +1840,7 @@ func (pp *PublicParameter) CoinbaseTxMLPSanityCheck(cbTx *CoinbaseTxMLP, withWit
 	outForSingle := 0
 	for i := 0; i < len(cbTx.txos); i++ {
 		if !pp.TxoMLPSanityCheck(cbTx.txos[i]) {
-			return false
+			return newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPSanityCheck: the input cbTx.txos[%d] is not well-form", i)
 		}
 		// Conduct the sanity-check firstly, to make the following codes run normally.
 
@@ -1786,7 +1850,7 @@ func (pp *PublicParameter) CoinbaseTxMLPSanityCheck(cbTx *CoinbaseTxMLP, withWit
 				outForRing += 1
 			} else {
 				//	The coinAddresses for RingCT-Privacy should be at the fist successive positions.
-				return false
+				return newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPSanityCheck: the input cbTx.txos[%d] is TxoRCTPre, but TxoSDN appeared previously", i)
 			}
 
 		case *TxoRCT:
@@ -1794,72 +1858,72 @@ func (pp *PublicParameter) CoinbaseTxMLPSanityCheck(cbTx *CoinbaseTxMLP, withWit
 				outForRing += 1
 			} else {
 				//	The coinAddresses for RingCT-Privacy should be at the fist successive positions.
-				return false
+				return newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPSanityCheck: the input cbTx.txos[%d] is TxoRCT, but TxoSDN appeared previously", i)
 			}
 
 		case *TxoSDN:
 			outForSingle += 1
 
 			if txoInst.value > V {
-				return false
+				return newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPSanityCheck: the input cbTx.txos[%d] is TxoSDN, but its value (%v) exceeds the allowed maximum value (%v)", i, txoInst.value, V)
 			}
 			if txoInst.value == 0 {
 				if cbTx.vin != 0 {
-					return false
+					return newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPSanityCheck: the input cbTx.txos[%d] is TxoSDN, but its value is 0", i)
 				}
 			}
 			vOutPublic = vOutPublic + txoInst.value
 			if vOutPublic > V {
-				return false
+				return newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPSanityCheck: the vOutPublic before and cbTx.txos[%d] exceeds the allowed maximum value (%v)", i, V)
 			}
 
 		default:
-			return false
+			return newTxError(ErrMalformedEncoding, -1, i, "CoinbaseTxMLPSanityCheck: the input cbTx.txos[%d] is not TxoRCTPre, TxoRCT, or TxoSDN", i)
 		}
 	}
 
 	if outForRing > int(pp.paramJ) {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: outForRing (%d) exceeds the allowed maximum value (%d)", outForRing, pp.paramJ)
 	}
 
 	if outForSingle > int(pp.paramJSingle) {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: outForSingle (%d) exceeds the allowed maximum value (%d)", outForSingle, pp.paramJSingle)
 	}
 
 	if outForRing+outForSingle != len(cbTx.txos) {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: (should not happen) outForRing (%d) + outForSingle (%d) != outputNum (%d)", outForRing, outForSingle, len(cbTx.txos))
 	}
 
 	if cbTx.vin < vOutPublic {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: the input cbTx.vin (%v) is smaller than vOutPublic (%v)", cbTx.vin, vOutPublic)
 	}
 
 	//	Now cbTx.vin >= voutPublic
 	vL := cbTx.vin - vOutPublic
 	if vL < uint64(outForRing) {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: vL (%v) is smaller than outForRing (%d)", vL, outForRing)
 	}
 
 	if int64(len(cbTx.txMemo)) > int64(MaxAllowedTxMemoMLPSize) {
-		return false
+		return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: the input cbTx.txMemo has a size (%v) exceeds the allowed maximum value", len(cbTx.txMemo))
 	}
 
 	if withWitness {
 		if !pp.TxWitnessCbTxSanityCheck(cbTx.txWitness) {
-			return false
+			return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: cbTx.txWitness is not well-form")
 		}
 
 		if cbTx.txWitness.vL != vL {
-			return false
+			return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: cbTx.txWitness.vL != vL")
 		}
 
 		if int(cbTx.txWitness.outForRing) != outForRing ||
 			int(cbTx.txWitness.outForSingle) != outForSingle {
-			return false
+			return newTxError(ErrMalformedEncoding, -1, -1, "CoinbaseTxMLPSanityCheck: (cbTx.txWitness.outForRing, cbTx.txWitness.outForSingle) != (outForRing, outForSingle)")
 		}
 	}
 
-	return true
+	return nil
 }
 
 // TransferTxMLPSanityCheck checks whether the input trTx *TransferTxMLP is well-from:
@@ -1873,37 +1937,37 @@ func (pp *PublicParameter) CoinbaseTxMLPSanityCheck(cbTx *CoinbaseTxMLP, withWit
 // reviewed by Ocean
 func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWitness bool) error {
 	if trTx == nil {
-		return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx *TransferTxMLP is nil")
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: the input trTx *TransferTxMLP is nil")
 	}
 
 	//	check the well-form of the inputs and outputs
 	inputNum := len(trTx.txInputs)
 	outputNum := len(trTx.txos)
 	if inputNum == 0 {
-		return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txInputs is nil/empty")
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: the input trTx.txInputs is nil/empty")
 	}
 
 	if outputNum == 0 {
-		return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos is nil/empty")
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: the input trTx.txos is nil/empty")
 	}
 
 	if inputNum > int(pp.paramI)+int(pp.paramISingle) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txInputs has size (%d) exceeding the allowed maximum value pp.paramI + pp.paramISingle", inputNum)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: the input trTx.txInputs has size (%d) exceeding the allowed maximum value pp.paramI + pp.paramISingle", inputNum)
 	}
 
 	if outputNum > int(pp.paramJ)+int(pp.paramJSingle) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos has size (%d) exceeding the allowed maximum value pp.paramJ + pp.paramJSingle", outputNum)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: the input trTx.txos has size (%d) exceeding the allowed maximum value pp.paramJ + pp.paramJSingle", outputNum)
 	}
 
 	V := (uint64(1) << pp.paramN) - 1
 
 	//	check the fee is simple, check it first
 	if trTx.fee > V {
-		return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.fee (%v) exceeds the allowed maximum value (%v)", trTx.fee, V)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: the input trTx.fee (%v) exceeds the allowed maximum value (%v)", trTx.fee, V)
 	}
 
 	if int64(len(trTx.txMemo)) > int64(MaxAllowedTxMemoMLPSize) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txMemo has a size (%v) exceeds the allowed maximum value", len(trTx.txMemo))
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: the input trTx.txMemo has a size (%v) exceeds the allowed maximum value", len(trTx.txMemo))
 	}
 
 	//	check on the txOutputDescs
@@ -1913,7 +1977,7 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 	for j := 0; j < outputNum; j++ {
 
 		if !pp.TxoMLPSanityCheck(trTx.txos[j]) {
-			return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos[%d] is not well-form", j)
+			return newTxError(ErrMalformedEncoding, -1, j, "TransferTxMLPSanityCheck: the input trTx.txos[%d] is not well-form", j)
 		}
 		//	Conduct the sanity-check firstly, to make the following codes run normally.
 
@@ -1923,7 +1987,7 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 				outForRing += 1
 			} else {
 				//	The coinAddresses for RingCT-Privacy should be at the fist successive positions.
-				return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoRCTPre, but TxoSDN appeared previously", j)
+				return newTxError(ErrMalformedEncoding, -1, j, "TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoRCTPre, but TxoSDN appeared previously", j)
 			}
 
 		case *TxoRCT:
@@ -1931,39 +1995,39 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 				outForRing += 1
 			} else {
 				//	The coinAddresses for RingCT-Privacy should be at the fist successive positions.
-				return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoRCT, but TxoSDN appeared previously", j)
+				return newTxError(ErrMalformedEncoding, -1, j, "TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoRCT, but TxoSDN appeared previously", j)
 			}
 
 		case *TxoSDN:
 			outForSingle += 1
 
 			if txoInst.value > V {
-				return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoSDN, but its value (%v) exceeds the allowed maximum value (%v)", j, txoInst.value, V)
+				return newTxError(ErrMalformedEncoding, -1, j, "TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoSDN, but its value (%v) exceeds the allowed maximum value (%v)", j, txoInst.value, V)
 			}
 			if txoInst.value == 0 {
 				// For TransferTx, the coin on pseudonym address could not use 0-value.
-				return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoSDN, but its value is 0", j)
+				return newTxError(ErrMalformedEncoding, -1, j, "TransferTxMLPSanityCheck: the input trTx.txos[%d] is TxoSDN, but its value is 0", j)
 			}
 
 			vOutPublic = vOutPublic + txoInst.value
 			if vOutPublic > V {
-				return fmt.Errorf("TransferTxMLPSanityCheck: the vOutPublic before and trTx.txos[%d] exceeds the allowed maximum value (%v)", j, V)
+				return newTxError(ErrMalformedEncoding, -1, j, "TransferTxMLPSanityCheck: the vOutPublic before and trTx.txos[%d] exceeds the allowed maximum value (%v)", j, V)
 			}
 
 		default:
-			return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txos[%d] is not TxoRCTPre, TxoRCT, or TxoSDN", j)
+			return newTxError(ErrMalformedEncoding, -1, j, "TransferTxMLPSanityCheck: the input trTx.txos[%d] is not TxoRCTPre, TxoRCT, or TxoSDN", j)
 		}
 	}
 
 	if outForRing > int(pp.paramJ) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: outForRing (%d) exceeds the allowed maximum value (%d)", outForRing, pp.paramJ)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: outForRing (%d) exceeds the allowed maximum value (%d)", outForRing, pp.paramJ)
 	}
 	if outForSingle > int(pp.paramJSingle) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: outForSingle (%d) exceeds the allowed maximum value (%d)", outForRing, pp.paramJSingle)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: outForSingle (%d) exceeds the allowed maximum value (%d)", outForRing, pp.paramJSingle)
 	}
 	if outForRing+outForSingle != outputNum {
 		// assert
-		return fmt.Errorf("TransferTxMLPSanityCheck: (shoud not happen) outForRing (%d) + outForSingle (%d) != outputNum (%d)", outForRing, outForSingle, outputNum)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: (shoud not happen) outForRing (%d) + outForSingle (%d) != outputNum (%d)", outForRing, outForSingle, outputNum)
 	}
 
 	// check the txInputDescs
@@ -1976,13 +2040,16 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 	addressPublicKeyForSingleHashMap := make(map[string]int) // This is used to help collect addressPublicKeyForSingleHashDistinctList, detecting the repeated ones.
 	for i := 0; i < inputNum; i++ {
 		if !pp.TxInputMLPSanityCheck(trTx.txInputs[i]) {
-			return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txInputs[%d] is not well-form", i)
+			if trTx.txInputs[i] != nil && len(trTx.txInputs[i].serialNumber) == pp.ledgerTxoSerialNumberSerializeSizeMLP() {
+				return newTxError(ErrRingMemberInvalid, i, -1, "TransferTxMLPSanityCheck: the input trTx.txInputs[%d].lgrTxoList is not well-form", i)
+			}
+			return newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPSanityCheck: the input trTx.txInputs[%d] is not well-form", i)
 		}
 
 		//	double-spending check by serialNumber
 		snString := hex.EncodeToString(trTx.txInputs[i].serialNumber)
 		if index, exists := spentCoinSerialNumberMap[snString]; exists {
-			return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txInputs[%d].serialNumber is the same as that of trTx.txInputs[%d]", i, index)
+			return newTxError(ErrDoubleSpending, i, -1, "TransferTxMLPSanityCheck: the input trTx.txInputs[%d].serialNumber is the same as that of trTx.txInputs[%d]", i, index)
 		}
 		spentCoinSerialNumberMap[snString] = i
 
@@ -1993,7 +2060,7 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 				inForRing += 1
 			} else {
 				//	The coinAddresses for RingCT-Privacy should be at the fist successive positions.
-				return fmt.Errorf("TransferTxMLPSanityCheck: the input trTx.txInputs[%d] is a ring, but pseudo-ring appeared before that", i)
+				return newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPSanityCheck: the input trTx.txInputs[%d] is a ring, but pseudo-ring appeared before that", i)
 			}
 
 		} else if coinAddressType == CoinAddressTypePublicKeyHashForSingle {
@@ -2002,11 +2069,11 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 			switch txoInst := trTx.txInputs[i].lgrTxoList[0].txo.(type) {
 			case *TxoSDN:
 				if txoInst.value > V {
-					return fmt.Errorf("TransferTxMLPSanityCheck: (should not happen) the input trTx.txInputs[%d] is a TxoSDN, and its value (%v) exceeds tha allowed maximum value (%v)", i, txoInst.value, V)
+					return newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPSanityCheck: (should not happen) the input trTx.txInputs[%d] is a TxoSDN, and its value (%v) exceeds tha allowed maximum value (%v)", i, txoInst.value, V)
 				}
 				vInPublic += txoInst.value
 				if vInPublic > V {
-					return fmt.Errorf("TransferTxMLPSanityCheck: the vInPublic (%v) before and trTx.txInputs[%d] exceeds tha allowed maximum value (%v)", vInPublic, i, V)
+					return newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPSanityCheck: the vInPublic (%v) before and trTx.txInputs[%d] exceeds tha allowed maximum value (%v)", vInPublic, i, V)
 				}
 
 				// collect the addressPublicKeyForSingleHashMap
@@ -2019,34 +2086,34 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 
 			default:
 				// should not happen
-				return fmt.Errorf("TransferTxMLPSanityCheck: (should not happen) the input trTx.txInputs[%d] has coinAddressType = CoinAddressTypePublicKeyHashForSingle, but it is not TxoSDN", i)
+				return newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPSanityCheck: (should not happen) the input trTx.txInputs[%d] has coinAddressType = CoinAddressTypePublicKeyHashForSingle, but it is not TxoSDN", i)
 			}
 
 		} else {
 			// should not happen
-			return fmt.Errorf("TransferTxMLPSanityCheck: (should not happen) the input trTx.txInputs[%d] is a not TxoRCTPre, TxoRCT, or TxoSDN", i)
+			return newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPSanityCheck: (should not happen) the input trTx.txInputs[%d] is a not TxoRCTPre, TxoRCT, or TxoSDN", i)
 		}
 	}
 
 	if inForRing > int(pp.paramI) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: inForRing (%d) exceeds the allowed maximum value (%d)", inForRing, pp.paramI)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: inForRing (%d) exceeds the allowed maximum value (%d)", inForRing, pp.paramI)
 	}
 
 	if inForSingle > int(pp.paramISingle) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: inForSingle (%d) exceeds the allowed maximum value (%d)", inForSingle, pp.paramISingle)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: inForSingle (%d) exceeds the allowed maximum value (%d)", inForSingle, pp.paramISingle)
 	}
 
 	if inForSingleDistinct > int(pp.paramISingleDistinct) {
-		return fmt.Errorf("TransferTxMLPSanityCheck: inForSingleDistinct (%d) exceeds the allowed maximum value (%d)", inForSingleDistinct, pp.paramISingleDistinct)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: inForSingleDistinct (%d) exceeds the allowed maximum value (%d)", inForSingleDistinct, pp.paramISingleDistinct)
 	}
 
 	if inForRing+inForSingle != inputNum {
 		// assert
-		return fmt.Errorf("TransferTxMLPSanityCheck: (should not happen) inForRing (%d) + inForSingle (%d) != inputNum (%d)", inForRing, inForSingle, inputNum)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: (should not happen) inForRing (%d) + inForSingle (%d) != inputNum (%d)", inForRing, inForSingle, inputNum)
 	}
 	if inForSingleDistinct > inForSingle {
 		// assert
-		return fmt.Errorf("TransferTxMLPSanityCheck: (should not happen) inForSingleDistinct (%d) > inForSingle (%d)", inForSingleDistinct, inForSingle)
+		return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: (should not happen) inForSingleDistinct (%d) > inForSingle (%d)", inForSingleDistinct, inForSingle)
 	}
 	//if len(addressPublicKeyForSingleHashDistinctList) != inForSingleDistinct {
 	//	// assert
@@ -2062,37 +2129,37 @@ func (pp *PublicParameter) TransferTxMLPSanityCheck(trTx *TransferTxMLP, withWit
 		//	(inForRing, outForRing, vPublic) will determine the balance proof type for the transaction.
 
 		if !pp.TxWitnessTrTxSanityCheck(trTx.txWitness) {
-			return fmt.Errorf("TransferTxMLPSanityCheck: trTx.txWitness is not well-form")
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: trTx.txWitness is not well-form")
 		}
 
 		if int(trTx.txWitness.inForRing) != inForRing {
-			return fmt.Errorf("TransferTxMLPSanityCheck: int(trTx.txWitness.inForRing) != inForRing")
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: int(trTx.txWitness.inForRing) != inForRing")
 		}
 
 		if int(trTx.txWitness.inForSingle) != inForSingle {
-			return fmt.Errorf("TransferTxMLPSanityCheck: int(trTx.txWitness.inForSingle) != inForSingle")
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: int(trTx.txWitness.inForSingle) != inForSingle")
 		}
 
 		if int(trTx.txWitness.inForSingleDistinct) != inForSingleDistinct {
-			return fmt.Errorf("TransferTxMLPSanityCheck: int(trTx.txWitness.inForSingleDistinct) != inForSingleDistinct")
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: int(trTx.txWitness.inForSingleDistinct) != inForSingleDistinct")
 		}
 
 		if int(trTx.txWitness.outForRing) != outForRing {
-			return fmt.Errorf("TransferTxMLPSanityCheck: int(trTx.txWitness.outForRing) != outForRing")
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: int(trTx.txWitness.outForRing) != outForRing")
 		}
 
 		if int(trTx.txWitness.outForSingle) != outForSingle {
-			return fmt.Errorf("TransferTxMLPSanityCheck: int(trTx.txWitness.outForSingle) != outForSingle")
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: int(trTx.txWitness.outForSingle) != outForSingle")
 		}
 
 		if trTx.txWitness.vPublic != vPublic {
-			return fmt.Errorf("TransferTxMLPSanityCheck: trTx.txWitness.vPublic != vPublic")
+			return newTxError(ErrMalformedEncoding, -1, -1, "TransferTxMLPSanityCheck: trTx.txWitness.vPublic != vPublic")
 		}
 
 		for i := 0; i < inForRing; i++ {
 			//	Note that previous sanity-check guarantees the following check makes sense.
 			if len(trTx.txInputs[i].lgrTxoList) != int(trTx.txWitness.inRingSizes[i]) {
-				return fmt.Errorf("TransferTxMLPSanityCheck: len(trTx.txInputs[%d].lgrTxoList) != int(trTx.txWitness.inRingSizes[%d])", i, i)
+				return newTxError(ErrMalformedEncoding, i, -1, "TransferTxMLPSanityCheck: len(trTx.txInputs[%d].lgrTxoList) != int(trTx.txWitness.inRingSizes[%d])", i, i)
 			}
 		}
 
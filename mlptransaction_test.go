@@ -1,14 +1,20 @@
 package pqringctx
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"golang.org/x/crypto/sha3"
+	"io"
 	"math/rand"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
 )
 
-var pp = Initialize(nil)
+var pp = initializeForTest()
 
 var coinAddressMapping map[CoinAddressType][][]byte
 var coinSpendSecretKeyMapping map[CoinAddressType][][]byte
@@ -2100,3 +2106,202 @@ func TestPublicParameter_TransferTxMLPGen_TransferTxMLPVerify_For_All_Witness_Ty
 		})
 	}
 }
+
+func TestPublicParameter_WithRandReader_Deterministic(t *testing.T) {
+	InitialAddress()
+
+	newRandReader := func(seed string) io.Reader {
+		xof := sha3.NewShake256()
+		xof.Write([]byte(seed))
+		return xof
+	}
+
+	//	CoinbaseTxMLP
+	txOutputDescMLPs, _ := GenerateOutput(100, 100, 1, 1, 1)
+	var serializedCbTxs [3][]byte
+	for i, seed := range []string{"seed-0", "seed-0", "seed-1"} {
+		cbTx, err := pp.WithRandReader(newRandReader(seed)).CoinbaseTxMLPGen(200, txOutputDescMLPs, []byte("memo"))
+		if err != nil {
+			t.Fatalf("CoinbaseTxMLPGen() error = %v", err)
+		}
+		if i == 0 {
+			if err = pp.CoinbaseTxMLPVerify(cbTx); err != nil {
+				t.Fatalf("CoinbaseTxMLPVerify() error = %v", err)
+			}
+		}
+		serializedCbTxs[i], err = pp.SerializeCoinbaseTxMLP(cbTx, true)
+		if err != nil {
+			t.Fatalf("SerializeCoinbaseTxMLP() error = %v", err)
+		}
+	}
+	if !bytes.Equal(serializedCbTxs[0], serializedCbTxs[1]) {
+		t.Errorf("CoinbaseTxMLPGen() with the same randomness generates different transactions")
+	}
+	if bytes.Equal(serializedCbTxs[0], serializedCbTxs[2]) {
+		t.Errorf("CoinbaseTxMLPGen() with different randomness generates the same transaction")
+	}
+
+	//	TransferTxMLP
+	txInputDescMLPs, totalInputValueForRing, totalInputValueForSingle, _ := GenerateInputWithTypeSize(0, 1, 1)
+	fee := uint64(1)
+	txOutputDescMLPs, _ = GenerateOutput(totalInputValueForRing+totalInputValueForSingle-fee-2, 2, 0, 1, 1)
+	var serializedTrTxs [2][]byte
+	for i, seed := range []string{"seed-0", "seed-0"} {
+		trTx, err := pp.WithRandReader(newRandReader(seed)).TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+		if err != nil {
+			t.Fatalf("TransferTxMLPGen() error = %v", err)
+		}
+		if i == 0 {
+			if err = pp.TransferTxMLPVerify(trTx); err != nil {
+				t.Fatalf("TransferTxMLPVerify() error = %v", err)
+			}
+		}
+		serializedTrTxs[i], err = pp.SerializeTransferTxMLP(trTx, true)
+		if err != nil {
+			t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+		}
+	}
+	if !bytes.Equal(serializedTrTxs[0], serializedTrTxs[1]) {
+		t.Errorf("TransferTxMLPGen() with the same randomness generates different transactions")
+	}
+
+	//	an exhausted randomness source results in an error, rather than a hang
+	_, err := pp.WithRandReader(bytes.NewReader(make([]byte, 10))).CoinbaseTxMLPGen(totalInputValueForRing+totalInputValueForSingle-fee, txOutputDescMLPs, nil)
+	if err == nil {
+		t.Errorf("CoinbaseTxMLPGen() with an exhausted randomness source should fail")
+	}
+}
+
+func TestPublicParameter_WithContext_Cancel(t *testing.T) {
+	InitialAddress()
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expiredCtx, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+
+	checkCtxErr := func(t *testing.T, funcName string, err error, target error) {
+		if !errors.Is(err, target) {
+			t.Fatalf("%s() error = %v, want %v", funcName, err, target)
+		}
+		if GetErrorCode(err) != 0 {
+			t.Fatalf("%s() error = %v, which should not carry an ErrorCode", funcName, err)
+		}
+	}
+
+	//	CoinbaseTxMLP
+	txOutputDescMLPs, _ := GenerateOutput(100, 100, 1, 1, 1)
+	cbTx, err := pp.WithContext(context.Background()).CoinbaseTxMLPGen(200, txOutputDescMLPs, []byte("memo"))
+	if err != nil {
+		t.Fatalf("CoinbaseTxMLPGen() error = %v", err)
+	}
+	if err = pp.WithContext(context.Background()).CoinbaseTxMLPVerify(cbTx); err != nil {
+		t.Fatalf("CoinbaseTxMLPVerify() error = %v", err)
+	}
+	_, err = pp.WithContext(cancelledCtx).CoinbaseTxMLPGen(200, txOutputDescMLPs, []byte("memo"))
+	checkCtxErr(t, "CoinbaseTxMLPGen", err, context.Canceled)
+	checkCtxErr(t, "CoinbaseTxMLPVerify", pp.WithContext(expiredCtx).CoinbaseTxMLPVerify(cbTx), context.DeadlineExceeded)
+
+	//	TransferTxMLP
+	txInputDescMLPs, totalInputValueForRing, totalInputValueForSingle, _ := GenerateInputWithTypeSize(0, 2, 1)
+	fee := uint64(1)
+	txOutputDescMLPs, _ = GenerateOutput(totalInputValueForRing+totalInputValueForSingle-fee-2, 2, 0, 1, 1)
+	trTx, err := pp.WithContext(context.Background()).TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+	if err = pp.WithContext(context.Background()).TransferTxMLPVerify(trTx); err != nil {
+		t.Fatalf("TransferTxMLPVerify() error = %v", err)
+	}
+	_, err = pp.WithContext(cancelledCtx).TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	checkCtxErr(t, "TransferTxMLPGen", err, context.Canceled)
+	checkCtxErr(t, "TransferTxMLPVerify", pp.WithContext(cancelledCtx).TransferTxMLPVerify(trTx), context.Canceled)
+	checkCtxErr(t, "VerifyTransferTxMLPBatch", pp.WithContext(cancelledCtx).VerifyTransferTxMLPBatch([]*TransferTxMLP{trTx})[0], context.Canceled)
+
+	//	pp itself is not affected
+	if err = pp.TransferTxMLPVerify(trTx); err != nil {
+		t.Fatalf("TransferTxMLPVerify() error = %v", err)
+	}
+}
+
+func TestPublicParameter_WithRingWorkers(t *testing.T) {
+	newRandReader := func(seed string) io.Reader {
+		xof := sha3.NewShake256()
+		xof.Write([]byte(seed))
+		return xof
+	}
+
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	var serializedTrTxs [3][]byte
+	for i, ringWorkerNum := range []int{0, 1, 4} {
+		trTx, err := pp.WithRingWorkers(ringWorkerNum).WithRandReader(newRandReader("seed-0")).TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+		if err != nil {
+			t.Fatalf("TransferTxMLPGen() with %d ring workers error = %v", ringWorkerNum, err)
+		}
+		for _, verifyRingWorkerNum := range []int{0, 4} {
+			if err = pp.WithRingWorkers(verifyRingWorkerNum).TransferTxMLPVerify(trTx); err != nil {
+				t.Fatalf("TransferTxMLPVerify() with %d ring workers error = %v", verifyRingWorkerNum, err)
+			}
+		}
+		serializedTrTxs[i], err = pp.SerializeTransferTxMLP(trTx, true)
+		if err != nil {
+			t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+		}
+	}
+	for i := 1; i < len(serializedTrTxs); i++ {
+		if !bytes.Equal(serializedTrTxs[0], serializedTrTxs[i]) {
+			t.Errorf("TransferTxMLPGen() generates different transactions with different numbers of ring workers")
+		}
+	}
+
+	//	the cancellation is checked by the ring workers as well
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+	err = pp.WithRingWorkers(4).WithContext(cancelledCtx).TransferTxMLPVerify(trTx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("TransferTxMLPVerify() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func BenchmarkPublicParameter_TransferTxMLPGen(b *testing.B) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, RandomBytes(10)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPublicParameter_TransferTxMLPVerify(b *testing.B) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(b)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, RandomBytes(10))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := pp.TransferTxMLPVerify(trTx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPublicParameter_TransferTxMLPVerify_RingWorkers(b *testing.B) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(b)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, RandomBytes(10))
+	if err != nil {
+		b.Fatal(err)
+	}
+	ppWorkers := pp.WithRingWorkers(runtime.GOMAXPROCS(0))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ppWorkers.TransferTxMLPVerify(trTx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"io"
 )
 
 // TxoMLP is used as a component object for CoinbaseTxMLP and TransferTxMLP.
@@ -86,7 +87,7 @@ func (pp *PublicParameter) txoRCTPreGen(coinAddress []byte, coinValuePublicKey [
 	//	got (C, kappa) from key encapsulate mechanism
 	// Restore the KEM version
 	// todo: review by 2024.06
-	CtKemSerialized, kappa, err := pqringctxkem.Encaps(pp.paramKem, coinValuePublicKey)
+	CtKemSerialized, kappa, err := pqringctxkem.EncapsWithRand(pp.paramKem, coinValuePublicKey, pp.randReader)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,7 +161,7 @@ func (pp *PublicParameter) txoRCTGen(coinAddress []byte, coinValuePublicKey []by
 	//	got (C, kappa) from key encapsulate mechanism
 	// Restore the KEM version
 	// todo: review by 2024.06
-	CtKemSerialized, kappa, err := pqringctxkem.Encaps(pp.paramKem, coinValuePublicKey)
+	CtKemSerialized, kappa, err := pqringctxkem.EncapsWithRand(pp.paramKem, coinValuePublicKey, pp.randReader)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -342,6 +343,7 @@ func (pp *PublicParameter) ExtractValueAndRandFromTxoMLP(txoMLP TxoMLP, coinValu
 	}
 
 	copiedCoinValueSecretKey := make([]byte, len(coinValueSecretKey))
+	defer wipeBytes(copiedCoinValueSecretKey)
 	copy(copiedCoinValueSecretKey, coinValueSecretKey)
 	validValueKey, hints := pp.CoinValueKeyVerify(coinValuePublicKey, copiedCoinValueSecretKey)
 	if !validValueKey {
@@ -354,6 +356,7 @@ func (pp *PublicParameter) ExtractValueAndRandFromTxoMLP(txoMLP TxoMLP, coinValu
 	if err != nil {
 		return 0, nil, err
 	}
+	defer wipeBytes(kappa)
 
 	//	decrypt vct to obtain the value
 	//	vpt = vct ^ sk
@@ -361,6 +364,7 @@ func (pp *PublicParameter) ExtractValueAndRandFromTxoMLP(txoMLP TxoMLP, coinValu
 	if err != nil {
 		return 0, nil, err
 	}
+	defer wipeBytes(sk)
 	if len(sk) != pp.TxoValueBytesLen() {
 		return 0, nil, fmt.Errorf("ExtractValueAndRandFromTxoMLP: the expanded sk for value pad has a wrong length (%d)", len(sk))
 	}
@@ -383,6 +387,7 @@ func (pp *PublicParameter) ExtractValueAndRandFromTxoMLP(txoMLP TxoMLP, coinValu
 		return 0, nil, err
 	}
 	cmtr = pp.NTTPolyCVec(cmtr_poly)
+	cmtr_poly.wipe()
 
 	mtmp := pp.intToBinary(value)
 	m := &PolyCNTT{coeffs: mtmp}
@@ -460,26 +465,44 @@ func (pp *PublicParameter) SerializeTxoMLP(txoMLP TxoMLP) (serializedTxo []byte,
 		return nil, fmt.Errorf("SerializeTxoMLP: the input TxoMLP is nil")
 	}
 
+	length, err := pp.TxoMLPSerializeSize(txoMLP)
+	if err != nil {
+		return nil, err
+	}
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeTxoMLP(w, txoMLP)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeTxoMLP writes the input TxoMLP to w, in the format of SerializeTxoMLP.
+func (pp *PublicParameter) writeTxoMLP(w io.Writer, txoMLP TxoMLP) error {
+	if txoMLP == nil {
+		return fmt.Errorf("writeTxoMLP: the input TxoMLP is nil")
+	}
+
 	switch txoInst := txoMLP.(type) {
 	case *TxoRCTPre:
 		if txoMLP.CoinAddressType() != CoinAddressTypePublicKeyForRingPre {
-			return nil, fmt.Errorf("SerializeTxoMLP: the input TxoMLP is TxoRCTPre, but the CoinAddressType %d does not match", txoMLP.CoinAddressType())
+			return fmt.Errorf("writeTxoMLP: the input TxoMLP is TxoRCTPre, but the CoinAddressType %d does not match", txoMLP.CoinAddressType())
 		}
-		return pp.serializeTxoRCTPre(txoInst)
+		return pp.writeTxoRCTPre(w, txoInst)
 
 	case *TxoRCT:
 		if txoMLP.CoinAddressType() != CoinAddressTypePublicKeyForRing {
-			return nil, fmt.Errorf("SerializeTxoMLP: the input TxoMLP is TxoRCT, but the CoinAddressType %d does not match", txoMLP.CoinAddressType())
+			return fmt.Errorf("writeTxoMLP: the input TxoMLP is TxoRCT, but the CoinAddressType %d does not match", txoMLP.CoinAddressType())
 		}
-		return pp.serializeTxoRCT(txoInst)
+		return pp.writeTxoRCT(w, txoInst)
 
 	case *TxoSDN:
 		if txoMLP.CoinAddressType() != CoinAddressTypePublicKeyHashForSingle {
-			return nil, fmt.Errorf("SerializeTxoMLP: the input TxoMLP is TxoSDN, but the CoinAddressType %d does not match", txoMLP.CoinAddressType())
+			return fmt.Errorf("writeTxoMLP: the input TxoMLP is TxoSDN, but the CoinAddressType %d does not match", txoMLP.CoinAddressType())
 		}
-		return pp.serializeTxoSDN(txoInst)
+		return pp.writeTxoSDN(w, txoInst)
 	default:
-		return nil, fmt.Errorf("SerializeTxoMLP: the input TxoMLP is not TxoRCTPre, TxoRCT, TxoSDN")
+		return fmt.Errorf("writeTxoMLP: the input TxoMLP is not TxoRCTPre, TxoRCT, TxoSDN")
 	}
 }
 
@@ -491,16 +514,67 @@ func (pp *PublicParameter) DeserializeTxoMLP(serializedTxo []byte) (txoMLP TxoML
 		return nil, fmt.Errorf("DeserializeTxoMLP: the input serializedTxo is empty")
 	}
 
-	n := len(serializedTxo)
-	if n == pp.TxoRCTPreSerializeSize() {
-		return pp.deserializeTxoRCTPre(serializedTxo)
-	} else if n == pp.TxoRCTSerializeSize() {
-		return pp.deserializeTxoRCT(serializedTxo)
-	} else if n == pp.TxoSDNSerializeSize() {
-		return pp.deserializeTxoSDN(serializedTxo)
+	return pp.readTxoMLP(bytes.NewReader(serializedTxo), len(serializedTxo))
+}
+
+// readTxoMLP reads a TxoMLP of size bytes from r, which was written by writeTxoMLP.
+// As the serialization of TxoRCTPre does not contain the coinAddressType, the TxoMLP is told by the input size, as in DeserializeTxoMLP.
+func (pp *PublicParameter) readTxoMLP(r io.Reader, size int) (txoMLP TxoMLP, err error) {
+	if size == pp.TxoRCTPreSerializeSize() {
+		return pp.readTxoRCTPre(r)
+	} else if size == pp.TxoRCTSerializeSize() {
+		return pp.readTxoRCT(r)
+	} else if size == pp.TxoSDNSerializeSize() {
+		return pp.readTxoSDN(r)
 	} else {
-		return nil, fmt.Errorf("DeserializeTxoMLP: the input serializedTxo has a length that is not supported")
+		return nil, fmt.Errorf("readTxoMLP: the input serializedTxo has a length that is not supported")
+	}
+}
+
+// WriteTxoMLP writes the input TxoMLP to w, as var-bytes, i.e., the serialized size followed by the serialized TxoMLP,
+// which is the same as the format of the Txos in SerializeCoinbaseTxMLP and SerializeTransferTxMLP.
+// Note that the serialized TxoMLP itself cannot be used here, since the serialized TxoRCTPre does not contain the coinAddressType,
+// and DeserializeTxoMLP tells the TxoMLP by the length of the input.
+// The writes to w are buffered, and are flushed before WriteTxoMLP returns.
+func (pp *PublicParameter) WriteTxoMLP(w io.Writer, txoMLP TxoMLP) error {
+	return writeBuffered(w, func(w io.Writer) error {
+		return pp.writeTxoMLPFrame(w, txoMLP)
+	})
+}
+
+// ReadTxoMLP reads a TxoMLP from r, which was written by WriteTxoMLP.
+// ReadTxoMLP does not read beyond the TxoMLP, so that it issues many small reads, and r shall be buffered, e.g., by bufio.Reader.
+func (pp *PublicParameter) ReadTxoMLP(r io.Reader) (TxoMLP, error) {
+	txoMLP, err := pp.readTxoMLPFrame(r, "TxoMLP")
+	if err != nil {
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
 	}
+	return txoMLP, nil
+}
+
+// writeTxoMLPFrame writes the input TxoMLP to w, as var-bytes.
+func (pp *PublicParameter) writeTxoMLPFrame(w io.Writer, txoMLP TxoMLP) error {
+	txoLen, err := pp.TxoMLPSerializeSize(txoMLP)
+	if err != nil {
+		return err
+	}
+	return writeVarBytesFrame(w, txoLen, func(w io.Writer) error {
+		return pp.writeTxoMLP(w, txoMLP)
+	})
+}
+
+// readTxoMLPFrame reads a TxoMLP from r, which was written by writeTxoMLPFrame.
+func (pp *PublicParameter) readTxoMLPFrame(r io.Reader, fieldName string) (TxoMLP, error) {
+	var txoMLP TxoMLP
+	err := readVarBytesFrame(r, MaxAllowedTxoMLPSize, fieldName, func(r io.Reader, serializedTxoLen int) error {
+		var err error
+		txoMLP, err = pp.readTxoMLP(r, serializedTxoLen)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txoMLP, nil
 }
 
 // TxoRCTPreSerializeSize returns the serialized size for TxoRCTPre.
@@ -523,48 +597,49 @@ func (pp *PublicParameter) TxoRCTPreSerializeSize() int {
 // reviewed on 2023.12.14
 // reviewed by Alice, 2024.06.25
 func (pp *PublicParameter) serializeTxoRCTPre(txoRCTPre *TxoRCTPre) ([]byte, error) {
+	length := pp.TxoRCTPreSerializeSize()
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeTxoRCTPre(w, txoRCTPre)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeTxoRCTPre writes the input TxoRCTPre to w, in the format of serializeTxoRCTPre.
+func (pp *PublicParameter) writeTxoRCTPre(w io.Writer, txoRCTPre *TxoRCTPre) error {
 
 	if !pp.TxoRCTPreSanityCheck(txoRCTPre) {
-		return nil, fmt.Errorf("serializeTxoRCTPre: the input TxoRCTPre is not well-form")
+		return fmt.Errorf("writeTxoRCTPre: the input TxoRCTPre is not well-form")
 	}
 
 	var err error
-	length := pp.TxoRCTPreSerializeSize()
-	w := bytes.NewBuffer(make([]byte, 0, length))
 
 	//	serializedAddressPublicKey is fixed-length
-	serializedAddressPublicKeyForRing, err := pp.serializeAddressPublicKeyForRing(txoRCTPre.addressPublicKeyForRing)
+	err = pp.writeAddressPublicKeyForRing(w, txoRCTPre.addressPublicKeyForRing)
 	if err != nil {
-		return nil, err
-	}
-	_, err = w.Write(serializedAddressPublicKeyForRing)
-	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	serializedValueCmt is fixed-length
-	serializedValueCmt, err := pp.SerializeValueCommitment(txoRCTPre.valueCommitment)
+	err = pp.writeValueCommitment(w, txoRCTPre.valueCommitment)
 	if err != nil {
-		return nil, err
-	}
-	_, err = w.Write(serializedValueCmt)
-	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txo.Vct is fixed-length
 	_, err = w.Write(txoRCTPre.vct)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txo.CtKemSerialized depends on the KEM, the length is not in the scope of pqringctx.
 	err = writeVarBytes(w, txoRCTPre.ctKemSerialized)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeTxoRCTPre deserialize the input []byte to a TxoRCTPre.
@@ -573,33 +648,27 @@ func (pp *PublicParameter) serializeTxoRCTPre(txoRCTPre *TxoRCTPre) ([]byte, err
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.06.25
 func (pp *PublicParameter) deserializeTxoRCTPre(serializedTxoRCTPre []byte) (*TxoRCTPre, error) {
+	return pp.readTxoRCTPre(bytes.NewReader(serializedTxoRCTPre))
+}
+
+// readTxoRCTPre reads a TxoRCTPre from r, which was written by writeTxoRCTPre.
+func (pp *PublicParameter) readTxoRCTPre(r io.Reader) (*TxoRCTPre, error) {
 	var err error
-	r := bytes.NewReader(serializedTxoRCTPre)
 
 	var apk *AddressPublicKeyForRing
-	tmp := make([]byte, pp.addressPublicKeyForRingSerializeSize())
-	_, err = r.Read(tmp)
-	if err != nil {
-		return nil, err
-	}
-	apk, err = pp.deserializeAddressPublicKeyForRing(tmp)
+	apk, err = pp.readAddressPublicKeyForRing(r)
 	if err != nil {
 		return nil, err
 	}
 
 	var cmt *ValueCommitment
-	tmp = make([]byte, pp.ValueCommitmentSerializeSize())
-	_, err = r.Read(tmp)
-	if err != nil {
-		return nil, err
-	}
-	cmt, err = pp.DeserializeValueCommitment(tmp)
+	cmt, err = pp.readValueCommitment(r)
 	if err != nil {
 		return nil, err
 	}
 
 	vct := make([]byte, pp.TxoValueBytesLen())
-	_, err = r.Read(vct)
+	_, err = io.ReadFull(r, vct)
 	if err != nil {
 		return nil, err
 	}
@@ -637,66 +706,67 @@ func (pp *PublicParameter) TxoRCTSerializeSize() int {
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.06.25
 func (pp *PublicParameter) serializeTxoRCT(txoRCT *TxoRCT) ([]byte, error) {
+	length := pp.TxoRCTSerializeSize()
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeTxoRCT(w, txoRCT)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeTxoRCT writes the input TxoRCT to w, in the format of serializeTxoRCT.
+func (pp *PublicParameter) writeTxoRCT(w io.Writer, txoRCT *TxoRCT) error {
 
 	if !pp.TxoRCTSanityCheck(txoRCT) {
-		return nil, fmt.Errorf("serializeTxoRCT: the input txoRCT is not well-form")
+		return fmt.Errorf("writeTxoRCT: the input txoRCT is not well-form")
 	}
 
 	var err error
-	length := pp.TxoRCTSerializeSize()
-	w := bytes.NewBuffer(make([]byte, 0, length))
 
 	// coinAddressType is fixed-length, say 1 byte
-	err = w.WriteByte(byte(txoRCT.coinAddressType))
+	err = binarySerializer.PutUint8(w, uint8(txoRCT.coinAddressType))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	serializedAddressPublicKey is fixed-length
-	serializedAddressPublicKeyForRing, err := pp.serializeAddressPublicKeyForRing(txoRCT.addressPublicKeyForRing)
+	err = pp.writeAddressPublicKeyForRing(w, txoRCT.addressPublicKeyForRing)
 	if err != nil {
-		return nil, err
-	}
-	_, err = w.Write(serializedAddressPublicKeyForRing)
-	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	publicRand is fixed length
 	_, err = w.Write(txoRCT.publicRand)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	detectorTag is fixed length
 	_, err = w.Write(txoRCT.detectorTag)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	serializedValueCmt is fixed-length
-	serializedValueCmt, err := pp.SerializeValueCommitment(txoRCT.valueCommitment)
+	err = pp.writeValueCommitment(w, txoRCT.valueCommitment)
 	if err != nil {
-		return nil, err
-	}
-	_, err = w.Write(serializedValueCmt)
-	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txo.Vct is fixed-length
 	_, err = w.Write(txoRCT.vct)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txo.CtKemSerialized depends on the KEM, the length is not in the scope of pqringctx.
 	err = writeVarBytes(w, txoRCT.ctKemSerialized)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeTxoRCT deserialize the input []byte to a TxoRCT.
@@ -704,54 +774,48 @@ func (pp *PublicParameter) serializeTxoRCT(txoRCT *TxoRCT) ([]byte, error) {
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.06.25
 func (pp *PublicParameter) deserializeTxoRCT(serializedTxoRCT []byte) (*TxoRCT, error) {
+	return pp.readTxoRCT(bytes.NewReader(serializedTxoRCT))
+}
+
+// readTxoRCT reads a TxoRCT from r, which was written by writeTxoRCT.
+func (pp *PublicParameter) readTxoRCT(r io.Reader) (*TxoRCT, error) {
 	var err error
-	r := bytes.NewReader(serializedTxoRCT)
 
 	var coinAddressType byte
-	coinAddressType, err = r.ReadByte()
+	coinAddressType, err = binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 	if CoinAddressType(coinAddressType) != CoinAddressTypePublicKeyForRing {
-		return nil, fmt.Errorf("deserializeTxoRCT: the deserialized coinAddressType is not CoinAddressTypePublicKeyForRing")
+		return nil, fmt.Errorf("readTxoRCT: the deserialized coinAddressType is not CoinAddressTypePublicKeyForRing")
 	}
 
 	var apk *AddressPublicKeyForRing
-	tmp := make([]byte, pp.addressPublicKeyForRingSerializeSize())
-	_, err = r.Read(tmp)
-	if err != nil {
-		return nil, err
-	}
-	apk, err = pp.deserializeAddressPublicKeyForRing(tmp)
+	apk, err = pp.readAddressPublicKeyForRing(r)
 	if err != nil {
 		return nil, err
 	}
 
 	publicRand := make([]byte, pp.GetParamKeyGenPublicRandBytesLen())
-	_, err = r.Read(publicRand)
+	_, err = io.ReadFull(r, publicRand)
 	if err != nil {
 		return nil, err
 	}
 
 	detectorTag := make([]byte, pp.GetParamMACOutputBytesLen())
-	_, err = r.Read(detectorTag)
+	_, err = io.ReadFull(r, detectorTag)
 	if err != nil {
 		return nil, err
 	}
 
 	var cmt *ValueCommitment
-	tmp = make([]byte, pp.ValueCommitmentSerializeSize())
-	_, err = r.Read(tmp)
-	if err != nil {
-		return nil, err
-	}
-	cmt, err = pp.DeserializeValueCommitment(tmp)
+	cmt, err = pp.readValueCommitment(r)
 	if err != nil {
 		return nil, err
 	}
 
 	vct := make([]byte, pp.TxoValueBytesLen())
-	_, err = r.Read(vct)
+	_, err = io.ReadFull(r, vct)
 	if err != nil {
 		return nil, err
 	}
@@ -789,49 +853,58 @@ func (pp *PublicParameter) TxoSDNSerializeSize() int {
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.06.25
 func (pp *PublicParameter) serializeTxoSDN(txoSDN *TxoSDN) ([]byte, error) {
+	length := pp.TxoSDNSerializeSize()
+	w := bytes.NewBuffer(make([]byte, 0, length))
+	err := pp.writeTxoSDN(w, txoSDN)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeTxoSDN writes the input TxoSDN to w, in the format of serializeTxoSDN.
+func (pp *PublicParameter) writeTxoSDN(w io.Writer, txoSDN *TxoSDN) error {
 	if txoSDN == nil || len(txoSDN.addressPublicKeyForSingleHash) == 0 {
-		return nil, fmt.Errorf("serializeTxoSDN: there is nil pointer in the input txoSDN")
+		return fmt.Errorf("writeTxoSDN: there is nil pointer in the input txoSDN")
 	}
 
 	if !pp.TxoSDNSanityCheck(txoSDN) {
-		return nil, fmt.Errorf("serializeTxoSDN: the input txoSDN is not well-form")
+		return fmt.Errorf("writeTxoSDN: the input txoSDN is not well-form")
 	}
 
 	var err error
-	length := pp.TxoSDNSerializeSize()
-	w := bytes.NewBuffer(make([]byte, 0, length))
 
 	// txoSDN.coinAddressType is fixed-length, say 1 byte
-	err = w.WriteByte(byte(txoSDN.coinAddressType))
+	err = binarySerializer.PutUint8(w, uint8(txoSDN.coinAddressType))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txoSDN.addressPublicKeyForSingleHash is fixed-length
 	_, err = w.Write(txoSDN.addressPublicKeyForSingleHash)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txoSDN.publicRand is fixed-length
 	_, err = w.Write(txoSDN.publicRand)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txoSDN.detectorTag is fixed-length
 	_, err = w.Write(txoSDN.detectorTag)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	txoSDN.value is fixed-length
 	err = binarySerializer.PutUint64(w, binary.LittleEndian, txoSDN.value)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // deserializeTxoSDN deserialize the input []byte to a TxoSDN.
@@ -839,32 +912,36 @@ func (pp *PublicParameter) serializeTxoSDN(txoSDN *TxoSDN) ([]byte, error) {
 // reviewed on 2023.12.07
 // reviewed by Alice, 2024.06.25
 func (pp *PublicParameter) deserializeTxoSDN(serializedTxoSDN []byte) (*TxoSDN, error) {
+	return pp.readTxoSDN(bytes.NewReader(serializedTxoSDN))
+}
+
+// readTxoSDN reads a TxoSDN from r, which was written by writeTxoSDN.
+func (pp *PublicParameter) readTxoSDN(r io.Reader) (*TxoSDN, error) {
 	var err error
-	r := bytes.NewReader(serializedTxoSDN)
 
 	var coinAddressType byte
-	coinAddressType, err = r.ReadByte()
+	coinAddressType, err = binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 	if CoinAddressType(coinAddressType) != CoinAddressTypePublicKeyHashForSingle {
-		return nil, fmt.Errorf("deserializeTxoSDN: the deserialized coinAddressType is not CoinAddressTypePublicKeyHashForSingle")
+		return nil, fmt.Errorf("readTxoSDN: the deserialized coinAddressType is not CoinAddressTypePublicKeyHashForSingle")
 	}
 
 	apkHash := make([]byte, HashOutputBytesLen)
-	_, err = r.Read(apkHash)
+	_, err = io.ReadFull(r, apkHash)
 	if err != nil {
 		return nil, err
 	}
 
 	publicRand := make([]byte, pp.GetParamKeyGenPublicRandBytesLen())
-	_, err = r.Read(publicRand)
+	_, err = io.ReadFull(r, publicRand)
 	if err != nil {
 		return nil, err
 	}
 
 	detectorTag := make([]byte, pp.GetParamMACOutputBytesLen())
-	_, err = r.Read(detectorTag)
+	_, err = io.ReadFull(r, detectorTag)
 	if err != nil {
 		return nil, err
 	}
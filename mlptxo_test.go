@@ -6,7 +6,7 @@ import (
 )
 
 func TestPublicParameter_ExtractValueAndRandFromTxoMLP(t *testing.T) {
-	ppLocal := Initialize(nil)
+	ppLocal := initializeForTest()
 
 	t.Run("Pre", func(t *testing.T) {
 		value := uint64(512)
@@ -0,0 +1,951 @@
+package pqringctx
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"golang.org/x/crypto/sha3"
+	"io"
+)
+
+//	TransferTxMLPProposal	begin
+
+// TransferTxMLPProposal is an unsigned TransferTxMLP, for the two-phase (offline/air-gapped) signing.
+// An online watch-only wallet, which holds the coinDetectorKey and the coinValue key pair but not the spend keys,
+// generates it by TransferTxMLPProposalGen, namely, the rings, the Txos, the fee, the memo,
+// and the openings, i.e., the sidx, the coinDetectorKey and the value of each input,
+// and the value openings (the values and the commitment randomness) of the RingCT-Privacy inputs and outputs.
+// An offline signer, which holds the coinSpendSecretKeys and coinSerialNumberSecretKeys,
+// opens it by TransferTxMLPProposalOpen and
+// completes the serial numbers, the signatures, and the balance proof by TransferTxMLPProposalSign.
+//
+// The openings reveal the values and which ring member is spent, so that they are encrypted to the signer's KEM key,
+// namely, a key pair generated by the signer by CoinValueKeyGen, and only (rings, txos, fee, txMemo) are in plaintext.
+// The trust assumption is as below:
+// (1) The encryption provides only confidentiality: anyone who knows the signer's public key could generate a proposal,
+// so that the signer does not trust the origin of the proposal.
+// Instead, the signer checks that the value openings open the value-commitments and that the values balance,
+// and is responsible for reviewing the payees (by GetTxos and GetTxoValues) and the fee before signing.
+// (2) The signer trusts the online wallet on the choice of the ring members, which affects only the privacy.
+// (3) After TransferTxMLPProposalGen or TransferTxMLPProposalOpen, the proposal in memory holds the openings in plaintext,
+// so that it should be kept as confidential as the coinValueSecretKey.
+type TransferTxMLPProposal struct {
+	txInputs []*txInputProposalMLP
+	txos     []TxoMLP
+	fee      uint64
+	txMemo   []byte
+	//	the openings of the value-commitments of the RingCT-Privacy txos, which are at the first successive positions of txos
+	cmtrsOut  []*PolyCNTTVec
+	valuesOut []uint64
+	//	the openings encrypted to the signer's KEM key (see transferTxMLPProposalSeal)
+	ctKemSerialized   []byte
+	encryptedOpenings []byte
+	//	opened is true if the openings are available in plaintext, i.e., after TransferTxMLPProposalGen or TransferTxMLPProposalOpen
+	opened bool
+}
+
+// txInputProposalMLP is an input of TransferTxMLPProposal, where (sidx, coinDetectorKey, value, cmtrIn) are the openings,
+// and cmtrIn is the commitment randomness of the coin-to-spend, which is nil for the coin with Pseudonym-Privacy.
+type txInputProposalMLP struct {
+	lgrTxoList      []*LgrTxoMLP
+	sidx            uint8
+	coinDetectorKey []byte
+	value           uint64
+	cmtrIn          *PolyCNTTVec
+}
+
+// transferTxMLPProposalTagLen is the length of the tag of the encrypted openings in TransferTxMLPProposal.
+const transferTxMLPProposalTagLen = 32
+
+// transferTxMLPProposalInfo collects the information of a checked TransferTxMLPProposal.
+type transferTxMLPProposalInfo struct {
+	inForRing                        int
+	inForSingle                      int
+	coinAddressForSingleDistinctList [][]byte
+	outForRing                       int
+	outForSingle                     int
+	cmtsOut                          []*ValueCommitment
+	vPublic                          int64
+}
+
+// IsOpened reports whether the openings of the TransferTxMLPProposal are available in plaintext,
+// i.e., it is generated by TransferTxMLPProposalGen, or opened by TransferTxMLPProposalOpen.
+func (proposal *TransferTxMLPProposal) IsOpened() bool {
+	return proposal.opened
+}
+
+// GetTxInputLgrTxoToSpendList returns the coins-to-spend of the TransferTxMLPProposal, for the signer to review.
+// It returns nil if the proposal is not opened.
+func (proposal *TransferTxMLPProposal) GetTxInputLgrTxoToSpendList() []*LgrTxoMLP {
+	if !proposal.opened {
+		return nil
+	}
+	lgrTxos := make([]*LgrTxoMLP, len(proposal.txInputs))
+	for i, txInput := range proposal.txInputs {
+		lgrTxos[i] = txInput.lgrTxoList[txInput.sidx]
+	}
+	return lgrTxos
+}
+
+// GetTxInputValues returns the values of the coins-to-spend of the TransferTxMLPProposal.
+// It returns nil if the proposal is not opened.
+func (proposal *TransferTxMLPProposal) GetTxInputValues() []uint64 {
+	if !proposal.opened {
+		return nil
+	}
+	values := make([]uint64, len(proposal.txInputs))
+	for i, txInput := range proposal.txInputs {
+		values[i] = txInput.value
+	}
+	return values
+}
+
+// GetTxos returns the txos of the TransferTxMLPProposal.
+func (proposal *TransferTxMLPProposal) GetTxos() []TxoMLP {
+	return proposal.txos
+}
+
+// GetTxoValues returns the values of the txos of the TransferTxMLPProposal, for the signer to review.
+// It returns nil if the proposal is not opened.
+func (proposal *TransferTxMLPProposal) GetTxoValues() []uint64 {
+	if !proposal.opened {
+		return nil
+	}
+	values := make([]uint64, len(proposal.txos))
+	for j, txo := range proposal.txos {
+		if j < len(proposal.valuesOut) {
+			values[j] = proposal.valuesOut[j]
+		} else if txoSDN, ok := txo.(*TxoSDN); ok {
+			values[j] = txoSDN.value
+		}
+	}
+	return values
+}
+
+// GetFee returns the fee of the TransferTxMLPProposal.
+func (proposal *TransferTxMLPProposal) GetFee() uint64 {
+	return proposal.fee
+}
+
+// GetTxMemo returns the txMemo of the TransferTxMLPProposal.
+func (proposal *TransferTxMLPProposal) GetTxMemo() []byte {
+	return proposal.txMemo
+}
+
+// TransferTxMLPProposalGen generates a TransferTxMLPProposal, with the same inputs as TransferTxMLPGen,
+// except that the coinSpendSecretKey and coinSerialNumberSecretKey in txInputDescs are not used (and could be nil).
+// For the coins-to-spend with RingCT-Privacy, the (coinValuePublicKey, coinValueSecretKey) are used to extract the value openings.
+// The openings are encrypted to signerValuePublicKey, which the signer generates by CoinValueKeyGen.
+func (pp *PublicParameter) TransferTxMLPProposalGen(txInputDescs []*TxInputDescMLP, txOutputDescs []*TxOutputDescMLP, fee uint64, txMemo []byte, signerValuePublicKey []byte) (*TransferTxMLPProposal, error) {
+	if len(signerValuePublicKey) != pqringctxkem.GetKemPublicKeyBytesLen(pp.paramKem) {
+		return nil, fmt.Errorf("TransferTxMLPProposalGen: the input signerValuePublicKey is not well-form")
+	}
+
+	inputNum := len(txInputDescs)
+	outputNum := len(txOutputDescs)
+	if inputNum == 0 || outputNum == 0 {
+		return nil, fmt.Errorf("TransferTxMLPProposalGen: neither txInputDescs or txOutputDescs could be empty")
+	}
+	if inputNum > int(pp.paramI)+int(pp.paramISingle) {
+		return nil, fmt.Errorf("TransferTxMLPProposalGen: the number of txInputDescs (%d) exceeds the allowed maximum value (%d)", inputNum, int(pp.paramI)+int(pp.paramISingle))
+	}
+	if outputNum > int(pp.paramJ)+int(pp.paramJSingle) {
+		return nil, fmt.Errorf("TransferTxMLPProposalGen: the number of txOutputDescs (%d) exceeds the allowed maximum value (%d)", outputNum, int(pp.paramJ)+int(pp.paramJSingle))
+	}
+
+	proposal := &TransferTxMLPProposal{
+		txInputs: make([]*txInputProposalMLP, inputNum),
+		fee:      fee,
+		txMemo:   txMemo,
+		opened:   true,
+	}
+
+	//	the value openings of the inputs
+	for i, txInputDescItem := range txInputDescs {
+		if txInputDescItem == nil {
+			return nil, fmt.Errorf("TransferTxMLPProposalGen: txInputDescs[%d] is nil", i)
+		}
+		lgrTxoToSpend := txInputDescItem.GetLgrTxoToSpend()
+		if !pp.LgrTxoMLPSanityCheck(lgrTxoToSpend) {
+			return nil, fmt.Errorf("TransferTxMLPProposalGen: the coin-to-spend of txInputDescs[%d] is not well-form", i)
+		}
+
+		proposal.txInputs[i] = &txInputProposalMLP{
+			lgrTxoList:      txInputDescItem.lgrTxoList,
+			sidx:            txInputDescItem.sidx,
+			coinDetectorKey: txInputDescItem.coinDetectorKey,
+			value:           txInputDescItem.value,
+		}
+
+		coinAddressType := lgrTxoToSpend.txo.CoinAddressType()
+		if coinAddressType == CoinAddressTypePublicKeyForRingPre || coinAddressType == CoinAddressTypePublicKeyForRing {
+			copiedCoinValueSecretKey := make([]byte, len(txInputDescItem.coinValueSecretKey))
+			copy(copiedCoinValueSecretKey, txInputDescItem.coinValueSecretKey)
+			value, cmtr, err := pp.ExtractValueAndRandFromTxoMLP(lgrTxoToSpend.txo, txInputDescItem.coinValuePublicKey, copiedCoinValueSecretKey)
+			if err != nil {
+				return nil, fmt.Errorf("TransferTxMLPProposalGen: fail to extract the value opening of txInputDescs[%d]: %v", i, err)
+			}
+			if value != txInputDescItem.value {
+				return nil, fmt.Errorf("TransferTxMLPProposalGen: txInputDescs[%d].value (%d) is different from the extracted value from the commitment", i, txInputDescItem.value)
+			}
+			proposal.txInputs[i].cmtrIn = cmtr
+		}
+	}
+
+	//	the txos and their value openings
+	outForRing := 0
+	for j, txOutputDescItem := range txOutputDescs {
+		if txOutputDescItem == nil {
+			return nil, fmt.Errorf("TransferTxMLPProposalGen: txOutputDescs[%d] is nil", j)
+		}
+		coinAddressType, err := pp.ExtractCoinAddressTypeFromCoinAddress(txOutputDescItem.coinAddress)
+		if err != nil {
+			return nil, err
+		}
+		if coinAddressType == CoinAddressTypePublicKeyForRingPre || coinAddressType == CoinAddressTypePublicKeyForRing {
+			if j != outForRing {
+				return nil, fmt.Errorf("TransferTxMLPProposalGen: on the output side, the coinAddresses for RingCT-Privacy should be at the fist successive positions, but the %d -th one is not", j)
+			}
+			if len(txOutputDescItem.coinValuePublicKey) == 0 {
+				return nil, fmt.Errorf("TransferTxMLPProposalGen: txOutputDescs[%d].coinAddress is for RingCT-Privacy, but txOutputDescs[%d].coinValuePublicKey is nil/empty", j, j)
+			}
+			outForRing++
+		}
+	}
+	txos, _, cmtrsOut, valuesOut, err := pp.transferTxMLPTxosGen(txOutputDescs, outForRing)
+	if err != nil {
+		return nil, err
+	}
+	proposal.txos = txos
+	proposal.cmtrsOut = cmtrsOut
+	proposal.valuesOut = valuesOut
+
+	//	the same checks as the signer will conduct
+	if _, err = pp.transferTxMLPProposalCheck(proposal); err != nil {
+		return nil, err
+	}
+
+	if err = pp.transferTxMLPProposalSeal(proposal, signerValuePublicKey); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+// valueCommitmentOfTxoMLP returns the value-commitment of the input TxoRCTPre or TxoRCT.
+func valueCommitmentOfTxoMLP(txo TxoMLP) (*ValueCommitment, error) {
+	switch txoInst := txo.(type) {
+	case *TxoRCTPre:
+		return txoInst.valueCommitment, nil
+	case *TxoRCT:
+		return txoInst.valueCommitment, nil
+	default:
+		return nil, fmt.Errorf("valueCommitmentOfTxoMLP: the input txo is not TxoRCTPre or TxoRCT")
+	}
+}
+
+// transferTxMLPProposalCheck checks the well-form and the balance of the input TransferTxMLPProposal,
+// in particular, it checks that the value openings open the value-commitments in the Txos,
+// so that the signer does not need to trust the online wallet on the values.
+// The checks follow those of TransferTxMLPGen, except the ones on the keys.
+func (pp *PublicParameter) transferTxMLPProposalCheck(proposal *TransferTxMLPProposal) (*transferTxMLPProposalInfo, error) {
+	if proposal == nil {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the input proposal is nil")
+	}
+	if !proposal.opened {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the input proposal is not opened")
+	}
+
+	inputNum := len(proposal.txInputs)
+	outputNum := len(proposal.txos)
+	if inputNum == 0 || outputNum == 0 {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: neither the txInputs or the txos could be empty")
+	}
+
+	V := (uint64(1) << pp.paramN) - 1
+	if proposal.fee > V {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the transaction fee (%d) is not in the scope[0, V (%d)]", proposal.fee, V)
+	}
+	if int64(len(proposal.txMemo)) > int64(MaxAllowedTxMemoMLPSize) {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the txMemo has a size (%d) not in the allowed scope", len(proposal.txMemo))
+	}
+
+	info := &transferTxMLPProposalInfo{}
+
+	//	the txos
+	vOutTotal := proposal.fee
+	vOutPublic := proposal.fee
+	for j, txo := range proposal.txos {
+		if !pp.TxoMLPSanityCheck(txo) {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the %d -th txo is not well-form", j)
+		}
+
+		var value uint64
+		coinAddressType := txo.CoinAddressType()
+		if coinAddressType == CoinAddressTypePublicKeyForRingPre || coinAddressType == CoinAddressTypePublicKeyForRing {
+			if j != info.outForRing {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: on the output side, the txos for RingCT-Privacy should be at the fist successive positions, but the %d -th one is not", j)
+			}
+			if j >= len(proposal.cmtrsOut) || j >= len(proposal.valuesOut) {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the %d -th txo does not have the value opening", j)
+			}
+			value = proposal.valuesOut[j]
+			if value > V {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the value (%d) of the %d -th txo is not in the scope [0, V(%d)]", value, j, V)
+			}
+			cmt, err := valueCommitmentOfTxoMLP(txo)
+			if err != nil {
+				return nil, err
+			}
+			if !pp.ValueCommitmentOpen(cmt, &PolyCNTT{coeffs: pp.intToBinary(value)}, proposal.cmtrsOut[j], 0) {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the value opening of the %d -th txo does not open its value-commitment", j)
+			}
+			info.outForRing++
+			info.cmtsOut = append(info.cmtsOut, cmt)
+
+		} else if coinAddressType == CoinAddressTypePublicKeyHashForSingle {
+			txoSDN, ok := txo.(*TxoSDN)
+			if !ok {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the %d -th txo has CoinAddressTypePublicKeyHashForSingle, but it is not a TxoSDN", j)
+			}
+			value = txoSDN.value
+			//	apply the 0-value-coin-rule.
+			if value == 0 || value > V {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the value (%d) of the %d -th txo is not in the scope [1, V(%d)]", value, j, V)
+			}
+			info.outForSingle++
+			vOutPublic += value
+
+		} else {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the coinAddressType (%d) of the %d -th txo is not supported", coinAddressType, j)
+		}
+
+		vOutTotal += value
+		if vOutTotal > V {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the vOutTotal of the first %d txos, say %d, exceeds V(%d)", j+1, vOutTotal, V)
+		}
+	}
+	if len(proposal.cmtrsOut) != info.outForRing || len(proposal.valuesOut) != info.outForRing {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the number of value openings of the txos is different from outForRing (%d)", info.outForRing)
+	}
+	if info.outForRing > int(pp.paramJ) {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: outForRing (%d) exceeds the allowed maximum value (%d)", info.outForRing, pp.paramJ)
+	}
+	if info.outForSingle > int(pp.paramJSingle) {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: outForSingle (%d) exceeds the the allowed maximum value (%d)", info.outForSingle, pp.paramJSingle)
+	}
+
+	//	the txInputs
+	vInTotal := uint64(0)
+	vInPublic := uint64(0)
+	lgrTxoIdsToSpendMap := make(map[string]int)
+	coinAddressForSingleDistinctMap := make(map[string]struct{})
+	for i, txInput := range proposal.txInputs {
+		if txInput == nil {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the %d -th txInput is nil", i)
+		}
+		if txInput.value > V {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the value (%d) of the %d -th txInput is not in the scope [0, V(%d)]", txInput.value, i, V)
+		}
+		vInTotal += txInput.value
+		if vInTotal > V {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the vInTotal of the first %d txInputs, say %d, exceeds V (%d)", i+1, vInTotal, V)
+		}
+
+		if !pp.LgrTxoRingForSingleSanityCheck(txInput.lgrTxoList) && !pp.LgrTxoRingForRingSanityCheck(txInput.lgrTxoList) {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the lgrTxoList of the %d -th txInput is not well-form", i)
+		}
+		if int(txInput.sidx) >= len(txInput.lgrTxoList) {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the sidx (%d) of the %d -th txInput is out of the range of its lgrTxoList", txInput.sidx, i)
+		}
+		lgrTxoToSpend := txInput.lgrTxoList[txInput.sidx]
+
+		idStringToSpend := hex.EncodeToString(lgrTxoToSpend.id)
+		if index, exists := lgrTxoIdsToSpendMap[idStringToSpend]; exists {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the %d -th txInput spends the same coin as the %d -th one", i, index)
+		}
+		lgrTxoIdsToSpendMap[idStringToSpend] = i
+
+		coinAddressType := lgrTxoToSpend.txo.CoinAddressType()
+		if coinAddressType == CoinAddressTypePublicKeyForRingPre || coinAddressType == CoinAddressTypePublicKeyForRing {
+			if i != info.inForRing {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: on the input side, the coins-to-spend with RingCT-Privacy should be at the first successive positions, but the %d -th one is not", i)
+			}
+			cmt, err := valueCommitmentOfTxoMLP(lgrTxoToSpend.txo)
+			if err != nil {
+				return nil, err
+			}
+			if !pp.ValueCommitmentOpen(cmt, &PolyCNTT{coeffs: pp.intToBinary(txInput.value)}, txInput.cmtrIn, 0) {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the value opening of the %d -th txInput does not open the value-commitment of its coin-to-spend", i)
+			}
+			info.inForRing++
+
+		} else if coinAddressType == CoinAddressTypePublicKeyHashForSingle {
+			txoSDN, ok := lgrTxoToSpend.txo.(*TxoSDN)
+			if !ok {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the coin-to-spend of the %d -th txInput has CoinAddressTypePublicKeyHashForSingle, but it is not a TxoSDN", i)
+			}
+			if txoSDN.value != txInput.value {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the coin-to-spend of the %d -th txInput has value=%d, but the txInput has value %d", i, txoSDN.value, txInput.value)
+			}
+			if txInput.cmtrIn != nil {
+				return nil, fmt.Errorf("transferTxMLPProposalCheck: the %d -th txInput has Pseudonym-Privacy, but it has a value opening", i)
+			}
+			info.inForSingle++
+			vInPublic += txInput.value
+
+			coinAddress, err := pp.GetCoinAddressFromTxoMLP(lgrTxoToSpend.txo)
+			if err != nil {
+				return nil, err
+			}
+			coinAddressString := hex.EncodeToString(coinAddress)
+			if _, exists := coinAddressForSingleDistinctMap[coinAddressString]; !exists {
+				coinAddressForSingleDistinctMap[coinAddressString] = struct{}{}
+				info.coinAddressForSingleDistinctList = append(info.coinAddressForSingleDistinctList, coinAddress)
+			}
+
+		} else {
+			return nil, fmt.Errorf("transferTxMLPProposalCheck: the coinAddressType (%d) of the coin-to-spend of the %d -th txInput is not supported", coinAddressType, i)
+		}
+	}
+	if info.inForRing > int(pp.paramI) {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the number of RingCT-privacy coins to be spent (%d) exceeds the allowed maximum value (%d)", info.inForRing, pp.paramI)
+	}
+	if info.inForSingle > int(pp.paramISingle) {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the number of Pseudonym-privacy coins to be spent (%d) exceeds the allowed maximum value (%d)", info.inForSingle, pp.paramISingle)
+	}
+	if len(info.coinAddressForSingleDistinctList) > int(pp.paramISingleDistinct) {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the number of distinct coin-addresses for Pseudonym-privacy coins to be spent (%d) exceeds the allowed maximum value (%d)", len(info.coinAddressForSingleDistinctList), pp.paramISingleDistinct)
+	}
+
+	if vOutTotal != vInTotal {
+		return nil, fmt.Errorf("transferTxMLPProposalCheck: the total value on the output side (%d) is different that on the input side (%d)", vOutTotal, vInTotal)
+	}
+	info.vPublic = int64(vOutPublic) - int64(vInPublic)
+
+	return info, nil
+}
+
+// TransferTxMLPProposalSign completes the input TransferTxMLPProposal to a TransferTxMLP, using the spend keys of the coins-to-spend,
+// where coinSpendSecretKeys[i] and coinSerialNumberSecretKeys[i] are for the i-th txInput of the proposal,
+// and coinSerialNumberSecretKeys[i] is not used (and could be nil) for the coin with Pseudonym-Privacy.
+// The proposal should be opened, i.e., generated by TransferTxMLPProposalGen or opened by TransferTxMLPProposalOpen.
+// It checks the proposal (in particular, the value openings) and the keys before signing,
+// while the caller is responsible for reviewing the payees (by GetTxos and GetTxoValues) and the fee.
+func (pp *PublicParameter) TransferTxMLPProposalSign(proposal *TransferTxMLPProposal, coinSpendSecretKeys [][]byte, coinSerialNumberSecretKeys [][]byte) (*TransferTxMLP, error) {
+	info, err := pp.transferTxMLPProposalCheck(proposal)
+	if err != nil {
+		return nil, fmt.Errorf("TransferTxMLPProposalSign: the input proposal is not well-form: %v", err)
+	}
+
+	inputNum := len(proposal.txInputs)
+	if len(coinSpendSecretKeys) != inputNum || len(coinSerialNumberSecretKeys) != inputNum {
+		return nil, fmt.Errorf("TransferTxMLPProposalSign: the numbers of coinSpendSecretKeys (%d) and coinSerialNumberSecretKeys (%d) are not the same as the number of txInputs (%d)", len(coinSpendSecretKeys), len(coinSerialNumberSecretKeys), inputNum)
+	}
+
+	txInputDescs := make([]*TxInputDescMLP, inputNum)
+	cmtrsIn := make([]*PolyCNTTVec, 0, info.inForRing)
+	coinAddressSpendSecretKeyMap := make(map[string][]byte)
+	for i, txInput := range proposal.txInputs {
+		lgrTxoToSpend := txInput.lgrTxoList[txInput.sidx]
+		coinAddress, err := pp.GetCoinAddressFromTxoMLP(lgrTxoToSpend.txo)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(coinSpendSecretKeys[i]) == 0 {
+			return nil, fmt.Errorf("TransferTxMLPProposalSign: coinSpendSecretKeys[%d] is nil", i)
+		}
+		var validKey bool
+		if i < info.inForRing {
+			if len(coinSerialNumberSecretKeys[i]) == 0 {
+				return nil, fmt.Errorf("TransferTxMLPProposalSign: the %d -th txInput has RingCT-Privacy, but coinSerialNumberSecretKeys[%d] is nil", i, i)
+			}
+			validKey, err = pp.CoinAddressKeyForPKRingVerify(coinAddress, coinSpendSecretKeys[i], coinSerialNumberSecretKeys[i], txInput.coinDetectorKey)
+			cmtrsIn = append(cmtrsIn, txInput.cmtrIn)
+		} else {
+			validKey, err = pp.CoinAddressKeyForPKHSingleVerify(coinAddress, coinSpendSecretKeys[i], txInput.coinDetectorKey)
+			coinAddressSpendSecretKeyMap[hex.EncodeToString(coinAddress)] = coinSpendSecretKeys[i]
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !validKey {
+			return nil, fmt.Errorf("TransferTxMLPProposalSign: the coin-to-spend of the %d -th txInput and the corresponding keys do not match", i)
+		}
+
+		txInputDescs[i] = &TxInputDescMLP{
+			lgrTxoList:                txInput.lgrTxoList,
+			sidx:                      txInput.sidx,
+			coinSpendSecretKey:        coinSpendSecretKeys[i],
+			coinSerialNumberSecretKey: coinSerialNumberSecretKeys[i],
+			coinDetectorKey:           txInput.coinDetectorKey,
+			value:                     txInput.value,
+		}
+	}
+
+	trTx := &TransferTxMLP{
+		txInputs: make([]*TxInputMLP, inputNum),
+		txos:     proposal.txos,
+		fee:      proposal.fee,
+		txMemo:   proposal.txMemo,
+	}
+	err = pp.transferTxMLPInputsAndWitnessGen(trTx, txInputDescs, info.inForRing, info.inForSingle, info.coinAddressForSingleDistinctList, coinAddressSpendSecretKeyMap,
+		cmtrsIn, info.outForRing, info.outForSingle, info.cmtsOut, proposal.cmtrsOut, proposal.valuesOut, info.vPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	return trTx, nil
+}
+
+// writeValueOpening writes the value opening (value, cmtr), where cmtr is fixed-length, i.e., paramLC PolyCNTTs.
+func (pp *PublicParameter) writeValueOpening(w io.Writer, value uint64, cmtr *PolyCNTTVec) error {
+	if !pp.ValueCommitmentRandomnessNTTSanityCheck(cmtr) {
+		return fmt.Errorf("writeValueOpening: the input cmtr is not well-form")
+	}
+	err := binarySerializer.PutUint64(w, binary.LittleEndian, value)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < pp.paramLC; i++ {
+		err = pp.writePolyCNTT(w, cmtr.polyCNTTs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readValueOpening reads the value opening written by writeValueOpening.
+func (pp *PublicParameter) readValueOpening(r io.Reader) (uint64, *PolyCNTTVec, error) {
+	value, err := binarySerializer.Uint64(r, littleEndian)
+	if err != nil {
+		return 0, nil, err
+	}
+	cmtr := &PolyCNTTVec{polyCNTTs: make([]*PolyCNTT, pp.paramLC)}
+	for i := 0; i < pp.paramLC; i++ {
+		cmtr.polyCNTTs[i], err = pp.readPolyCNTT(r)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	return value, cmtr, nil
+}
+
+// transferTxMLPProposalOpeningsMaxSize returns the maximum size of the encryptedOpenings of a TransferTxMLPProposal
+// with inputNum txInputs and outputNum txos.
+func (pp *PublicParameter) transferTxMLPProposalOpeningsMaxSize(inputNum int, outputNum int) int {
+	valueOpeningSize := 8 + pp.paramLC*pp.PolyCNTTSerializeSize()
+	return inputNum*(1+VarIntSerializeSize(uint64(pp.GetParamMACKeyBytesLen()))+pp.GetParamMACKeyBytesLen()+1+valueOpeningSize) +
+		VarIntSerializeSize(uint64(outputNum)) + outputNum*valueOpeningSize + transferTxMLPProposalTagLen
+}
+
+// writeTransferTxMLPProposalOpenings writes the openings of the input TransferTxMLPProposal, in the format
+// sidx (1 byte) || VarBytes(coinDetectorKey) || hasValueOpening (1 byte) || value (8 bytes) || cmtrIn (fixed-length, if hasValueOpening = 1)
+// for each txInput, then VarInt(outForRing) || (value (8 bytes) || cmtr (fixed-length)) for each RingCT-Privacy txo.
+func (pp *PublicParameter) writeTransferTxMLPProposalOpenings(w io.Writer, proposal *TransferTxMLPProposal) error {
+	for i, txInput := range proposal.txInputs {
+		_, err := w.Write([]byte{txInput.sidx})
+		if err != nil {
+			return err
+		}
+		if len(txInput.coinDetectorKey) != 0 && len(txInput.coinDetectorKey) != pp.GetParamMACKeyBytesLen() {
+			return fmt.Errorf("writeTransferTxMLPProposalOpenings: the coinDetectorKey of the %d -th txInput has an invalid length (%d)", i, len(txInput.coinDetectorKey))
+		}
+		err = writeVarBytes(w, txInput.coinDetectorKey)
+		if err != nil {
+			return err
+		}
+		if txInput.cmtrIn == nil {
+			_, err = w.Write([]byte{0})
+			if err != nil {
+				return err
+			}
+			err = binarySerializer.PutUint64(w, binary.LittleEndian, txInput.value)
+		} else {
+			_, err = w.Write([]byte{1})
+			if err != nil {
+				return err
+			}
+			err = pp.writeValueOpening(w, txInput.value, txInput.cmtrIn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	err := WriteVarInt(w, uint64(len(proposal.cmtrsOut)))
+	if err != nil {
+		return err
+	}
+	for j := range proposal.cmtrsOut {
+		err = pp.writeValueOpening(w, proposal.valuesOut[j], proposal.cmtrsOut[j])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readTransferTxMLPProposalOpenings reads the openings written by writeTransferTxMLPProposalOpenings into the input proposal,
+// whose txInputs have been set with the lgrTxoList.
+func (pp *PublicParameter) readTransferTxMLPProposalOpenings(r *bytes.Reader, proposal *TransferTxMLPProposal) error {
+	for i, txInput := range proposal.txInputs {
+		sidx, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if int(sidx) >= len(txInput.lgrTxoList) {
+			return fmt.Errorf("readTransferTxMLPProposalOpenings: the sidx (%d) of the %d -th txInput is out of the range of its lgrTxoList", sidx, i)
+		}
+		txInput.sidx = sidx
+		txInput.coinDetectorKey, err = readVarBytes(r, uint32(pp.GetParamMACKeyBytesLen()), "TransferTxMLPProposal.txInputs[].coinDetectorKey")
+		if err != nil {
+			return err
+		}
+		hasValueOpening, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch hasValueOpening {
+		case 0:
+			txInput.value, err = binarySerializer.Uint64(r, littleEndian)
+		case 1:
+			txInput.value, txInput.cmtrIn, err = pp.readValueOpening(r)
+		default:
+			return fmt.Errorf("readTransferTxMLPProposalOpenings: the %d -th txInput has an invalid hasValueOpening (%d)", i, hasValueOpening)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	openingNum, err := ReadVarInt(r)
+	if err != nil {
+		return err
+	}
+	if openingNum > uint64(len(proposal.txos)) {
+		return fmt.Errorf("readTransferTxMLPProposalOpenings: the number of value openings (%d) exceeds the number of txos (%d)", openingNum, len(proposal.txos))
+	}
+	proposal.cmtrsOut = make([]*PolyCNTTVec, openingNum)
+	proposal.valuesOut = make([]uint64, openingNum)
+	for j := 0; j < int(openingNum); j++ {
+		proposal.valuesOut[j], proposal.cmtrsOut[j], err = pp.readValueOpening(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.Len() != 0 {
+		return fmt.Errorf("readTransferTxMLPProposalOpenings: the openings have %d redundant bytes", r.Len())
+	}
+	return nil
+}
+
+// transferTxMLPProposalTag returns the tag of the input encryptedOpenings, i.e., SHA3-256('P' || 'T' || kappa || encryptedOpenings).
+func transferTxMLPProposalTag(kappa []byte, encryptedOpenings []byte) []byte {
+	h := sha3.New256()
+	h.Write([]byte{'P', 'T'})
+	h.Write(kappa)
+	h.Write(encryptedOpenings)
+	return h.Sum(nil)
+}
+
+// transferTxMLPProposalSeal encrypts the openings of the input proposal to signerValuePublicKey,
+// namely, with kappa encapsulated to signerValuePublicKey,
+// encryptedOpenings = (openings XOR expandProposalOpeningsPad(kappa)) || transferTxMLPProposalTag.
+func (pp *PublicParameter) transferTxMLPProposalSeal(proposal *TransferTxMLPProposal, signerValuePublicKey []byte) error {
+	w := bytes.NewBuffer(nil)
+	if err := pp.writeTransferTxMLPProposalOpenings(w, proposal); err != nil {
+		return err
+	}
+	openings := w.Bytes()
+	defer wipeBytes(openings)
+
+	ctKemSerialized, kappa, err := pqringctxkem.EncapsWithRand(pp.paramKem, signerValuePublicKey, pp.randReader)
+	if err != nil {
+		return err
+	}
+	defer wipeBytes(kappa)
+
+	pad, err := pp.expandProposalOpeningsPad(kappa, len(openings))
+	if err != nil {
+		return err
+	}
+	defer wipeBytes(pad)
+
+	encryptedOpenings := make([]byte, len(openings), len(openings)+transferTxMLPProposalTagLen)
+	for i := 0; i < len(openings); i++ {
+		encryptedOpenings[i] = openings[i] ^ pad[i]
+	}
+
+	proposal.ctKemSerialized = ctKemSerialized
+	proposal.encryptedOpenings = append(encryptedOpenings, transferTxMLPProposalTag(kappa, encryptedOpenings)...)
+	return nil
+}
+
+// TransferTxMLPProposalOpen decrypts the openings of the input TransferTxMLPProposal, which are encrypted to signerValuePublicKey,
+// by the signer's (signerValuePublicKey, signerValueSecretKey), and checks the proposal with the openings.
+// The input proposal is changed only if it succeeds.
+func (pp *PublicParameter) TransferTxMLPProposalOpen(proposal *TransferTxMLPProposal, signerValuePublicKey []byte, signerValueSecretKey []byte) error {
+	if proposal == nil {
+		return fmt.Errorf("TransferTxMLPProposalOpen: the input proposal is nil")
+	}
+	if len(proposal.encryptedOpenings) < transferTxMLPProposalTagLen {
+		return fmt.Errorf("TransferTxMLPProposalOpen: the input proposal does not have the encrypted openings")
+	}
+
+	if len(signerValuePublicKey) != pqringctxkem.GetKemPublicKeyBytesLen(pp.paramKem) {
+		return fmt.Errorf("TransferTxMLPProposalOpen: the input signerValuePublicKey is not well-form")
+	}
+	if len(signerValueSecretKey) != pqringctxkem.GetKemSecretKeyBytesLen(pp.paramKem) {
+		return fmt.Errorf("TransferTxMLPProposalOpen: the input signerValueSecretKey is not well-form")
+	}
+	copiedSignerValueSecretKey := make([]byte, len(signerValueSecretKey))
+	defer wipeBytes(copiedSignerValueSecretKey)
+	copy(copiedSignerValueSecretKey, signerValueSecretKey)
+	validValueKey, hints := pp.CoinValueKeyVerify(signerValuePublicKey, copiedSignerValueSecretKey)
+	if !validValueKey {
+		return fmt.Errorf("TransferTxMLPProposalOpen: the input (signerValuePublicKey, signerValueSecretKey) is not a valid key pair: %v", hints)
+	}
+	copy(copiedSignerValueSecretKey, signerValueSecretKey)
+
+	kappa, err := pqringctxkem.Decaps(pp.paramKem, proposal.ctKemSerialized, copiedSignerValueSecretKey)
+	if err != nil {
+		return err
+	}
+	defer wipeBytes(kappa)
+
+	bodyLen := len(proposal.encryptedOpenings) - transferTxMLPProposalTagLen
+	encryptedOpenings := proposal.encryptedOpenings[:bodyLen]
+	if subtle.ConstantTimeCompare(proposal.encryptedOpenings[bodyLen:], transferTxMLPProposalTag(kappa, encryptedOpenings)) != 1 {
+		return fmt.Errorf("TransferTxMLPProposalOpen: the encrypted openings fail the tag check, e.g., they are not encrypted to the input signerValuePublicKey")
+	}
+
+	pad, err := pp.expandProposalOpeningsPad(kappa, bodyLen)
+	if err != nil {
+		return err
+	}
+	defer wipeBytes(pad)
+	openings := make([]byte, bodyLen)
+	defer wipeBytes(openings)
+	for i := 0; i < bodyLen; i++ {
+		openings[i] = encryptedOpenings[i] ^ pad[i]
+	}
+
+	openedProposal := &TransferTxMLPProposal{
+		txInputs:          make([]*txInputProposalMLP, len(proposal.txInputs)),
+		txos:              proposal.txos,
+		fee:               proposal.fee,
+		txMemo:            proposal.txMemo,
+		ctKemSerialized:   proposal.ctKemSerialized,
+		encryptedOpenings: proposal.encryptedOpenings,
+		opened:            true,
+	}
+	for i, txInput := range proposal.txInputs {
+		if txInput == nil {
+			return fmt.Errorf("TransferTxMLPProposalOpen: the %d -th txInput is nil", i)
+		}
+		openedProposal.txInputs[i] = &txInputProposalMLP{lgrTxoList: txInput.lgrTxoList}
+	}
+	if err = pp.readTransferTxMLPProposalOpenings(bytes.NewReader(openings), openedProposal); err != nil {
+		return fmt.Errorf("TransferTxMLPProposalOpen: fail to read the openings: %v", err)
+	}
+	if _, err = pp.transferTxMLPProposalCheck(openedProposal); err != nil {
+		return fmt.Errorf("TransferTxMLPProposalOpen: %v", err)
+	}
+
+	*proposal = *openedProposal
+	return nil
+}
+
+// SerializeTransferTxMLPProposal serializes the input TransferTxMLPProposal to []byte, so that it can be transferred to the offline signer.
+// The openings are serialized only in the encrypted form, and the format is
+// txInputs: VarInt(inputNum), and for each txInput, VarInt(ringSize) || VarBytes(lgrTxo) for each ring member;
+// txos: VarInt(outputNum) || VarBytes(txo) for each txo;
+// fee (8 bytes) || VarBytes(txMemo) || VarBytes(ctKemSerialized) || VarBytes(encryptedOpenings).
+func (pp *PublicParameter) SerializeTransferTxMLPProposal(proposal *TransferTxMLPProposal) ([]byte, error) {
+	if proposal == nil {
+		return nil, fmt.Errorf("SerializeTransferTxMLPProposal: the input proposal is nil")
+	}
+	if proposal.opened {
+		if _, err := pp.transferTxMLPProposalCheck(proposal); err != nil {
+			return nil, fmt.Errorf("SerializeTransferTxMLPProposal: the input proposal is not well-form: %v", err)
+		}
+	}
+	if len(proposal.ctKemSerialized) != pqringctxkem.GetKemCiphertextBytesLen(pp.paramKem) || len(proposal.encryptedOpenings) < transferTxMLPProposalTagLen {
+		return nil, fmt.Errorf("SerializeTransferTxMLPProposal: the input proposal does not have the encrypted openings")
+	}
+
+	w := bytes.NewBuffer(nil)
+
+	//	txInputs
+	err := WriteVarInt(w, uint64(len(proposal.txInputs)))
+	if err != nil {
+		return nil, err
+	}
+	for i, txInput := range proposal.txInputs {
+		if txInput == nil {
+			return nil, fmt.Errorf("SerializeTransferTxMLPProposal: the %d -th txInput is nil", i)
+		}
+		err = WriteVarInt(w, uint64(len(txInput.lgrTxoList)))
+		if err != nil {
+			return nil, err
+		}
+		for _, lgrTxo := range txInput.lgrTxoList {
+			serializedLgrTxo, err := pp.SerializeLgrTxoMLP(lgrTxo)
+			if err != nil {
+				return nil, err
+			}
+			err = writeVarBytes(w, serializedLgrTxo)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	//	txos
+	err = WriteVarInt(w, uint64(len(proposal.txos)))
+	if err != nil {
+		return nil, err
+	}
+	for _, txo := range proposal.txos {
+		serializedTxo, err := pp.SerializeTxoMLP(txo)
+		if err != nil {
+			return nil, err
+		}
+		err = writeVarBytes(w, serializedTxo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//	fee and txMemo
+	err = binarySerializer.PutUint64(w, binary.LittleEndian, proposal.fee)
+	if err != nil {
+		return nil, err
+	}
+	err = writeVarBytes(w, proposal.txMemo)
+	if err != nil {
+		return nil, err
+	}
+
+	//	the encrypted openings
+	err = writeVarBytes(w, proposal.ctKemSerialized)
+	if err != nil {
+		return nil, err
+	}
+	err = writeVarBytes(w, proposal.encryptedOpenings)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// DeserializeTransferTxMLPProposal deserializes the input []byte to a TransferTxMLPProposal, which is not opened.
+// It checks the plaintext part only, and TransferTxMLPProposalOpen checks the whole proposal with the openings.
+func (pp *PublicParameter) DeserializeTransferTxMLPProposal(serializedProposal []byte) (*TransferTxMLPProposal, error) {
+	r := bytes.NewReader(serializedProposal)
+
+	//	txInputs
+	inputNum, err := ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	if inputNum == 0 || inputNum > uint64(pp.paramI)+uint64(pp.paramISingle) {
+		return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the inputNum (%d) is not in [1, %d]", inputNum, uint64(pp.paramI)+uint64(pp.paramISingle))
+	}
+	proposal := &TransferTxMLPProposal{
+		txInputs: make([]*txInputProposalMLP, inputNum),
+	}
+	for i := 0; i < int(inputNum); i++ {
+		ringSize, err := ReadVarInt(r)
+		if err != nil {
+			return nil, err
+		}
+		if ringSize == 0 || ringSize > uint64(pp.paramRingSizeMax) {
+			return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the ring size (%d) of the %d -th txInput is not in [1, %d]", ringSize, i, pp.paramRingSizeMax)
+		}
+		lgrTxoList := make([]*LgrTxoMLP, ringSize)
+		for t := 0; t < int(ringSize); t++ {
+			serializedLgrTxo, err := readVarBytes(r, MaxAllowedLgrTxoMLPSize, "TransferTxMLPProposal.txInputs[].lgrTxoList[]")
+			if err != nil {
+				return nil, err
+			}
+			lgrTxoList[t], err = pp.DeserializeLgrTxoMLP(serializedLgrTxo)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !pp.LgrTxoRingForSingleSanityCheck(lgrTxoList) && !pp.LgrTxoRingForRingSanityCheck(lgrTxoList) {
+			return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the lgrTxoList of the %d -th txInput is not well-form", i)
+		}
+		proposal.txInputs[i] = &txInputProposalMLP{lgrTxoList: lgrTxoList}
+	}
+
+	//	txos
+	outputNum, err := ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	if outputNum == 0 || outputNum > uint64(pp.paramJ)+uint64(pp.paramJSingle) {
+		return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the outputNum (%d) is not in [1, %d]", outputNum, uint64(pp.paramJ)+uint64(pp.paramJSingle))
+	}
+	proposal.txos = make([]TxoMLP, outputNum)
+	for j := 0; j < int(outputNum); j++ {
+		serializedTxo, err := readVarBytes(r, MaxAllowedTxoMLPSize, "TransferTxMLPProposal.txos")
+		if err != nil {
+			return nil, err
+		}
+		proposal.txos[j], err = pp.DeserializeTxoMLP(serializedTxo)
+		if err != nil {
+			return nil, err
+		}
+		if !pp.TxoMLPSanityCheck(proposal.txos[j]) {
+			return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the %d -th txo is not well-form", j)
+		}
+	}
+
+	//	fee and txMemo
+	proposal.fee, err = binarySerializer.Uint64(r, littleEndian)
+	if err != nil {
+		return nil, err
+	}
+	V := (uint64(1) << pp.paramN) - 1
+	if proposal.fee > V {
+		return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the transaction fee (%d) is not in the scope[0, V (%d)]", proposal.fee, V)
+	}
+	proposal.txMemo, err = readVarBytes(r, MaxAllowedTxMemoMLPSize, "TransferTxMLPProposal.txMemo")
+	if err != nil {
+		return nil, err
+	}
+
+	//	the encrypted openings
+	proposal.ctKemSerialized, err = readVarBytes(r, uint32(pqringctxkem.GetKemCiphertextBytesLen(pp.paramKem)), "TransferTxMLPProposal.ctKemSerialized")
+	if err != nil {
+		return nil, err
+	}
+	if len(proposal.ctKemSerialized) != pqringctxkem.GetKemCiphertextBytesLen(pp.paramKem) {
+		return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the ctKemSerialized has an invalid length (%d)", len(proposal.ctKemSerialized))
+	}
+	proposal.encryptedOpenings, err = readVarBytes(r, uint32(pp.transferTxMLPProposalOpeningsMaxSize(int(inputNum), int(outputNum))), "TransferTxMLPProposal.encryptedOpenings")
+	if err != nil {
+		return nil, err
+	}
+	if len(proposal.encryptedOpenings) < transferTxMLPProposalTagLen {
+		return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the encryptedOpenings has an invalid length (%d)", len(proposal.encryptedOpenings))
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("DeserializeTransferTxMLPProposal: the input serializedProposal has %d redundant bytes", r.Len())
+	}
+
+	return proposal, nil
+}
+
+//	TransferTxMLPProposal	end
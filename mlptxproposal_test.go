@@ -0,0 +1,254 @@
+package pqringctx
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"testing"
+)
+
+// proposalTestKeys is the keys of a coin in the proposal tests.
+type proposalTestKeys struct {
+	coinAddress               []byte
+	coinSpendSecretKey        []byte
+	coinSerialNumberSecretKey []byte
+	coinDetectorKey           []byte
+	coinValuePublicKey        []byte
+	coinValueSecretKey        []byte
+}
+
+// proposalTestSeed returns the length-byte seed which is determined by the input label,
+// so that the keys generated from different labels are always distinct.
+func proposalTestSeed(label string, length int) []byte {
+	seed := make([]byte, length)
+	xof := sha3.NewShake256()
+	xof.Write([]byte("pqringctx-proposal-test/" + label))
+	xof.Read(seed)
+	return seed
+}
+
+// proposalTestKeyGen generates the keys of the input coinAddressType from the seeds determined by the input label.
+func proposalTestKeyGen(t *testing.T, label string, coinAddressType CoinAddressType) *proposalTestKeys {
+	keys := &proposalTestKeys{
+		coinDetectorKey: proposalTestSeed(label+"/coinDetectorKey", pp.GetParamMACKeyBytesLen()),
+	}
+	coinSpendKeyRandSeed := proposalTestSeed(label+"/coinSpendKeyRandSeed", pp.paramKeyGenSeedBytesLen)
+	publicRand := proposalTestSeed(label+"/publicRand", pp.GetParamKeyGenPublicRandBytesLen())
+
+	var err error
+	switch coinAddressType {
+	case CoinAddressTypePublicKeyForRing:
+		coinSerialNumberKeyRandSeed := proposalTestSeed(label+"/coinSerialNumberKeyRandSeed", pp.paramKeyGenSeedBytesLen)
+		keys.coinAddress, keys.coinSpendSecretKey, keys.coinSerialNumberSecretKey, err = pp.CoinAddressKeyForPKRingGen(coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, keys.coinDetectorKey, publicRand)
+		if err != nil {
+			t.Fatalf("%s: CoinAddressKeyForPKRingGen: %v", label, err)
+		}
+		keys.coinValuePublicKey, keys.coinValueSecretKey, err = pp.CoinValueKeyGen(proposalTestSeed(label+"/coinValueKeyRandSeed", pp.paramKeyGenSeedBytesLen))
+		if err != nil {
+			t.Fatalf("%s: CoinValueKeyGen: %v", label, err)
+		}
+	case CoinAddressTypePublicKeyHashForSingle:
+		keys.coinAddress, keys.coinSpendSecretKey, err = pp.CoinAddressKeyForPKHSingleGen(coinSpendKeyRandSeed, keys.coinDetectorKey, publicRand)
+		if err != nil {
+			t.Fatalf("%s: CoinAddressKeyForPKHSingleGen: %v", label, err)
+		}
+	default:
+		t.Fatalf("%s: unsupported coinAddressType %d", label, coinAddressType)
+	}
+	return keys
+}
+
+// proposalTestInputDesc generates a TxInputDescMLP that consumes a coin of the input value,
+// in a ring of size ringSize (which must be 1 for CoinAddressTypePublicKeyHashForSingle),
+// where the coins in the ring belong to the keys generated from the input label.
+func proposalTestInputDesc(t *testing.T, label string, coinAddressType CoinAddressType, ringSize int, value uint64) *TxInputDescMLP {
+	ringKeys := make([]*proposalTestKeys, ringSize)
+	txOutputDescs := make([]*TxOutputDescMLP, ringSize)
+	vin := uint64(0)
+	for i := 0; i < ringSize; i++ {
+		ringKeys[i] = proposalTestKeyGen(t, fmt.Sprintf("%s/ring/%d", label, i), coinAddressType)
+		txOutputDescs[i] = NewTxOutputDescMLP(ringKeys[i].coinAddress, ringKeys[i].coinValuePublicKey, value)
+		vin += value
+	}
+	cbTx, err := pp.CoinbaseTxMLPGen(vin, txOutputDescs, []byte(label))
+	if err != nil {
+		t.Fatalf("%s: CoinbaseTxMLPGen: %v", label, err)
+	}
+
+	lgrTxoList := make([]*LgrTxoMLP, ringSize)
+	for i := 0; i < ringSize; i++ {
+		lgrTxoList[i] = NewLgrTxoMLP(cbTx.txos[i], proposalTestSeed(fmt.Sprintf("%s/lgrTxoId/%d", label, i), HashOutputBytesLen))
+	}
+
+	sidx := ringSize - 1
+	return &TxInputDescMLP{
+		lgrTxoList:                lgrTxoList,
+		sidx:                      uint8(sidx),
+		coinSpendSecretKey:        ringKeys[sidx].coinSpendSecretKey,
+		coinSerialNumberSecretKey: ringKeys[sidx].coinSerialNumberSecretKey,
+		coinValuePublicKey:        ringKeys[sidx].coinValuePublicKey,
+		coinValueSecretKey:        ringKeys[sidx].coinValueSecretKey,
+		coinDetectorKey:           ringKeys[sidx].coinDetectorKey,
+		value:                     value,
+	}
+}
+
+func TestPublicParameter_TransferTxMLPProposalGen_TransferTxMLPProposalSign(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputTypes   []CoinAddressType
+		outputValues []uint64 // the values of the outputs with RingCT-privacy
+		outputSingle []uint64 // the values of the outputs with Pseudonym-privacy
+	}{
+		{"Ring -> Ring", []CoinAddressType{CoinAddressTypePublicKeyForRing, CoinAddressTypePublicKeyForRing}, []uint64{280}, nil},
+		{"Hybrid -> Hybrid", []CoinAddressType{CoinAddressTypePublicKeyForRing, CoinAddressTypePublicKeyForRing, CoinAddressTypePublicKeyHashForSingle, CoinAddressTypePublicKeyHashForSingle}, []uint64{600}, []uint64{390}},
+		{"Single -> Single", []CoinAddressType{CoinAddressTypePublicKeyHashForSingle, CoinAddressTypePublicKeyHashForSingle}, nil, []uint64{140, 150}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//	the coins are owned by distinct keys, and the online (watch-only) side has no spend keys
+			totalInputValue := uint64(0)
+			coinSpendSecretKeys := make([][]byte, len(tt.inputTypes))
+			coinSerialNumberSecretKeys := make([][]byte, len(tt.inputTypes))
+			watchOnlyInputDescs := make([]*TxInputDescMLP, len(tt.inputTypes))
+			for i, coinAddressType := range tt.inputTypes {
+				ringSize := 2
+				if coinAddressType == CoinAddressTypePublicKeyHashForSingle {
+					ringSize = 1
+				}
+				value := uint64(100 * (i + 1))
+				txInputDesc := proposalTestInputDesc(t, fmt.Sprintf("%s/input/%d", tt.name, i), coinAddressType, ringSize, value)
+				totalInputValue += value
+
+				coinSpendSecretKeys[i] = txInputDesc.coinSpendSecretKey
+				coinSerialNumberSecretKeys[i] = txInputDesc.coinSerialNumberSecretKey
+				txInputDesc.coinSpendSecretKey = nil
+				txInputDesc.coinSerialNumberSecretKey = nil
+				watchOnlyInputDescs[i] = txInputDesc
+			}
+
+			txOutputDescs := make([]*TxOutputDescMLP, 0, len(tt.outputValues)+len(tt.outputSingle))
+			totalOutputValue := uint64(0)
+			for j, value := range tt.outputValues {
+				keys := proposalTestKeyGen(t, fmt.Sprintf("%s/output/%d", tt.name, j), CoinAddressTypePublicKeyForRing)
+				txOutputDescs = append(txOutputDescs, NewTxOutputDescMLP(keys.coinAddress, keys.coinValuePublicKey, value))
+				totalOutputValue += value
+			}
+			for j, value := range tt.outputSingle {
+				keys := proposalTestKeyGen(t, fmt.Sprintf("%s/output/%d", tt.name, len(tt.outputValues)+j), CoinAddressTypePublicKeyHashForSingle)
+				txOutputDescs = append(txOutputDescs, NewTxOutputDescMLP(keys.coinAddress, nil, value))
+				totalOutputValue += value
+			}
+			fee := totalInputValue - totalOutputValue
+			txMemo := []byte(tt.name)
+
+			//	the offline signer has a KEM key pair, to which the openings are encrypted
+			signerValuePublicKey, signerValueSecretKey, err := pp.CoinValueKeyGen(proposalTestSeed(tt.name+"/signer", pp.paramKeyGenSeedBytesLen))
+			if err != nil {
+				t.Fatalf("CoinValueKeyGen: %v", err)
+			}
+			otherValuePublicKey, otherValueSecretKey, err := pp.CoinValueKeyGen(proposalTestSeed(tt.name+"/other", pp.paramKeyGenSeedBytesLen))
+			if err != nil {
+				t.Fatalf("CoinValueKeyGen: %v", err)
+			}
+
+			proposal, err := pp.TransferTxMLPProposalGen(watchOnlyInputDescs, txOutputDescs, fee, txMemo, signerValuePublicKey)
+			if err != nil {
+				t.Fatalf("TransferTxMLPProposalGen: %v", err)
+			}
+
+			serializedProposal, err := pp.SerializeTransferTxMLPProposal(proposal)
+			if err != nil {
+				t.Fatalf("SerializeTransferTxMLPProposal: %v", err)
+			}
+			//	the openings are not in plaintext
+			for _, txInputDesc := range watchOnlyInputDescs {
+				if bytes.Contains(serializedProposal, txInputDesc.coinDetectorKey) {
+					t.Fatalf("the serialized proposal contains a coinDetectorKey in plaintext")
+				}
+			}
+			deserializedProposal, err := pp.DeserializeTransferTxMLPProposal(serializedProposal)
+			if err != nil {
+				t.Fatalf("DeserializeTransferTxMLPProposal: %v", err)
+			}
+			if deserializedProposal.IsOpened() || deserializedProposal.GetTxoValues() != nil || deserializedProposal.GetTxInputValues() != nil {
+				t.Fatalf("the deserialized proposal reveals the openings before it is opened")
+			}
+			reserializedProposal, err := pp.SerializeTransferTxMLPProposal(deserializedProposal)
+			if err != nil {
+				t.Fatalf("SerializeTransferTxMLPProposal: %v", err)
+			}
+			if !bytes.Equal(serializedProposal, reserializedProposal) {
+				t.Fatalf("the deserialized proposal is different from the original one")
+			}
+			if _, err = pp.DeserializeTransferTxMLPProposal(append(serializedProposal, 0)); err == nil {
+				t.Fatalf("DeserializeTransferTxMLPProposal accepts redundant bytes")
+			}
+			if _, err = pp.TransferTxMLPProposalSign(deserializedProposal, coinSpendSecretKeys, coinSerialNumberSecretKeys); err == nil {
+				t.Fatalf("TransferTxMLPProposalSign accepts a proposal which is not opened")
+			}
+
+			//	the openings are encrypted to the signer only, and are authenticated
+			if err = pp.TransferTxMLPProposalOpen(deserializedProposal, otherValuePublicKey, otherValueSecretKey); err == nil {
+				t.Fatalf("TransferTxMLPProposalOpen succeeds with the key of another signer")
+			}
+			if deserializedProposal.IsOpened() {
+				t.Fatalf("the failed TransferTxMLPProposalOpen changes the proposal")
+			}
+			tamperedProposal := append([]byte{}, serializedProposal...)
+			tamperedProposal[len(tamperedProposal)-transferTxMLPProposalTagLen-1] ^= 0x01
+			deserializedTamperedProposal, err := pp.DeserializeTransferTxMLPProposal(tamperedProposal)
+			if err != nil {
+				t.Fatalf("DeserializeTransferTxMLPProposal: %v", err)
+			}
+			if err = pp.TransferTxMLPProposalOpen(deserializedTamperedProposal, signerValuePublicKey, signerValueSecretKey); err == nil {
+				t.Fatalf("TransferTxMLPProposalOpen accepts the tampered openings")
+			}
+
+			if err = pp.TransferTxMLPProposalOpen(deserializedProposal, signerValuePublicKey, signerValueSecretKey); err != nil {
+				t.Fatalf("TransferTxMLPProposalOpen: %v", err)
+			}
+			reserializedProposal, err = pp.SerializeTransferTxMLPProposal(deserializedProposal)
+			if err != nil {
+				t.Fatalf("SerializeTransferTxMLPProposal: %v", err)
+			}
+			if !bytes.Equal(serializedProposal, reserializedProposal) {
+				t.Fatalf("the opened proposal is different from the original one")
+			}
+
+			//	the offline side reviews the outputs and the fee, then signs
+			for j, value := range deserializedProposal.GetTxoValues() {
+				if value != txOutputDescs[j].value {
+					t.Fatalf("the value (%d) of the %d -th txo in the proposal is different from the planned one (%d)", value, j, txOutputDescs[j].value)
+				}
+			}
+			if deserializedProposal.GetFee() != fee {
+				t.Fatalf("the fee (%d) in the proposal is different from the planned one (%d)", deserializedProposal.GetFee(), fee)
+			}
+
+			//	the keys of another coin are rejected
+			coinSpendSecretKeys[0], coinSpendSecretKeys[1] = coinSpendSecretKeys[1], coinSpendSecretKeys[0]
+			coinSerialNumberSecretKeys[0], coinSerialNumberSecretKeys[1] = coinSerialNumberSecretKeys[1], coinSerialNumberSecretKeys[0]
+			if _, err = pp.TransferTxMLPProposalSign(deserializedProposal, coinSpendSecretKeys, coinSerialNumberSecretKeys); err == nil {
+				t.Fatalf("TransferTxMLPProposalSign accepts the keys of another coin")
+			}
+			coinSpendSecretKeys[0], coinSpendSecretKeys[1] = coinSpendSecretKeys[1], coinSpendSecretKeys[0]
+			coinSerialNumberSecretKeys[0], coinSerialNumberSecretKeys[1] = coinSerialNumberSecretKeys[1], coinSerialNumberSecretKeys[0]
+
+			//	the signer holds the spend keys as SecretBytes
+			coinSpendSecretKeysSecret := make([]*SecretBytes, len(coinSpendSecretKeys))
+			coinSerialNumberSecretKeysSecret := make([]*SecretBytes, len(coinSerialNumberSecretKeys))
+			for i := range coinSpendSecretKeys {
+				coinSpendSecretKeysSecret[i] = NewSecretBytes(coinSpendSecretKeys[i])
+				coinSerialNumberSecretKeysSecret[i] = NewSecretBytes(coinSerialNumberSecretKeys[i])
+			}
+			trTx, err := pp.TransferTxMLPProposalSignSecret(deserializedProposal, coinSpendSecretKeysSecret, coinSerialNumberSecretKeysSecret)
+			if err != nil {
+				t.Fatalf("TransferTxMLPProposalSignSecret: %v", err)
+			}
+			if err = pp.TransferTxMLPVerify(trTx); err != nil {
+				t.Fatalf("TransferTxMLPVerify: %v", err)
+			}
+		})
+	}
+}
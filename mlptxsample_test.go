@@ -0,0 +1,32 @@
+package pqringctx
+
+import "testing"
+
+// sampleTransferTxMLPDescs generates the inputs and outputs of a TransferTxMLP, which spends 2 coins with RingCT-privacy (in rings of size 4) and 1 coin with Pseudonym-privacy,
+// to 1 coin with RingCT-privacy and 1 coin with Pseudonym-privacy, so that the witness contains both the signatures and the balance proof.
+// The ring sizes and the values are fixed, so that the benchmarks are comparable across runs,
+// and the tests of the serialization formats share the same transaction shape.
+func sampleTransferTxMLPDescs(tb testing.TB) (txInputDescMLPs []*TxInputDescMLP, txOutputDescMLPs []*TxOutputDescMLP, fee uint64) {
+	InitialAddress()
+	ringSize := 4
+	req := &InputRequest{
+		inputRingRandNum:        2,
+		inputRingRandRingSizes:  []int{ringSize, ringSize},
+		inputRingRandSelectNums: []int{1, 1},
+		inputRingRandValues:     [][]uint64{make([]uint64, ringSize), make([]uint64, ringSize)},
+		inputRingRandTotalValue: make([]uint64, 2),
+		inputSingleNum:          1,
+		inputSingleValues:       []uint64{1000},
+	}
+	for i := 0; i < req.inputRingRandNum; i++ {
+		for j := 0; j < ringSize; j++ {
+			req.inputRingRandValues[i][j] = uint64(100 * (i*ringSize + j + 1))
+			req.inputRingRandTotalValue[i] += req.inputRingRandValues[i][j]
+		}
+	}
+	txInputDescMLPs, totalInputValueForRing, totalInputValueForSingle, _ := GenerateInput(req)
+	fee = 10
+	totalOutputValue := totalInputValueForRing + totalInputValueForSingle - fee
+	txOutputDescMLPs, _ = GenerateOutput(totalOutputValue/2, totalOutputValue-totalOutputValue/2, 0, 1, 1)
+	return txInputDescMLPs, txOutputDescMLPs, fee
+}
@@ -0,0 +1,286 @@
+package pqringctx
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+//	TransferTxMLP size and fee estimation	begin
+
+// TransferTxMLPSizeDesc describes the shape of a planned TransferTxMLP, which determines its serialize size,
+// so that the size (and the fee) can be computed before the expensive proof generation.
+// It can be filled in by the caller directly (with just the types and the ring sizes),
+// or be constructed from the planned TxInputDescMLP/TxOutputDescMLP lists by NewTransferTxMLPSizeDesc.
+type TransferTxMLPSizeDesc struct {
+	// InputRings[i] lists the coinAddressTypes of the ring members of the i-th input,
+	// where a pseudonym-privacy input has exactly one ring member with CoinAddressTypePublicKeyHashForSingle.
+	InputRings [][]CoinAddressType
+	// InForSingleDistinct is the number of distinct coinAddresses of the pseudonym-privacy inputs.
+	InForSingleDistinct int
+	// OutputCoinAddressTypes[j] is the coinAddressType of the j-th output.
+	OutputCoinAddressTypes []CoinAddressType
+	TxMemoLen              int
+	// VInForSingle (resp. VOutForSingle) is the total value of the pseudonym-privacy inputs (resp. outputs), excluding the fee.
+	// Together with the fee, they determine the public value in the balance proof, which affects the witness size.
+	VInForSingle  uint64
+	VOutForSingle uint64
+}
+
+// NewTransferTxMLPSizeDesc constructs a TransferTxMLPSizeDesc from the planned TxInputDescMLP/TxOutputDescMLP lists and txMemo.
+// Note that the order of the inputs (resp. outputs) does not matter here.
+func (pp *PublicParameter) NewTransferTxMLPSizeDesc(txInputDescs []*TxInputDescMLP, txOutputDescs []*TxOutputDescMLP, txMemo []byte) (*TransferTxMLPSizeDesc, error) {
+	desc := &TransferTxMLPSizeDesc{
+		InputRings:             make([][]CoinAddressType, len(txInputDescs)),
+		OutputCoinAddressTypes: make([]CoinAddressType, len(txOutputDescs)),
+		TxMemoLen:              len(txMemo),
+	}
+
+	coinAddressForSingleMap := make(map[string]struct{})
+	for i, txInputDesc := range txInputDescs {
+		if txInputDesc == nil || len(txInputDesc.lgrTxoList) == 0 {
+			return nil, fmt.Errorf("NewTransferTxMLPSizeDesc: txInputDescs[%d] is nil or has an empty lgrTxoList", i)
+		}
+		desc.InputRings[i] = make([]CoinAddressType, len(txInputDesc.lgrTxoList))
+		for t, lgrTxo := range txInputDesc.lgrTxoList {
+			if lgrTxo == nil || lgrTxo.txo == nil {
+				return nil, fmt.Errorf("NewTransferTxMLPSizeDesc: txInputDescs[%d].lgrTxoList[%d] is nil", i, t)
+			}
+			desc.InputRings[i][t] = lgrTxo.txo.CoinAddressType()
+		}
+
+		lgrTxoToSpend := txInputDesc.GetLgrTxoToSpend()
+		if lgrTxoToSpend == nil {
+			return nil, fmt.Errorf("NewTransferTxMLPSizeDesc: txInputDescs[%d].sidx (%d) is out of the range of lgrTxoList", i, txInputDesc.sidx)
+		}
+		if lgrTxoToSpend.txo.CoinAddressType() == CoinAddressTypePublicKeyHashForSingle {
+			coinAddress, err := pp.GetCoinAddressFromTxoMLP(lgrTxoToSpend.txo)
+			if err != nil {
+				return nil, err
+			}
+			coinAddressForSingleMap[hex.EncodeToString(coinAddress)] = struct{}{}
+			desc.VInForSingle += txInputDesc.value
+		}
+	}
+	desc.InForSingleDistinct = len(coinAddressForSingleMap)
+
+	for j, txOutputDesc := range txOutputDescs {
+		if txOutputDesc == nil {
+			return nil, fmt.Errorf("NewTransferTxMLPSizeDesc: txOutputDescs[%d] is nil", j)
+		}
+		coinAddressType, err := pp.ExtractCoinAddressTypeFromCoinAddress(txOutputDesc.coinAddress)
+		if err != nil {
+			return nil, err
+		}
+		desc.OutputCoinAddressTypes[j] = coinAddressType
+		if coinAddressType == CoinAddressTypePublicKeyHashForSingle {
+			desc.VOutForSingle += txOutputDesc.value
+		}
+	}
+
+	return desc, nil
+}
+
+// TransferTxMLPSerializeSizeByDesc returns the exact serialize size of the TransferTxMLP described by the input TransferTxMLPSizeDesc and fee,
+// i.e., the same as TransferTxMLPSerializeSize on the generated TransferTxMLP.
+// Note that the fee affects only the witness, via the public value in the balance proof.
+func (pp *PublicParameter) TransferTxMLPSerializeSizeByDesc(desc *TransferTxMLPSizeDesc, fee uint64, withWitness bool) (int, error) {
+	if desc == nil {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the input desc is nil")
+	}
+
+	inputNum := len(desc.InputRings)
+	outputNum := len(desc.OutputCoinAddressTypes)
+	if inputNum == 0 || outputNum == 0 {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: neither the inputs or the outputs could be empty")
+	}
+	if desc.TxMemoLen < 0 {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the input TxMemoLen (%d) is negative", desc.TxMemoLen)
+	}
+
+	V := (uint64(1) << pp.paramN) - 1
+	if fee > V || desc.VInForSingle > V || desc.VOutForSingle > V {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the fee (%d), VInForSingle (%d), or VOutForSingle (%d) is not in the scope[0, V (%d)]", fee, desc.VInForSingle, desc.VOutForSingle, V)
+	}
+
+	var length = 0
+
+	//	txInputs  []*TxInputMLP
+	inForRing := 0
+	inForSingle := 0
+	inRingSizes := make([]uint8, 0, inputNum)
+	length = length + VarIntSerializeSize(uint64(inputNum))
+	for i, ring := range desc.InputRings {
+		ringSize := len(ring)
+		if ringSize == 0 || ringSize > int(pp.paramRingSizeMax) {
+			return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the ring size (%d) of the %d -th input is not in [1, %d]", ringSize, i, pp.paramRingSizeMax)
+		}
+
+		if ring[0] == CoinAddressTypePublicKeyHashForSingle {
+			if ringSize != 1 {
+				return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the %d -th input has Pseudonym-Privacy, but its ring size (%d) is not 1", i, ringSize)
+			}
+			inForSingle++
+		} else {
+			inForRing++
+			inRingSizes = append(inRingSizes, uint8(ringSize))
+		}
+
+		txInputLen := 1 //	ringSize
+		for t, coinAddressType := range ring {
+			if t > 0 && coinAddressType != CoinAddressTypePublicKeyForRingPre && coinAddressType != CoinAddressTypePublicKeyForRing {
+				return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the %d -th ring member of the %d -th input has a coinAddressType (%d) not for RingCT-Privacy", t, i, coinAddressType)
+			}
+			txoLen, err := pp.GetTxoMLPSerializeSizeByCoinAddressType(coinAddressType)
+			if err != nil {
+				return 0, err
+			}
+			lgrTxoLen := txoLen + pp.LgrTxoMLPIdSerializeSize()
+			txInputLen += VarIntSerializeSize(uint64(lgrTxoLen)) + lgrTxoLen
+		}
+		txInputLen += pp.ledgerTxoSerialNumberSerializeSizeMLP()
+		length += VarIntSerializeSize(uint64(txInputLen)) + txInputLen
+	}
+
+	if inForRing > int(pp.paramI) {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the number of RingCT-privacy inputs (%d) exceeds the allowed maximum value (%d)", inForRing, pp.paramI)
+	}
+	if inForSingle > int(pp.paramISingle) {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the number of Pseudonym-privacy inputs (%d) exceeds the allowed maximum value (%d)", inForSingle, pp.paramISingle)
+	}
+	if desc.InForSingleDistinct > int(pp.paramISingleDistinct) || desc.InForSingleDistinct > inForSingle ||
+		(inForSingle > 0 && desc.InForSingleDistinct == 0) || desc.InForSingleDistinct < 0 {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the input InForSingleDistinct (%d) does not match the number of Pseudonym-privacy inputs (%d) or exceeds the allowed maximum value (%d)", desc.InForSingleDistinct, inForSingle, pp.paramISingleDistinct)
+	}
+
+	//	txos      []TxoMLP
+	outForRing := 0
+	outForSingle := 0
+	length += VarIntSerializeSize(uint64(outputNum))
+	for _, coinAddressType := range desc.OutputCoinAddressTypes {
+		txoLen, err := pp.GetTxoMLPSerializeSizeByCoinAddressType(coinAddressType)
+		if err != nil {
+			return 0, err
+		}
+		if coinAddressType == CoinAddressTypePublicKeyHashForSingle {
+			outForSingle++
+		} else {
+			outForRing++
+		}
+		length += VarIntSerializeSize(uint64(txoLen)) + txoLen
+	}
+
+	if outForRing > int(pp.paramJ) {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the number of RingCT-privacy outputs (%d) exceeds the allowed maximum value (%d)", outForRing, pp.paramJ)
+	}
+	if outForSingle > int(pp.paramJSingle) {
+		return 0, fmt.Errorf("TransferTxMLPSerializeSizeByDesc: the number of Pseudonym-privacy outputs (%d) exceeds the allowed maximum value (%d)", outForSingle, pp.paramJSingle)
+	}
+
+	//	fee       uint64
+	length += 8
+
+	//	txMemo    []byte
+	length += VarIntSerializeSize(uint64(desc.TxMemoLen)) + desc.TxMemoLen
+
+	//	txWitness *TxWitnessTrTx
+	if withWitness {
+		//	the same as TransferTxMLPGen
+		vPublic := int64(desc.VOutForSingle+fee) - int64(desc.VInForSingle)
+		witnessLen, err := pp.TxWitnessTrTxSerializeSize(uint8(inForRing), uint8(desc.InForSingleDistinct), uint8(outForRing), inRingSizes, vPublic)
+		if err != nil {
+			return 0, err
+		}
+		length += VarIntSerializeSize(uint64(witnessLen)) + witnessLen
+	}
+
+	return length, nil
+}
+
+// FeePolicy computes the required fee of a TransferTxMLP from its serialize size (with witness) and its number of inputs.
+// The fee is expected to be non-decreasing in the size.
+// As Fee cannot return an error, a fee that overflows uint64 shall saturate at math.MaxUint64, which no inputs can cover.
+type FeePolicy interface {
+	Fee(txSize int, inputNum int) uint64
+}
+
+// FeePolicyFunc is an adapter to use a function as a FeePolicy.
+type FeePolicyFunc func(txSize int, inputNum int) uint64
+
+// Fee implements FeePolicy.
+func (f FeePolicyFunc) Fee(txSize int, inputNum int) uint64 {
+	return f(txSize, inputNum)
+}
+
+// PerByteFeePolicy charges BaseFee plus FeePerByte for each byte of the TransferTxMLP with witness.
+type PerByteFeePolicy struct {
+	BaseFee    uint64
+	FeePerByte uint64
+}
+
+// Fee implements FeePolicy, saturating at math.MaxUint64 on overflow.
+func (p PerByteFeePolicy) Fee(txSize int, inputNum int) uint64 {
+	return saturatingMulAdd(p.BaseFee, p.FeePerByte, uint64(txSize))
+}
+
+// PerInputFeePolicy charges BaseFee plus FeePerInput for each input of the TransferTxMLP.
+type PerInputFeePolicy struct {
+	BaseFee     uint64
+	FeePerInput uint64
+}
+
+// Fee implements FeePolicy, saturating at math.MaxUint64 on overflow.
+func (p PerInputFeePolicy) Fee(txSize int, inputNum int) uint64 {
+	return saturatingMulAdd(p.BaseFee, p.FeePerInput, uint64(inputNum))
+}
+
+// saturatingMulAdd returns base + rate * count, or math.MaxUint64 if it overflows uint64.
+func saturatingMulAdd(base uint64, rate uint64, count uint64) uint64 {
+	hi, lo := bits.Mul64(rate, count)
+	if hi != 0 {
+		return math.MaxUint64
+	}
+	sum, carry := bits.Add64(base, lo, 0)
+	if carry != 0 {
+		return math.MaxUint64
+	}
+	return sum
+}
+
+// TransferTxMLPFeeEstimate solves for the fee of the TransferTxMLP described by the input TransferTxMLPSizeDesc under the input FeePolicy.
+// As the fee affects the witness size (via the public value in the balance proof) and hence the required fee,
+// it iterates from the fee for the size without witness (a lower bound), and returns the first fee that covers
+// the required fee at the resulting size, together with that size (with witness).
+// Note that the fee is non-decreasing during the iteration, and the witness size has only a few possible values,
+// so the iteration terminates in a few rounds.
+func (pp *PublicParameter) TransferTxMLPFeeEstimate(desc *TransferTxMLPSizeDesc, feePolicy FeePolicy) (fee uint64, txSize int, err error) {
+	if feePolicy == nil {
+		return 0, 0, fmt.Errorf("TransferTxMLPFeeEstimate: the input feePolicy is nil")
+	}
+
+	txSizeWithoutWitness, err := pp.TransferTxMLPSerializeSizeByDesc(desc, 0, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	inputNum := len(desc.InputRings)
+	fee = feePolicy.Fee(txSizeWithoutWitness, inputNum)
+
+	V := (uint64(1) << pp.paramN) - 1
+	for {
+		if fee > V {
+			return 0, 0, fmt.Errorf("TransferTxMLPFeeEstimate: the required fee (%d) exceeds V (%d)", fee, V)
+		}
+		txSize, err = pp.TransferTxMLPSerializeSizeByDesc(desc, fee, true)
+		if err != nil {
+			return 0, 0, err
+		}
+		requiredFee := feePolicy.Fee(txSize, inputNum)
+		if requiredFee <= fee {
+			return fee, txSize, nil
+		}
+		fee = requiredFee
+	}
+}
+
+//	TransferTxMLP size and fee estimation	end
@@ -0,0 +1,148 @@
+package pqringctx
+
+import (
+	"math"
+	"testing"
+)
+
+// generateInputWithValue generates the inputs as GenerateInputWithTypeSize, but each input hosts the input value,
+// and each RingCT-privacy input is in a ring of size 2, so that the total input value is known to cover the fee of the tests.
+func generateInputWithValue(inputRingPreSize, inputRingRandSize, inputSingleSize int, value uint64) ([]*TxInputDescMLP, uint64) {
+	req := &InputRequest{
+		inputRingPreNum:  inputRingPreSize,
+		inputRingRandNum: inputRingRandSize,
+		inputSingleNum:   inputSingleSize,
+	}
+	for i := 0; i < inputRingPreSize; i++ {
+		req.inputRingPreRingSizes = append(req.inputRingPreRingSizes, 2)
+		req.inputRingPreRingSelectNums = append(req.inputRingPreRingSelectNums, 1)
+		req.inputRingPreValues = append(req.inputRingPreValues, []uint64{value, value})
+		req.inputRingPreTotalValue = append(req.inputRingPreTotalValue, 2*value)
+	}
+	for i := 0; i < inputRingRandSize; i++ {
+		req.inputRingRandRingSizes = append(req.inputRingRandRingSizes, 2)
+		req.inputRingRandSelectNums = append(req.inputRingRandSelectNums, 1)
+		req.inputRingRandValues = append(req.inputRingRandValues, []uint64{value, value})
+		req.inputRingRandTotalValue = append(req.inputRingRandTotalValue, 2*value)
+	}
+	for i := 0; i < inputSingleSize; i++ {
+		req.inputSingleValues = append(req.inputSingleValues, value)
+	}
+
+	txInputDescs, totalInputValueForRing, totalInputValueForSingle, _ := GenerateInput(req)
+	return txInputDescs, totalInputValueForRing + totalInputValueForSingle
+}
+
+func TestPublicParameter_TransferTxMLPFeeEstimate(t *testing.T) {
+	InitialAddress()
+
+	tests := []struct {
+		name              string
+		inputRingPreSize  int
+		inputRingRandSize int
+		inputSingleSize   int
+		outputSingleSize  int
+	}{
+		{"Ring -> Ring", 0, 2, 0, 0},
+		{"Hybrid -> Hybrid", 1, 1, 2, 1},
+		{"Single -> Hybrid", 0, 0, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//	each input hosts 2^32, which is far more than the fee of any transaction (about 1 per byte)
+			txInputDescs, totalInputValue := generateInputWithValue(tt.inputRingPreSize, tt.inputRingRandSize, tt.inputSingleSize, 1<<32)
+			outputValueForSingle := uint64(0)
+			if tt.outputSingleSize > 0 {
+				outputValueForSingle = totalInputValue / 4
+			}
+			txMemo := RandomBytes(10)
+
+			//	the desc does not depend on the value of the RingCT-privacy (change) output
+			planOutputDescs := GenerateOutputWithValues(nil, []uint64{1}, SplitNum(outputValueForSingle, tt.outputSingleSize))
+			desc, err := pp.NewTransferTxMLPSizeDesc(txInputDescs, planOutputDescs, txMemo)
+			if err != nil {
+				t.Fatalf("NewTransferTxMLPSizeDesc: %v", err)
+			}
+
+			feePolicy := PerByteFeePolicy{BaseFee: 1, FeePerByte: 1}
+			fee, txSize, err := pp.TransferTxMLPFeeEstimate(desc, feePolicy)
+			if err != nil {
+				t.Fatalf("TransferTxMLPFeeEstimate: %v", err)
+			}
+			if fee < feePolicy.Fee(txSize, len(txInputDescs)) {
+				t.Fatalf("the estimated fee (%d) does not cover the required fee at size %d", fee, txSize)
+			}
+			if fee+outputValueForSingle >= totalInputValue {
+				t.Fatalf("the input value (%d) does not cover the fee (%d) and the pseudonym-privacy outputs (%d)", totalInputValue, fee, outputValueForSingle)
+			}
+
+			txOutputDescs := GenerateOutputWithValues(nil, []uint64{totalInputValue - fee - outputValueForSingle}, SplitNum(outputValueForSingle, tt.outputSingleSize))
+			trTx, err := pp.TransferTxMLPGen(txInputDescs, txOutputDescs, fee, txMemo)
+			if err != nil {
+				t.Fatalf("TransferTxMLPGen: %v", err)
+			}
+
+			serializedTrTx, err := pp.SerializeTransferTxMLP(trTx, true)
+			if err != nil {
+				t.Fatalf("SerializeTransferTxMLP: %v", err)
+			}
+			if len(serializedTrTx) != txSize {
+				t.Fatalf("the estimated size (%d) is different from the actual size (%d)", txSize, len(serializedTrTx))
+			}
+
+			serializedTrTxWithoutWitness, err := pp.SerializeTransferTxMLP(trTx, false)
+			if err != nil {
+				t.Fatalf("SerializeTransferTxMLP: %v", err)
+			}
+			txSizeWithoutWitness, err := pp.TransferTxMLPSerializeSizeByDesc(desc, fee, false)
+			if err != nil {
+				t.Fatalf("TransferTxMLPSerializeSizeByDesc: %v", err)
+			}
+			if len(serializedTrTxWithoutWitness) != txSizeWithoutWitness {
+				t.Fatalf("the estimated size without witness (%d) is different from the actual size (%d)", txSizeWithoutWitness, len(serializedTrTxWithoutWitness))
+			}
+		})
+	}
+
+	//	the per-input policy does not depend on the size
+	desc := &TransferTxMLPSizeDesc{
+		InputRings:             [][]CoinAddressType{{CoinAddressTypePublicKeyForRing, CoinAddressTypePublicKeyForRingPre}},
+		OutputCoinAddressTypes: []CoinAddressType{CoinAddressTypePublicKeyForRing},
+	}
+	fee, _, err := pp.TransferTxMLPFeeEstimate(desc, PerInputFeePolicy{BaseFee: 10, FeePerInput: 5})
+	if err != nil {
+		t.Fatalf("TransferTxMLPFeeEstimate: %v", err)
+	}
+	if fee != 15 {
+		t.Fatalf("the estimated fee (%d) under PerInputFeePolicy is not 15", fee)
+	}
+
+	//	a pseudonym-privacy input must have ring size 1
+	desc.InputRings = [][]CoinAddressType{{CoinAddressTypePublicKeyHashForSingle, CoinAddressTypePublicKeyHashForSingle}}
+	desc.InForSingleDistinct = 1
+	if _, err = pp.TransferTxMLPSerializeSizeByDesc(desc, 0, false); err == nil {
+		t.Fatalf("TransferTxMLPSerializeSizeByDesc accepts a pseudonym-privacy input with ring size 2")
+	}
+}
+
+func TestFeePolicy_Saturating(t *testing.T) {
+	tests := []struct {
+		name      string
+		feePolicy FeePolicy
+		want      uint64
+	}{
+		{"PerByte", PerByteFeePolicy{BaseFee: 10, FeePerByte: 2}, 10 + 2*1000},
+		{"PerByte, the product overflows", PerByteFeePolicy{BaseFee: 10, FeePerByte: math.MaxUint64 / 2}, math.MaxUint64},
+		{"PerByte, the sum overflows", PerByteFeePolicy{BaseFee: math.MaxUint64 - 100, FeePerByte: 1}, math.MaxUint64},
+		{"PerInput", PerInputFeePolicy{BaseFee: 10, FeePerInput: 3}, 10 + 3*4},
+		{"PerInput, the product overflows", PerInputFeePolicy{BaseFee: 10, FeePerInput: math.MaxUint64 / 2}, math.MaxUint64},
+		{"PerInput, the sum overflows", PerInputFeePolicy{BaseFee: math.MaxUint64, FeePerInput: 1}, math.MaxUint64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.feePolicy.Fee(1000, 4); got != tt.want {
+				t.Errorf("Fee() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
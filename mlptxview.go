@@ -0,0 +1,270 @@
+package pqringctx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+//	TransferTxMLP View	begin
+
+// TransferTxMLPView is a read-only view over a serialized TransferTxMLP, which is generated by SerializeTransferTxMLP or WriteTransferTxMLP.
+// NewTransferTxMLPView only walks the var-bytes frames of the serialized TransferTxMLP,
+// so that the txInputs, Txos, and txWitness are not deserialized, and the returned []byte are sub-slices of the serialized TransferTxMLP, rather than copies.
+// This suits the callers that need only the serial numbers, ring member ids, Txos and fee, e.g., the mempool admission and the indexers.
+// NOTE: The caller must not modify the serialized TransferTxMLP, or the []byte returned by the view.
+// NOTE: A TransferTxMLPView is not a validated TransferTxMLP. The caller must still run DeserializeTransferTxMLP and TransferTxMLPVerify before accepting the transaction.
+type TransferTxMLPView struct {
+	txInputs  []*txInputMLPView
+	txos      [][]byte
+	fee       uint64
+	txMemo    []byte
+	txWitness []byte // nil if the view is created with withWitness = false
+}
+
+// txInputMLPView is the view of a serialized TxInputMLP.
+type txInputMLPView struct {
+	lgrTxoList   [][]byte // the serialized LgrTxoMLPs
+	lgrTxoIdSize int      // pp.LgrTxoMLPIdSerializeSize(), the size of the id at the end of each serialized LgrTxoMLP
+	serialNumber []byte
+}
+
+// NewTransferTxMLPView creates a TransferTxMLPView over the input serializedTransferTxMLP,
+// where withWitness shall be the same as that used to serialize the TransferTxMLP.
+// The returned error wraps ErrMalformedEncoding if the frames of serializedTransferTxMLP are malformed.
+func (pp *PublicParameter) NewTransferTxMLPView(serializedTransferTxMLP []byte, withWitness bool) (*TransferTxMLPView, error) {
+	if len(serializedTransferTxMLP) == 0 {
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "NewTransferTxMLPView: the input serializedTransferTxMLP is empty")
+	}
+
+	v := &byteView{b: serializedTransferTxMLP}
+
+	//	txInputs  []*TxInputMLP
+	inputNum, err := ReadVarInt(v)
+	if err != nil {
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
+	}
+	if inputNum > uint64(pp.paramI)+uint64(pp.paramISingle) {
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "NewTransferTxMLPView: the inputNum (%d) exceeds the allowed maximum value (%d)", inputNum, uint64(pp.paramI)+uint64(pp.paramISingle))
+	}
+	txInputs := make([]*txInputMLPView, inputNum)
+	for i := 0; i < int(inputNum); i++ {
+		serializedTxInput, err := v.varBytes(MaxAllowedTxInputMLPSize, "TransferTxMLP.txInputs")
+		if err != nil {
+			return nil, wrapTxError(err, ErrMalformedEncoding, i, -1)
+		}
+		txInputs[i], err = pp.newTxInputMLPView(serializedTxInput)
+		if err != nil {
+			return nil, wrapTxError(err, ErrMalformedEncoding, i, -1)
+		}
+	}
+
+	//	txos      []TxoMLP
+	outputNum, err := ReadVarInt(v)
+	if err != nil {
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
+	}
+	if outputNum > uint64(pp.paramJ)+uint64(pp.paramJSingle) {
+		return nil, newTxError(ErrMalformedEncoding, -1, -1, "NewTransferTxMLPView: the outputNum (%d) exceeds the allowed maximum value (%d)", outputNum, uint64(pp.paramJ)+uint64(pp.paramJSingle))
+	}
+	txos := make([][]byte, outputNum)
+	for i := 0; i < int(outputNum); i++ {
+		txos[i], err = v.varBytes(MaxAllowedTxoMLPSize, "TransferTxMLP.txos")
+		if err != nil {
+			return nil, wrapTxError(err, ErrMalformedEncoding, -1, i)
+		}
+	}
+
+	//	fee       uint64
+	fee, err := binarySerializer.Uint64(v, littleEndian)
+	if err != nil {
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
+	}
+
+	//	txMemo    []byte
+	txMemo, err := v.varBytes(MaxAllowedTxMemoMLPSize, "TransferTxMLP.txMemo")
+	if err != nil {
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
+	}
+
+	//	txWitness *TxWitnessTrTx
+	var txWitness []byte
+	if withWitness {
+		txWitness, err = v.varBytes(MaxAllowedTxWitnessTrTxSize, "TransferTxMLP.txWitness")
+		if err != nil {
+			return nil, wrapTxError(err, ErrMalformedEncoding, -1, -1)
+		}
+		if len(txWitness) == 0 {
+			return nil, newTxError(ErrMalformedEncoding, -1, -1, "NewTransferTxMLPView: withWitness = true while the txWitness is empty")
+		}
+	}
+
+	return &TransferTxMLPView{
+		txInputs:  txInputs,
+		txos:      txos,
+		fee:       fee,
+		txMemo:    txMemo,
+		txWitness: txWitness,
+	}, nil
+}
+
+// newTxInputMLPView creates a txInputMLPView over the input serializedTxInput, which is in the format of serializeTxInputMLP.
+// As deserializeTxInputMLP, the bytes after the serialNumber are ignored.
+func (pp *PublicParameter) newTxInputMLPView(serializedTxInput []byte) (*txInputMLPView, error) {
+	v := &byteView{b: serializedTxInput}
+
+	//	lgrTxoList   []*LgrTxoMLP
+	ringSize, err := binarySerializer.Uint8(v)
+	if err != nil {
+		return nil, err
+	}
+	if ringSize > pp.paramRingSizeMax {
+		return nil, fmt.Errorf("newTxInputMLPView: the ringSize (%d) exceeds the allowed maximum value (%d)", ringSize, pp.paramRingSizeMax)
+	}
+	lgrTxoList := make([][]byte, ringSize)
+	for i := uint8(0); i < ringSize; i++ {
+		lgrTxoList[i], err = v.varBytes(MaxAllowedLgrTxoMLPSize, "TxInputMLP.lgrTxoList[]")
+		if err != nil {
+			return nil, err
+		}
+		//	The id is the last LgrTxoMLPIdSerializeSize bytes, as in DeserializeLgrTxoMLP.
+		if len(lgrTxoList[i]) <= pp.LgrTxoMLPIdSerializeSize() {
+			return nil, fmt.Errorf("newTxInputMLPView: the lgrTxoList[%d] has an incorrect length %d", i, len(lgrTxoList[i]))
+		}
+	}
+
+	//	serialNumber []byte
+	serialNumber, err := v.next(pp.ledgerTxoSerialNumberSerializeSizeMLP())
+	if err != nil {
+		return nil, err
+	}
+
+	return &txInputMLPView{
+		lgrTxoList:   lgrTxoList,
+		lgrTxoIdSize: pp.LgrTxoMLPIdSerializeSize(),
+		serialNumber: serialNumber,
+	}, nil
+}
+
+// GetTxInputNum returns the number of txInputs.
+func (view *TransferTxMLPView) GetTxInputNum() int {
+	return len(view.txInputs)
+}
+
+// GetSerialNumber returns the serialNumber of the i-th txInput, where i must be in [0, GetTxInputNum()).
+func (view *TransferTxMLPView) GetSerialNumber(i int) []byte {
+	return view.txInputs[i].serialNumber
+}
+
+// GetSerialNumbers returns the serialNumbers of the txInputs.
+func (view *TransferTxMLPView) GetSerialNumbers() [][]byte {
+	serialNumbers := make([][]byte, len(view.txInputs))
+	for i, txInput := range view.txInputs {
+		serialNumbers[i] = txInput.serialNumber
+	}
+	return serialNumbers
+}
+
+// GetRingSize returns the ring size of the i-th txInput, where i must be in [0, GetTxInputNum()).
+func (view *TransferTxMLPView) GetRingSize(i int) int {
+	return len(view.txInputs[i].lgrTxoList)
+}
+
+// GetRingMemberIds returns the ids of the ring members (LgrTxoMLPs) of the i-th txInput, where i must be in [0, GetTxInputNum()).
+func (view *TransferTxMLPView) GetRingMemberIds(i int) [][]byte {
+	txInput := view.txInputs[i]
+	ids := make([][]byte, len(txInput.lgrTxoList))
+	for j, serializedLgrTxo := range txInput.lgrTxoList {
+		ids[j] = serializedLgrTxo[len(serializedLgrTxo)-txInput.lgrTxoIdSize:]
+	}
+	return ids
+}
+
+// GetSerializedRingMember returns the serialized LgrTxoMLP of the j-th ring member of the i-th txInput,
+// which can be deserialized by DeserializeLgrTxoMLP.
+func (view *TransferTxMLPView) GetSerializedRingMember(i int, j int) []byte {
+	return view.txInputs[i].lgrTxoList[j]
+}
+
+// GetTxoNum returns the number of Txos.
+func (view *TransferTxMLPView) GetTxoNum() int {
+	return len(view.txos)
+}
+
+// GetSerializedTxo returns the serialized j-th Txo, which can be deserialized by DeserializeTxoMLP, where j must be in [0, GetTxoNum()).
+func (view *TransferTxMLPView) GetSerializedTxo(j int) []byte {
+	return view.txos[j]
+}
+
+// GetFee returns the fee.
+func (view *TransferTxMLPView) GetFee() uint64 {
+	return view.fee
+}
+
+// GetTxMemo returns the txMemo.
+func (view *TransferTxMLPView) GetTxMemo() []byte {
+	return view.txMemo
+}
+
+// GetSerializedTxWitness returns the serialized txWitness, which can be deserialized by DeserializeTxWitnessTrTx,
+// and is nil if the view is created with withWitness = false.
+func (view *TransferTxMLPView) GetSerializedTxWitness() []byte {
+	return view.txWitness
+}
+
+// TxoMLPFromView deserializes the j-th Txo of the input view, where j must be in [0, view.GetTxoNum()).
+func (pp *PublicParameter) TxoMLPFromView(view *TransferTxMLPView, j int) (TxoMLP, error) {
+	txo, err := pp.DeserializeTxoMLP(view.txos[j])
+	if err != nil {
+		return nil, wrapTxError(err, ErrMalformedEncoding, -1, j)
+	}
+	return txo, nil
+}
+
+// byteView is a cursor over a []byte, which returns sub-slices of the []byte, rather than copies.
+// It implements io.Reader, so that ReadVarInt and binarySerializer can be used on it.
+type byteView struct {
+	b   []byte
+	pos int
+}
+
+func (v *byteView) Read(p []byte) (int, error) {
+	if v.pos >= len(v.b) {
+		return 0, io.EOF
+	}
+	n := copy(p, v.b[v.pos:])
+	v.pos += n
+	return n, nil
+}
+
+// next returns the next n bytes.
+func (v *byteView) next(n int) ([]byte, error) {
+	if n > len(v.b)-v.pos {
+		return nil, io.ErrUnexpectedEOF
+	}
+	rst := v.b[v.pos : v.pos+n : v.pos+n]
+	v.pos += n
+	return rst, nil
+}
+
+// varBytes returns the next var-bytes, as readVarBytes.
+func (v *byteView) varBytes(maxAllowed uint32, fieldName string) ([]byte, error) {
+	count, err := ReadVarInt(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, nil
+	}
+
+	if count > uint64(maxAllowed) {
+		str := fmt.Sprintf("%s is larger than the max allowed size "+
+			"[count %d, max %d]", fieldName, count, maxAllowed)
+		return nil, errors.New(str)
+	}
+
+	return v.next(int(count))
+}
+
+//	TransferTxMLP View	end
@@ -0,0 +1,161 @@
+package pqringctx
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestPublicParameter_NewTransferTxMLPView(t *testing.T) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+
+	for _, withWitness := range []bool{true, false} {
+		serialized, err := pp.SerializeTransferTxMLP(trTx, withWitness)
+		if err != nil {
+			t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+		}
+		view, err := pp.NewTransferTxMLPView(serialized, withWitness)
+		if err != nil {
+			t.Fatalf("NewTransferTxMLPView(withWitness = %v) error = %v", withWitness, err)
+		}
+
+		if view.GetTxInputNum() != len(trTx.txInputs) {
+			t.Fatalf("GetTxInputNum() = %d, want %d", view.GetTxInputNum(), len(trTx.txInputs))
+		}
+		serialNumbers := view.GetSerialNumbers()
+		for i, txInput := range trTx.txInputs {
+			if !bytes.Equal(view.GetSerialNumber(i), txInput.serialNumber) || !bytes.Equal(serialNumbers[i], txInput.serialNumber) {
+				t.Errorf("GetSerialNumber(%d) does not match the serialNumber", i)
+			}
+			if view.GetRingSize(i) != len(txInput.lgrTxoList) {
+				t.Fatalf("GetRingSize(%d) = %d, want %d", i, view.GetRingSize(i), len(txInput.lgrTxoList))
+			}
+			ids := view.GetRingMemberIds(i)
+			for j, lgrTxo := range txInput.lgrTxoList {
+				if !bytes.Equal(ids[j], lgrTxo.id) {
+					t.Errorf("GetRingMemberIds(%d)[%d] does not match the id", i, j)
+				}
+				serializedLgrTxo, _ := pp.SerializeLgrTxoMLP(lgrTxo)
+				if !bytes.Equal(view.GetSerializedRingMember(i, j), serializedLgrTxo) {
+					t.Errorf("GetSerializedRingMember(%d, %d) does not match SerializeLgrTxoMLP", i, j)
+				}
+			}
+		}
+
+		if view.GetTxoNum() != len(trTx.txos) {
+			t.Fatalf("GetTxoNum() = %d, want %d", view.GetTxoNum(), len(trTx.txos))
+		}
+		for j, txo := range trTx.txos {
+			serializedTxo, _ := pp.SerializeTxoMLP(txo)
+			if !bytes.Equal(view.GetSerializedTxo(j), serializedTxo) {
+				t.Errorf("GetSerializedTxo(%d) does not match SerializeTxoMLP", j)
+			}
+			decodedTxo, err := pp.TxoMLPFromView(view, j)
+			if err != nil {
+				t.Fatalf("TxoMLPFromView(%d) error = %v", j, err)
+			}
+			if decodedTxo.CoinAddressType() != txo.CoinAddressType() {
+				t.Errorf("TxoMLPFromView(%d) has coinAddressType %d, want %d", j, decodedTxo.CoinAddressType(), txo.CoinAddressType())
+			}
+		}
+
+		if view.GetFee() != fee || !bytes.Equal(view.GetTxMemo(), []byte("memo")) {
+			t.Errorf("GetFee() = %d and GetTxMemo() = %q", view.GetFee(), view.GetTxMemo())
+		}
+
+		if withWitness {
+			serializedWitness, err := pp.SerializeTxWitnessTrTx(trTx.txWitness)
+			if err != nil {
+				t.Fatalf("SerializeTxWitnessTrTx() error = %v", err)
+			}
+			if !bytes.Equal(view.GetSerializedTxWitness(), serializedWitness) {
+				t.Errorf("GetSerializedTxWitness() does not match SerializeTxWitnessTrTx")
+			}
+			//	the view does not copy
+			if &view.GetSerializedTxWitness()[0] != &serialized[len(serialized)-len(serializedWitness)] {
+				t.Errorf("GetSerializedTxWitness() is not a sub-slice of the serialized TransferTxMLP")
+			}
+		} else if view.GetSerializedTxWitness() != nil {
+			t.Errorf("GetSerializedTxWitness() with withWitness = false is not nil")
+		}
+
+		//	truncated input
+		if _, err = pp.NewTransferTxMLPView(serialized[:len(serialized)-1], withWitness); !errors.Is(err, ErrMalformedEncoding) {
+			t.Errorf("NewTransferTxMLPView(withWitness = %v) with a truncated input error = %v, want %v", withWitness, err, ErrMalformedEncoding)
+		}
+	}
+
+	//	a serialized TransferTxMLP without witness is rejected if withWitness is true
+	serialized, err := pp.SerializeTransferTxMLP(trTx, false)
+	if err != nil {
+		t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+	}
+	if _, err = pp.NewTransferTxMLPView(serialized, true); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("NewTransferTxMLPView() without txWitness error = %v, want %v", err, ErrMalformedEncoding)
+	}
+	if _, err = pp.NewTransferTxMLPView(nil, false); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("NewTransferTxMLPView() with an empty input error = %v, want %v", err, ErrMalformedEncoding)
+	}
+}
+
+func TestPublicParameter_NewTransferTxMLPView_RingMembers(t *testing.T) {
+	txInputDescMLPs, txOutputDescMLPs, fee := sampleTransferTxMLPDescs(t)
+	trTx, err := pp.TransferTxMLPGen(txInputDescMLPs, txOutputDescMLPs, fee, []byte("memo"))
+	if err != nil {
+		t.Fatalf("TransferTxMLPGen() error = %v", err)
+	}
+	serialized, err := pp.SerializeTransferTxMLP(trTx, false)
+	if err != nil {
+		t.Fatalf("SerializeTransferTxMLP() error = %v", err)
+	}
+	view, err := pp.NewTransferTxMLPView(serialized, false)
+	if err != nil {
+		t.Fatalf("NewTransferTxMLPView() error = %v", err)
+	}
+
+	//	the ids are the last LgrTxoMLPIdSerializeSize bytes of the ring members, and are sub-slices of the serialized TransferTxMLP
+	for i := 0; i < view.GetTxInputNum(); i++ {
+		ids := view.GetRingMemberIds(i)
+		for j, id := range ids {
+			serializedLgrTxo := view.GetSerializedRingMember(i, j)
+			if len(id) != pp.LgrTxoMLPIdSerializeSize() {
+				t.Fatalf("GetRingMemberIds(%d)[%d] has length %d, want %d", i, j, len(id), pp.LgrTxoMLPIdSerializeSize())
+			}
+			if &id[len(id)-1] != &serializedLgrTxo[len(serializedLgrTxo)-1] {
+				t.Errorf("GetRingMemberIds(%d)[%d] is not a sub-slice of GetSerializedRingMember(%d, %d)", i, j, i, j)
+			}
+			lgrTxo, err := pp.DeserializeLgrTxoMLP(serializedLgrTxo)
+			if err != nil {
+				t.Fatalf("DeserializeLgrTxoMLP() error = %v", err)
+			}
+			if !bytes.Equal(id, lgrTxo.id) {
+				t.Errorf("GetRingMemberIds(%d)[%d] does not match DeserializeLgrTxoMLP", i, j)
+			}
+		}
+	}
+
+	//	a ring member with only the id bytes is rejected in the txInputs, as DeserializeTransferTxMLP does, so the txos etc. are omitted
+	malformedTxInput := &bytes.Buffer{}
+	malformedTxInput.WriteByte(1)
+	if err = writeVarBytes(malformedTxInput, make([]byte, pp.LgrTxoMLPIdSerializeSize())); err != nil {
+		t.Fatalf("writeVarBytes() error = %v", err)
+	}
+	malformedTxInput.Write(make([]byte, pp.ledgerTxoSerialNumberSerializeSizeMLP()))
+	malformed := &bytes.Buffer{}
+	if err = WriteVarInt(malformed, 1); err != nil {
+		t.Fatalf("WriteVarInt() error = %v", err)
+	}
+	if err = writeVarBytes(malformed, malformedTxInput.Bytes()); err != nil {
+		t.Fatalf("writeVarBytes() error = %v", err)
+	}
+	if _, err = pp.NewTransferTxMLPView(malformed.Bytes(), false); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("NewTransferTxMLPView() with a short ring member error = %v, want %v", err, ErrMalformedEncoding)
+	}
+	if _, err = pp.DeserializeTransferTxMLP(malformed.Bytes(), false); !errors.Is(err, ErrMalformedEncoding) {
+		t.Errorf("DeserializeTransferTxMLP() with a short ring member error = %v, want %v", err, ErrMalformedEncoding)
+	}
+}
@@ -141,8 +141,8 @@ func (pp *PublicParameter) TxWitnessCbTxSerializeSize(outForRing uint8) (int, er
 // reviewed by Alice, 2024.07.05
 func (pp *PublicParameter) SerializeTxWitnessCbTx(txWitness *TxWitnessCbTx) (serializedTxWitness []byte, err error) {
 
-	if !pp.TxWitnessCbTxSanityCheck(txWitness) {
-		return nil, fmt.Errorf("SerializeTxWitnessCbTx: the input TxWitnessCbTx is not well-form")
+	if txWitness == nil {
+		return nil, fmt.Errorf("SerializeTxWitnessCbTx: the input TxWitnessCbTx is nil")
 	}
 
 	length, err := pp.TxWitnessCbTxSerializeSize(txWitness.outForRing)
@@ -151,58 +151,61 @@ func (pp *PublicParameter) SerializeTxWitnessCbTx(txWitness *TxWitnessCbTx) (ser
 	}
 
 	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeTxWitnessCbTx(w, txWitness)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeTxWitnessCbTx writes the input TxWitnessCbTx to w, in the format of SerializeTxWitnessCbTx.
+func (pp *PublicParameter) writeTxWitnessCbTx(w io.Writer, txWitness *TxWitnessCbTx) error {
+
+	if !pp.TxWitnessCbTxSanityCheck(txWitness) {
+		return fmt.Errorf("writeTxWitnessCbTx: the input TxWitnessCbTx is not well-form")
+	}
 
 	// txCase       TxWitnessCbTxCase
-	err = w.WriteByte(byte(txWitness.txCase))
+	err := binarySerializer.PutUint8(w, uint8(txWitness.txCase))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// vL           uint64
 	err = binarySerializer.PutUint64(w, littleEndian, txWitness.vL)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// outForRing   uint8
-	err = w.WriteByte(txWitness.outForRing)
+	err = binarySerializer.PutUint8(w, txWitness.outForRing)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// outForSingle uint8
-	err = w.WriteByte(txWitness.outForSingle)
+	err = binarySerializer.PutUint8(w, txWitness.outForSingle)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	balanceProof               BalanceProof
-	serializedBpf, err := pp.serializeBalanceProof(txWitness.balanceProof)
-	if err != nil {
-		return nil, err
-	}
 	// we did not use writeVarBytes(), to avoid define the maxAllowLength used in readVarBytes().
 	// But for safety and robustness, we serialize the length of serializedBpf.
-	bpfLen := len(serializedBpf)
-	err = WriteVarInt(w, uint64(bpfLen))
-	if err != nil {
-		return nil, err
-	}
-	_, err = w.Write(serializedBpf) //	here we use fixed-length, since in the deserialization, we can call pp.balanceProofCbTxSerializeSize() to get the balance proof size.
-	if err != nil {
-		return nil, err
-	}
-
-	// an assert, could be removed when test is finished
+	// Here the length is fixed, since in the deserialization, we can call pp.balanceProofCbTxSerializeSize() to get the balance proof size,
+	// and writeVarBytesFrame asserts that the serializedBpf has exactly this length.
 	serializedBpfExpectedLen, err := pp.balanceProofCbTxSerializeSize(txWitness.outForRing)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if len(serializedBpf) != serializedBpfExpectedLen {
-		return nil, fmt.Errorf("SerializeTxWitnessCbTx: the length of serializedBpfExpectedLen is not the same as expected")
+	err = writeVarBytesFrame(w, serializedBpfExpectedLen, func(w io.Writer) error {
+		return pp.writeBalanceProof(w, txWitness.balanceProof)
+	})
+	if err != nil {
+		return err
 	}
 
-	return w.Bytes(), nil
+	return nil
 }
 
 // DeserializeTxWitnessCbTx deserialize the input []byte to TxWitnessCbTx.
@@ -215,11 +218,28 @@ func (pp *PublicParameter) DeserializeTxWitnessCbTx(serializedTxWitness []byte)
 		return nil, fmt.Errorf("DeserializeTxWitnessCbTx: the input serializedTxWitness is empty")
 	}
 
-	r := bytes.NewReader(serializedTxWitness)
+	return pp.readTxWitnessCbTx(bytes.NewReader(serializedTxWitness))
+}
+
+// WriteTxWitnessCbTx writes the input TxWitnessCbTx to w, in the same format as SerializeTxWitnessCbTx.
+// The writes to w are buffered, and are flushed before WriteTxWitnessCbTx returns.
+func (pp *PublicParameter) WriteTxWitnessCbTx(w io.Writer, txWitness *TxWitnessCbTx) error {
+	return writeBuffered(w, func(w io.Writer) error {
+		return pp.writeTxWitnessCbTx(w, txWitness)
+	})
+}
+
+// ReadTxWitnessCbTx reads a TxWitnessCbTx from r, which was written by WriteTxWitnessCbTx or SerializeTxWitnessCbTx.
+// ReadTxWitnessCbTx does not read beyond the TxWitnessCbTx, so that it issues many small reads, and r shall be buffered, e.g., by bufio.Reader.
+func (pp *PublicParameter) ReadTxWitnessCbTx(r io.Reader) (*TxWitnessCbTx, error) {
+	return pp.readTxWitnessCbTx(r)
+}
 
+// readTxWitnessCbTx reads a TxWitnessCbTx from r, which was written by writeTxWitnessCbTx.
+func (pp *PublicParameter) readTxWitnessCbTx(r io.Reader) (txWitness *TxWitnessCbTx, err error) {
 	// txCase       TxWitnessCbTxCase
 	var txCase byte
-	txCase, err = r.ReadByte()
+	txCase, err = binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
@@ -233,14 +253,14 @@ func (pp *PublicParameter) DeserializeTxWitnessCbTx(serializedTxWitness []byte)
 
 	// outForRing   uint8
 	var outForRing uint8
-	outForRing, err = r.ReadByte()
+	outForRing, err = binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	// outForSingle uint8
 	var outForSingle uint8
-	outForSingle, err = r.ReadByte()
+	outForSingle, err = binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
@@ -257,16 +277,16 @@ func (pp *PublicParameter) DeserializeTxWitnessCbTx(serializedTxWitness []byte)
 	}
 	if uint64(serializedBpfLen) != bpfLen {
 		//	This is to check the length. Actually, we can remove this check, and directly use bpfLen.
-		return nil, fmt.Errorf("DeserializeTxWitnessCbTx: the deserialized bpfLen (%v) does not match with the length (%v) implied by the deserialized outForRing (%d)",
+		return nil, fmt.Errorf("readTxWitnessCbTx: the deserialized bpfLen (%v) does not match with the length (%v) implied by the deserialized outForRing (%d)",
 			bpfLen, serializedBpfLen, outForRing)
 	}
 
-	serializedBpf := make([]byte, serializedBpfLen)
-	_, err = io.ReadFull(r, serializedBpf)
-	if err != nil {
-		return nil, err
-	}
-	balanceProof, err := pp.deserializeBalanceProof(serializedBpf)
+	var balanceProof BalanceProof
+	err = readFixedLength(r, serializedBpfLen, func(r io.Reader) error {
+		var err error
+		balanceProof, err = pp.readBalanceProof(r)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -280,7 +300,7 @@ func (pp *PublicParameter) DeserializeTxWitnessCbTx(serializedTxWitness []byte)
 	}
 
 	if !pp.TxWitnessCbTxSanityCheck(txWitnessCbTx) {
-		return nil, fmt.Errorf("DeserializeTxWitnessCbTx: the deserialzed TxWitnessCbTx is not well-form")
+		return nil, fmt.Errorf("readTxWitnessCbTx: the deserialzed TxWitnessCbTx is not well-form")
 	}
 
 	return txWitnessCbTx, nil
@@ -338,8 +358,8 @@ func (pp *PublicParameter) TxWitnessTrTxSerializeSize(inForRing uint8, inForSing
 // reviewed by Alice, 2024.07.06
 func (pp *PublicParameter) SerializeTxWitnessTrTx(txWitness *TxWitnessTrTx) (serializedTxWitness []byte, err error) {
 
-	if !pp.TxWitnessTrTxSanityCheck(txWitness) {
-		return nil, fmt.Errorf("SerializeTxWitnessTrTx: the input txWitness *TxWitnessTrTx is not well-form")
+	if txWitness == nil {
+		return nil, fmt.Errorf("SerializeTxWitnessTrTx: the input txWitness *TxWitnessTrTx is nil")
 	}
 
 	length, err := pp.TxWitnessTrTxSerializeSize(txWitness.inForRing, txWitness.inForSingleDistinct, txWitness.outForRing, txWitness.inRingSizes, txWitness.vPublic)
@@ -348,137 +368,127 @@ func (pp *PublicParameter) SerializeTxWitnessTrTx(txWitness *TxWitnessTrTx) (ser
 	}
 
 	w := bytes.NewBuffer(make([]byte, 0, length))
+	err = pp.writeTxWitnessTrTx(w, txWitness)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeTxWitnessTrTx writes the input TxWitnessTrTx to w, in the format of SerializeTxWitnessTrTx.
+func (pp *PublicParameter) writeTxWitnessTrTx(w io.Writer, txWitness *TxWitnessTrTx) error {
+
+	if !pp.TxWitnessTrTxSanityCheck(txWitness) {
+		return fmt.Errorf("writeTxWitnessTrTx: the input txWitness *TxWitnessTrTx is not well-form")
+	}
+
+	var err error
 
 	//	txCase                     TxWitnessTrTxCase
-	err = w.WriteByte(byte(txWitness.txCase))
+	err = binarySerializer.PutUint8(w, uint8(txWitness.txCase))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	inForRing                  uint8
-	err = w.WriteByte(txWitness.inForRing)
+	err = binarySerializer.PutUint8(w, txWitness.inForRing)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	inForSingle                uint8
-	err = w.WriteByte(txWitness.inForSingle)
+	err = binarySerializer.PutUint8(w, txWitness.inForSingle)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	inForSingleDistinct        uint8
-	err = w.WriteByte(txWitness.inForSingleDistinct)
+	err = binarySerializer.PutUint8(w, txWitness.inForSingleDistinct)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	inRingSizes                []uint8
-	for i := uint8(0); i < txWitness.inForRing; i++ {
-		err = w.WriteByte(txWitness.inRingSizes[i])
-		if err != nil {
-			return nil, err
-		}
+	_, err = w.Write(txWitness.inRingSizes[:txWitness.inForRing])
+	if err != nil {
+		return err
 	}
 
 	//	outForRing                 uint8
-	err = w.WriteByte(txWitness.outForRing)
+	err = binarySerializer.PutUint8(w, txWitness.outForRing)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	outForSingle               uint8
-	err = w.WriteByte(txWitness.outForSingle)
+	err = binarySerializer.PutUint8(w, txWitness.outForSingle)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	vPublic                    int64
 	err = binarySerializer.PutUint64(w, littleEndian, uint64(txWitness.vPublic))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	//	ma_ps                      []*PolyANTT
 	for i := uint8(0); i < txWitness.inForRing; i++ {
 		err = pp.writePolyANTT(w, txWitness.ma_ps[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//	cmts_in_p                  []*ValueCommitment
 	for i := uint8(0); i < txWitness.inForRing; i++ {
-		serializedCmt, err := pp.SerializeValueCommitment(txWitness.cmts_in_p[i])
+		err = pp.writeValueCommitment(w, txWitness.cmts_in_p[i])
 		if err != nil {
-			return nil, err
-		}
-		_, err = w.Write(serializedCmt)
-		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//	elrSigs                    []*ElrSignatureMLP
 	for i := uint8(0); i < txWitness.inForRing; i++ {
-		serializedElrSig, err := pp.serializeElrSignatureMLP(txWitness.elrSigs[i])
+		err = pp.writeElrSignatureMLP(w, txWitness.elrSigs[i])
 		if err != nil {
-			return nil, err
-		}
-		_, err = w.Write(serializedElrSig)
-		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//	addressPublicKeyForSingles []*AddressPublicKeyForSingle
 	for i := uint8(0); i < txWitness.inForSingleDistinct; i++ {
-		serializedApk, err := pp.serializeAddressPublicKeyForSingle(txWitness.addressPublicKeyForSingles[i])
-		if err != nil {
-			return nil, err
-		}
-		_, err = w.Write(serializedApk)
+		err = pp.writeAddressPublicKeyForSingle(w, txWitness.addressPublicKeyForSingles[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//	simpleSigs                 []*SimpleSignatureMLP
 	for i := uint8(0); i < txWitness.inForSingleDistinct; i++ {
-		serializedSimpleSig, err := pp.serializeSimpleSignature(txWitness.simpleSigs[i])
+		err = pp.writeSimpleSignature(w, txWitness.simpleSigs[i])
 		if err != nil {
-			return nil, err
-		}
-		_, err = w.Write(serializedSimpleSig)
-		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	//	balanceProof               BalanceProof
-	serializedBpf, err := pp.serializeBalanceProof(txWitness.balanceProof)
-	if err != nil {
-		return nil, err
-	}
 	// we did not use writeVarBytes(), to avoid define the maxAllowedLength used in readVarBytes().
 	// But for safety and robustness, we serialize the length of serializedBpf.
-	bpfLen := len(serializedBpf)
-	err = WriteVarInt(w, uint64(bpfLen))
+	// Here the length is fixed, since in the deserialization, we can call pp.balanceProofTrTxSerializeSize() to get the balance proof size,
+	// and writeVarBytesFrame asserts that the serializedBpf has exactly this length.
+	serializedBpfExpectedLen, err := pp.balanceProofTrTxSerializeSize(txWitness.inForRing, txWitness.outForRing, txWitness.vPublic)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	_, err = w.Write(serializedBpf) //	here we use fixed-length, since in the deserialization, we can call pp.balanceProofTrTxSerializeSize() to get the balance proof size.
+	err = writeVarBytesFrame(w, serializedBpfExpectedLen, func(w io.Writer) error {
+		return pp.writeBalanceProof(w, txWitness.balanceProof)
+	})
 	if err != nil {
-		return nil, err
-	}
-
-	// an assert, could be removed when test is finished
-	serializedBpfExpectedLen, err := pp.balanceProofTrTxSerializeSize(txWitness.inForRing, txWitness.outForRing, txWitness.vPublic)
-	if len(serializedBpf) != serializedBpfExpectedLen {
-		return nil, fmt.Errorf("SerializeTxWitnessTrTx: the length of serializedBpfExpectedLen is not the same as expected")
+		return err
 	}
 
-	return w.Bytes(), err
+	return nil
 }
 
 // DeserializeTxWitnessTrTx deserialize the input []byte to TxWitnessTrTx.
@@ -490,49 +500,67 @@ func (pp *PublicParameter) DeserializeTxWitnessTrTx(serializedTxWitness []byte)
 		return nil, fmt.Errorf("DeserializeTxWitnessTrTx: the input serializedTxWitness is empty")
 	}
 
-	r := bytes.NewReader(serializedTxWitness)
+	return pp.readTxWitnessTrTx(bytes.NewReader(serializedTxWitness))
+}
+
+// WriteTxWitnessTrTx writes the input TxWitnessTrTx to w, in the same format as SerializeTxWitnessTrTx,
+// without materializing the serialized TxWitnessTrTx or its components as []byte.
+// The writes to w are buffered, and are flushed before WriteTxWitnessTrTx returns.
+func (pp *PublicParameter) WriteTxWitnessTrTx(w io.Writer, txWitness *TxWitnessTrTx) error {
+	return writeBuffered(w, func(w io.Writer) error {
+		return pp.writeTxWitnessTrTx(w, txWitness)
+	})
+}
+
+// ReadTxWitnessTrTx reads a TxWitnessTrTx from r, which was written by WriteTxWitnessTrTx or SerializeTxWitnessTrTx,
+// without materializing the serialized TxWitnessTrTx or its components as []byte.
+// ReadTxWitnessTrTx does not read beyond the TxWitnessTrTx, so that it issues many small reads, and r shall be buffered, e.g., by bufio.Reader.
+func (pp *PublicParameter) ReadTxWitnessTrTx(r io.Reader) (*TxWitnessTrTx, error) {
+	return pp.readTxWitnessTrTx(r)
+}
+
+// readTxWitnessTrTx reads a TxWitnessTrTx from r, which was written by writeTxWitnessTrTx.
+func (pp *PublicParameter) readTxWitnessTrTx(r io.Reader) (*TxWitnessTrTx, error) {
 
 	// txCase       TxWitnessCbTxCase
-	txCase, err := r.ReadByte()
+	txCase, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	//	inForRing                  uint8
-	inForRing, err := r.ReadByte()
+	inForRing, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	//	inForSingle                uint8
-	inForSingle, err := r.ReadByte()
+	inForSingle, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	//	inForSingleDistinct        uint8
-	inForSingleDistinct, err := r.ReadByte()
+	inForSingleDistinct, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	//	inRingSizes                []uint8
 	inRingSizes := make([]uint8, inForRing)
-	for i := uint8(0); i < inForRing; i++ {
-		inRingSizes[i], err = r.ReadByte()
-		if err != nil {
-			return nil, err
-		}
+	_, err = io.ReadFull(r, inRingSizes)
+	if err != nil {
+		return nil, err
 	}
 
 	//	outForRing                 uint8
-	outForRing, err := r.ReadByte()
+	outForRing, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
 
 	//	outForSingle               uint8
-	outForSingle, err := r.ReadByte()
+	outForSingle, err := binarySerializer.Uint8(r)
 	if err != nil {
 		return nil, err
 	}
@@ -555,27 +583,22 @@ func (pp *PublicParameter) DeserializeTxWitnessTrTx(serializedTxWitness []byte)
 
 	//	cmts_in_p                  []*ValueCommitment
 	cmts_in_p := make([]*ValueCommitment, inForRing)
-	serializedCmt := make([]byte, pp.ValueCommitmentSerializeSize())
 	for i := uint8(0); i < inForRing; i++ {
-		_, err = io.ReadFull(r, serializedCmt)
-		if err != nil {
-			return nil, err
-		}
-		cmts_in_p[i], err = pp.DeserializeValueCommitment(serializedCmt)
+		cmts_in_p[i], err = pp.readValueCommitment(r)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	//	elrSigs                    []*ElrSignatureMLP
+	//	Each elrSig has the size implied by inRingSizes[i], no matter what its own ringSize is.
 	elrSigs := make([]*ElrSignatureMLP, inForRing)
 	for i := uint8(0); i < inForRing; i++ {
-		serializedElrSig := make([]byte, pp.elrSignatureMLPSerializeSize(inRingSizes[i]))
-		_, err = io.ReadFull(r, serializedElrSig)
-		if err != nil {
-			return nil, err
-		}
-		elrSigs[i], err = pp.deserializeElrSignatureMLP(serializedElrSig)
+		err = readFixedLength(r, pp.elrSignatureMLPSerializeSize(inRingSizes[i]), func(r io.Reader) error {
+			var err error
+			elrSigs[i], err = pp.readElrSignatureMLP(r)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -583,13 +606,8 @@ func (pp *PublicParameter) DeserializeTxWitnessTrTx(serializedTxWitness []byte)
 
 	//	addressPublicKeyForSingles []*AddressPublicKeyForSingle
 	addressPublicKeyForSingles := make([]*AddressPublicKeyForSingle, inForSingleDistinct)
-	serializedApk := make([]byte, pp.addressPublicKeyForSingleSerializeSize())
 	for i := uint8(0); i < inForSingleDistinct; i++ {
-		_, err = io.ReadFull(r, serializedApk)
-		if err != nil {
-			return nil, err
-		}
-		addressPublicKeyForSingles[i], err = pp.deserializeAddressPublicKeyForSingle(serializedApk)
+		addressPublicKeyForSingles[i], err = pp.readAddressPublicKeyForSingle(r)
 		if err != nil {
 			return nil, err
 		}
@@ -597,13 +615,12 @@ func (pp *PublicParameter) DeserializeTxWitnessTrTx(serializedTxWitness []byte)
 
 	//	simpleSigs                 []*SimpleSignatureMLP
 	simpleSigs := make([]*SimpleSignatureMLP, inForSingleDistinct)
-	serializedSimpleSig := make([]byte, pp.simpleSignatureSerializeSize())
 	for i := uint8(0); i < inForSingleDistinct; i++ {
-		_, err = io.ReadFull(r, serializedSimpleSig)
-		if err != nil {
-			return nil, err
-		}
-		simpleSigs[i], err = pp.deserializeSimpleSignature(serializedSimpleSig)
+		err = readFixedLength(r, pp.simpleSignatureSerializeSize(), func(r io.Reader) error {
+			var err error
+			simpleSigs[i], err = pp.readSimpleSignature(r)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -620,16 +637,16 @@ func (pp *PublicParameter) DeserializeTxWitnessTrTx(serializedTxWitness []byte)
 	}
 	if uint64(serializedBpfLen) != bpfLen {
 		//	This is to check the length. Actually, we can remove this check, and directly use bpfLen.
-		return nil, fmt.Errorf("DeserializeTxWitnessTrTx: the deserialized bpfLen (%v) does not match with the length (%v) implied by the deserialized (inForRing, outForRing, vPublic) (%d, %d, %v)",
+		return nil, fmt.Errorf("readTxWitnessTrTx: the deserialized bpfLen (%v) does not match with the length (%v) implied by the deserialized (inForRing, outForRing, vPublic) (%d, %d, %v)",
 			bpfLen, serializedBpfLen, inForRing, outForRing, vPublic)
 	}
 
-	serializedBpf := make([]byte, serializedBpfLen)
-	_, err = io.ReadFull(r, serializedBpf)
-	if err != nil {
-		return nil, err
-	}
-	balanceProof, err := pp.deserializeBalanceProof(serializedBpf)
+	var balanceProof BalanceProof
+	err = readFixedLength(r, serializedBpfLen, func(r io.Reader) error {
+		var err error
+		balanceProof, err = pp.readBalanceProof(r)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -652,7 +669,7 @@ func (pp *PublicParameter) DeserializeTxWitnessTrTx(serializedTxWitness []byte)
 	}
 
 	if !pp.TxWitnessTrTxSanityCheck(txWitnessTrTx) {
-		return nil, fmt.Errorf("DeserializeTxWitnessTrTx: the deserialzied TxWitnessTrTx is not well-form")
+		return nil, fmt.Errorf("readTxWitnessTrTx: the deserialzied TxWitnessTrTx is not well-form")
 	}
 
 	return txWitnessTrTx, nil
@@ -0,0 +1,312 @@
+package pqringctx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+//	ViewKey	begin
+
+// MaxAllowedViewKeyCoinAddressNum is the allowed maximum number of coinAddresses in a ViewKey.
+const MaxAllowedViewKeyCoinAddressNum = 65536 // 2^16
+
+// ViewKey packages the view authority of a wallet (account) as a standalone credential,
+// which enables the holder to detect the owned Txos and decrypt their values, but not to spend them.
+// It consists of
+// (1) coinDetectorKey, which detects the coinAddresses (with detector tag) of the wallet,
+// (2) (coinValuePublicKey, coinValueSecretKey), which decrypts the values in the RingCT-privacy Txos of the wallet, and
+// (3) coinAddresses, which are matched by exact coinAddress, e.g., the CoinAddressTypePublicKeyForRingPre coinAddresses that do not have detector tag.
+// Note that, when derived by ViewKeyDerive, none of the components is derived from (or can be used to derive) coinSpendSecretKey or coinSerialNumberSecretKey:
+// coinAddresses are public, the coinDetectorKey is a MAC key independent of the address keys,
+// and (coinValuePublicKey, coinValueSecretKey) is a standalone KEM key pair,
+// since ViewKeyDerive derives them by labels different from the ones of coinSpendKeyRandSeed and coinSerialNumberKeyRandSeed.
+// For a ViewKey constructed by NewViewKey or DeserializeViewKey, this is up to the creator of the components (see viewKeyCheck).
+//
+// A ViewKey is checked once when it is constructed, and it keeps the Scanner configured by it,
+// so that ViewKeyTxoCoinReceive does not re-check the ViewKey on each call.
+// Hence, a ViewKey must be constructed by NewViewKey, ViewKeyDerive, or DeserializeViewKey.
+type ViewKey struct {
+	coinDetectorKey    []byte
+	coinValuePublicKey []byte
+	coinValueSecretKey []byte
+	coinAddresses      [][]byte
+
+	//	scanner is the Scanner configured by the ViewKey, which is set only after the ViewKey is checked.
+	scanner *Scanner
+}
+
+// NewViewKey constructs a ViewKey from the input components, and checks them.
+// The input coinAddresses could be empty, e.g., when all the coinAddresses are detected by the coinDetectorKey.
+func (pp *PublicParameter) NewViewKey(coinDetectorKey []byte, coinValuePublicKey []byte, coinValueSecretKey []byte, coinAddresses [][]byte) (*ViewKey, error) {
+	viewKey := &ViewKey{
+		coinDetectorKey:    coinDetectorKey,
+		coinValuePublicKey: coinValuePublicKey,
+		coinValueSecretKey: coinValueSecretKey,
+		coinAddresses:      coinAddresses,
+	}
+	if err := pp.viewKeyCheck(viewKey); err != nil {
+		return nil, err
+	}
+	viewKey.scanner = pp.NewScanner()
+	if err := viewKey.scanner.addCheckedViewKey(viewKey); err != nil {
+		return nil, err
+	}
+	return viewKey, nil
+}
+
+// ViewKeyDerive derives the ViewKey of the input account from the input masterSeed,
+// which detects and decrypts all the coins on the coinAddresses derived by CoinAddressKeyForPKRingDerive and CoinAddressKeyForPKHSingleDerive for the account.
+func (pp *PublicParameter) ViewKeyDerive(masterSeed []byte, account uint32) (*ViewKey, error) {
+	coinDetectorKey, err := pp.CoinDetectorKeyDerive(masterSeed, account)
+	if err != nil {
+		return nil, err
+	}
+	coinValuePublicKey, coinValueSecretKey, err := pp.CoinValueKeyDerive(masterSeed, account)
+	if err != nil {
+		return nil, err
+	}
+	return pp.NewViewKey(coinDetectorKey, coinValuePublicKey, coinValueSecretKey, nil)
+}
+
+// CoinDetectorKey returns the coinDetectorKey of the ViewKey.
+func (viewKey *ViewKey) CoinDetectorKey() []byte {
+	return viewKey.coinDetectorKey
+}
+
+// CoinValuePublicKey returns the coinValuePublicKey of the ViewKey.
+func (viewKey *ViewKey) CoinValuePublicKey() []byte {
+	return viewKey.coinValuePublicKey
+}
+
+// CoinValueSecretKey returns the coinValueSecretKey of the ViewKey.
+func (viewKey *ViewKey) CoinValueSecretKey() []byte {
+	return viewKey.coinValueSecretKey
+}
+
+// CoinAddresses returns the coinAddresses of the ViewKey.
+func (viewKey *ViewKey) CoinAddresses() [][]byte {
+	return viewKey.coinAddresses
+}
+
+// viewKeyCheck checks whether the input ViewKey is well-form, namely,
+// the coinDetectorKey has the length of a MAC key, (coinValuePublicKey, coinValueSecretKey) is a valid KEM key pair,
+// and each coinAddress has the length of a coinAddress of some CoinAddressType.
+// Note that these are only checks of the lengths (and of the KEM key pair):
+// viewKeyCheck does not, and cannot, tell whether the coinDetectorKey or a coinAddress is actually some other secret,
+// e.g., a coinSpendSecretKey or coinSerialNumberSecretKey with a matching length.
+// What rules out the spend authority is that the ViewKey has no component for it, and ViewKeyDerive never puts it in.
+func (pp *PublicParameter) viewKeyCheck(viewKey *ViewKey) error {
+	if viewKey == nil {
+		return fmt.Errorf("viewKeyCheck: the input ViewKey is nil")
+	}
+
+	if len(viewKey.coinDetectorKey) != pp.GetParamMACKeyBytesLen() {
+		return fmt.Errorf("viewKeyCheck: the coinDetectorKey's length (%d) is incorrect", len(viewKey.coinDetectorKey))
+	}
+
+	if len(viewKey.coinValuePublicKey) != pp.GetCoinValuePublicKeySize() {
+		return fmt.Errorf("viewKeyCheck: the coinValuePublicKey's length (%d) is incorrect", len(viewKey.coinValuePublicKey))
+	}
+	if len(viewKey.coinValueSecretKey) != pp.GetCoinValueSecretKeySize() {
+		return fmt.Errorf("viewKeyCheck: the coinValueSecretKey's length (%d) is incorrect", len(viewKey.coinValueSecretKey))
+	}
+	if valid, hints := pp.CoinValueKeyVerify(viewKey.coinValuePublicKey, viewKey.coinValueSecretKey); !valid {
+		return fmt.Errorf("viewKeyCheck: the (coinValuePublicKey, coinValueSecretKey) pair is invalid: %s", hints)
+	}
+
+	if len(viewKey.coinAddresses) > MaxAllowedViewKeyCoinAddressNum {
+		return fmt.Errorf("viewKeyCheck: the number of coinAddresses (%d) exceeds the allowed maximum value (%d)", len(viewKey.coinAddresses), MaxAllowedViewKeyCoinAddressNum)
+	}
+	for i, coinAddress := range viewKey.coinAddresses {
+		//	ExtractCoinAddressTypeFromCoinAddress identifies the coinAddressType only by the exact length.
+		if _, err := pp.ExtractCoinAddressTypeFromCoinAddress(coinAddress); err != nil {
+			return fmt.Errorf("viewKeyCheck: the %d -th coinAddress is not well-form: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ViewKeySerializeSize returns the serialize size of the input ViewKey.
+func (pp *PublicParameter) ViewKeySerializeSize(viewKey *ViewKey) int {
+	length := len(viewKey.coinDetectorKey) + len(viewKey.coinValuePublicKey) + len(viewKey.coinValueSecretKey) +
+		VarIntSerializeSize(uint64(len(viewKey.coinAddresses)))
+	for _, coinAddress := range viewKey.coinAddresses {
+		length = length + VarIntSerializeSize(uint64(len(coinAddress))) + len(coinAddress)
+	}
+	return length
+}
+
+// checkedViewKey checks that the input ViewKey has been checked by viewKeyCheck, i.e., it is constructed by NewViewKey, with the input pp.
+func (pp *PublicParameter) checkedViewKey(viewKey *ViewKey) error {
+	if viewKey == nil {
+		return fmt.Errorf("the input ViewKey is nil")
+	}
+	if viewKey.scanner == nil {
+		return fmt.Errorf("the input ViewKey is not constructed by NewViewKey, ViewKeyDerive, or DeserializeViewKey")
+	}
+	if viewKey.scanner.pp != pp {
+		return fmt.Errorf("the input ViewKey is constructed with a different PublicParameter")
+	}
+	return nil
+}
+
+// SerializeViewKey serializes the input ViewKey to []byte.
+func (pp *PublicParameter) SerializeViewKey(viewKey *ViewKey) ([]byte, error) {
+	if err := pp.checkedViewKey(viewKey); err != nil {
+		return nil, fmt.Errorf("SerializeViewKey: %v", err)
+	}
+
+	w := bytes.NewBuffer(make([]byte, 0, pp.ViewKeySerializeSize(viewKey)))
+
+	//	coinDetectorKey: fixed-length
+	_, err := w.Write(viewKey.coinDetectorKey)
+	if err != nil {
+		return nil, err
+	}
+
+	//	coinValuePublicKey: fixed-length
+	_, err = w.Write(viewKey.coinValuePublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	//	coinValueSecretKey: fixed-length
+	_, err = w.Write(viewKey.coinValueSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	//	coinAddresses: var-length
+	err = WriteVarInt(w, uint64(len(viewKey.coinAddresses)))
+	if err != nil {
+		return nil, err
+	}
+	for _, coinAddress := range viewKey.coinAddresses {
+		err = writeVarBytes(w, coinAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return w.Bytes(), nil
+}
+
+// DeserializeViewKey deserializes the input []byte to a ViewKey, and checks it.
+// The input must be consumed exactly.
+func (pp *PublicParameter) DeserializeViewKey(serializedViewKey []byte) (*ViewKey, error) {
+	r := bytes.NewReader(serializedViewKey)
+
+	//	coinDetectorKey: fixed-length
+	coinDetectorKey := make([]byte, pp.GetParamMACKeyBytesLen())
+	_, err := io.ReadFull(r, coinDetectorKey)
+	if err != nil {
+		return nil, err
+	}
+
+	//	coinValuePublicKey: fixed-length
+	coinValuePublicKey := make([]byte, pp.GetCoinValuePublicKeySize())
+	_, err = io.ReadFull(r, coinValuePublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	//	coinValueSecretKey: fixed-length
+	coinValueSecretKey := make([]byte, pp.GetCoinValueSecretKeySize())
+	_, err = io.ReadFull(r, coinValueSecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	//	coinAddresses: var-length
+	coinAddressNum, err := ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	if coinAddressNum > MaxAllowedViewKeyCoinAddressNum {
+		return nil, fmt.Errorf("DeserializeViewKey: the number of coinAddresses (%d) exceeds the allowed maximum value (%d)", coinAddressNum, MaxAllowedViewKeyCoinAddressNum)
+	}
+	var coinAddresses [][]byte
+	if coinAddressNum > 0 {
+		coinAddresses = make([][]byte, coinAddressNum)
+		for i := 0; i < int(coinAddressNum); i++ {
+			coinAddresses[i], err = readVarBytes(r, MaxAllowedTxoMLPSize, "ViewKey.coinAddresses")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("DeserializeViewKey: the input serializedViewKey has %d redundant bytes", r.Len())
+	}
+
+	return pp.NewViewKey(coinDetectorKey, coinValuePublicKey, coinValueSecretKey, coinAddresses)
+}
+
+// ViewKeyTxoCoinReceive checks whether the input txoMLP is viewed by the input ViewKey, and if true,
+// it extracts the value from txoMLP.
+// It uses the Scanner kept by the ViewKey, so the ViewKey is not re-checked.
+// Note that the coinAddresses in the ViewKey are assumed to use the (coinValuePublicKey, coinValueSecretKey) of the ViewKey.
+func (pp *PublicParameter) ViewKeyTxoCoinReceive(viewKey *ViewKey, txoMLP TxoMLP) (valid bool, value uint64, err error) {
+	if err = pp.checkedViewKey(viewKey); err != nil {
+		return false, 0, fmt.Errorf("ViewKeyTxoCoinReceive: %v", err)
+	}
+	if txoMLP == nil {
+		return false, 0, fmt.Errorf("ViewKeyTxoCoinReceive: the input txoMLP is nil")
+	}
+
+	owned, _, value, err := viewKey.scanner.scanTxo(txoMLP)
+	if err != nil {
+		return false, 0, fmt.Errorf("ViewKeyTxoCoinReceive: %w", err)
+	}
+	return owned, value, nil
+}
+
+// AddViewKey adds the coinDetectorKey and the coinAddresses of the input ViewKey to the Scanner.
+// The ViewKey is not re-checked, since it has been checked when constructed.
+func (s *Scanner) AddViewKey(viewKey *ViewKey) error {
+	if err := s.pp.checkedViewKey(viewKey); err != nil {
+		return fmt.Errorf("Scanner.AddViewKey: %v", err)
+	}
+	return s.addCheckedViewKey(viewKey)
+}
+
+// addCheckedViewKey adds the coinDetectorKey and the coinAddresses of the input ViewKey, which has been checked by viewKeyCheck,
+// without re-checking the (coinValuePublicKey, coinValueSecretKey) pair as AddCoinDetectorKey and AddCoinAddress do.
+func (s *Scanner) addCheckedViewKey(viewKey *ViewKey) error {
+	coinValueKey := &scannerCoinValueKey{
+		coinValuePublicKey: viewKey.coinValuePublicKey,
+		coinValueSecretKey: viewKey.coinValueSecretKey,
+	}
+	s.coinDetectorKeys = append(s.coinDetectorKeys, &scannerCoinDetectorKey{
+		coinDetectorKey: viewKey.coinDetectorKey,
+		coinValueKey:    coinValueKey,
+	})
+	for _, coinAddress := range viewKey.coinAddresses {
+		coinAddressType, err := s.pp.ExtractCoinAddressTypeFromCoinAddress(coinAddress)
+		if err != nil {
+			return err
+		}
+		if coinAddressType == CoinAddressTypePublicKeyHashForSingle {
+			s.coinAddresses[string(coinAddress)] = nil
+		} else {
+			s.coinAddresses[string(coinAddress)] = coinValueKey
+		}
+	}
+	return nil
+}
+
+// NewScannerWithViewKey creates a Scanner configured by the input ViewKey,
+// which scans the history of the wallet with only the view authority.
+// The returned Scanner is a new one, which the caller could configure further.
+func (pp *PublicParameter) NewScannerWithViewKey(viewKey *ViewKey) (*Scanner, error) {
+	scanner := pp.NewScanner()
+	if err := scanner.AddViewKey(viewKey); err != nil {
+		return nil, err
+	}
+	return scanner, nil
+}
+
+//	ViewKey	end
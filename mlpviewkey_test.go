@@ -0,0 +1,107 @@
+package pqringctx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestViewKey(t *testing.T) {
+	pp := initializeForTest()
+
+	masterSeed := RandomBytes(64)
+	viewKey, err := pp.ViewKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coinAddressRing, coinSpendSecretKeyRing, coinSerialNumberSecretKeyRing, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddressSingle, coinSpendSecretKeySingle, err := pp.CoinAddressKeyForPKHSingleDerive(masterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCoinAddressRing, _, _, err := pp.CoinAddressKeyForPKRingDerive(masterSeed, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	viewKey, err = pp.NewViewKey(viewKey.CoinDetectorKey(), viewKey.CoinValuePublicKey(), viewKey.CoinValueSecretKey(), [][]byte{coinAddressRing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	serializedViewKey, err := pp.SerializeViewKey(viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(serializedViewKey) != pp.ViewKeySerializeSize(viewKey) {
+		t.Fatalf("the serialized ViewKey has length %d, rather than %d", len(serializedViewKey), pp.ViewKeySerializeSize(viewKey))
+	}
+	viewKey, err = pp.DeserializeViewKey(serializedViewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pp.DeserializeViewKey(append(serializedViewKey, 0)); err == nil {
+		t.Fatalf("a serializedViewKey with redundant bytes is accepted")
+	}
+
+	//	the ViewKey does not contain the spend authority
+	coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, _, _, err := pp.CoinAddressKeyForPKRingRandSeedsDerive(masterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range [][]byte{coinSpendSecretKeyRing[1:], coinSerialNumberSecretKeyRing[1:], coinSpendSecretKeySingle[1:], coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed} {
+		if bytes.Contains(serializedViewKey, secret) {
+			t.Fatalf("the serialized ViewKey contains the spend authority")
+		}
+	}
+
+	//	the ViewKey views the coins of the account
+	coinValuePublicKey, _, err := pp.CoinValueKeyDerive(masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCoinValuePublicKey, _, err := pp.CoinValueKeyDerive(masterSeed, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txOutputDescs := []*TxOutputDescMLP{
+		NewTxOutputDescMLP(coinAddressRing, coinValuePublicKey, 10),
+		NewTxOutputDescMLP(otherCoinAddressRing, otherCoinValuePublicKey, 20),
+		NewTxOutputDescMLP(coinAddressSingle, nil, 30),
+	}
+	cbTx, err := pp.CoinbaseTxMLPGen(60, txOutputDescs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := pp.NewScannerWithViewKey(viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownedTxos, errs := scanner.ScanCoinbaseTxs([]*CoinbaseTxMLP{cbTx})
+	checkScanErrors(t, errs, 1)
+	if len(ownedTxos) != 2 || ownedTxos[0].Value != 10 || ownedTxos[1].Value != 30 {
+		t.Fatalf("the owned txos found by the ViewKey are not as expected")
+	}
+
+	expected := []struct {
+		valid bool
+		value uint64
+	}{{true, 10}, {false, 0}, {true, 30}}
+	for i, txo := range cbTx.txos {
+		valid, value, err := pp.ViewKeyTxoCoinReceive(viewKey, txo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid != expected[i].valid || value != expected[i].value {
+			t.Fatalf("ViewKeyTxoCoinReceive on the %d -th txo returns (%v, %d)", i, valid, value)
+		}
+	}
+
+	//	a ViewKey not constructed by NewViewKey has not been checked, and is rejected
+	if _, _, err = pp.ViewKeyTxoCoinReceive(&ViewKey{coinDetectorKey: viewKey.coinDetectorKey}, cbTx.txos[0]); err == nil {
+		t.Fatalf("an unchecked ViewKey is accepted")
+	}
+}
@@ -1,8 +1,11 @@
 package pqringctx
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"io"
 	"log"
 	"math/big"
 )
@@ -135,6 +138,12 @@ func NewPublicParameter(
 		res.paramZetasA[i] = reduceInt64(curr.Int64(), res.paramQA)
 	}
 
+	res.paramQAModulus = newCTModulus(res.paramQA)
+	res.paramQCModulus = newCTModulus(res.paramQC)
+	res.paramNTTATable = newNTTTable(res.paramQAModulus, res.paramDA, res.paramZetasA, res.paramZetaAOrder, res.paramNTTAFactors, false)
+	res.paramNTTCTable = newNTTTable(res.paramQCModulus, res.paramDC, res.paramZetasC, res.paramZetaCOrder, res.paramNTTCFactors, true)
+	res.scratch = newPolyScratch(res.paramDC)
+
 	seed, err := Hash(res.paramParameterSeedString)
 	if err != nil {
 		return nil, err
@@ -304,6 +313,14 @@ type PublicParameter struct {
 	paramZetaCOrder  int
 	paramNTTCFactors []int
 
+	// paramQAModulus and paramQCModulus implement the constant-time arithmetic modulo q_a and q_c, respectively,
+	// which is applied to the polynomials that may be derived from secrets.
+	paramQAModulus *ctModulus
+	paramQCModulus *ctModulus
+	// paramNTTATable and paramNTTCTable are the precomputed twiddles for the NTT over R_{q_a} and R_{q_c}, respectively.
+	paramNTTATable *nttTable
+	paramNTTCTable *nttTable
+
 	// paramSigmaPermutations is determined by (d_c,k) and the selection of sigma
 	// paramSigmaPermutations [t] with t=0~(k-1) works for sigma^t
 	paramSigmaPermutations [][]int
@@ -368,6 +385,101 @@ type PublicParameter struct {
 
 	// paramKem defines the key encapsulate mechanism
 	paramKem *pqringctxkem.ParamKem
+
+	// randReader is the randomness source of the generation algorithms, such as CoinbaseTxMLPGen and TransferTxMLPGen.
+	// nil means the default source crypto/rand.Reader.
+	// It is set only by WithRandReader, on a copy of the PublicParameter.
+	randReader io.Reader
+
+	// ctx is the context of the generation and verification algorithms, such as TransferTxMLPGen and TransferTxMLPVerify.
+	// nil means no cancellation.
+	// It is set only by WithContext, on a copy of the PublicParameter.
+	ctx context.Context
+
+	// scratch is the scratch allocator of the temporary polynomials, which is shared by the copies of the PublicParameter.
+	scratch *polyScratch
+
+	// ringWorkerNum is the number of goroutines on which the ELR signatures process the ring members.
+	// 0 (and 1) means processing the ring members one after another.
+	// It is set only by WithRingWorkers, on a copy of the PublicParameter.
+	ringWorkerNum int
+}
+
+// WithRandReader returns a copy of pp, which uses randReader as the randomness source of the generation algorithms,
+// such as CoinbaseTxMLPGen and TransferTxMLPGen. pp itself is unchanged.
+// The copy shares the public matrices with pp, which are read-only.
+// A nil randReader means the default source crypto/rand.Reader.
+// NOTE: With a deterministic randReader, the generated transactions are reproducible byte-for-byte,
+// which is useful for test vectors and bug replay, but is insecure for real transactions.
+// NOTE: The generation algorithms read randReader sequentially, so that a copy must not be used by multiple goroutines concurrently.
+// NOTE: The KEM encapsulation of the Txo values reads randReader as well (see pqringctxkem.EncapsWithRand), which depends on the KEM:
+//   - KEM_OQS_KYBER and KEM_KYBER: a non-nil randReader is rejected, i.e., the generation algorithms return an error,
+//     as liboqs and kyber-go do not accept the encapsulation randomness per call.
+//   - KEM_MLKEM768: the encapsulation reads randReader, so that the generated transactions are reproducible.
+func (pp *PublicParameter) WithRandReader(randReader io.Reader) *PublicParameter {
+	ppCopy := *pp
+	ppCopy.randReader = randReader
+	return &ppCopy
+}
+
+// WithContext returns a copy of pp, whose generation and verification algorithms,
+// such as CoinbaseTxMLPGen, TransferTxMLPGen, CoinbaseTxMLPVerify, and TransferTxMLPVerify, abort once ctx is done,
+// returning an error that wraps ctx.Err(). pp itself is unchanged.
+// The cancellation is checked between the rejection-sampling iterations of the proofs/signatures and between the ring members.
+// A nil ctx means no cancellation.
+func (pp *PublicParameter) WithContext(ctx context.Context) *PublicParameter {
+	ppCopy := *pp
+	ppCopy.ctx = ctx
+	return &ppCopy
+}
+
+// WithRingWorkers returns a copy of pp, whose ELR signature generation and verification (for the RingCT inputs of TransferTxMLPGen and TransferTxMLPVerify)
+// process the ring members on ringWorkerNum goroutines. pp itself is unchanged.
+// ringWorkerNum <= 1 means processing the ring members one after another, which is the default.
+// The outputs do not depend on ringWorkerNum, since the randomness is still read sequentially, in the order of the ring members.
+// NOTE: VerifyTransferTxMLPBatch already verifies the transactions concurrently, so that the ring workers are mainly for the single (large) transactions.
+func (pp *PublicParameter) WithRingWorkers(ringWorkerNum int) *PublicParameter {
+	ppCopy := *pp
+	if ringWorkerNum < 0 {
+		ringWorkerNum = 0
+	}
+	ppCopy.ringWorkerNum = ringWorkerNum
+	return &ppCopy
+}
+
+// runRingWorkers runs task(j) for j in [0, ringLen) on pp.ringWorkerNum goroutines,
+// and returns the error of the smallest j whose task fails, so that the returned error does not depend on the number of workers.
+func (pp *PublicParameter) runRingWorkers(ringLen int, task func(j int) error) error {
+	if pp.ringWorkerNum <= 1 {
+		for j := 0; j < ringLen; j++ {
+			if err := task(j); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, ringLen)
+	runWorkerPoolWithWorkerNum(ringLen, pp.ringWorkerNum, func(j int) {
+		errs[j] = task(j)
+	})
+	for j := 0; j < ringLen; j++ {
+		if errs[j] != nil {
+			return errs[j]
+		}
+	}
+	return nil
+}
+
+// contextErr returns an error wrapping pp.ctx.Err() if pp.ctx is done, and nil otherwise.
+func (pp *PublicParameter) contextErr(funcName string) error {
+	if pp.ctx == nil {
+		return nil
+	}
+	if err := pp.ctx.Err(); err != nil {
+		return fmt.Errorf("%s: aborted as the context is done: %w", funcName, err)
+	}
+	return nil
 }
 
 // expandPubMatrixA expand matrix from specified seed
@@ -563,6 +675,19 @@ func (pp *PublicParameter) expandPubMatrixH(seed []byte) (matrixH []*PolyCNTTVec
 // Initialize is the init function, it must be called explicitly when using this package
 // reviewed by Alice, 2024.06.18
 func Initialize(parameterSeedString []byte) *PublicParameter {
+	return InitializeWithParamKem(parameterSeedString, &pqringctxkem.ParamKem{
+		Version: pqringctxkem.KEM_OQS_KYBER,
+		//Kyber:   kyber.Kyber768,
+		Kyber:    nil,
+		OQSKyber: "Kyber768",
+	})
+}
+
+// InitializeWithParamKem is the same as Initialize, except that the KEM, which encapsulates the values of the Txos, is specified by paramKem.
+// Initialize uses pqringctxkem.KEM_OQS_KYBER, which requires cgo and liboqs,
+// while pqringctxkem.KEM_MLKEM768 is implemented in pure Go, e.g., for the binaries built without cgo.
+// NOTE: The coinValuePublicKeys, coinValueSecretKeys and Txos of the PublicParameters with different KEMs are not compatible.
+func InitializeWithParamKem(parameterSeedString []byte, paramKem *pqringctxkem.ParamKem) *PublicParameter {
 	var err error
 	var defaultPP *PublicParameter
 	defaultPP, err = NewPublicParameter(
@@ -642,12 +767,7 @@ func Initialize(parameterSeedString []byte) *PublicParameter {
 		},
 		parameterSeedString,
 		//[]byte("Welcome to Post Quantum World!")
-		&pqringctxkem.ParamKem{
-			Version: pqringctxkem.KEM_OQS_KYBER,
-			//Kyber:   kyber.Kyber768,
-			Kyber:    nil,
-			OQSKyber: "Kyber768",
-		},
+		paramKem,
 	)
 	if err != nil {
 		log.Fatalln(err)
@@ -681,6 +801,11 @@ func (pp *PublicParameter) GetParamMACOutputBytesLen() int {
 	return MACOutputBytesLen
 }
 
+// GetParamRingSizeMax returns the allowed maximum ring size.
+func (pp *PublicParameter) GetParamRingSizeMax() uint8 {
+	return pp.paramRingSizeMax
+}
+
 // GetTxInputMaxNumForRing returns the allowed maximum number of Inputs for Ring.
 // reviewed on 2024.01.01, by Alice
 // reviewed by Alice, 2024.06.18
@@ -8,7 +8,7 @@ import (
 )
 
 func TestGeneratePolyANTTMatrix(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	slotNum := 100
 	start := -(pp.paramQA - 1) / 2
@@ -69,7 +69,7 @@ func TestGeneratePolyANTTMatrix(t *testing.T) {
 }
 
 func TestGeneratePolyCNTTMatrix(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	slotNum := 100
 	start := -(pp.paramQC - 1) / 2
@@ -132,7 +132,7 @@ func TestGeneratePolyCNTTMatrix(t *testing.T) {
 // todo: matrix elements
 func TestPublicParameters_MatrixA(t *testing.T) {
 	//seedStr := RandomBytes(RandSeedBytesLen)
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	type empty struct {
 		a int8
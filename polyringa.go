@@ -2,7 +2,6 @@ package pqringctx
 
 import (
 	"log"
-	"math/big"
 )
 
 type PolyA struct {
@@ -53,14 +52,11 @@ func (pp *PublicParameter) NewZeroPolyANTT() *PolyANTT {
 
 // infNorm
 // reviewed by Alice, 2024.06.18
+// It is in constant time, since it is applied to the responses of the signatures before the rejection, which are related to the secrets.
 func (polyA *PolyA) infNorm() (infNorm int64) {
 	rst := int64(0)
 	for _, coeff := range polyA.coeffs {
-		if coeff > rst {
-			rst = coeff
-		} else if coeff < 0 && -coeff > rst {
-			rst = -coeff
-		}
+		rst = ctMax(rst, ctAbs(coeff))
 	}
 	return rst
 }
@@ -70,153 +66,22 @@ func (polyA *PolyA) infNorm() (infNorm int64) {
 func (polyAVec *PolyAVec) infNorm() (infNorm int64) {
 	rst := int64(0)
 	for _, polyA := range polyAVec.polyAs {
-		tmp := polyA.infNorm()
-		if tmp > rst {
-			rst = tmp
-		}
+		rst = ctMax(rst, polyA.infNorm())
 	}
 
 	return rst
 }
 
+// NTTPolyA returns the NTT form of the input polyA.
+// It uses the precomputed twiddles in paramNTTATable and is in constant time, since polyA may be derived from secrets.
 func (pp *PublicParameter) NTTPolyA(polyA *PolyA) *PolyANTT {
-	//	NTT
-	zetaAOrder := pp.paramZetaAOrder
-	slotNum := zetaAOrder / 2 //	will factor to irreducible factors
-	segNum := 1
-	segLen := pp.paramDA
-	factors := make([]int, 1)
-	factors[0] = slotNum / 2
-
-	coeffs := make([]int64, pp.paramDA)
-	//for i := 0; i < pp.paramDA; i++ {
-	//	coeffs[i] = polyA.coeffs[i]
-	//}
-	copy(coeffs, polyA.coeffs)
-
-	var qaBig, tmp, tmp1, tmp2, zetaTmp big.Int
-	qaBig.SetInt64(pp.paramQA)
-	for {
-		segLenHalf := segLen / 2
-		for k := 0; k < segNum; k++ {
-			zetaTmp.SetInt64(pp.paramZetasA[factors[k]])
-			for i := 0; i < segLenHalf; i++ {
-				//	X^2 - Y^2 = (X+Y)(X-Y)
-				//				tmp := int64(coeffs[k*segLen+i+segLenHalf]) * zetas[factors[k]]
-				tmp.SetInt64(coeffs[k*segLen+i+segLenHalf])
-				tmp.Mul(&tmp, &zetaTmp)
-				tmp.Mod(&tmp, &qaBig)
-				//				tmp1 := reduceToQc(int64(coeffs[k*segLen+i]) - tmp)
-				//				tmp2 := reduceToQc(int64(coeffs[k*segLen+i]) + tmp)
-				tmp1.SetInt64(coeffs[k*segLen+i])
-				tmp2.SetInt64(coeffs[k*segLen+i])
-				tmp1.Sub(&tmp1, &tmp)
-				tmp2.Add(&tmp2, &tmp)
-				tmp1.Mod(&tmp1, &qaBig)
-				tmp2.Mod(&tmp2, &qaBig)
-				//				coeffs[k*segLen+i] = tmp1
-				//				coeffs[k*segLen+i+segLenHalf] = tmp2
-				coeffs[k*segLen+i] = reduceInt64(tmp1.Int64(), pp.paramQA)
-				coeffs[k*segLen+i+segLenHalf] = reduceInt64(tmp2.Int64(), pp.paramQA)
-			}
-		}
-		segNum = segNum << 1
-		segLen = segLen >> 1
-		if segNum == slotNum {
-			break
-		}
-
-		tmpFactors := make([]int, 2*len(factors))
-		for i := 0; i < len(factors); i++ {
-			tmpFactors[2*i] = (factors[i] + slotNum) / 2
-			tmpFactors[2*i+1] = factors[i] / 2
-		}
-		factors = tmpFactors
-	}
-
-	//	factors: 7, 3, 5, 1
-	//if pp.paramNTTAFactors == nil {
-	//	pp.paramNTTAFactors = make([]int, 2*len(factors))
-	//	for i := 0; i < len(factors); i++ {
-	//		pp.paramNTTAFactors[2*i] = factors[i] + slotNum
-	//		pp.paramNTTAFactors[2*i+1] = factors[i]
-	//	}
-	//}
-	//	finalFactors: 15,7, 11,3, 13,5, 9,1
-
-	//rst := pp.NewPolyANTT()
-	//for i := 0; i < pp.paramDA; i++ {
-	//	rst.coeffs[i] = coeffs[i]
-	//}
-	//return rst
-
-	return &PolyANTT{coeffs}
+	return &PolyANTT{coeffs: pp.paramNTTATable.forward(polyA.coeffs)}
 }
 
+// NTTInvPolyA returns the polynomial of the input NTT form polyANTT.
+// It uses the precomputed twiddles in paramNTTATable and is in constant time.
 func (pp *PublicParameter) NTTInvPolyA(polyANTT *PolyANTT) (polyA *PolyA) {
-	// NTT Inverse
-	zetaAOrder := pp.paramZetaAOrder
-	slotNum := zetaAOrder / 2 //	have been factored to irreducible factors
-	segNum := slotNum
-	segLen := pp.paramDA / segNum
-	//factors := pp.paramNTTAFactors
-	factors := make([]int, len(pp.paramNTTAFactors))
-	copy(factors, pp.paramNTTAFactors)
-
-	nttCoeffs := make([]int64, pp.paramDA)
-	//for i := 0; i < pp.paramDA; i++ {
-	//	nttCoeffs[i] = polyANTT.nttCoeffs[i]
-	//}
-	copy(nttCoeffs, polyANTT.coeffs)
-
-	// twoInv := int64((pp.paramQC+1)/2) - int64(pp.paramQC)
-	var qaBig, twoInv, tmp1, tmp2, tmpZetaInv big.Int
-	qaBig.SetInt64(pp.paramQA)
-	twoInv.SetInt64((pp.paramQA+1)/2 - pp.paramQA)
-
-	for {
-		segLenDouble := segLen * 2
-
-		for k := 0; k < segNum/2; k++ {
-			tmpZetaInv.SetInt64(pp.paramZetasA[zetaAOrder-factors[k]])
-			for i := 0; i < segLen; i++ {
-				//				tmp1 := reduceToQc(pp.reduceInt64(int64(nttCoeffs[k*segLenDouble+i+segLen])+int64(nttCoeffs[k*segLenDouble+i])) * twoInv)
-				//				nttCoeffs[k*segLenDouble+i] = tmp1
-				tmp1.SetInt64(nttCoeffs[k*segLenDouble+i+segLen] + nttCoeffs[k*segLenDouble+i])
-				tmp1.Mul(&tmp1, &twoInv)
-				tmp1.Mod(&tmp1, &qaBig)
-				//				tmp2 := reduceToQc(pp.reduceInt64(pp.reduceInt64(int64(nttCoeffs[k*segLenDouble+i+segLen])-int64(nttCoeffs[k*segLenDouble+i]))*twoInv) * zetas[2*pp.paramDC-factors[k]])
-				//				nttCoeffs[k*segLenDouble+i+segLen] = tmp2
-				tmp2.SetInt64(nttCoeffs[k*segLenDouble+i+segLen] - nttCoeffs[k*segLenDouble+i])
-				tmp2.Mul(&tmp2, &twoInv)
-				tmp2.Mod(&tmp2, &qaBig)
-				tmp2.Mul(&tmp2, &tmpZetaInv)
-				tmp2.Mod(&tmp2, &qaBig)
-
-				nttCoeffs[k*segLenDouble+i] = reduceInt64(tmp1.Int64(), pp.paramQA)
-				nttCoeffs[k*segLenDouble+i+segLen] = reduceInt64(tmp2.Int64(), pp.paramQA)
-			}
-		}
-		segNum = segNum >> 1
-		segLen = segLen << 1
-		if segNum == 1 {
-			break
-		}
-
-		tmpFactors := make([]int, len(factors)/2)
-		for i := 0; i < len(tmpFactors); i++ {
-			tmpFactors[i] = factors[2*i+1] * 2
-		}
-		factors = tmpFactors
-	}
-
-	//rst := pp.NewPolyA()
-	//for i := 0; i < pp.paramDA; i++ {
-	//	rst.nttCoeffs[i] = nttCoeffs[i]
-	//}
-	//return rst
-
-	return &PolyA{nttCoeffs}
+	return &PolyA{coeffs: pp.paramNTTATable.inverse(polyANTT.coeffs)}
 }
 
 // NewPolyAVec
@@ -289,20 +154,9 @@ func (pp *PublicParameter) PolyANTTAdd(a *PolyANTT, b *PolyANTT) (r *PolyANTT) {
 		log.Panic("PolyANTTAdd: the length of the input polyANTT is not paramDA")
 	}
 
-	bigQA := big.NewInt(pp.paramQA)
-
 	rst := pp.NewPolyANTT()
-
-	tmp := big.NewInt(1)
-	tmp1 := big.NewInt(1)
-	tmp2 := big.NewInt(1)
 	for i := 0; i < pp.paramDA; i++ {
-		tmp1.SetInt64(a.coeffs[i])
-		tmp2.SetInt64(b.coeffs[i])
-		tmp.Add(tmp1, tmp2)
-		tmp.Mod(tmp, bigQA)
-
-		rst.coeffs[i] = reduceInt64(tmp.Int64(), pp.paramQA)
+		rst.coeffs[i] = pp.paramQAModulus.add(a.coeffs[i], b.coeffs[i])
 	}
 	return rst
 }
@@ -315,62 +169,22 @@ func (pp *PublicParameter) PolyANTTSub(a *PolyANTT, b *PolyANTT) (r *PolyANTT) {
 		log.Panic("PolyANTTSub: the length of the input polyANTT is not paramDA")
 	}
 
-	bigQA := big.NewInt(pp.paramQA)
-
 	rst := pp.NewPolyANTT()
-
-	tmp := big.NewInt(1)
-	tmp1 := big.NewInt(1)
-	tmp2 := big.NewInt(1)
 	for i := 0; i < pp.paramDA; i++ {
-		tmp1.SetInt64(a.coeffs[i])
-		tmp2.SetInt64(b.coeffs[i])
-		tmp.Sub(tmp1, tmp2)
-		tmp.Mod(tmp, bigQA)
-
-		rst.coeffs[i] = reduceInt64(tmp.Int64(), pp.paramQA)
+		rst.coeffs[i] = pp.paramQAModulus.sub(a.coeffs[i], b.coeffs[i])
 	}
 	return rst
 }
 
+// PolyANTTMul multiplies the input a and b in NTT form, i.e., in each of the paramZetaAOrder/2 groups modulo X^{groupSize} - zeta_a^{f},
+// with the lazy reduction in paramNTTATable.
+// Note that q_a has 33 bits, so that the 32-bit Montgomery arithmetic does not apply,
+// and the products are accumulated in 128 bits by math/bits.Mul64, which is constant-time, instead.
 func (pp *PublicParameter) PolyANTTMul(a *PolyANTT, b *PolyANTT) *PolyANTT {
 	if len(a.coeffs) != pp.paramDA || len(b.coeffs) != pp.paramDA {
 		log.Panic("PolyANTTMul: the length of the input polyANTT is not paramDA")
 	}
-	bigQA := big.NewInt(pp.paramQA)
-	rst := pp.NewPolyANTT()
-	factor := make([]int, pp.paramZetaAOrder/2)
-	for i := 0; i < pp.paramZetaAOrder/4; i++ {
-		factor[2*i] = pp.paramNTTAFactors[i] + pp.paramZetaAOrder/2
-		factor[2*i+1] = pp.paramNTTAFactors[i]
-	}
-	// the size of every group is pp.paramDA/(pp.paramZetaAOrder/2)
-	groupSize := 2 * pp.paramDA / pp.paramZetaAOrder
-	// the group num is pp.paramDA / groupSize
-	groupNum := pp.paramDA / groupSize
-	left := make([]int64, groupSize)
-	right := make([]int64, groupSize)
-	// perform multiply in every group
-	for i := 0; i < groupNum; i++ {
-		for j := 0; j < groupSize; j++ {
-			left[j] = a.coeffs[i*groupSize+j]
-			right[j] = b.coeffs[i*groupSize+j]
-		}
-		tr := pp.MulKaratsuba(left, right, groupSize/2)
-		// reduce with zetasA[i]
-		var op1, op2 *big.Int
-		for j := 0; j < groupSize; j++ {
-			op1 = big.NewInt(tr[j+groupSize])
-			op2 = big.NewInt(pp.paramZetasA[factor[i]])
-			op1.Mul(op1, op2)
-			op1.Mod(op1, bigQA)
-			tr[j] = reduceInt64(tr[j]+op1.Int64(), pp.paramQA)
-		}
-		for j := 0; j < groupSize; j++ {
-			rst.coeffs[i*groupSize+j] = tr[j]
-		}
-	}
-	return rst
+	return &PolyANTT{coeffs: pp.paramNTTATable.mul(a.coeffs, b.coeffs)}
 }
 
 // PolyANTTVecAdd
@@ -405,12 +219,17 @@ func (pp *PublicParameter) PolyANTTVecInnerProduct(a *PolyANTTVec, b *PolyANTTVe
 	if len(a.polyANTTs) != vecLen || len(b.polyANTTs) != vecLen {
 		log.Panic("PolyANTTVecInnerProduct: the length of the input vector not equal to specific length")
 	}
-	var rst = pp.NewZeroPolyANTT()
+	as := make([][]int64, vecLen)
+	bs := make([][]int64, vecLen)
 	for i := 0; i < vecLen; i++ {
-		tmp := pp.PolyANTTMul(a.polyANTTs[i], b.polyANTTs[i])
-		rst = pp.PolyANTTAdd(rst, tmp)
+		if len(a.polyANTTs[i].coeffs) != pp.paramDA || len(b.polyANTTs[i].coeffs) != pp.paramDA {
+			log.Panic("PolyANTTVecInnerProduct: the length of the input polyANTT is not paramDA")
+		}
+		as[i] = a.polyANTTs[i].coeffs
+		bs[i] = b.polyANTTs[i].coeffs
 	}
-	return rst
+	//	the products are accumulated before the reduction
+	return &PolyANTT{coeffs: pp.paramNTTATable.innerProduct(as, bs)}
 }
 
 // PolyANTTMatrixMulVector
@@ -454,8 +273,9 @@ func (pp *PublicParameter) PolyANTTVecScaleMul(polyANTTScale *PolyANTT, polyANTT
 //
 // F[0]+F[1],G[0]+G[1],F[0]G[0],F[1]G[1] as intermediate variables
 // It uses several addition/subtraction to substitute  multiplication
+// The arithmetic is in constant time, since the inputs may be derived from secrets.
 func (pp *PublicParameter) MulKaratsuba(a, b []int64, n int) []int64 {
-	bigQA := big.NewInt(pp.paramQA)
+	modQA := pp.paramQAModulus
 	if len(a) != 2*n || len(b) != 2*n {
 		log.Fatal("MulKaratsuba() called by array with invalid length")
 	}
@@ -468,61 +288,48 @@ func (pp *PublicParameter) MulKaratsuba(a, b []int64, n int) []int64 {
 		f[i] = make([]int64, n)
 		g[i] = make([]int64, n)
 		for j := 0; j < n; j++ {
-			f[i][j] = a[j+i*n]
-			g[i][j] = b[j+i*n]
+			f[i][j] = modQA.reduce(a[j+i*n])
+			g[i][j] = modQA.reduce(b[j+i*n])
 		}
 	}
 	f0g0 := make([]int64, 2*n)
 	f1g1 := make([]int64, 2*n)
 
-	var left, right big.Int
 	for i := 0; i < n; i++ {
 		for j := 0; j < n; j++ {
 			// f0*g0
-			left.SetInt64(f[0][i])
-			right.SetInt64(g[0][j])
-			left.Mul(&left, &right)
-			left.Mod(&left, bigQA)
-			f0g0[i+j] = reduceInt64(f0g0[i+j]+left.Int64(), pp.paramQA)
+			f0g0[i+j] = modQA.add(f0g0[i+j], modQA.mul(f[0][i], g[0][j]))
 			// f1*g1
-			left.SetInt64(f[1][i])
-			right.SetInt64(g[1][j])
-			left.Mul(&left, &right)
-			left.Mod(&left, bigQA)
-			f1g1[i+j] = reduceInt64(f1g1[i+j]+left.Int64(), pp.paramQA)
+			f1g1[i+j] = modQA.add(f1g1[i+j], modQA.mul(f[1][i], g[1][j]))
 		}
 	}
 	// f0g0 + x^(2n) * f1g1
 	for i := 0; i < 2*n; i++ {
-		res[i] = reduceInt64(res[i]+f0g0[i], pp.paramQA)
-		res[i+2*n] = reduceInt64(res[i+2*n]+f1g1[i], pp.paramQA)
+		res[i] = modQA.add(res[i], f0g0[i])
+		res[i+2*n] = modQA.add(res[i+2*n], f1g1[i])
 	}
 	// f0g0=f0g0+f1g1
 	for i := 0; i < 2*n; i++ {
-		f0g0[i] = reduceInt64(f0g0[i]+f1g1[i], pp.paramQA)
+		f0g0[i] = modQA.add(f0g0[i], f1g1[i])
 		f1g1[i] = 0
 	}
 	// f1g1=(f0+f1)(g0+g1)
 	for i := 0; i < n; i++ {
-		f[0][i] = reduceInt64(f[0][i]+f[1][i], pp.paramQA)
-		g[0][i] = reduceInt64(g[0][i]+g[1][i], pp.paramQA)
+		f[0][i] = modQA.add(f[0][i], f[1][i])
+		g[0][i] = modQA.add(g[0][i], g[1][i])
 	}
 	for i := 0; i < n; i++ {
 		for j := 0; j < n; j++ {
 			// f1g1[i+j]+= f[0][i] * g[0][j]
-			left.SetInt64(f[0][i])
-			right.SetInt64(g[0][j])
-			left.Mul(&left, &right)
-			left.Mod(&left, bigQA)
-			f1g1[i+j] = reduceInt64(f1g1[i+j]+left.Int64(), pp.paramQA)
+			f1g1[i+j] = modQA.add(f1g1[i+j], modQA.mul(f[0][i], g[0][j]))
 		}
 	}
 	// f1g1 = f1g1 - f0g0 = (f0+f1)(g0+g1)-(f0g0+f1g1)
 	for i := 0; i < 2*n; i++ {
-		f1g1[i] = reduceInt64(f1g1[i]-f0g0[i], pp.paramQA)
+		f1g1[i] = modQA.sub(f1g1[i], f0g0[i])
 	}
 	for i := 0; i < 2*n; i++ {
-		res[i+n] = reduceInt64(res[i+n]+f1g1[i], pp.paramQA)
+		res[i+n] = modQA.add(res[i+n], f1g1[i])
 	}
 	return res
 }
@@ -611,3 +418,39 @@ func (pp *PublicParameter) PolyANTTSanityCheck(a *PolyANTT) (bl bool) {
 }
 
 // sanity check functions	end
+
+//	wipe functions	begin
+
+// wipe zeroes the coefficients of the PolyA, which may hold secret, e.g., the AddressSecretKeySp or the masking randomness of the signatures.
+func (polyA *PolyA) wipe() {
+	if polyA != nil {
+		wipeInt64s(polyA.coeffs)
+	}
+}
+
+// wipe zeroes the coefficients of the PolyANTT.
+func (polyANTT *PolyANTT) wipe() {
+	if polyANTT != nil {
+		wipeInt64s(polyANTT.coeffs)
+	}
+}
+
+// wipe zeroes the coefficients of all the PolyAs in the PolyAVec.
+func (polyAVec *PolyAVec) wipe() {
+	if polyAVec != nil {
+		for _, polyA := range polyAVec.polyAs {
+			polyA.wipe()
+		}
+	}
+}
+
+// wipe zeroes the coefficients of all the PolyANTTs in the PolyANTTVec.
+func (polyANTTVec *PolyANTTVec) wipe() {
+	if polyANTTVec != nil {
+		for _, polyANTT := range polyANTTVec.polyANTTs {
+			polyANTT.wipe()
+		}
+	}
+}
+
+//	wipe functions	end
@@ -24,7 +24,7 @@ func (pp *PublicParameter) Mul(a []int64, b []int64) []int64 {
 }
 
 func TestPublicParameter_MulKaratsuba(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	length := 32
 	seed := RandomBytes(pp.paramKeyGenSeedBytesLen)
 	ap, err := pp.randomDaIntegersInQa(seed)
@@ -70,7 +70,7 @@ func (pp *PublicParameter) PolyAMul(a *PolyA, b *PolyA) *PolyA {
 	return &PolyA{coeffs: res[:pp.paramDA]}
 }
 func TestPublicParameter_PolyANTTMul(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	seed := RandomBytes(pp.paramKeyGenSeedBytesLen)
 	tmpA, err := pp.randomDaIntegersInQa(seed)
 	if err != nil {
@@ -100,7 +100,7 @@ func TestPublicParameter_PolyANTTMul(t *testing.T) {
 }
 
 func TestPublicParameter_NTTPolyA(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	//bigQa := new(big.Int).SetInt64(pp.paramQA)
 	//for i := 1; i < pp.paramZetaAOrder; i++ {
@@ -137,7 +137,7 @@ func TestPublicParameter_NTTPolyA(t *testing.T) {
 }
 
 func TestZetaAsList(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	bigQa := new(big.Int).SetInt64(pp.paramQA)
 	//for i := 0; i < pp.paramZetaAOrder; i++ {
 	//	zetaABig := new(big.Int).SetInt64(pp.paramZetasA[i])
@@ -163,3 +163,46 @@ func TestZetaAsList(t *testing.T) {
 	//	fmt.Println(zetaABig.Int64())
 	//}
 }
+
+func BenchmarkPublicParameter_NTTPolyA(b *testing.B) {
+	pp := initializeForTest()
+	coeffs, err := pp.randomDaIntegersInQa(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	a := &PolyA{coeffs: coeffs}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.NTTPolyA(a)
+	}
+}
+
+func BenchmarkPublicParameter_NTTInvPolyA(b *testing.B) {
+	pp := initializeForTest()
+	coeffs, err := pp.randomDaIntegersInQa(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	a := &PolyANTT{coeffs: coeffs}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.NTTInvPolyA(a)
+	}
+}
+
+func BenchmarkPublicParameter_PolyANTTMul(b *testing.B) {
+	pp := initializeForTest()
+	coeffsA, err := pp.randomDaIntegersInQa(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	coeffsB, err := pp.randomDaIntegersInQa(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	a, c := &PolyANTT{coeffs: coeffsA}, &PolyANTT{coeffs: coeffsB}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.PolyANTTMul(a, c)
+	}
+}
@@ -3,7 +3,6 @@ package pqringctx
 import (
 	"fmt"
 	"log"
-	"math/big"
 )
 
 type PolyC struct {
@@ -76,14 +75,11 @@ func (pp *PublicParameter) NewZeroPolyCNTT() *PolyCNTT {
 
 // infNorm
 // reviewed by Alice, 2024.06.18
+// It is in constant time, since it is applied to the responses of the signatures and proofs before the rejection, which are related to the secrets.
 func (polyC *PolyC) infNorm() (infNorm int64) {
 	rst := int64(0)
 	for _, coeff := range polyC.coeffs {
-		if coeff > rst {
-			rst = coeff
-		} else if coeff < 0 && -coeff > rst {
-			rst = -coeff
-		}
+		rst = ctMax(rst, ctAbs(coeff))
 	}
 	return rst
 }
@@ -93,161 +89,37 @@ func (polyC *PolyC) infNorm() (infNorm int64) {
 func (polyCVec *PolyCVec) infNorm() (infNorm int64) {
 	rst := int64(0)
 	for _, polyC := range polyCVec.polyCs {
-		tmp := polyC.infNorm()
-		if tmp > rst {
-			rst = tmp
-		}
+		rst = ctMax(rst, polyC.infNorm())
 	}
 	return rst
 }
 
+// NTTPolyC returns the NTT form of the input polyC.
+// It uses the precomputed twiddles in paramNTTCTable and is in constant time, since polyC may be derived from secrets.
 func (pp *PublicParameter) NTTPolyC(polyC *PolyC) *PolyCNTT {
-	//	NTT
-	zetaCOrder := pp.paramZetaCOrder
-	slotNum := zetaCOrder / 2 // fully splitting
-	segNum := 1
-	segLen := pp.paramDC
-	factors := make([]int, 1)
-	factors[0] = slotNum / 2
-
-	coeffs := make([]int64, pp.paramDC)
-	//for i := 0; i < len(polyC.coeffs); i++ {
-	//	coeffs[i] = polyC.coeffs[i]
-	//}
-	copy(coeffs, polyC.coeffs)
-
-	var qcBig, tmp, tmp1, tmp2, zetaTmp big.Int
-	qcBig.SetInt64(pp.paramQC)
-	for {
-		segLenHalf := segLen / 2
-		for k := 0; k < segNum; k++ {
-			zetaTmp.SetInt64(pp.paramZetasC[factors[k]])
-			for i := 0; i < segLenHalf; i++ {
-				//	X^2 - a^2 = (X+a)(X-a)
-				//				tmp := int64(coeffs[k*segLen+i+segLenHalf]) * zetas[factors[k]]
-				tmp.SetInt64(coeffs[k*segLen+i+segLenHalf])
-				tmp.Mul(&tmp, &zetaTmp)
-				tmp.Mod(&tmp, &qcBig)
-				//				tmp1 := reduceToQc(int64(coeffs[k*segLen+i]) - tmp)
-				//				tmp2 := reduceToQc(int64(coeffs[k*segLen+i]) + tmp)
-				tmp1.SetInt64(coeffs[k*segLen+i])
-				tmp2.SetInt64(coeffs[k*segLen+i])
-				tmp1.Sub(&tmp1, &tmp)
-				tmp2.Add(&tmp2, &tmp)
-				tmp1.Mod(&tmp1, &qcBig)
-				tmp2.Mod(&tmp2, &qcBig)
-
-				//				coeffs[k*segLen+i] = tmp1
-				//				coeffs[k*segLen+i+segLenHalf] = tmp2
-				coeffs[k*segLen+i] = reduceInt64(tmp1.Int64(), pp.paramQC)
-				coeffs[k*segLen+i+segLenHalf] = reduceInt64(tmp2.Int64(), pp.paramQC)
-			}
-		}
-		segNum = segNum << 1
-		segLen = segLen >> 1
-		if segNum == slotNum {
-			break
-		}
-		tmpFactors := make([]int, 2*len(factors))
-		for i := 0; i < len(factors); i++ {
-			tmpFactors[2*i] = (factors[i] + slotNum) / 2
-			tmpFactors[2*i+1] = factors[i] / 2
-		}
-		factors = tmpFactors
-	}
-
-	finalFactors := make([]int, 2*len(factors))
-	for i := 0; i < len(factors); i++ {
-		finalFactors[2*i] = factors[i] + slotNum
-		finalFactors[2*i+1] = factors[i]
-	}
+	return &PolyCNTT{coeffs: pp.paramNTTCTable.forward(polyC.coeffs)}
+}
 
-	nttCoeffs := make([]int64, pp.paramDC)
-	for i := 0; i < pp.paramDC; i++ {
-		nttCoeffs[(finalFactors[i]-1)/2] = coeffs[i]
+// NTTPolyCTo sets dst to the NTT form of the input polyC, without allocation.
+func (pp *PublicParameter) NTTPolyCTo(dst *PolyCNTT, polyC *PolyC) {
+	if len(polyC.coeffs) != pp.paramDC || len(dst.coeffs) != pp.paramDC {
+		log.Panic("NTTPolyCTo: the length of the input polyC is not paramDC")
 	}
-	return &PolyCNTT{coeffs: nttCoeffs}
-
-	/*	rst := pp.NewPolyCNTT()
-		for i := 0; i < pp.paramDC; i++ {
-			rst.coeffs[i] = coeffs[i].Int64()
-		}
-		return rst*/
+	pp.paramNTTCTable.forwardTo(dst.coeffs, polyC.coeffs)
 }
 
+// NTTInvPolyC returns the polynomial of the input NTT form polyCNTT.
+// It uses the precomputed twiddles in paramNTTCTable and is in constant time.
 func (pp *PublicParameter) NTTInvPolyC(polyCNTT *PolyCNTT) (polyC *PolyC) {
-	//	NTT Inverse
-	zetaCOrder := pp.paramZetaCOrder
-	slotNum := zetaCOrder / 2 //	have been factored to irreducible factors, i.e., fully splitting
-	segNum := slotNum
-	segLen := 1
-	//	factors := pp.paramNTTCFactors
-	factors := make([]int, len(pp.paramNTTCFactors))
-	copy(factors, pp.paramNTTCFactors)
-
-	finalFactors := make([]int, 2*len(factors))
-	for i := 0; i < len(factors); i++ {
-		finalFactors[2*i] = factors[i] + slotNum
-		finalFactors[2*i+1] = factors[i]
-	}
-
-	nttCoeffs := make([]int64, pp.paramDC)
-	for i := 0; i < pp.paramDC; i++ {
-		nttCoeffs[i] = polyCNTT.coeffs[(finalFactors[i]-1)/2]
-	}
-
-	/*	nttCoeffs := make([]big.Int, pp.paramDC)
-		for i := 0; i < pp.paramDC; i++ {
-			nttCoeffs[i].SetInt64( polyCNTT.coeffs[i] )
-		}*/
-	// twoInv := int64((pp.paramQC+1)/2) - int64(pp.paramQC)
-	var twoInv, qcBig, tmp1, tmp2, tmpZetaInv big.Int
-	twoInv.SetInt64((pp.paramQC+1)/2 - pp.paramQC)
-	qcBig.SetInt64(pp.paramQC)
-
-	for {
-		segLenDouble := segLen * 2
-
-		for k := 0; k < segNum/2; k++ {
-			// tmpZeta.SetInt64( zetas[2*pp.paramDC-factors[k]] )
-			tmpZetaInv.SetInt64(pp.paramZetasC[zetaCOrder-factors[k]])
-			for i := 0; i < segLen; i++ {
-				// tmp1 := reduceToQc(pp.reduceInt64(int64(nttCoeffs[k*segLenDouble+i+segLen])+int64(nttCoeffs[k*segLenDouble+i])) * twoInv)
-				// nttCoeffs[k*segLenDouble+i] = tmp1
-				tmp1.SetInt64(nttCoeffs[k*segLenDouble+i+segLen] + nttCoeffs[k*segLenDouble+i])
-				tmp1.Mul(&tmp1, &twoInv)
-				tmp1.Mod(&tmp1, &qcBig)
-				// tmp2 := reduceToQc(pp.reduceInt64(pp.reduceInt64(int64(nttCoeffs[k*segLenDouble+i+segLen])-int64(nttCoeffs[k*segLenDouble+i]))*twoInv) * zetas[2*pp.paramDC-factors[k]])
-				// nttCoeffs[k*segLenDouble+i+segLen] = tmp2
-				tmp2.SetInt64(nttCoeffs[k*segLenDouble+i+segLen] - nttCoeffs[k*segLenDouble+i])
-				tmp2.Mul(&tmp2, &twoInv)
-				tmp2.Mod(&tmp2, &qcBig)
-				tmp2.Mul(&tmp2, &tmpZetaInv)
-				tmp2.Mod(&tmp2, &qcBig)
-
-				nttCoeffs[k*segLenDouble+i] = reduceInt64(tmp1.Int64(), pp.paramQC)
-				nttCoeffs[k*segLenDouble+i+segLen] = reduceInt64(tmp2.Int64(), pp.paramQC)
-			}
-		}
-		segNum = segNum >> 1
-		segLen = segLen << 1
-		if segNum == 1 {
-			break
-		}
-		tmpFactors := make([]int, len(factors)/2)
-		for i := 0; i < len(tmpFactors); i++ {
-			tmpFactors[i] = factors[2*i+1] * 2
-		}
-		factors = tmpFactors
-	}
-
-	//rst := pp.NewPolyC()
-	//for i := 0; i < pp.paramDC; i++ {
-	//	rst.coeffs[i] = reduceInt64(nttCoeffs[i], pp.paramQC)
-	//}
-	//return rst
+	return &PolyC{coeffs: pp.paramNTTCTable.inverse(polyCNTT.coeffs)}
+}
 
-	return &PolyC{nttCoeffs}
+// NTTInvPolyCTo sets dst to the polynomial of the input NTT form polyCNTT, without allocation.
+func (pp *PublicParameter) NTTInvPolyCTo(dst *PolyC, polyCNTT *PolyCNTT) {
+	if len(polyCNTT.coeffs) != pp.paramDC || len(dst.coeffs) != pp.paramDC {
+		log.Panic("NTTInvPolyCTo: the length of the input polyCNTT is not paramDC")
+	}
+	pp.paramNTTCTable.inverseTo(dst.coeffs, polyCNTT.coeffs)
 }
 
 // NewPolyCVec
@@ -287,7 +159,7 @@ func (pp *PublicParameter) NTTPolyCVec(polyCVec *PolyCVec) *PolyCNTTVec {
 		return nil
 	}
 
-	rst := pp.NewPolyCNTTVec(len(polyCVec.polyCs))
+	rst := &PolyCNTTVec{polyCNTTs: make([]*PolyCNTT, len(polyCVec.polyCs))}
 
 	for i := 0; i < len(polyCVec.polyCs); i++ {
 		rst.polyCNTTs[i] = pp.NTTPolyC(polyCVec.polyCs[i])
@@ -302,7 +174,7 @@ func (pp *PublicParameter) NTTInvPolyCVec(polyCNTTVec *PolyCNTTVec) (polyCVec *P
 		return nil
 	}
 
-	rst := pp.NewPolyCVec(len(polyCNTTVec.polyCNTTs))
+	rst := &PolyCVec{polyCs: make([]*PolyC, len(polyCNTTVec.polyCNTTs))}
 
 	for i := 0; i < len(polyCNTTVec.polyCNTTs); i++ {
 		rst.polyCs[i] = pp.NTTInvPolyC(polyCNTTVec.polyCNTTs[i])
@@ -315,131 +187,142 @@ func (pp *PublicParameter) NTTInvPolyCVec(polyCNTTVec *PolyCNTTVec) (polyCVec *P
 // reviewed by Alice, 2024.06.18
 // todo: review
 func (pp *PublicParameter) PolyCNTTAdd(a *PolyCNTT, b *PolyCNTT) (r *PolyCNTT) {
-	if len(a.coeffs) != pp.paramDC || len(b.coeffs) != pp.paramDC {
+	rst := pp.NewPolyCNTT()
+	pp.PolyCNTTAddTo(rst, a, b)
+	return rst
+}
+
+// PolyCNTTAddTo sets dst to a + b, without allocation. dst may be a or b.
+func (pp *PublicParameter) PolyCNTTAddTo(dst *PolyCNTT, a *PolyCNTT, b *PolyCNTT) {
+	if len(a.coeffs) != pp.paramDC || len(b.coeffs) != pp.paramDC || len(dst.coeffs) != pp.paramDC {
 		log.Panic("the length of the input polyCNTT is not paramDC")
 	}
 
-	bigQc := big.NewInt(pp.paramQC)
-
-	rst := pp.NewPolyCNTT()
-
-	tmp := big.NewInt(1)
-	tmp1 := big.NewInt(1)
-	tmp2 := big.NewInt(1)
 	for i := 0; i < pp.paramDC; i++ {
-		tmp1.SetInt64(a.coeffs[i])
-		tmp2.SetInt64(b.coeffs[i])
-		tmp.Add(tmp1, tmp2)
-		tmp.Mod(tmp, bigQc)
-
-		rst.coeffs[i] = reduceInt64(tmp.Int64(), pp.paramQC)
+		dst.coeffs[i] = pp.paramQCModulus.add(a.coeffs[i], b.coeffs[i])
 	}
-	return rst
 }
 
 // PolyCNTTSub
 // reviewed by Alice, 2024.06.18
 // todo: review
 func (pp *PublicParameter) PolyCNTTSub(a *PolyCNTT, b *PolyCNTT) (r *PolyCNTT) {
-	if len(a.coeffs) != pp.paramDC || len(b.coeffs) != pp.paramDC {
+	rst := pp.NewPolyCNTT()
+	pp.PolyCNTTSubTo(rst, a, b)
+	return rst
+}
+
+// PolyCNTTSubTo sets dst to a - b, without allocation. dst may be a or b.
+func (pp *PublicParameter) PolyCNTTSubTo(dst *PolyCNTT, a *PolyCNTT, b *PolyCNTT) {
+	if len(a.coeffs) != pp.paramDC || len(b.coeffs) != pp.paramDC || len(dst.coeffs) != pp.paramDC {
 		log.Panic("the length of the input polyCNTT is not paramDC")
 	}
 
-	bigQc := big.NewInt(pp.paramQC)
-
-	rst := pp.NewPolyCNTT()
-
-	tmp := big.NewInt(1)
-	tmp1 := big.NewInt(1)
-	tmp2 := big.NewInt(1)
 	for i := 0; i < pp.paramDC; i++ {
-		tmp1.SetInt64(a.coeffs[i])
-		tmp2.SetInt64(b.coeffs[i])
-		tmp.Sub(tmp1, tmp2)
-		tmp.Mod(tmp, bigQc)
-
-		rst.coeffs[i] = reduceInt64(tmp.Int64(), pp.paramQC)
+		dst.coeffs[i] = pp.paramQCModulus.sub(a.coeffs[i], b.coeffs[i])
 	}
-	return rst
 }
 
 // PolyCNTTMul
 // reviewed by Alice, 2024.06.18
 // todo: review
 func (pp *PublicParameter) PolyCNTTMul(a *PolyCNTT, b *PolyCNTT) (r *PolyCNTT) {
-	if len(a.coeffs) != pp.paramDC || len(b.coeffs) != pp.paramDC {
-		log.Panic("PolyCNTTMul: the length of the input polyCNTT is not paramDC")
-	}
-
-	bigQc := big.NewInt(pp.paramQC)
-
 	rst := pp.NewPolyCNTT()
-	tmp := big.NewInt(1)
-	tmp1 := big.NewInt(1)
-	tmp2 := big.NewInt(1)
-	for i := 0; i < pp.paramDC; i++ {
-		tmp1.SetInt64(a.coeffs[i])
-		tmp2.SetInt64(b.coeffs[i])
-		tmp.Mul(tmp1, tmp2)
-		//		rst.coeffs[i] = reduceBigInt(&tmp, pp.paramQC)
-		tmp.Mod(tmp, bigQc)
+	pp.PolyCNTTMulTo(rst, a, b)
+	return rst
+}
 
-		rst.coeffs[i] = reduceInt64(tmp.Int64(), pp.paramQC)
+// PolyCNTTMulTo sets dst to a * b, without allocation. dst may be a or b.
+func (pp *PublicParameter) PolyCNTTMulTo(dst *PolyCNTT, a *PolyCNTT, b *PolyCNTT) {
+	if len(a.coeffs) != pp.paramDC || len(b.coeffs) != pp.paramDC || len(dst.coeffs) != pp.paramDC {
+		log.Panic("PolyCNTTMul: the length of the input polyCNTT is not paramDC")
 	}
-	return rst
+
+	//	a * (b * 2^64) * 2^{-64}, i.e., one Montgomery multiplication for each coefficient, since the product of two 53-bit coefficients overflows int64.
+	pp.paramNTTCTable.mulTo(dst.coeffs, a.coeffs, b.coeffs)
 }
 
 // PolyCNTTVecAdd
 // reviewed by Alice, 2024.06.18
 func (pp *PublicParameter) PolyCNTTVecAdd(a *PolyCNTTVec, b *PolyCNTTVec, vecLen int) (r *PolyCNTTVec) {
-	if len(a.polyCNTTs) != vecLen || len(b.polyCNTTs) != vecLen {
+	rst := pp.NewZeroPolyCNTTVec(vecLen)
+	pp.PolyCNTTVecAddTo(rst, a, b, vecLen)
+	return rst
+}
+
+// PolyCNTTVecAddTo sets dst to a + b, without allocation. dst may be a or b.
+func (pp *PublicParameter) PolyCNTTVecAddTo(dst *PolyCNTTVec, a *PolyCNTTVec, b *PolyCNTTVec, vecLen int) {
+	if len(a.polyCNTTs) != vecLen || len(b.polyCNTTs) != vecLen || len(dst.polyCNTTs) != vecLen {
 		log.Panic("PolyCNTTVecAdd: the length of the input polyCNTT should be specific length")
 	}
-	var rst = pp.NewPolyCNTTVec(vecLen)
 	for i := 0; i < vecLen; i++ {
-		rst.polyCNTTs[i] = pp.PolyCNTTAdd(a.polyCNTTs[i], b.polyCNTTs[i])
+		pp.PolyCNTTAddTo(dst.polyCNTTs[i], a.polyCNTTs[i], b.polyCNTTs[i])
 	}
-	return rst
 }
 
 // PolyCNTTVecSub
 // reviewed by Alice, 2024.06.18
 func (pp *PublicParameter) PolyCNTTVecSub(a *PolyCNTTVec, b *PolyCNTTVec, vecLen int) (r *PolyCNTTVec) {
-	if len(a.polyCNTTs) != vecLen || len(b.polyCNTTs) != vecLen {
+	rst := pp.NewZeroPolyCNTTVec(vecLen)
+	pp.PolyCNTTVecSubTo(rst, a, b, vecLen)
+	return rst
+}
+
+// PolyCNTTVecSubTo sets dst to a - b, without allocation. dst may be a or b.
+func (pp *PublicParameter) PolyCNTTVecSubTo(dst *PolyCNTTVec, a *PolyCNTTVec, b *PolyCNTTVec, vecLen int) {
+	if len(a.polyCNTTs) != vecLen || len(b.polyCNTTs) != vecLen || len(dst.polyCNTTs) != vecLen {
 		log.Panic("PolyCNTTVecSub: the length of the input polyCNTT should be specific length")
 	}
-	var rst = pp.NewPolyCNTTVec(vecLen)
 	for i := 0; i < vecLen; i++ {
-		rst.polyCNTTs[i] = pp.PolyCNTTSub(a.polyCNTTs[i], b.polyCNTTs[i])
+		pp.PolyCNTTSubTo(dst.polyCNTTs[i], a.polyCNTTs[i], b.polyCNTTs[i])
 	}
-	return rst
 }
 
 // PolyCNTTVecInnerProduct
 // reviewed by Alice, 2024.06.18
 func (pp *PublicParameter) PolyCNTTVecInnerProduct(a *PolyCNTTVec, b *PolyCNTTVec, vecLen int) (r *PolyCNTT) {
+	rst := pp.NewPolyCNTT()
+	pp.PolyCNTTVecInnerProductTo(rst, a, b, vecLen)
+	return rst
+}
+
+// PolyCNTTVecInnerProductTo sets dst to the inner product of a and b, without allocation.
+// dst may be an element of a or b.
+func (pp *PublicParameter) PolyCNTTVecInnerProductTo(dst *PolyCNTT, a *PolyCNTTVec, b *PolyCNTTVec, vecLen int) {
 	if len(a.polyCNTTs) != vecLen || len(b.polyCNTTs) != vecLen {
 		log.Panic("PolyCNTTVecInnerProduct: the length of the input polyCNTT should be specific length")
 	}
-	var rst = pp.NewZeroPolyCNTT()
+	if len(dst.coeffs) != pp.paramDC {
+		log.Panic("PolyCNTTVecInnerProduct: the length of the output polyCNTT is not paramDC")
+	}
+	//	the products are accumulated before the reduction
+	acc := pp.paramNTTCTable.getAcc()
 	for i := 0; i < vecLen; i++ {
-		tmp := pp.PolyCNTTMul(a.polyCNTTs[i], b.polyCNTTs[i])
-		rst = pp.PolyCNTTAdd(rst, tmp)
+		if len(a.polyCNTTs[i].coeffs) != pp.paramDC || len(b.polyCNTTs[i].coeffs) != pp.paramDC {
+			log.Panic("PolyCNTTVecInnerProduct: the length of the input polyCNTT is not paramDC")
+		}
+		acc.add(a.polyCNTTs[i].coeffs, b.polyCNTTs[i].coeffs)
 	}
-	return rst
+	acc.resultTo(dst.coeffs)
+	pp.paramNTTCTable.putAcc(acc)
 }
 
 // PolyCNTTMatrixMulVector
 // reviewed by Alice, 2024.06.18
 func (pp *PublicParameter) PolyCNTTMatrixMulVector(M []*PolyCNTTVec, vec *PolyCNTTVec, rowNum int, vecLen int) (r *PolyCNTTVec) {
-	if len(M) != rowNum {
+	rst := pp.NewZeroPolyCNTTVec(rowNum)
+	pp.PolyCNTTMatrixMulVectorTo(rst, M, vec, rowNum, vecLen)
+	return rst
+}
+
+// PolyCNTTMatrixMulVectorTo sets dst to M * vec, without allocation. dst must not share elements with vec.
+func (pp *PublicParameter) PolyCNTTMatrixMulVectorTo(dst *PolyCNTTVec, M []*PolyCNTTVec, vec *PolyCNTTVec, rowNum int, vecLen int) {
+	if len(M) != rowNum || len(dst.polyCNTTs) != rowNum {
 		log.Panic("PolyCNTTMatrixMulVector: the length of the input matrix and vector should be specific lengths")
 	}
-	rst := pp.NewPolyCNTTVec(rowNum)
 	for i := 0; i < rowNum; i++ {
-		rst.polyCNTTs[i] = pp.PolyCNTTVecInnerProduct(M[i], vec, vecLen)
+		pp.PolyCNTTVecInnerProductTo(dst.polyCNTTs[i], M[i], vec, vecLen)
 	}
-	return rst
 }
 
 // PolyCNTTVecScaleMul
@@ -452,21 +335,35 @@ func (pp *PublicParameter) PolyCNTTVecScaleMul(polyCNTTScale *PolyCNTT, polyCNTT
 		log.Panic("PolyCNTTVecScaleMul: vecLen is bigger than the length of polyCNTTVec")
 	}
 
-	rst := pp.NewPolyCNTTVec(vecLen)
+	rst := pp.NewZeroPolyCNTTVec(vecLen)
+	pp.PolyCNTTVecScaleMulTo(rst, polyCNTTScale, polyCNTTVec, vecLen)
+	return rst
+}
+
+// PolyCNTTVecScaleMulTo sets dst to polyCNTTScale * polyCNTTVec (the first vecLen elements), without allocation.
+// dst may be polyCNTTVec, but must not share elements with polyCNTTScale.
+func (pp *PublicParameter) PolyCNTTVecScaleMulTo(dst *PolyCNTTVec, polyCNTTScale *PolyCNTT, polyCNTTVec *PolyCNTTVec, vecLen int) {
+	if vecLen > len(polyCNTTVec.polyCNTTs) || vecLen > len(dst.polyCNTTs) {
+		log.Panic("PolyCNTTVecScaleMul: vecLen is bigger than the length of polyCNTTVec")
+	}
 	for i := 0; i < vecLen; i++ {
-		rst.polyCNTTs[i] = pp.PolyCNTTMul(polyCNTTScale, polyCNTTVec.polyCNTTs[i])
+		pp.PolyCNTTMulTo(dst.polyCNTTs[i], polyCNTTScale, polyCNTTVec.polyCNTTs[i])
 	}
-	return rst
 }
 
 // sigmaPowerPolyCNTT
 // reviewed by Alice, 2024.06.18
 func (pp *PublicParameter) sigmaPowerPolyCNTT(polyCNTT *PolyCNTT, t int) (r *PolyCNTT) {
 	rst := pp.NewPolyCNTT()
+	pp.sigmaPowerPolyCNTTTo(rst, polyCNTT, t)
+	return rst
+}
+
+// sigmaPowerPolyCNTTTo sets dst to sigma^t(polyCNTT), without allocation. dst must not be polyCNTT.
+func (pp *PublicParameter) sigmaPowerPolyCNTTTo(dst *PolyCNTT, polyCNTT *PolyCNTT, t int) {
 	for i := 0; i < pp.paramDC; i++ {
-		rst.coeffs[i] = polyCNTT.coeffs[pp.paramSigmaPermutations[t][i]]
+		dst.coeffs[i] = polyCNTT.coeffs[pp.paramSigmaPermutations[t][i]]
 	}
-	return rst
 }
 
 // PolyCNTTVecEqualCheck
@@ -548,3 +445,39 @@ func (pp *PublicParameter) PolyCSanityCheck(c *PolyC) bool {
 
 	return true
 }
+
+//	wipe functions	begin
+
+// wipe zeroes the coefficients of the PolyC, which may hold secret, e.g., the masking randomness of the signatures.
+func (polyC *PolyC) wipe() {
+	if polyC != nil {
+		wipeInt64s(polyC.coeffs)
+	}
+}
+
+// wipe zeroes the coefficients of the PolyCNTT.
+func (polyCNTT *PolyCNTT) wipe() {
+	if polyCNTT != nil {
+		wipeInt64s(polyCNTT.coeffs)
+	}
+}
+
+// wipe zeroes the coefficients of all the PolyCs in the PolyCVec.
+func (polyCVec *PolyCVec) wipe() {
+	if polyCVec != nil {
+		for _, polyC := range polyCVec.polyCs {
+			polyC.wipe()
+		}
+	}
+}
+
+// wipe zeroes the coefficients of all the PolyCNTTs in the PolyCNTTVec.
+func (polyCNTTVec *PolyCNTTVec) wipe() {
+	if polyCNTTVec != nil {
+		for _, polyCNTT := range polyCNTTVec.polyCNTTs {
+			polyCNTT.wipe()
+		}
+	}
+}
+
+//	wipe functions	end
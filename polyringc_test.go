@@ -15,7 +15,7 @@ import (
 //}
 
 func TestPublicParameterv2_NTTPolyC_NTTInvPolyC(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	c := pp.NewPolyC()
 	for i := 0; i < pp.paramDC; i++ {
 		c.coeffs[i] = int64(i + 1)
@@ -28,7 +28,7 @@ func TestPublicParameterv2_NTTPolyC_NTTInvPolyC(t *testing.T) {
 }
 
 func TestPublicParameter_NTTPolyC(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	//bigQa := new(big.Int).SetInt64(pp.paramQA)
 	//for i := 1; i < pp.paramZetaAOrder; i++ {
@@ -62,3 +62,139 @@ func TestPublicParameter_NTTPolyC(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkPublicParameter_NTTPolyC(b *testing.B) {
+	pp := initializeForTest()
+	coeffs, err := pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := &PolyC{coeffs: coeffs}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.NTTPolyC(c)
+	}
+}
+
+func BenchmarkPublicParameter_NTTInvPolyC(b *testing.B) {
+	pp := initializeForTest()
+	coeffs, err := pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := &PolyCNTT{coeffs: coeffs}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.NTTInvPolyC(c)
+	}
+}
+
+func BenchmarkPublicParameter_PolyCNTTMul(b *testing.B) {
+	pp := initializeForTest()
+	coeffsA, err := pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	coeffsB, err := pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen))
+	if err != nil {
+		b.Fatal(err)
+	}
+	a, c := &PolyCNTT{coeffs: coeffsA}, &PolyCNTT{coeffs: coeffsB}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.PolyCNTTMul(a, c)
+	}
+}
+
+func TestPublicParameter_PolyCNTTTo(t *testing.T) {
+	pp := initializeForTest()
+	randPolyCNTTVec := func(vecLen int) *PolyCNTTVec {
+		rst := pp.NewPolyCNTTVec(vecLen)
+		for i := 0; i < vecLen; i++ {
+			coeffs, err := pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rst.polyCNTTs[i] = &PolyCNTT{coeffs: coeffs}
+		}
+		return rst
+	}
+	copyPolyCNTT := func(polyCNTT *PolyCNTT) *PolyCNTT {
+		coeffs := make([]int64, pp.paramDC)
+		copy(coeffs, polyCNTT.coeffs)
+		return &PolyCNTT{coeffs: coeffs}
+	}
+
+	vs := randPolyCNTTVec(pp.paramLC)
+	ws := randPolyCNTTVec(pp.paramLC)
+	a, b := vs.polyCNTTs[0], ws.polyCNTTs[0]
+
+	//	dst aliasing one of the inputs
+	ops := []struct {
+		name  string
+		alloc func(a, b *PolyCNTT) *PolyCNTT
+		to    func(dst, a, b *PolyCNTT)
+	}{
+		{"Add", pp.PolyCNTTAdd, pp.PolyCNTTAddTo},
+		{"Sub", pp.PolyCNTTSub, pp.PolyCNTTSubTo},
+		{"Mul", pp.PolyCNTTMul, pp.PolyCNTTMulTo},
+	}
+	for _, op := range ops {
+		want := op.alloc(a, b)
+		dst := copyPolyCNTT(a)
+		op.to(dst, dst, b)
+		if !pp.PolyCNTTEqualCheck(dst, want) {
+			t.Errorf("PolyCNTT%sTo(a, a, b) differs from PolyCNTT%s", op.name, op.name)
+		}
+		dst = copyPolyCNTT(b)
+		op.to(dst, a, dst)
+		if !pp.PolyCNTTEqualCheck(dst, want) {
+			t.Errorf("PolyCNTT%sTo(b, a, b) differs from PolyCNTT%s", op.name, op.name)
+		}
+	}
+
+	want := pp.PolyCNTTVecInnerProduct(vs, ws, pp.paramLC)
+	dst := pp.getPolyCNTT()
+	pp.PolyCNTTVecInnerProductTo(dst, vs, ws, pp.paramLC)
+	if !pp.PolyCNTTEqualCheck(dst, want) {
+		t.Errorf("PolyCNTTVecInnerProductTo differs from PolyCNTTVecInnerProduct")
+	}
+	pp.putPolyCNTT(dst)
+
+	wantVec := pp.PolyCNTTMatrixMulVector(pp.paramMatrixB, vs, pp.paramKC, pp.paramLC)
+	dstVec := pp.getPolyCNTTVec(pp.paramKC)
+	pp.PolyCNTTMatrixMulVectorTo(dstVec, pp.paramMatrixB, vs, pp.paramKC, pp.paramLC)
+	if !pp.PolyCNTTVecEqualCheck(dstVec, wantVec) {
+		t.Errorf("PolyCNTTMatrixMulVectorTo differs from PolyCNTTMatrixMulVector")
+	}
+	pp.putPolyCNTTVec(dstVec)
+
+	//	the scratch polynomials are zero, whatever they held when returned
+	dstVec = pp.getPolyCNTTVec(pp.paramLC)
+	for i := 0; i < pp.paramLC; i++ {
+		for j := 0; j < pp.paramDC; j++ {
+			if dstVec.polyCNTTs[i].coeffs[j] != 0 {
+				t.Fatalf("getPolyCNTTVec returns a non-zero PolyCNTTVec")
+			}
+		}
+	}
+	pp.putPolyCNTTVec(dstVec)
+}
+
+func BenchmarkPublicParameter_PolyCNTTVecInnerProductTo(b *testing.B) {
+	pp := initializeForTest()
+	vec := pp.NewPolyCNTTVec(pp.paramLC)
+	for i := 0; i < pp.paramLC; i++ {
+		coeffs, err := pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen))
+		if err != nil {
+			b.Fatal(err)
+		}
+		vec.polyCNTTs[i] = &PolyCNTT{coeffs: coeffs}
+	}
+	dst := pp.getPolyCNTT()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pp.PolyCNTTVecInnerProductTo(dst, pp.paramMatrixH[0], vec, pp.paramLC)
+	}
+}
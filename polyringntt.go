@@ -0,0 +1,366 @@
+package pqringctx
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+)
+
+//	NTT with precomputed twiddles	begin
+
+// nttTable holds the precomputed twiddles of the NTT and the inverse NTT over Z_q[X]/(X^n+1),
+// which splits X^n+1 into slotNum factors, i.e., X^{n/slotNum} - zeta^{f} with f odd.
+// The twiddles are in Montgomery form (with R = 2^64), so that each butterfly takes a single Montgomery multiplication,
+// and the coefficients are kept in [0, 2q) between the levels (lazy reduction), with only one full reduction at the end.
+// All the arithmetic is in constant time, since the NTT is applied to the polynomials derived from secrets.
+// Note that the level structure (and the order of the output coefficients) is the same as that of the original implementation,
+// which iterates the factors level by level, so that the NTT forms are unchanged.
+type nttTable struct {
+	mod     *ctModulus
+	n       int
+	slotNum int
+
+	// zetas[level][k] is the Montgomery form of the twiddle for the k-th segment at the level of the forward NTT.
+	zetas [][]uint64
+	// invZetas[level][k] is the Montgomery form of 2^{-1} * zeta^{-f} for the k-th pair of segments at the level of the inverse NTT.
+	invZetas [][]uint64
+	// twoInv is the Montgomery form of 2^{-1}.
+	twoInv uint64
+	// segZetas[k] is the Montgomery form of zeta^{f} for the k-th segment of the NTT form, i.e., the segment modulo X^{n/slotNum} - zeta^{f}.
+	segZetas []uint64
+	// maxAccTerms is the maximum number of the polynomial products accumulated before a reduction, i.e., maxAccTerms * (n/slotNum) * q < 2^64.
+	maxAccTerms int
+	// order is nil, or the positions of the coefficients in the NTT form, i.e., the i-th coefficient of the last level is put at order[i].
+	order []int
+
+	// bufPool and accPool are the scratch buffers of the NTT and the accumulators of the products, respectively,
+	// so that the operations do not allocate in the hot paths.
+	bufPool sync.Pool
+	accPool sync.Pool
+}
+
+// newNTTTable precomputes the twiddles from the powers of zeta (zetas[i] = zeta^i, with zeta a primitive zetaOrder-th root of unity),
+// and the factors of the last level (nttFactors), as computed in NewPublicParameter.
+// If reordered is true, the coefficients of the NTT form are reordered by the exponents of the factors, as NTTPolyC does,
+// which is supported only for the fully splitting case, i.e., n = slotNum.
+func newNTTTable(mod *ctModulus, n int, zetas []int64, zetaOrder int, nttFactors []int, reordered bool) *nttTable {
+	slotNum := zetaOrder / 2
+	table := &nttTable{
+		mod:     mod,
+		n:       n,
+		slotNum: slotNum,
+		twoInv:  mod.toMont((mod.q + 1) / 2),
+	}
+	table.bufPool.New = func() interface{} {
+		buf := make([]uint64, n)
+		return &buf
+	}
+	table.accPool.New = func() interface{} {
+		return table.newAcc()
+	}
+
+	//	forward NTT
+	factors := []int{slotNum / 2}
+	for segNum := 1; segNum < slotNum; segNum <<= 1 {
+		levelZetas := make([]uint64, segNum)
+		for k := 0; k < segNum; k++ {
+			levelZetas[k] = mod.toMont(zetas[factors[k]])
+		}
+		table.zetas = append(table.zetas, levelZetas)
+
+		tmpFactors := make([]int, 2*len(factors))
+		for i := 0; i < len(factors); i++ {
+			tmpFactors[2*i] = (factors[i] + slotNum) / 2
+			tmpFactors[2*i+1] = factors[i] / 2
+		}
+		factors = tmpFactors
+	}
+
+	table.maxAccTerms = int(math.MaxUint64 / (uint64(mod.q) * uint64(n/slotNum)))
+
+	table.segZetas = make([]uint64, slotNum)
+	for i := 0; i < len(nttFactors); i++ {
+		table.segZetas[2*i] = mod.toMont(zetas[nttFactors[i]+slotNum])
+		table.segZetas[2*i+1] = mod.toMont(zetas[nttFactors[i]])
+	}
+	if reordered {
+		table.order = make([]int, slotNum)
+		for i := 0; i < len(nttFactors); i++ {
+			table.order[2*i] = (nttFactors[i] + slotNum - 1) / 2
+			table.order[2*i+1] = (nttFactors[i] - 1) / 2
+		}
+	}
+
+	//	inverse NTT
+	factors = make([]int, len(nttFactors))
+	copy(factors, nttFactors)
+	for segNum := slotNum; segNum > 1; segNum >>= 1 {
+		levelInvZetas := make([]uint64, segNum/2)
+		for k := 0; k < segNum/2; k++ {
+			levelInvZetas[k] = mod.toMont(mod.mul(zetas[zetaOrder-factors[k]], (mod.q+1)/2))
+		}
+		table.invZetas = append(table.invZetas, levelInvZetas)
+
+		tmpFactors := make([]int, len(factors)/2)
+		for i := 0; i < len(tmpFactors); i++ {
+			tmpFactors[i] = factors[2*i+1] * 2
+		}
+		factors = tmpFactors
+	}
+
+	return table
+}
+
+// forward returns the NTT form of the input coeffs (any int64), with the results in the centered scope.
+func (table *nttTable) forward(coeffs []int64) []int64 {
+	rst := make([]int64, table.n)
+	table.forwardTo(rst, coeffs)
+	return rst
+}
+
+// forwardTo sets dst to the NTT form of the input coeffs (any int64), with the results in the centered scope.
+// dst may be coeffs itself.
+func (table *nttTable) forwardTo(dst []int64, coeffs []int64) {
+	mod := table.mod
+	q := uint64(mod.q)
+
+	bufPtr := table.bufPool.Get().(*[]uint64)
+	buf := *bufPtr
+	for i := 0; i < table.n; i++ {
+		buf[i] = mod.toUnsigned(coeffs[i])
+	}
+
+	segLen := table.n
+	for _, levelZetas := range table.zetas {
+		segLenHalf := segLen / 2
+		for k, zeta := range levelZetas {
+			lo := buf[k*segLen : k*segLen+segLenHalf]
+			hi := buf[k*segLen+segLenHalf : (k+1)*segLen]
+			for i := range lo {
+				//	X^2 - Y^2 = (X+Y)(X-Y), with (lo, hi) -> (lo - zeta*hi, lo + zeta*hi), all in [0, 2q)
+				t := mod.montMulLazy(hi[i], zeta)
+				a := lo[i]
+				lo[i] = mod.ctSub2Q(a + 2*q - t)
+				hi[i] = mod.ctSub2Q(a + t)
+			}
+		}
+		segLen >>= 1
+	}
+
+	for i := 0; i < table.n; i++ {
+		if table.order == nil {
+			dst[i] = mod.center(mod.ctSubQ(buf[i]))
+		} else {
+			dst[table.order[i]] = mod.center(mod.ctSubQ(buf[i]))
+		}
+	}
+	table.putBuf(bufPtr)
+}
+
+// inverse returns the polynomial of the input NTT form (any int64), with the results in the centered scope.
+func (table *nttTable) inverse(nttCoeffs []int64) []int64 {
+	rst := make([]int64, table.n)
+	table.inverseTo(rst, nttCoeffs)
+	return rst
+}
+
+// inverseTo sets dst to the polynomial of the input NTT form (any int64), with the results in the centered scope.
+// dst may be nttCoeffs itself.
+func (table *nttTable) inverseTo(dst []int64, nttCoeffs []int64) {
+	mod := table.mod
+	q := uint64(mod.q)
+
+	bufPtr := table.bufPool.Get().(*[]uint64)
+	buf := *bufPtr
+	for i := 0; i < table.n; i++ {
+		if table.order == nil {
+			buf[i] = mod.toUnsigned(nttCoeffs[i])
+		} else {
+			buf[i] = mod.toUnsigned(nttCoeffs[table.order[i]])
+		}
+	}
+
+	segLen := table.n / table.slotNum
+	for _, levelInvZetas := range table.invZetas {
+		segLenDouble := segLen * 2
+		for k, invZeta := range levelInvZetas {
+			lo := buf[k*segLenDouble : k*segLenDouble+segLen]
+			hi := buf[k*segLenDouble+segLen : (k+1)*segLenDouble]
+			for i := range lo {
+				//	(lo, hi) -> ((hi + lo)/2, (hi - lo)/2 * zeta^{-f}), all in [0, 2q)
+				a, b := lo[i], hi[i]
+				lo[i] = mod.montMulLazy(b+a, table.twoInv)
+				hi[i] = mod.montMulLazy(b+2*q-a, invZeta)
+			}
+		}
+		segLen <<= 1
+	}
+
+	for i := 0; i < table.n; i++ {
+		dst[i] = mod.center(mod.ctSubQ(buf[i]))
+	}
+	table.putBuf(bufPtr)
+}
+
+// putBuf wipes the scratch buffer and returns it to bufPool, since it may hold the coefficients derived from secrets.
+func (table *nttTable) putBuf(bufPtr *[]uint64) {
+	buf := *bufPtr
+	for i := range buf {
+		buf[i] = 0
+	}
+	table.bufPool.Put(bufPtr)
+}
+
+// mul returns the product of the input a and b in NTT form (any int64), with the results in the centered scope.
+func (table *nttTable) mul(a []int64, b []int64) []int64 {
+	rst := make([]int64, table.n)
+	table.mulTo(rst, a, b)
+	return rst
+}
+
+// mulTo sets dst to the product of the input a and b in NTT form (any int64), with the results in the centered scope.
+// dst may be a or b.
+func (table *nttTable) mulTo(dst []int64, a []int64, b []int64) {
+	acc := table.getAcc()
+	acc.add(a, b)
+	acc.resultTo(dst)
+	table.putAcc(acc)
+}
+
+// innerProduct returns sum_v as[v] * bs[v] in NTT form (any int64), with the results in the centered scope.
+func (table *nttTable) innerProduct(as [][]int64, bs [][]int64) []int64 {
+	acc := table.getAcc()
+	for v := 0; v < len(as); v++ {
+		acc.add(as[v], bs[v])
+	}
+	rst := make([]int64, table.n)
+	acc.resultTo(rst)
+	table.putAcc(acc)
+	return rst
+}
+
+// nttAcc accumulates the products of the polynomials in NTT form, i.e., segment by segment modulo X^{n/slotNum} - zeta^{f}.
+// For each coefficient, the products are accumulated in 128 bits and reduced once (lazy reduction),
+// where at most maxAccTerms products are accumulated before a reduction, so that the accumulation is in the scope of the Montgomery reduction.
+// Note that when the segments have length 1 (fully splitting), it degenerates to the coefficient-wise multiplication,
+// so that the order of the coefficients does not matter.
+// An nttAcc is obtained by getAcc, and must be returned by putAcc after use.
+type nttAcc struct {
+	table *nttTable
+	// accHi and accLo are the 128-bit accumulations of the products since the last reduction.
+	accHi []uint64
+	accLo []uint64
+	// sum is the sum of the reduced accumulations, in [0, q).
+	sum []uint64
+	// terms is the number of the products accumulated since the last reduction.
+	terms int
+
+	//	ua is the operand a in [0, q), and ub (resp. ubz) is the operand b (resp. b * zeta^{f}) in Montgomery form,
+	//	so that the accumulated sum is reduced to the normal form by a single redc.
+	//	They are used only when the segments have length more than 1.
+	ua  []uint64
+	ub  []uint64
+	ubz []uint64
+}
+
+func (table *nttTable) newAcc() *nttAcc {
+	acc := &nttAcc{
+		table: table,
+		accHi: make([]uint64, table.n),
+		accLo: make([]uint64, table.n),
+		sum:   make([]uint64, table.n),
+	}
+	if table.n > table.slotNum {
+		acc.ua = make([]uint64, table.n)
+		acc.ub = make([]uint64, table.n)
+		acc.ubz = make([]uint64, table.n)
+	}
+	return acc
+}
+
+// getAcc returns an nttAcc with zero accumulation.
+func (table *nttTable) getAcc() *nttAcc {
+	return table.accPool.Get().(*nttAcc)
+}
+
+// putAcc wipes the accumulator and returns it to accPool, since it may hold the coefficients derived from secrets.
+func (table *nttTable) putAcc(acc *nttAcc) {
+	for i := 0; i < table.n; i++ {
+		acc.accHi[i], acc.accLo[i], acc.sum[i] = 0, 0, 0
+	}
+	for i := range acc.ua {
+		acc.ua[i], acc.ub[i], acc.ubz[i] = 0, 0, 0
+	}
+	acc.terms = 0
+	table.accPool.Put(acc)
+}
+
+// add accumulates the product of a and b in NTT form (any int64).
+func (acc *nttAcc) add(a []int64, b []int64) {
+	table := acc.table
+	mod := table.mod
+	if acc.terms == table.maxAccTerms {
+		acc.flush()
+	}
+	acc.terms++
+
+	var hi, lo, carry uint64
+	segLen := table.n / table.slotNum
+	if segLen == 1 {
+		for i := 0; i < table.n; i++ {
+			hi, lo = bits.Mul64(mod.toUnsigned(a[i]), mod.toMont(b[i]))
+			acc.accLo[i], carry = bits.Add64(acc.accLo[i], lo, 0)
+			acc.accHi[i] += hi + carry
+		}
+		return
+	}
+
+	ua, ub, ubz := acc.ua, acc.ub, acc.ubz
+	for i := 0; i < table.n; i++ {
+		ua[i] = mod.toUnsigned(a[i])
+		ub[i] = mod.toMont(b[i])
+	}
+	for k := 0; k < table.slotNum; k++ {
+		for j := k * segLen; j < (k+1)*segLen; j++ {
+			ubz[j] = mod.ctSubQ(mod.montMulLazy(ub[j], table.segZetas[k]))
+		}
+	}
+	for k := 0; k < table.slotNum; k++ {
+		seg := k * segLen
+		for j := 0; j < segLen; j++ {
+			//	rst[j] += sum_{i <= j} a[i] * b[j-i] + zeta^{f} * sum_{i > j} a[i] * b[j-i+segLen]
+			accHi, accLo := acc.accHi[seg+j], acc.accLo[seg+j]
+			for i := 0; i <= j; i++ {
+				hi, lo = bits.Mul64(ua[seg+i], ub[seg+j-i])
+				accLo, carry = bits.Add64(accLo, lo, 0)
+				accHi += hi + carry
+			}
+			for i := j + 1; i < segLen; i++ {
+				hi, lo = bits.Mul64(ua[seg+i], ubz[seg+j-i+segLen])
+				accLo, carry = bits.Add64(accLo, lo, 0)
+				accHi += hi + carry
+			}
+			acc.accHi[seg+j], acc.accLo[seg+j] = accHi, accLo
+		}
+	}
+}
+
+// flush reduces the accumulation into sum.
+func (acc *nttAcc) flush() {
+	mod := acc.table.mod
+	for i := 0; i < acc.table.n; i++ {
+		acc.sum[i] = mod.ctSubQ(acc.sum[i] + mod.redc(acc.accHi[i], acc.accLo[i]))
+		acc.accHi[i], acc.accLo[i] = 0, 0
+	}
+	acc.terms = 0
+}
+
+// resultTo sets dst to the accumulated sum, with the results in the centered scope.
+func (acc *nttAcc) resultTo(dst []int64) {
+	acc.flush()
+	for i := 0; i < acc.table.n; i++ {
+		dst[i] = acc.table.mod.center(acc.sum[i])
+	}
+}
+
+//	NTT with precomputed twiddles	end
@@ -0,0 +1,98 @@
+package pqringctx
+
+import (
+	"math/big"
+	"testing"
+)
+
+// polyCMul is the schoolbook multiplication in R_{q_c} = Z_{q_c}[X]/(X^{d_c}+1), as the reference for the NTT.
+func (pp *PublicParameter) polyCMul(a *PolyC, b *PolyC) *PolyC {
+	res := make([]int64, 2*pp.paramDC)
+	bigQC := new(big.Int).SetInt64(pp.paramQC)
+	for i := 0; i < pp.paramDC; i++ {
+		for j := 0; j < pp.paramDC; j++ {
+			left := new(big.Int).SetInt64(a.coeffs[i])
+			right := new(big.Int).SetInt64(b.coeffs[j])
+			left.Mul(left, right)
+			left.Mod(left, bigQC)
+			res[i+j] = reduceInt64(res[i+j]+left.Int64(), pp.paramQC)
+		}
+	}
+	for i := 0; i < pp.paramDC; i++ {
+		res[i] = reduceInt64(res[i]-res[i+pp.paramDC], pp.paramQC)
+	}
+	return &PolyC{coeffs: res[:pp.paramDC]}
+}
+
+// TestNTTTable checks the NTT with the precomputed twiddles against the schoolbook multiplication,
+// and the inner products with the lazy reduction against the sum of the products.
+func TestNTTTable(t *testing.T) {
+	pp := initializeForTest()
+
+	vecLen := 5
+	as := make([]*PolyA, vecLen)
+	bs := make([]*PolyA, vecLen)
+	cs := make([]*PolyC, vecLen)
+	ds := make([]*PolyC, vecLen)
+	for i := 0; i < vecLen; i++ {
+		var err error
+		as[i], bs[i] = pp.NewPolyA(), pp.NewPolyA()
+		if as[i].coeffs, err = pp.randomDaIntegersInQa(RandomBytes(pp.paramKeyGenSeedBytesLen)); err != nil {
+			t.Fatal(err)
+		}
+		if bs[i].coeffs, err = pp.randomDaIntegersInQa(RandomBytes(pp.paramKeyGenSeedBytesLen)); err != nil {
+			t.Fatal(err)
+		}
+		cs[i], ds[i] = pp.NewPolyC(), pp.NewPolyC()
+		if cs[i].coeffs, err = pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen)); err != nil {
+			t.Fatal(err)
+		}
+		if ds[i].coeffs, err = pp.randomDcIntegersInQc(RandomBytes(pp.paramKeyGenSeedBytesLen)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	//	R_{q_a}
+	asNTT := pp.NTTPolyAVec(&PolyAVec{polyAs: as})
+	bsNTT := pp.NTTPolyAVec(&PolyAVec{polyAs: bs})
+	expectedA := pp.NewZeroPolyANTT()
+	for i := 0; i < vecLen; i++ {
+		got := pp.NTTInvPolyA(pp.PolyANTTMul(asNTT.polyANTTs[i], bsNTT.polyANTTs[i]))
+		if !pp.PolyANTTEqualCheck(&PolyANTT{coeffs: got.coeffs}, &PolyANTT{coeffs: pp.PolyAMul(as[i], bs[i]).coeffs}) {
+			t.Fatalf("the product of the %d-th PolyA pair via NTT does not match the schoolbook multiplication", i)
+		}
+		expectedA = pp.PolyANTTAdd(expectedA, pp.PolyANTTMul(asNTT.polyANTTs[i], bsNTT.polyANTTs[i]))
+	}
+	if !pp.PolyANTTEqualCheck(pp.PolyANTTVecInnerProduct(asNTT, bsNTT, vecLen), expectedA) {
+		t.Fatalf("PolyANTTVecInnerProduct does not match the sum of the products")
+	}
+
+	//	R_{q_c}
+	csNTT := pp.NTTPolyCVec(&PolyCVec{polyCs: cs})
+	dsNTT := pp.NTTPolyCVec(&PolyCVec{polyCs: ds})
+	expectedC := pp.NewZeroPolyCNTT()
+	for i := 0; i < vecLen; i++ {
+		got := pp.NTTInvPolyC(pp.PolyCNTTMul(csNTT.polyCNTTs[i], dsNTT.polyCNTTs[i]))
+		if !pp.PolyCNTTEqualCheck(&PolyCNTT{coeffs: got.coeffs}, &PolyCNTT{coeffs: pp.polyCMul(cs[i], ds[i]).coeffs}) {
+			t.Fatalf("the product of the %d-th PolyC pair via NTT does not match the schoolbook multiplication", i)
+		}
+		expectedC = pp.PolyCNTTAdd(expectedC, pp.PolyCNTTMul(csNTT.polyCNTTs[i], dsNTT.polyCNTTs[i]))
+	}
+	if !pp.PolyCNTTEqualCheck(pp.PolyCNTTVecInnerProduct(csNTT, dsNTT, vecLen), expectedC) {
+		t.Fatalf("PolyCNTTVecInnerProduct does not match the sum of the products")
+	}
+
+	//	the accumulation is split into chunks of maxAccTerms products
+	table := pp.paramNTTCTable
+	longA := make([][]int64, 2*table.maxAccTerms+1)
+	longB := make([][]int64, len(longA))
+	expected := make([]int64, pp.paramDC)
+	for v := 0; v < len(longA); v++ {
+		longA[v] = csNTT.polyCNTTs[v%vecLen].coeffs
+		longB[v] = dsNTT.polyCNTTs[v%vecLen].coeffs
+		expected = pp.PolyCNTTAdd(&PolyCNTT{coeffs: expected}, pp.PolyCNTTMul(csNTT.polyCNTTs[v%vecLen], dsNTT.polyCNTTs[v%vecLen])).coeffs
+	}
+	if !pp.PolyCNTTEqualCheck(&PolyCNTT{coeffs: table.innerProduct(longA, longB)}, &PolyCNTT{coeffs: expected}) {
+		t.Fatalf("the inner product over %d terms does not match the sum of the products", len(longA))
+	}
+}
@@ -0,0 +1,75 @@
+package pqringctx
+
+import "sync"
+
+//	Scratch allocator	begin
+
+// polyScratch is a sync.Pool-backed scratch allocator of the temporary PolyCNTT and PolyCNTTVec,
+// which are created and dropped in large numbers by the proofs, e.g., rpulpProveMLP and the balance proofs.
+// Each PublicParameter has its own polyScratch (see newPolyScratch in NewPublicParameter),
+// which is shared by its copies, e.g., those returned by WithRandReader and WithContext, and is safe for concurrent use.
+//
+// The polynomials returned by getPolyCNTT and getPolyCNTTVec are zero.
+// The caller must return them by putPolyCNTT and putPolyCNTTVec once they are no longer used,
+// by a defer right after the get, so that an early (error) return does not skip the wiping,
+// and must not keep any reference to them (including the elements of a PolyCNTTVec) afterwards.
+// As the temporaries may be derived from secrets, they are wiped before they are returned to the pools.
+type polyScratch struct {
+	dc           int
+	polyCNTTs    sync.Pool
+	polyCNTTVecs sync.Pool
+}
+
+func newPolyScratch(dc int) *polyScratch {
+	s := &polyScratch{dc: dc}
+	s.polyCNTTs.New = func() interface{} {
+		return &PolyCNTT{coeffs: make([]int64, dc)}
+	}
+	s.polyCNTTVecs.New = func() interface{} {
+		return &PolyCNTTVec{}
+	}
+	return s
+}
+
+// getPolyCNTT returns a zero PolyCNTT from the scratch allocator.
+func (pp *PublicParameter) getPolyCNTT() *PolyCNTT {
+	return pp.scratch.polyCNTTs.Get().(*PolyCNTT)
+}
+
+// putPolyCNTT wipes polyCNTT and returns it to the scratch allocator.
+func (pp *PublicParameter) putPolyCNTT(polyCNTT *PolyCNTT) {
+	if polyCNTT == nil {
+		return
+	}
+	polyCNTT.wipe()
+	pp.scratch.polyCNTTs.Put(polyCNTT)
+}
+
+// getPolyCNTTVec returns a zero PolyCNTTVec with length vecLen from the scratch allocator.
+func (pp *PublicParameter) getPolyCNTTVec(vecLen int) *PolyCNTTVec {
+	polyCNTTVec := pp.scratch.polyCNTTVecs.Get().(*PolyCNTTVec)
+	//	The elements beyond the length are kept in the backing array, and are reused.
+	polyCNTTs := polyCNTTVec.polyCNTTs[:cap(polyCNTTVec.polyCNTTs)]
+	for len(polyCNTTs) < vecLen {
+		polyCNTTs = append(polyCNTTs, nil)
+		polyCNTTs = polyCNTTs[:cap(polyCNTTs)]
+	}
+	for i := 0; i < vecLen; i++ {
+		if polyCNTTs[i] == nil {
+			polyCNTTs[i] = pp.getPolyCNTT()
+		}
+	}
+	polyCNTTVec.polyCNTTs = polyCNTTs[:vecLen]
+	return polyCNTTVec
+}
+
+// putPolyCNTTVec wipes polyCNTTVec and returns it, together with its elements, to the scratch allocator.
+func (pp *PublicParameter) putPolyCNTTVec(polyCNTTVec *PolyCNTTVec) {
+	if polyCNTTVec == nil {
+		return
+	}
+	polyCNTTVec.wipe()
+	pp.scratch.polyCNTTVecs.Put(polyCNTTVec)
+}
+
+//	Scratch allocator	end
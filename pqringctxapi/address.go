@@ -0,0 +1,283 @@
+package pqringctxapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/pqabelian/pqringctx"
+	"strings"
+)
+
+//	Address Encoding	begin
+
+// AddressNetwork is the network of an encoded address, which determines its human-readable prefix.
+type AddressNetwork uint8
+
+const (
+	AddressNetworkMainnet AddressNetwork = iota
+	AddressNetworkTestnet
+)
+
+// addressHRPs are the human-readable prefixes of the networks.
+var addressHRPs = map[AddressNetwork]string{
+	AddressNetworkMainnet: "abe",
+	AddressNetworkTestnet: "tabe",
+}
+
+// HRP returns the human-readable prefix of the network.
+func (network AddressNetwork) HRP() (string, error) {
+	hrp, ok := addressHRPs[network]
+	if !ok {
+		return "", fmt.Errorf("AddressNetwork.HRP: the network (%d) is not supported", network)
+	}
+	return hrp, nil
+}
+
+// The errors returned by DecodeAddress, which can be checked by errors.Is.
+var (
+	ErrAddressWrongNetwork = errors.New("the address is for another network")
+	ErrAddressBadChecksum  = errors.New("the address has a bad checksum")
+	ErrAddressBadLength    = errors.New("the address has an incorrect length")
+	ErrAddressMalformed    = errors.New("the address is malformed")
+)
+
+const (
+	// addressSeparator separates the human-readable prefix and the data part, as in Bech32m.
+	addressSeparator = '1'
+	// addressCharset is the Bech32 charset, which avoids the characters that are easily mistaken for each other.
+	addressCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	// addressChecksumLen is the length of the checksum in bytes.
+	addressChecksumLen = 8
+)
+
+// EncodeAddress encodes the input (coinAddress, coinValuePublicKey) pair into a human-readable string for the input network,
+// i.e., hrp || '1' || base32(version || coinAddress || coinValuePublicKey || checksum),
+// where version is the CoinAddressType of coinAddress, base32 uses the Bech32 charset,
+// and checksum is the first 8 bytes of Hash(hrp || 0x00 || version || coinAddress || coinValuePublicKey).
+// The coinValuePublicKey is required for the coinAddress with RingCT-privacy, and must be nil/empty for the coinAddress with pseudonym-privacy.
+// NOTE: The Bech32m polymod checksum only guarantees the error detection for strings shorter than 90 characters,
+// while the coinAddresses are several kilobytes, so that a truncated Hash is used as the checksum instead.
+func EncodeAddress(pp *PublicParameter, network AddressNetwork, coinAddress []byte, coinValuePublicKey []byte) (string, error) {
+	payload, err := addressPayload(pp, coinAddress, coinValuePublicKey)
+	if err != nil {
+		return "", fmt.Errorf("EncodeAddress: %v", err)
+	}
+	return encodeAddressData(network, payload)
+}
+
+// DecodeAddress decodes the input encodedAddress, which is generated by EncodeAddress for the input network,
+// and returns the (coinAddress, coinValuePublicKey) pair, where coinValuePublicKey is nil for the coinAddress with pseudonym-privacy.
+// The returned error wraps ErrAddressWrongNetwork, ErrAddressBadChecksum, ErrAddressBadLength, or ErrAddressMalformed.
+func DecodeAddress(pp *PublicParameter, network AddressNetwork, encodedAddress string) (coinAddress []byte, coinValuePublicKey []byte, err error) {
+	payload, err := decodeAddressData(network, encodedAddress, func(version byte) (int, bool) {
+		coinAddressSize, err := pp.GetCoinAddressSize(CoinAddressType(version))
+		if err != nil {
+			return 0, false
+		}
+		valuePublicKeySize, err := addressValuePublicKeySize(pp, CoinAddressType(version))
+		if err != nil {
+			return 0, false
+		}
+		return 1 + coinAddressSize + valuePublicKeySize, true
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("DecodeAddress: %w", err)
+	}
+
+	coinAddressType := CoinAddressType(payload[0])
+	coinAddressSize, _ := pp.GetCoinAddressSize(coinAddressType)
+	coinAddress = make([]byte, coinAddressSize)
+	copy(coinAddress, payload[1:1+coinAddressSize])
+	extractedType, err := pp.ExtractCoinAddressTypeFromCoinAddress(coinAddress)
+	if err != nil || extractedType != coinAddressType {
+		return nil, nil, fmt.Errorf("DecodeAddress: %w: the version (%d) does not match the coinAddress", ErrAddressMalformed, coinAddressType)
+	}
+	if len(payload) > 1+coinAddressSize {
+		coinValuePublicKey = make([]byte, len(payload)-1-coinAddressSize)
+		copy(coinValuePublicKey, payload[1+coinAddressSize:])
+	}
+	return coinAddress, coinValuePublicKey, nil
+}
+
+// addressPayload checks the input (coinAddress, coinValuePublicKey) pair, and returns version || coinAddress || coinValuePublicKey,
+// where version is the CoinAddressType of coinAddress.
+func addressPayload(pp *PublicParameter, coinAddress []byte, coinValuePublicKey []byte) ([]byte, error) {
+	coinAddressType, err := pp.ExtractCoinAddressTypeFromCoinAddress(coinAddress)
+	if err != nil {
+		return nil, err
+	}
+	valuePublicKeySize, err := addressValuePublicKeySize(pp, coinAddressType)
+	if err != nil {
+		return nil, err
+	}
+	if len(coinValuePublicKey) != valuePublicKeySize {
+		return nil, fmt.Errorf("the input coinValuePublicKey has length %d, but %d is expected for the coinAddressType %d", len(coinValuePublicKey), valuePublicKeySize, coinAddressType)
+	}
+
+	payload := make([]byte, 0, 1+len(coinAddress)+len(coinValuePublicKey)+addressChecksumLen)
+	payload = append(payload, byte(coinAddressType))
+	payload = append(payload, coinAddress...)
+	payload = append(payload, coinValuePublicKey...)
+	return payload, nil
+}
+
+// encodeAddressData returns hrp || '1' || base32(payload || checksum), where the first byte of payload is the version.
+func encodeAddressData(network AddressNetwork, payload []byte) (string, error) {
+	hrp, err := network.HRP()
+	if err != nil {
+		return "", err
+	}
+
+	checksum, err := addressChecksum(hrp, payload)
+	if err != nil {
+		return "", err
+	}
+	data := make([]byte, 0, len(payload)+addressChecksumLen)
+	data = append(data, payload...)
+	data = append(data, checksum...)
+
+	var sb strings.Builder
+	sb.Grow(len(hrp) + 1 + (len(data)*8+4)/5)
+	sb.WriteString(hrp)
+	sb.WriteByte(addressSeparator)
+	for _, v := range convertBits(data, 8, 5) {
+		sb.WriteByte(addressCharset[v])
+	}
+	return sb.String(), nil
+}
+
+// decodeAddressData decodes the input encodedAddress generated by encodeAddressData for the input network, and returns the payload.
+// payloadLen returns the length of the payload for a version, and false if the version is not supported.
+// The returned error wraps ErrAddressWrongNetwork, ErrAddressBadChecksum, ErrAddressBadLength, or ErrAddressMalformed.
+func decodeAddressData(network AddressNetwork, encodedAddress string, payloadLen func(version byte) (int, bool)) ([]byte, error) {
+	hrp, err := network.HRP()
+	if err != nil {
+		return nil, err
+	}
+
+	//	As in Bech32m, either all lowercase or all uppercase is allowed.
+	lower := strings.ToLower(encodedAddress)
+	if lower != encodedAddress && strings.ToUpper(encodedAddress) != encodedAddress {
+		return nil, fmt.Errorf("%w: mixed case", ErrAddressMalformed)
+	}
+
+	sepPos := strings.LastIndexByte(lower, addressSeparator)
+	if sepPos < 1 {
+		return nil, fmt.Errorf("%w: no human-readable prefix", ErrAddressMalformed)
+	}
+	if addrHRP := lower[:sepPos]; addrHRP != hrp {
+		for otherNetwork, otherHRP := range addressHRPs {
+			if addrHRP == otherHRP {
+				return nil, fmt.Errorf("%w: the address is for network %d, rather than %d", ErrAddressWrongNetwork, otherNetwork, network)
+			}
+		}
+		return nil, fmt.Errorf("%w: unknown human-readable prefix %q", ErrAddressMalformed, addrHRP)
+	}
+
+	dataPart := lower[sepPos+1:]
+	values := make([]byte, len(dataPart))
+	for i := 0; i < len(dataPart); i++ {
+		v := strings.IndexByte(addressCharset, dataPart[i])
+		if v < 0 {
+			return nil, fmt.Errorf("%w: invalid character %q at position %d", ErrAddressMalformed, dataPart[i], sepPos+1+i)
+		}
+		values[i] = byte(v)
+	}
+	data, ok := convertBitsBack(values)
+	if !ok {
+		return nil, fmt.Errorf("%w: the data part has %d characters, which is not a valid length", ErrAddressBadLength, len(dataPart))
+	}
+	if len(data) < 1+addressChecksumLen {
+		return nil, fmt.Errorf("%w: the data part has only %d bytes", ErrAddressBadLength, len(data))
+	}
+
+	//	The length is checked before the checksum, so that a truncated address is reported as such.
+	//	An unknown version is reported after the checksum, as it is more likely a typo.
+	version := data[0]
+	expectedLen, knownVersion := payloadLen(version)
+	if knownVersion && len(data) != expectedLen+addressChecksumLen {
+		return nil, fmt.Errorf("%w: the data part has %d bytes, but %d bytes are expected for the version %d", ErrAddressBadLength, len(data), expectedLen+addressChecksumLen, version)
+	}
+
+	payload := data[:len(data)-addressChecksumLen]
+	checksum, err := addressChecksum(hrp, payload)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(checksum, data[len(data)-addressChecksumLen:]) {
+		return nil, ErrAddressBadChecksum
+	}
+
+	if !knownVersion {
+		return nil, fmt.Errorf("%w: the version (%d) is not supported", ErrAddressMalformed, version)
+	}
+	return payload, nil
+}
+
+// addressValuePublicKeySize returns the size of the coinValuePublicKey encoded together with the coinAddress of the input coinAddressType,
+// namely the size of coinValuePublicKey for RingCT-privacy, and 0 for pseudonym-privacy.
+func addressValuePublicKeySize(pp *PublicParameter, coinAddressType CoinAddressType) (int, error) {
+	switch coinAddressType {
+	case CoinAddressTypePublicKeyForRingPre, CoinAddressTypePublicKeyForRing:
+		return GetCoinValuePublicKeySize(pp), nil
+	case CoinAddressTypePublicKeyHashForSingle:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("the coinAddressType (%d) is not supported", coinAddressType)
+	}
+}
+
+// addressChecksum returns the first addressChecksumLen bytes of Hash(hrp || 0x00 || payload).
+func addressChecksum(hrp string, payload []byte) ([]byte, error) {
+	data := make([]byte, 0, len(hrp)+1+len(payload))
+	data = append(data, hrp...)
+	data = append(data, 0)
+	data = append(data, payload...)
+	digest, err := pqringctx.Hash(data)
+	if err != nil {
+		return nil, err
+	}
+	return digest[:addressChecksumLen], nil
+}
+
+// convertBits regroups the input data of fromBits-bit groups into toBits-bit groups, padding the last group with zeros.
+func convertBits(data []byte, fromBits uint, toBits uint) []byte {
+	rst := make([]byte, 0, (uint(len(data))*fromBits+toBits-1)/toBits)
+	acc := uint(0)
+	bits := uint(0)
+	maxV := uint(1)<<toBits - 1
+	for _, v := range data {
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			rst = append(rst, byte(acc>>bits&maxV))
+		}
+	}
+	if bits > 0 {
+		rst = append(rst, byte(acc<<(toBits-bits)&maxV))
+	}
+	return rst
+}
+
+// convertBitsBack regroups the input 5-bit groups into bytes,
+// and returns false if the padding is longer than 4 bits or is not zero, e.g., the data part is truncated.
+func convertBitsBack(values []byte) ([]byte, bool) {
+	rst := make([]byte, 0, len(values)*5/8)
+	acc := uint(0)
+	bits := uint(0)
+	for _, v := range values {
+		acc = acc<<5 | uint(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			rst = append(rst, byte(acc>>bits))
+		}
+	}
+	if bits >= 5 || acc&(1<<bits-1) != 0 {
+		return nil, false
+	}
+	return rst, true
+}
+
+//	Address Encoding	end
@@ -0,0 +1,98 @@
+package pqringctxapi
+
+import (
+	"bytes"
+	"errors"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"strings"
+	"testing"
+)
+
+func TestEncodeAddress_DecodeAddress(t *testing.T) {
+	pp := InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+
+	coinAddressForRing, _, _, err := CoinAddressKeyForPKRingGen(pp,
+		randomBytesForTest(t, GetParamSeedBytesLen(pp)), randomBytesForTest(t, GetParamSeedBytesLen(pp)),
+		randomBytesForTest(t, GetParamMACKeyBytesLen(pp)), randomBytesForTest(t, GetParamKeyGenPublicRandBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinAddressKeyForPKRingGen: %v", err)
+	}
+	coinValuePublicKey, _, err := CoinValueKeyGen(pp, randomBytesForTest(t, GetParamSeedBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinValueKeyGen: %v", err)
+	}
+	coinAddressForSingle, _, err := CoinAddressKeyForPKHSingleGen(pp,
+		randomBytesForTest(t, GetParamSeedBytesLen(pp)), randomBytesForTest(t, GetParamMACKeyBytesLen(pp)), randomBytesForTest(t, GetParamKeyGenPublicRandBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinAddressKeyForPKHSingleGen: %v", err)
+	}
+
+	tests := []struct {
+		name               string
+		coinAddress        []byte
+		coinValuePublicKey []byte
+	}{
+		{"ring", coinAddressForRing, coinValuePublicKey},
+		{"single", coinAddressForSingle, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := EncodeAddress(pp, AddressNetworkMainnet, tt.coinAddress, tt.coinValuePublicKey)
+			if err != nil {
+				t.Fatalf("EncodeAddress: %v", err)
+			}
+			if !strings.HasPrefix(encoded, "abe1") {
+				t.Fatalf("EncodeAddress: the encoded address %q... does not have the mainnet prefix", encoded[:10])
+			}
+
+			for _, input := range []string{encoded, strings.ToUpper(encoded)} {
+				coinAddress, coinValuePublicKey, err := DecodeAddress(pp, AddressNetworkMainnet, input)
+				if err != nil {
+					t.Fatalf("DecodeAddress: %v", err)
+				}
+				if !bytes.Equal(coinAddress, tt.coinAddress) || !bytes.Equal(coinValuePublicKey, tt.coinValuePublicKey) {
+					t.Fatalf("DecodeAddress: the decoded keys differ from the encoded ones")
+				}
+			}
+
+			//	wrong network
+			if _, _, err = DecodeAddress(pp, AddressNetworkTestnet, encoded); !errors.Is(err, ErrAddressWrongNetwork) {
+				t.Errorf("DecodeAddress with the wrong network: error = %v, want %v", err, ErrAddressWrongNetwork)
+			}
+
+			//	a typo
+			pos := len(encoded) / 2
+			typo := []byte(encoded)
+			if typo[pos] == 'q' {
+				typo[pos] = 'p'
+			} else {
+				typo[pos] = 'q'
+			}
+			if _, _, err = DecodeAddress(pp, AddressNetworkMainnet, string(typo)); !errors.Is(err, ErrAddressBadChecksum) {
+				t.Errorf("DecodeAddress with a typo: error = %v, want %v", err, ErrAddressBadChecksum)
+			}
+
+			//	truncated
+			for _, cut := range []int{1, 8, 100} {
+				if _, _, err = DecodeAddress(pp, AddressNetworkMainnet, encoded[:len(encoded)-cut]); !errors.Is(err, ErrAddressBadLength) {
+					t.Errorf("DecodeAddress with %d characters truncated: error = %v, want %v", cut, err, ErrAddressBadLength)
+				}
+			}
+
+			//	malformed
+			for _, malformed := range []string{encoded[:4] + "b" + encoded[5:], "abe", strings.ToUpper(encoded[:10]) + encoded[10:], "xyz" + encoded[3:]} {
+				if _, _, err = DecodeAddress(pp, AddressNetworkMainnet, malformed); !errors.Is(err, ErrAddressMalformed) {
+					t.Errorf("DecodeAddress with a malformed address: error = %v, want %v", err, ErrAddressMalformed)
+				}
+			}
+		})
+	}
+
+	//	the coinValuePublicKey is required exactly for RingCT-privacy
+	if _, err = EncodeAddress(pp, AddressNetworkMainnet, coinAddressForRing, nil); err == nil {
+		t.Errorf("EncodeAddress should fail for a coinAddress with RingCT-privacy but no coinValuePublicKey")
+	}
+	if _, err = EncodeAddress(pp, AddressNetworkMainnet, coinAddressForSingle, coinValuePublicKey); err == nil {
+		t.Errorf("EncodeAddress should fail for a coinAddress with pseudonym-privacy and a coinValuePublicKey")
+	}
+}
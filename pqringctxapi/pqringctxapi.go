@@ -1,8 +1,11 @@
 package pqringctxapi
 
 import (
+	"context"
 	"fmt"
 	"github.com/pqabelian/pqringctx"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"io"
 )
 
 // PublicParameter is defined the alias of pqringctx.PublicParameter,
@@ -49,12 +52,73 @@ type LgrTxoMLP = pqringctx.LgrTxoMLP
 type TxWitnessCbTx = pqringctx.TxWitnessCbTx
 type TxWitnessTrTx = pqringctx.TxWitnessTrTx
 
+// Scanner detects and decodes the owned Txos from a batch of transactions, for a wallet.
+type Scanner = pqringctx.Scanner
+
+// OwnedTxoMLP is an owned Txo found by Scanner, with its decoded value and its index.
+type OwnedTxoMLP = pqringctx.OwnedTxoMLP
+
+// ViewKey packages the view authority (coinDetectorKey, coinValueKey pair, and coinAddresses) of a wallet,
+// which can detect the owned Txos and decrypt their values, but cannot spend them.
+type ViewKey = pqringctx.ViewKey
+
+// TransferTxMLPSizeDesc describes the shape of a planned TransferTxMLP, which determines its serialize size.
+type TransferTxMLPSizeDesc = pqringctx.TransferTxMLPSizeDesc
+
+// FeePolicy computes the required fee of a TransferTxMLP from its serialize size (with witness) and its number of inputs.
+type FeePolicy = pqringctx.FeePolicy
+type FeePolicyFunc = pqringctx.FeePolicyFunc
+type PerByteFeePolicy = pqringctx.PerByteFeePolicy
+type PerInputFeePolicy = pqringctx.PerInputFeePolicy
+
+// ErrorCode classifies the failures of the sanity-check, (de)serialization, and verification of the transactions.
+// Each ErrorCode serves as the sentinel for errors.Is.
+type ErrorCode = pqringctx.ErrorCode
+
+const (
+	ErrMalformedEncoding    = pqringctx.ErrMalformedEncoding
+	ErrRingMemberInvalid    = pqringctx.ErrRingMemberInvalid
+	ErrSerialNumberMismatch = pqringctx.ErrSerialNumberMismatch
+	ErrDoubleSpending       = pqringctx.ErrDoubleSpending
+	ErrSignatureInvalid     = pqringctx.ErrSignatureInvalid
+	ErrBalanceProofFailed   = pqringctx.ErrBalanceProofFailed
+	ErrRangeProofFailed     = pqringctx.ErrRangeProofFailed
+)
+
+// TxError carries the ErrorCode and the index of the related input/output, and can be obtained by errors.As.
+type TxError = pqringctx.TxError
+
+// GetErrorCode returns the ErrorCode carried by the input err, and 0 if there is none.
+func GetErrorCode(err error) ErrorCode {
+	return pqringctx.GetErrorCode(err)
+}
+
+// TransferTxMLPProposal is an unsigned TransferTxMLP, which is generated by an online watch-only wallet and signed by an offline signer.
+type TransferTxMLPProposal = pqringctx.TransferTxMLPProposal
+
+// TransferTxMLPView is a read-only view over a serialized TransferTxMLP, which does not deserialize the txInputs, Txos, and txWitness.
+type TransferTxMLPView = pqringctx.TransferTxMLPView
+
+// SecretBytes holds secret key material, which can be wiped from memory by Destroy.
+type SecretBytes = pqringctx.SecretBytes
+
+// NewSecretBytes wraps the input b into a SecretBytes, which takes over the ownership of b.
+func NewSecretBytes(b []byte) *SecretBytes {
+	return pqringctx.NewSecretBytes(b)
+}
+
 // InitializePQRingCTX is the init function, it must be called explicitly when using this PQRingCTX.
 // After calling this initialization, the caller can use the returned PublicParameter to call PQRingCTX's API.
 func InitializePQRingCTX(parameterSeedString []byte) *PublicParameter {
 	return pqringctx.Initialize(parameterSeedString)
 }
 
+// InitializePQRingCTXWithParamKem is the same as InitializePQRingCTX, except that the KEM is specified by paramKem,
+// e.g., pqringctxkem.KEM_MLKEM768 for the binaries built without cgo (see pqringctx.InitializeWithParamKem).
+func InitializePQRingCTXWithParamKem(parameterSeedString []byte, paramKem *pqringctxkem.ParamKem) *PublicParameter {
+	return pqringctx.InitializeWithParamKem(parameterSeedString, paramKem)
+}
+
 // CoinAddressKeyForPKRingGen generates coinAddress, coinSpendKey, and coinSnKey
 // for the key which will be used to host the coins with full-privacy.
 // Note that keys are purely in cryptography, we export bytes,
@@ -110,6 +174,62 @@ func CoinValueKeyVerify(pp *PublicParameter, coinValuePublicKey []byte, coinValu
 	return false, fmt.Errorf("%s", hints)
 }
 
+// CoinAddressKeyForPKRingDerive generates coinAddress, coinSpendSecretKey, and coinSerialNumberSecretKey
+// for the input (account, index) path from the input masterSeed.
+// The coinAddress is tagged by the coinDetectorKey from CoinDetectorKeyDerive on the same account.
+func CoinAddressKeyForPKRingDerive(pp *PublicParameter, masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey []byte, coinSerialNumberSecretKey []byte, err error) {
+	return pp.CoinAddressKeyForPKRingDerive(masterSeed, account, index)
+}
+
+// CoinAddressKeyForPKHSingleDerive generates coinAddress and coinSpendSecretKey
+// for the input (account, index) path from the input masterSeed.
+// The coinAddress is tagged by the coinDetectorKey from CoinDetectorKeyDerive on the same account.
+func CoinAddressKeyForPKHSingleDerive(pp *PublicParameter, masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey []byte, err error) {
+	return pp.CoinAddressKeyForPKHSingleDerive(masterSeed, account, index)
+}
+
+// CoinDetectorKeyDerive derives the coinDetectorKey of the input account from the input masterSeed.
+func CoinDetectorKeyDerive(pp *PublicParameter, masterSeed []byte, account uint32) (coinDetectorKey []byte, err error) {
+	return pp.CoinDetectorKeyDerive(masterSeed, account)
+}
+
+// CoinValueKeyDerive generates coinValuePublicKey and coinValueSecretKey for the input account from the input masterSeed.
+func CoinValueKeyDerive(pp *PublicParameter, masterSeed []byte, account uint32) (coinValuePublicKey []byte, coinValueSecretKey []byte, err error) {
+	return pp.CoinValueKeyDerive(masterSeed, account)
+}
+
+// CoinAddressKeyForPKRingGenSecret is the same as CoinAddressKeyForPKRingGen, except that it returns the secret keys as SecretBytes.
+func CoinAddressKeyForPKRingGenSecret(pp *PublicParameter,
+	coinSpendKeyRandSeed []byte, coinSerialNumberKeyRandSeed []byte,
+	coinDetectorKey []byte, publicRand []byte) (coinAddress []byte, coinSpendSecretKey *SecretBytes, coinSerialNumberSecretKey *SecretBytes, err error) {
+	return pp.CoinAddressKeyForPKRingGenSecret(coinSpendKeyRandSeed, coinSerialNumberKeyRandSeed, coinDetectorKey, publicRand)
+}
+
+// CoinAddressKeyForPKHSingleGenSecret is the same as CoinAddressKeyForPKHSingleGen, except that it returns the secret key as SecretBytes.
+func CoinAddressKeyForPKHSingleGenSecret(pp *PublicParameter, coinSpendKeyRandSeed []byte, coinDetectorKey []byte, publicRand []byte) (coinAddress []byte, coinSpendSecretKey *SecretBytes, err error) {
+	return pp.CoinAddressKeyForPKHSingleGenSecret(coinSpendKeyRandSeed, coinDetectorKey, publicRand)
+}
+
+// CoinValueKeyGenSecret is the same as CoinValueKeyGen, except that it returns the secret key as SecretBytes.
+func CoinValueKeyGenSecret(pp *PublicParameter, randSeed []byte) (coinValuePublicKey []byte, coinValueSecretKey *SecretBytes, err error) {
+	return pp.CoinValueKeyGenSecret(randSeed)
+}
+
+// CoinAddressKeyForPKRingDeriveSecret is the same as CoinAddressKeyForPKRingDerive, except that it returns the secret keys as SecretBytes.
+func CoinAddressKeyForPKRingDeriveSecret(pp *PublicParameter, masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey *SecretBytes, coinSerialNumberSecretKey *SecretBytes, err error) {
+	return pp.CoinAddressKeyForPKRingDeriveSecret(masterSeed, account, index)
+}
+
+// CoinAddressKeyForPKHSingleDeriveSecret is the same as CoinAddressKeyForPKHSingleDerive, except that it returns the secret key as SecretBytes.
+func CoinAddressKeyForPKHSingleDeriveSecret(pp *PublicParameter, masterSeed []byte, account uint32, index uint32) (coinAddress []byte, coinSpendSecretKey *SecretBytes, err error) {
+	return pp.CoinAddressKeyForPKHSingleDeriveSecret(masterSeed, account, index)
+}
+
+// CoinValueKeyDeriveSecret is the same as CoinValueKeyDerive, except that it returns the secret key as SecretBytes.
+func CoinValueKeyDeriveSecret(pp *PublicParameter, masterSeed []byte, account uint32) (coinValuePublicKey []byte, coinValueSecretKey *SecretBytes, err error) {
+	return pp.CoinValueKeyDeriveSecret(masterSeed, account)
+}
+
 // NewTxOutputDescMLP constructs a new TxOutputDescMLP from the input coinAddress, serializedVPK, and value.
 // To support Multi-Level Privacy (MLP), the value public key field can be nil
 // reviewed on 2023.12.07
@@ -126,6 +246,20 @@ func CoinbaseTxGen(pp *PublicParameter, vin uint64, txOutputDescs []*TxOutputDes
 	return pp.CoinbaseTxMLPGen(vin, txOutputDescs, txMemo)
 }
 
+// CoinbaseTxGenWithRand is the same as CoinbaseTxGen, except that all the randomness is read from randReader.
+// With a deterministic randReader, the generated CoinbaseTxMLP is reproducible byte-for-byte,
+// which is useful for test vectors and bug replay, but must not be used for real transactions.
+// Note that a non-nil randReader is rejected by KEM_KYBER and KEM_OQS_KYBER (see PublicParameter.WithRandReader).
+func CoinbaseTxGenWithRand(pp *PublicParameter, randReader io.Reader, vin uint64, txOutputDescs []*TxOutputDescMLP, txMemo []byte) (cbTx *CoinbaseTxMLP, err error) {
+	return pp.WithRandReader(randReader).CoinbaseTxMLPGen(vin, txOutputDescs, txMemo)
+}
+
+// CoinbaseTxGenWithContext is the same as CoinbaseTxGen, except that it aborts once ctx is done,
+// returning an error that wraps ctx.Err().
+func CoinbaseTxGenWithContext(ctx context.Context, pp *PublicParameter, vin uint64, txOutputDescs []*TxOutputDescMLP, txMemo []byte) (cbTx *CoinbaseTxMLP, err error) {
+	return pp.WithContext(ctx).CoinbaseTxMLPGen(vin, txOutputDescs, txMemo)
+}
+
 // NewCoinbaseTxMLP constructs a new CoinbaseTxMLP from the input (vin uint64, txos []TxoMLP, txMemo []byte, txWitnessCbTx *TxWitnessCbTx).
 // reviewed on 2023.12.07
 func NewCoinbaseTxMLP(vin uint64, txos []TxoMLP, txMemo []byte, txWitnessCbTx *TxWitnessCbTx) (cbTx *CoinbaseTxMLP) {
@@ -138,6 +272,24 @@ func CoinbaseTxVerify(pp *PublicParameter, cbTx *CoinbaseTxMLP) error {
 	return pp.CoinbaseTxMLPVerify(cbTx)
 }
 
+// CoinbaseTxVerifyWithContext is the same as CoinbaseTxVerify, except that it aborts once ctx is done,
+// returning an error that wraps ctx.Err().
+func CoinbaseTxVerifyWithContext(ctx context.Context, pp *PublicParameter, cbTx *CoinbaseTxMLP) error {
+	return pp.WithContext(ctx).CoinbaseTxMLPVerify(cbTx)
+}
+
+// CoinbaseTxVerifyBatch verifies the input CoinbaseTxMLPs concurrently, and returns the per-transaction results,
+// where errs[i] == nil implies that cbTxs[i] is valid.
+func CoinbaseTxVerifyBatch(pp *PublicParameter, cbTxs []*CoinbaseTxMLP) (errs []error) {
+	return pp.VerifyCoinbaseTxMLPBatch(cbTxs)
+}
+
+// CoinbaseTxSanityCheck checks whether the input CoinbaseTxMLP is well-form, without verifying its witness,
+// and returns a *TxError describing the failure, where withWitness specifies whether the witness is checked to be present and well-form.
+func CoinbaseTxSanityCheck(pp *PublicParameter, cbTx *CoinbaseTxMLP, withWitness bool) error {
+	return pp.CoinbaseTxMLPSanityCheck(cbTx, withWitness)
+}
+
 // NewTxInputDescMLP constructs a TxInputDescMLP, using the same inputs.
 // reviewed on 2023.12.21
 func NewTxInputDescMLP(lgrTxoList []*LgrTxoMLP, sidx uint8, coinSpendSecretKey []byte, coinSerialNumberSecretKey []byte,
@@ -145,6 +297,13 @@ func NewTxInputDescMLP(lgrTxoList []*LgrTxoMLP, sidx uint8, coinSpendSecretKey [
 	return pqringctx.NewTxInputDescMLP(lgrTxoList, sidx, coinSpendSecretKey, coinSerialNumberSecretKey, coinValuePublicKey, coinValueSecretKey, coinDetectorKey, value)
 }
 
+// NewTxInputDescMLPSecret constructs a TxInputDescMLP, taking the secret keys as SecretBytes,
+// which should be destroyed only after the transaction is generated.
+func NewTxInputDescMLPSecret(lgrTxoList []*LgrTxoMLP, sidx uint8, coinSpendSecretKey *SecretBytes, coinSerialNumberSecretKey *SecretBytes,
+	coinValuePublicKey []byte, coinValueSecretKey *SecretBytes, coinDetectorKey []byte, value uint64) *TxInputDescMLP {
+	return pqringctx.NewTxInputDescMLPSecret(lgrTxoList, sidx, coinSpendSecretKey, coinSerialNumberSecretKey, coinValuePublicKey, coinValueSecretKey, coinDetectorKey, value)
+}
+
 // TransferTxGen generates TransferTxMLP.
 // As the caller may decompose the components of the generated TransferTx
 // to make a chain-layer transaction,
@@ -154,12 +313,60 @@ func TransferTxGen(pp *PublicParameter, txInputDescs []*TxInputDescMLP, txOutput
 	return pp.TransferTxMLPGen(txInputDescs, txOutputDescs, fee, txMemo)
 }
 
+// TransferTxGenWithRand is the same as TransferTxGen, except that all the randomness is read from randReader.
+// With a deterministic randReader, the generated TransferTxMLP is reproducible byte-for-byte,
+// which is useful for test vectors and bug replay, but must not be used for real transactions.
+// Note that a non-nil randReader is rejected by KEM_KYBER and KEM_OQS_KYBER (see PublicParameter.WithRandReader).
+func TransferTxGenWithRand(pp *PublicParameter, randReader io.Reader, txInputDescs []*TxInputDescMLP, txOutputDescs []*TxOutputDescMLP, fee uint64, txMemo []byte) (trTx *TransferTxMLP, err error) {
+	return pp.WithRandReader(randReader).TransferTxMLPGen(txInputDescs, txOutputDescs, fee, txMemo)
+}
+
+// TransferTxGenWithContext is the same as TransferTxGen, except that it aborts once ctx is done,
+// returning an error that wraps ctx.Err().
+func TransferTxGenWithContext(ctx context.Context, pp *PublicParameter, txInputDescs []*TxInputDescMLP, txOutputDescs []*TxOutputDescMLP, fee uint64, txMemo []byte) (trTx *TransferTxMLP, err error) {
+	return pp.WithContext(ctx).TransferTxMLPGen(txInputDescs, txOutputDescs, fee, txMemo)
+}
+
 // NewTxInputMLP constructs a new TxInputMLP using the input (lgrTxoList []*LgrTxoMLP, serialNumber []byte).
 // reviewed on 2023.12.21
 func NewTxInputMLP(lgrTxoList []*LgrTxoMLP, serialNumber []byte) (txInputMLP *TxInputMLP) {
 	return pqringctx.NewTxInputMLP(lgrTxoList, serialNumber)
 }
 
+// TransferTxProposalGen generates a TransferTxMLPProposal, which does not need the coinSpendSecretKey and coinSerialNumberSecretKey in txInputDescs.
+// The openings in the proposal are encrypted to signerValuePublicKey, which the offline signer generates by CoinValueKeyGen.
+// The proposal is serialized by SerializeTransferTxProposal and transferred to the offline signer.
+func TransferTxProposalGen(pp *PublicParameter, txInputDescs []*TxInputDescMLP, txOutputDescs []*TxOutputDescMLP, fee uint64, txMemo []byte, signerValuePublicKey []byte) (*TransferTxMLPProposal, error) {
+	return pp.TransferTxMLPProposalGen(txInputDescs, txOutputDescs, fee, txMemo, signerValuePublicKey)
+}
+
+// TransferTxProposalOpen decrypts the openings of the input TransferTxMLPProposal by the signer's (signerValuePublicKey, signerValueSecretKey),
+// so that the signer can review and sign it.
+func TransferTxProposalOpen(pp *PublicParameter, proposal *TransferTxMLPProposal, signerValuePublicKey []byte, signerValueSecretKey []byte) error {
+	return pp.TransferTxMLPProposalOpen(proposal, signerValuePublicKey, signerValueSecretKey)
+}
+
+// TransferTxProposalSign completes the input (opened) TransferTxMLPProposal to a TransferTxMLP,
+// using coinSpendSecretKeys[i] and coinSerialNumberSecretKeys[i] for the i-th input of the proposal.
+func TransferTxProposalSign(pp *PublicParameter, proposal *TransferTxMLPProposal, coinSpendSecretKeys [][]byte, coinSerialNumberSecretKeys [][]byte) (*TransferTxMLP, error) {
+	return pp.TransferTxMLPProposalSign(proposal, coinSpendSecretKeys, coinSerialNumberSecretKeys)
+}
+
+// TransferTxProposalSignSecret is the same as TransferTxProposalSign, except that it takes the secret keys as SecretBytes.
+func TransferTxProposalSignSecret(pp *PublicParameter, proposal *TransferTxMLPProposal, coinSpendSecretKeys []*SecretBytes, coinSerialNumberSecretKeys []*SecretBytes) (*TransferTxMLP, error) {
+	return pp.TransferTxMLPProposalSignSecret(proposal, coinSpendSecretKeys, coinSerialNumberSecretKeys)
+}
+
+// SerializeTransferTxProposal serializes the input TransferTxMLPProposal.
+func SerializeTransferTxProposal(pp *PublicParameter, proposal *TransferTxMLPProposal) ([]byte, error) {
+	return pp.SerializeTransferTxMLPProposal(proposal)
+}
+
+// DeserializeTransferTxProposal deserializes the input []byte to a TransferTxMLPProposal, which is to be opened by TransferTxProposalOpen.
+func DeserializeTransferTxProposal(pp *PublicParameter, serializedProposal []byte) (*TransferTxMLPProposal, error) {
+	return pp.DeserializeTransferTxMLPProposal(serializedProposal)
+}
+
 // NewTransferTxMLP constructs a new TransferTxMLP using the input (txInputs []*TxInputMLP, txos []TxoMLP, fee uint64, txMemo []byte, txWitnessTrTx *TxWitnessTrTx).
 // reviewed on 2023.12.21
 func NewTransferTxMLP(txInputs []*TxInputMLP, txos []TxoMLP, fee uint64, txMemo []byte, txWitnessTrTx *TxWitnessTrTx) (trTx *TransferTxMLP) {
@@ -172,6 +379,25 @@ func TransferTxVerify(pp *PublicParameter, trTx *TransferTxMLP) error {
 	return pp.TransferTxMLPVerify(trTx)
 }
 
+// TransferTxVerifyWithContext is the same as TransferTxVerify, except that it aborts once ctx is done,
+// returning an error that wraps ctx.Err().
+func TransferTxVerifyWithContext(ctx context.Context, pp *PublicParameter, trTx *TransferTxMLP) error {
+	return pp.WithContext(ctx).TransferTxMLPVerify(trTx)
+}
+
+// TransferTxVerifyBatch verifies the input TransferTxMLPs concurrently, and returns the per-transaction results,
+// where errs[i] == nil implies that trTxs[i] is valid.
+// The ring members shared by the transactions are expanded only once.
+func TransferTxVerifyBatch(pp *PublicParameter, trTxs []*TransferTxMLP) (errs []error) {
+	return pp.VerifyTransferTxMLPBatch(trTxs)
+}
+
+// TransferTxSanityCheck checks whether the input TransferTxMLP is well-form, without verifying its witness,
+// and returns a *TxError describing the failure, where withWitness specifies whether the witness is checked to be present and well-form.
+func TransferTxSanityCheck(pp *PublicParameter, trTx *TransferTxMLP, withWitness bool) error {
+	return pp.TransferTxMLPSanityCheck(trTx, withWitness)
+}
+
 // API for AddressKeys	begin
 
 // ExtractCoinAddressTypeFromCoinAddress extracts the CoinAddressType from the input coinAddress,
@@ -295,6 +521,16 @@ func GetTxInputMaxNumForSingle(pp *PublicParameter) int {
 	return int(pp.GetTxInputMaxNumForSingle())
 }
 
+// GetTxInputMaxNumForSingleDistinct returns the allowed maximum number of distinct coinAddresses of the pseudonym-privacy TxInputs.
+func GetTxInputMaxNumForSingleDistinct(pp *PublicParameter) int {
+	return int(pp.GetTxInputMaxNumForSingleDistinct())
+}
+
+// GetRingSizeMax returns the allowed maximum ring size of a RingCT-privacy TxInput.
+func GetRingSizeMax(pp *PublicParameter) int {
+	return int(pp.GetParamRingSizeMax())
+}
+
 // GetTxOutputMaxNum returns the allowed maximum number of TxOutputs.
 // reviewed on 2024.01.01
 func GetTxOutputMaxNum(pp *PublicParameter) int {
@@ -415,6 +651,44 @@ func LedgerTxoSerialNumberGen(pp *PublicParameter, lgrTxo *LgrTxoMLP, coinSerial
 //	return pp.TxoCoinSerialNumberGen(lgrTxo, coinSerialNumberSecretKey)
 //}
 
+// NewScanner creates a Scanner without any key.
+// The caller configures it by Scanner.AddCoinDetectorKey and Scanner.AddCoinAddress,
+// then scans the transactions by Scanner.ScanCoinbaseTxs and Scanner.ScanTransferTxs,
+// or by Scanner.ScanSerializedCoinbaseTxs and Scanner.ScanSerializedTransferTxs.
+func NewScanner(pp *PublicParameter) *Scanner {
+	return pp.NewScanner()
+}
+
+// NewViewKey constructs a ViewKey from the input coinDetectorKey, (coinValuePublicKey, coinValueSecretKey) pair, and coinAddresses.
+func NewViewKey(pp *PublicParameter, coinDetectorKey []byte, coinValuePublicKey []byte, coinValueSecretKey []byte, coinAddresses [][]byte) (*ViewKey, error) {
+	return pp.NewViewKey(coinDetectorKey, coinValuePublicKey, coinValueSecretKey, coinAddresses)
+}
+
+// ViewKeyDerive derives the ViewKey of the input account from the input masterSeed.
+func ViewKeyDerive(pp *PublicParameter, masterSeed []byte, account uint32) (*ViewKey, error) {
+	return pp.ViewKeyDerive(masterSeed, account)
+}
+
+// SerializeViewKey serializes the input ViewKey to []byte, so that it can be exported.
+func SerializeViewKey(pp *PublicParameter, viewKey *ViewKey) ([]byte, error) {
+	return pp.SerializeViewKey(viewKey)
+}
+
+// DeserializeViewKey deserializes the input []byte to a ViewKey.
+func DeserializeViewKey(pp *PublicParameter, serializedViewKey []byte) (*ViewKey, error) {
+	return pp.DeserializeViewKey(serializedViewKey)
+}
+
+// NewScannerWithViewKey creates a Scanner configured by the input ViewKey, to scan the history with only the view authority.
+func NewScannerWithViewKey(pp *PublicParameter, viewKey *ViewKey) (*Scanner, error) {
+	return pp.NewScannerWithViewKey(viewKey)
+}
+
+// ViewKeyTxoCoinReceive checks whether the input txo is viewed by the input ViewKey, and if true, extracts its value.
+func ViewKeyTxoCoinReceive(pp *PublicParameter, viewKey *ViewKey, txo TxoMLP) (valid bool, value uint64, err error) {
+	return pp.ViewKeyTxoCoinReceive(viewKey, txo)
+}
+
 // APIs	for Txo	end
 
 // APIs for Witness 	begin
@@ -443,6 +717,22 @@ func GetTxWitnessTrTxSerializeSizeByDesc(pp *PublicParameter, inForRing uint8, i
 	return pp.GetTxWitnessTrTxSerializeSizeByDesc(inForRing, inForSingleDistinct, outForRing, inRingSizes, vPublic)
 }
 
+// NewTransferTxSizeDesc constructs a TransferTxMLPSizeDesc from the planned TxInputDescMLP/TxOutputDescMLP lists and txMemo.
+func NewTransferTxSizeDesc(pp *PublicParameter, txInputDescs []*TxInputDescMLP, txOutputDescs []*TxOutputDescMLP, txMemo []byte) (*TransferTxMLPSizeDesc, error) {
+	return pp.NewTransferTxMLPSizeDesc(txInputDescs, txOutputDescs, txMemo)
+}
+
+// GetTransferTxSerializeSizeByDesc returns the exact serialize size of the TransferTxMLP described by the input TransferTxMLPSizeDesc and fee.
+func GetTransferTxSerializeSizeByDesc(pp *PublicParameter, desc *TransferTxMLPSizeDesc, fee uint64, withWitness bool) (int, error) {
+	return pp.TransferTxMLPSerializeSizeByDesc(desc, fee, withWitness)
+}
+
+// TransferTxFeeEstimate solves for the fee of the TransferTxMLP described by the input TransferTxMLPSizeDesc under the input FeePolicy,
+// and returns the fee together with the resulting serialize size (with witness).
+func TransferTxFeeEstimate(pp *PublicParameter, desc *TransferTxMLPSizeDesc, feePolicy FeePolicy) (fee uint64, txSize int, err error) {
+	return pp.TransferTxMLPFeeEstimate(desc, feePolicy)
+}
+
 // SerializeTxWitnessTrTx serializes TxWitnessTrTx to []byte.
 // reviewed on 2023.12.21
 func SerializeTxWitnessTrTx(pp *PublicParameter, txWitness *TxWitnessTrTx) ([]byte, error) {
@@ -501,4 +791,146 @@ func GetTxInputSerialNumber(txInput *TxInputMLP) []byte {
 	return txInput.GetSerialNumber()
 }
 
+// GetTxInputLgrTxoList returns the ring, i.e., the LgrTxoMLPs, of the input TxInputMLP.
+func GetTxInputLgrTxoList(txInput *TxInputMLP) []*LgrTxoMLP {
+	return txInput.GetLgrTxoList()
+}
+
 //	Get functions of Transactions	end
+
+// APIs for JSON	begin
+//	The JSON schema is documented in package pqringctx (see mlpjson.go).
+
+// CoinbaseTxToJSON encodes the input CoinbaseTxMLP in JSON, where the txWitness is included only if withWitness is true.
+func CoinbaseTxToJSON(pp *PublicParameter, cbTx *CoinbaseTxMLP, withWitness bool) ([]byte, error) {
+	return pp.CoinbaseTxMLPToJSON(cbTx, withWitness)
+}
+
+// CoinbaseTxFromJSON decodes the input JSON, which is generated by CoinbaseTxToJSON, to a CoinbaseTxMLP.
+func CoinbaseTxFromJSON(pp *PublicParameter, data []byte, withWitness bool) (*CoinbaseTxMLP, error) {
+	return pp.CoinbaseTxMLPFromJSON(data, withWitness)
+}
+
+// TransferTxToJSON encodes the input TransferTxMLP in JSON, where the txWitness is included only if withWitness is true.
+func TransferTxToJSON(pp *PublicParameter, trTx *TransferTxMLP, withWitness bool) ([]byte, error) {
+	return pp.TransferTxMLPToJSON(trTx, withWitness)
+}
+
+// TransferTxFromJSON decodes the input JSON, which is generated by TransferTxToJSON, to a TransferTxMLP.
+func TransferTxFromJSON(pp *PublicParameter, data []byte, withWitness bool) (*TransferTxMLP, error) {
+	return pp.TransferTxMLPFromJSON(data, withWitness)
+}
+
+// TxInputToJSON encodes the input TxInputMLP in JSON.
+func TxInputToJSON(pp *PublicParameter, txInput *TxInputMLP) ([]byte, error) {
+	return pp.TxInputMLPToJSON(txInput)
+}
+
+// TxInputFromJSON decodes the input JSON, which is generated by TxInputToJSON, to a TxInputMLP.
+func TxInputFromJSON(pp *PublicParameter, data []byte) (*TxInputMLP, error) {
+	return pp.TxInputMLPFromJSON(data)
+}
+
+// TxoToJSON encodes the input TxoMLP in JSON.
+func TxoToJSON(pp *PublicParameter, txo TxoMLP) ([]byte, error) {
+	return pp.TxoMLPToJSON(txo)
+}
+
+// TxoFromJSON decodes the input JSON, which is generated by TxoToJSON, to a TxoMLP.
+func TxoFromJSON(pp *PublicParameter, data []byte) (TxoMLP, error) {
+	return pp.TxoMLPFromJSON(data)
+}
+
+// TxWitnessCbTxToJSON encodes the input TxWitnessCbTx in JSON.
+func TxWitnessCbTxToJSON(pp *PublicParameter, txWitness *TxWitnessCbTx) ([]byte, error) {
+	return pp.TxWitnessCbTxToJSON(txWitness)
+}
+
+// TxWitnessCbTxFromJSON decodes the input JSON, which is generated by TxWitnessCbTxToJSON, to a TxWitnessCbTx.
+func TxWitnessCbTxFromJSON(pp *PublicParameter, data []byte) (*TxWitnessCbTx, error) {
+	return pp.TxWitnessCbTxFromJSON(data)
+}
+
+// TxWitnessTrTxToJSON encodes the input TxWitnessTrTx in JSON.
+func TxWitnessTrTxToJSON(pp *PublicParameter, txWitness *TxWitnessTrTx) ([]byte, error) {
+	return pp.TxWitnessTrTxToJSON(txWitness)
+}
+
+// TxWitnessTrTxFromJSON decodes the input JSON, which is generated by TxWitnessTrTxToJSON, to a TxWitnessTrTx.
+func TxWitnessTrTxFromJSON(pp *PublicParameter, data []byte) (*TxWitnessTrTx, error) {
+	return pp.TxWitnessTrTxFromJSON(data)
+}
+
+//	APIs for JSON	end
+
+// APIs for Streaming Serialization	begin
+//	The formats are the same as the corresponding Serialize functions, except WriteTxo (see pqringctx.WriteTxoMLP).
+//	The Write functions buffer and flush the writes, while the Read functions do not read beyond the object,
+//	so that the input io.Reader shall be buffered, e.g., by bufio.Reader.
+
+// WriteCoinbaseTx writes the input CoinbaseTxMLP to w, where the txWitness is included only if withWitness is true.
+func WriteCoinbaseTx(pp *PublicParameter, w io.Writer, cbTx *CoinbaseTxMLP, withWitness bool) error {
+	return pp.WriteCoinbaseTxMLP(w, cbTx, withWitness)
+}
+
+// ReadCoinbaseTx reads a CoinbaseTxMLP, which was written by WriteCoinbaseTx, from r.
+func ReadCoinbaseTx(pp *PublicParameter, r io.Reader, withWitness bool) (*CoinbaseTxMLP, error) {
+	return pp.ReadCoinbaseTxMLP(r, withWitness)
+}
+
+// WriteTransferTx writes the input TransferTxMLP to w, where the txWitness is included only if withWitness is true.
+func WriteTransferTx(pp *PublicParameter, w io.Writer, trTx *TransferTxMLP, withWitness bool) error {
+	return pp.WriteTransferTxMLP(w, trTx, withWitness)
+}
+
+// ReadTransferTx reads a TransferTxMLP, which was written by WriteTransferTx, from r.
+func ReadTransferTx(pp *PublicParameter, r io.Reader, withWitness bool) (*TransferTxMLP, error) {
+	return pp.ReadTransferTxMLP(r, withWitness)
+}
+
+// WriteTxo writes the input TxoMLP to w, prefixed with its serialized size.
+func WriteTxo(pp *PublicParameter, w io.Writer, txo TxoMLP) error {
+	return pp.WriteTxoMLP(w, txo)
+}
+
+// ReadTxo reads a TxoMLP, which was written by WriteTxo, from r.
+func ReadTxo(pp *PublicParameter, r io.Reader) (TxoMLP, error) {
+	return pp.ReadTxoMLP(r)
+}
+
+// WriteTxWitnessCbTx writes the input TxWitnessCbTx to w.
+func WriteTxWitnessCbTx(pp *PublicParameter, w io.Writer, txWitness *TxWitnessCbTx) error {
+	return pp.WriteTxWitnessCbTx(w, txWitness)
+}
+
+// ReadTxWitnessCbTx reads a TxWitnessCbTx, which was written by WriteTxWitnessCbTx, from r.
+func ReadTxWitnessCbTx(pp *PublicParameter, r io.Reader) (*TxWitnessCbTx, error) {
+	return pp.ReadTxWitnessCbTx(r)
+}
+
+// WriteTxWitnessTrTx writes the input TxWitnessTrTx to w.
+func WriteTxWitnessTrTx(pp *PublicParameter, w io.Writer, txWitness *TxWitnessTrTx) error {
+	return pp.WriteTxWitnessTrTx(w, txWitness)
+}
+
+// ReadTxWitnessTrTx reads a TxWitnessTrTx, which was written by WriteTxWitnessTrTx, from r.
+func ReadTxWitnessTrTx(pp *PublicParameter, r io.Reader) (*TxWitnessTrTx, error) {
+	return pp.ReadTxWitnessTrTx(r)
+}
+
+//	APIs for Streaming Serialization	end
+
+// APIs for TransferTx View	begin
+
+// NewTransferTxView creates a TransferTxMLPView over the input serializedTransferTx, which was generated by pp.SerializeTransferTxMLP or WriteTransferTx with the same withWitness.
+// The []byte returned by the view are sub-slices of serializedTransferTx, so that serializedTransferTx must not be modified while the view is in use.
+func NewTransferTxView(pp *PublicParameter, serializedTransferTx []byte, withWitness bool) (*TransferTxMLPView, error) {
+	return pp.NewTransferTxMLPView(serializedTransferTx, withWitness)
+}
+
+// GetTxoFromTransferTxView deserializes the j-th Txo of the input view.
+func GetTxoFromTransferTxView(pp *PublicParameter, view *TransferTxMLPView, j int) (TxoMLP, error) {
+	return pp.TxoMLPFromView(view, j)
+}
+
+//	APIs for TransferTx View	end
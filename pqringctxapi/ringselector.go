@@ -0,0 +1,244 @@
+package pqringctxapi
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// DecoyCandidate is a LgrTxoMLP on the ledger that could be used as a decoy,
+// together with its age, e.g., the number of blocks since it was created.
+type DecoyCandidate struct {
+	LgrTxo *LgrTxoMLP
+	Age    uint64
+}
+
+// DecoyProvider provides the decoy candidates for a ring.
+// The candidates are expected to be the LgrTxoMLPs on the ledger, and the unqualified ones
+// (e.g., the pseudonym-privacy ones, the repeated ones, and the coin-to-spend itself) are ignored by RingSelector.
+type DecoyProvider interface {
+	DecoyCandidates(lgrTxoToSpend *LgrTxoMLP) ([]*DecoyCandidate, error)
+}
+
+// DecoyDistribution specifies the distribution by which RingSelector picks the decoys from the candidates.
+type DecoyDistribution uint8
+
+const (
+	// DecoyDistributionUniform picks each candidate with the same probability.
+	DecoyDistributionUniform DecoyDistribution = iota
+	// DecoyDistributionAgeWeighted picks each candidate with a probability proportional to AgeWeight(candidate.Age),
+	// which by default is 1/(1+age), i.e., the recent LgrTxoMLPs are preferred, as the real spends do.
+	DecoyDistributionAgeWeighted
+)
+
+// RingSelector constructs the rings for RingCT-privacy inputs, by picking decoys from a DecoyProvider.
+// RandReader is the randomness source for picking the decoys and the position of the coin-to-spend, and crypto/rand.Reader is used if it is nil.
+// AgeWeight is used only by DecoyDistributionAgeWeighted, and the default one is used if it is nil.
+// A NaN or non-positive AgeWeight excludes the candidate, while an infinite AgeWeight is rejected with an error.
+type RingSelector struct {
+	pp *PublicParameter
+
+	Provider     DecoyProvider
+	Distribution DecoyDistribution
+	AgeWeight    func(age uint64) float64
+	RandReader   io.Reader
+}
+
+// NewRingSelector creates a RingSelector with the input DecoyProvider and DecoyDistribution.
+func NewRingSelector(pp *PublicParameter, provider DecoyProvider, distribution DecoyDistribution) *RingSelector {
+	return &RingSelector{
+		pp:           pp,
+		Provider:     provider,
+		Distribution: distribution,
+	}
+}
+
+// defaultAgeWeight is the default AgeWeight of DecoyDistributionAgeWeighted.
+func defaultAgeWeight(age uint64) float64 {
+	return 1 / (1 + float64(age))
+}
+
+// randUint64 reads a uniform uint64 from the input randReader.
+func randUint64(randReader io.Reader) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(randReader, buf[:]); err != nil {
+		return 0, fmt.Errorf("randUint64: fail to read from the randReader: %v", err)
+	}
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+// randIntn returns a uniform int in [0, n), using rejection sampling.
+func randIntn(randReader io.Reader, n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("randIntn: the input n (%d) is not positive", n)
+	}
+	bound := uint64(n)
+	limit := ^uint64(0) - (^uint64(0) % bound)
+	for {
+		x, err := randUint64(randReader)
+		if err != nil {
+			return 0, err
+		}
+		if x < limit {
+			return int(x % bound), nil
+		}
+	}
+}
+
+// randFloat64 returns a uniform float64 in [0, 1).
+func randFloat64(randReader io.Reader) (float64, error) {
+	x, err := randUint64(randReader)
+	if err != nil {
+		return 0, err
+	}
+	return float64(x>>11) / (1 << 53), nil
+}
+
+// qualifiedDecoyCandidates returns the candidates that can be put in a ring with lgrTxoToSpend,
+// namely, the well-form RingCT-privacy ones, excluding lgrTxoToSpend and the repeated ones.
+func (s *RingSelector) qualifiedDecoyCandidates(lgrTxoToSpend *LgrTxoMLP, candidates []*DecoyCandidate) []*DecoyCandidate {
+	ids := map[string]struct{}{string(lgrTxoToSpend.GetId()): {}}
+	qualified := make([]*DecoyCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate == nil || !s.pp.LgrTxoMLPSanityCheck(candidate.LgrTxo) {
+			continue
+		}
+		coinAddressType := candidate.LgrTxo.GetTxo().CoinAddressType()
+		if coinAddressType != CoinAddressTypePublicKeyForRingPre && coinAddressType != CoinAddressTypePublicKeyForRing {
+			continue
+		}
+		if _, exists := ids[string(candidate.LgrTxo.GetId())]; exists {
+			continue
+		}
+		ids[string(candidate.LgrTxo.GetId())] = struct{}{}
+		qualified = append(qualified, candidate)
+	}
+	return qualified
+}
+
+// pickDecoys picks decoyNum distinct candidates by the configured DecoyDistribution.
+func (s *RingSelector) pickDecoys(candidates []*DecoyCandidate, decoyNum int, randReader io.Reader) ([]*LgrTxoMLP, error) {
+	weights := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		switch s.Distribution {
+		case DecoyDistributionUniform:
+			weights[i] = 1
+		case DecoyDistributionAgeWeighted:
+			ageWeight := s.AgeWeight
+			if ageWeight == nil {
+				ageWeight = defaultAgeWeight
+			}
+			weights[i] = ageWeight(candidate.Age)
+			if math.IsInf(weights[i], 0) {
+				return nil, fmt.Errorf("pickDecoys: the AgeWeight of the age (%d) is infinite", candidate.Age)
+			}
+			if !(weights[i] > 0) {
+				weights[i] = 0
+			}
+		default:
+			return nil, fmt.Errorf("pickDecoys: the DecoyDistribution (%d) is not supported", s.Distribution)
+		}
+	}
+
+	decoys := make([]*LgrTxoMLP, 0, decoyNum)
+	for len(decoys) < decoyNum {
+		totalWeight := float64(0)
+		for _, weight := range weights {
+			totalWeight += weight
+		}
+		if !(totalWeight > 0) {
+			return nil, fmt.Errorf("pickDecoys: there are not enough candidates with positive weight")
+		}
+		if math.IsInf(totalWeight, 0) {
+			return nil, fmt.Errorf("pickDecoys: the total AgeWeight of the candidates overflows")
+		}
+
+		r, err := randFloat64(randReader)
+		if err != nil {
+			return nil, err
+		}
+		target := r * totalWeight
+		picked := -1
+		for i, weight := range weights {
+			if weight == 0 {
+				continue
+			}
+			picked = i
+			if target < weight {
+				break
+			}
+			target -= weight
+		}
+
+		decoys = append(decoys, candidates[picked].LgrTxo)
+		weights[picked] = 0
+	}
+	return decoys, nil
+}
+
+// SelectRing constructs a ring of ringSize members for lgrTxoToSpend, where ringSize is in [1, GetRingSizeMax].
+// It returns the ring and the position sidx of lgrTxoToSpend in the ring, which is uniformly random.
+func (s *RingSelector) SelectRing(lgrTxoToSpend *LgrTxoMLP, ringSize int) (lgrTxoList []*LgrTxoMLP, sidx uint8, err error) {
+	if !s.pp.LgrTxoMLPSanityCheck(lgrTxoToSpend) {
+		return nil, 0, fmt.Errorf("RingSelector.SelectRing: the input lgrTxoToSpend is not well-form")
+	}
+	coinAddressType := lgrTxoToSpend.GetTxo().CoinAddressType()
+	if coinAddressType != CoinAddressTypePublicKeyForRingPre && coinAddressType != CoinAddressTypePublicKeyForRing {
+		return nil, 0, fmt.Errorf("RingSelector.SelectRing: the coinAddressType (%d) of the input lgrTxoToSpend is not for RingCT-privacy", coinAddressType)
+	}
+	if ringSize <= 0 || ringSize > GetRingSizeMax(s.pp) {
+		return nil, 0, fmt.Errorf("RingSelector.SelectRing: the input ringSize (%d) is not in [1, %d]", ringSize, GetRingSizeMax(s.pp))
+	}
+
+	randReader := s.RandReader
+	if randReader == nil {
+		randReader = rand.Reader
+	}
+
+	var decoys []*LgrTxoMLP
+	if ringSize > 1 {
+		if s.Provider == nil {
+			return nil, 0, fmt.Errorf("RingSelector.SelectRing: the DecoyProvider is nil")
+		}
+		candidates, err := s.Provider.DecoyCandidates(lgrTxoToSpend)
+		if err != nil {
+			return nil, 0, err
+		}
+		candidates = s.qualifiedDecoyCandidates(lgrTxoToSpend, candidates)
+		if len(candidates) < ringSize-1 {
+			return nil, 0, fmt.Errorf("RingSelector.SelectRing: the number of qualified candidates (%d) is less than the required number of decoys (%d)", len(candidates), ringSize-1)
+		}
+		decoys, err = s.pickDecoys(candidates, ringSize-1, randReader)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	position, err := randIntn(randReader, ringSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	lgrTxoList = make([]*LgrTxoMLP, 0, ringSize)
+	lgrTxoList = append(lgrTxoList, decoys[:position]...)
+	lgrTxoList = append(lgrTxoList, lgrTxoToSpend)
+	lgrTxoList = append(lgrTxoList, decoys[position:]...)
+
+	if !s.pp.LgrTxoRingForRingSanityCheck(lgrTxoList) {
+		return nil, 0, fmt.Errorf("RingSelector.SelectRing: the constructed ring does not pass LgrTxoRingForRingSanityCheck")
+	}
+
+	return lgrTxoList, uint8(position), nil
+}
+
+// NewTxInputDesc constructs a ring for lgrTxoToSpend by SelectRing, and returns the TxInputDescMLP ready for TransferTxGen,
+// where the remaining inputs are the same as NewTxInputDescMLP.
+func (s *RingSelector) NewTxInputDesc(lgrTxoToSpend *LgrTxoMLP, ringSize int, coinSpendSecretKey []byte, coinSerialNumberSecretKey []byte,
+	coinValuePublicKey []byte, coinValueSecretKey []byte, coinDetectorKey []byte, value uint64) (*TxInputDescMLP, error) {
+	lgrTxoList, sidx, err := s.SelectRing(lgrTxoToSpend, ringSize)
+	if err != nil {
+		return nil, err
+	}
+	return NewTxInputDescMLP(lgrTxoList, sidx, coinSpendSecretKey, coinSerialNumberSecretKey, coinValuePublicKey, coinValueSecretKey, coinDetectorKey, value), nil
+}
@@ -0,0 +1,160 @@
+package pqringctxapi
+
+import (
+	"bytes"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"golang.org/x/crypto/sha3"
+	"math"
+	"testing"
+)
+
+type sliceDecoyProvider []*DecoyCandidate
+
+func (p sliceDecoyProvider) DecoyCandidates(lgrTxoToSpend *LgrTxoMLP) ([]*DecoyCandidate, error) {
+	return p, nil
+}
+
+func TestRingSelector(t *testing.T) {
+	pp := InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+
+	detectorKey := randomBytesForTest(t, GetParamMACKeyBytesLen(pp))
+	coinAddress, coinSpendSecretKey, coinSerialNumberSecretKey, err := CoinAddressKeyForPKRingGen(pp,
+		randomBytesForTest(t, GetParamSeedBytesLen(pp)), randomBytesForTest(t, GetParamSeedBytesLen(pp)),
+		detectorKey, randomBytesForTest(t, GetParamKeyGenPublicRandBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinAddressKeyForPKRingGen: %v", err)
+	}
+	coinValuePublicKey, coinValueSecretKey, err := CoinValueKeyGen(pp, randomBytesForTest(t, GetParamSeedBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinValueKeyGen: %v", err)
+	}
+	coinAddressForSingle, _, err := CoinAddressKeyForPKHSingleGen(pp,
+		randomBytesForTest(t, GetParamSeedBytesLen(pp)), detectorKey, randomBytesForTest(t, GetParamKeyGenPublicRandBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinAddressKeyForPKHSingleGen: %v", err)
+	}
+
+	//	the coin-to-spend is the first output, and the others are decoy candidates
+	outForRing := GetTxOutputMaxNumForRing(pp)
+	txOutputDescs := make([]*TxOutputDescMLP, 0, outForRing+1)
+	for i := 0; i < outForRing; i++ {
+		txOutputDescs = append(txOutputDescs, NewTxOutputDescMLP(coinAddress, coinValuePublicKey, 10))
+	}
+	txOutputDescs = append(txOutputDescs, NewTxOutputDescMLP(coinAddressForSingle, nil, 10))
+	cbTx, err := CoinbaseTxGen(pp, uint64(10*(outForRing+1)), txOutputDescs, nil)
+	if err != nil {
+		t.Fatalf("CoinbaseTxGen: %v", err)
+	}
+	txos := GetCbTxTxos(cbTx)
+	lgrTxoToSpend := NewLgrTxo(txos[0], randomBytesForTest(t, 64))
+	candidates := sliceDecoyProvider{
+		{LgrTxo: lgrTxoToSpend, Age: 0},
+		{LgrTxo: NewLgrTxo(txos[outForRing], randomBytesForTest(t, 64)), Age: 0},
+	}
+	for i := 1; i < outForRing; i++ {
+		candidates = append(candidates, &DecoyCandidate{LgrTxo: NewLgrTxo(txos[i], randomBytesForTest(t, 64)), Age: uint64(i)})
+	}
+	//	a repeated candidate
+	candidates = append(candidates, candidates[2])
+
+	//	the coin-to-spend and the pseudonym-privacy candidate are not qualified
+	ringSizeMax := outForRing
+	if ringSizeMax > GetRingSizeMax(pp) {
+		ringSizeMax = GetRingSizeMax(pp)
+	}
+	selector := NewRingSelector(pp, candidates, DecoyDistributionUniform)
+	for ringSize := 1; ringSize <= ringSizeMax; ringSize++ {
+		lgrTxoList, sidx, err := selector.SelectRing(lgrTxoToSpend, ringSize)
+		if err != nil {
+			t.Fatalf("SelectRing: %v", err)
+		}
+		if len(lgrTxoList) != ringSize || lgrTxoList[sidx] != lgrTxoToSpend {
+			t.Fatalf("SelectRing returns a ring of size %d with the coin-to-spend not at sidx %d", len(lgrTxoList), sidx)
+		}
+	}
+	if _, _, err = selector.SelectRing(lgrTxoToSpend, ringSizeMax+1); err == nil {
+		t.Fatalf("SelectRing succeeds without enough candidates")
+	}
+
+	//	the age-weighted distribution with zero weights on the old candidates
+	selector = NewRingSelector(pp, candidates, DecoyDistributionAgeWeighted)
+	selector.AgeWeight = func(age uint64) float64 {
+		if age > 1 {
+			return 0
+		}
+		return 1
+	}
+	lgrTxoList, _, err := selector.SelectRing(lgrTxoToSpend, 2)
+	if err != nil {
+		t.Fatalf("SelectRing: %v", err)
+	}
+	for _, lgrTxo := range lgrTxoList {
+		if lgrTxo != lgrTxoToSpend && lgrTxo != candidates[2].LgrTxo {
+			t.Fatalf("SelectRing picks a candidate with zero weight")
+		}
+	}
+	if outForRing > 2 {
+		if _, _, err = selector.SelectRing(lgrTxoToSpend, 3); err == nil {
+			t.Fatalf("SelectRing succeeds without enough candidates with positive weight")
+		}
+	}
+
+	//	an infinite weight is rejected, rather than picked always
+	for _, inf := range []float64{math.Inf(1), math.Inf(-1)} {
+		selector = NewRingSelector(pp, candidates, DecoyDistributionAgeWeighted)
+		selector.AgeWeight = func(age uint64) float64 {
+			return inf
+		}
+		if _, _, err = selector.SelectRing(lgrTxoToSpend, 2); err == nil {
+			t.Fatalf("SelectRing succeeds with an infinite AgeWeight (%v)", inf)
+		}
+	}
+	//	so is the total weight that overflows
+	selector = NewRingSelector(pp, candidates, DecoyDistributionAgeWeighted)
+	selector.AgeWeight = func(age uint64) float64 {
+		return math.MaxFloat64
+	}
+	if outForRing > 2 {
+		if _, _, err = selector.SelectRing(lgrTxoToSpend, 2); err == nil {
+			t.Fatalf("SelectRing succeeds with the total AgeWeight overflowing")
+		}
+	}
+
+	//	deterministic with the same RandReader
+	selectRingWithSeed := func() ([]*LgrTxoMLP, uint8) {
+		selector := NewRingSelector(pp, candidates, DecoyDistributionAgeWeighted)
+		xof := sha3.NewShake256()
+		xof.Write([]byte("TestRingSelector"))
+		selector.RandReader = xof
+		lgrTxoList, sidx, err := selector.SelectRing(lgrTxoToSpend, ringSizeMax)
+		if err != nil {
+			t.Fatalf("SelectRing: %v", err)
+		}
+		return lgrTxoList, sidx
+	}
+	lgrTxoList, sidx := selectRingWithSeed()
+	lgrTxoListAgain, sidxAgain := selectRingWithSeed()
+	if sidx != sidxAgain {
+		t.Fatalf("SelectRing with the same RandReader returns different sidx")
+	}
+	for i := range lgrTxoList {
+		if !bytes.Equal(lgrTxoList[i].GetId(), lgrTxoListAgain[i].GetId()) {
+			t.Fatalf("SelectRing with the same RandReader returns different rings")
+		}
+	}
+
+	//	the constructed TxInputDescMLP is accepted by TransferTxGen
+	selector = NewRingSelector(pp, candidates, DecoyDistributionUniform)
+	txInputDesc, err := selector.NewTxInputDesc(lgrTxoToSpend, ringSizeMax, coinSpendSecretKey, coinSerialNumberSecretKey,
+		coinValuePublicKey, coinValueSecretKey, detectorKey, 10)
+	if err != nil {
+		t.Fatalf("NewTxInputDesc: %v", err)
+	}
+	trTx, err := TransferTxGen(pp, []*TxInputDescMLP{txInputDesc}, []*TxOutputDescMLP{NewTxOutputDescMLP(coinAddress, coinValuePublicKey, 10)}, 0, nil)
+	if err != nil {
+		t.Fatalf("TransferTxGen: %v", err)
+	}
+	if err = TransferTxVerify(pp, trTx); err != nil {
+		t.Fatalf("TransferTxVerify: %v", err)
+	}
+}
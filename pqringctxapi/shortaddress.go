@@ -0,0 +1,103 @@
+package pqringctxapi
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pqabelian/pqringctx"
+)
+
+//	Short Address	begin
+
+// ShortAddressLen is the length of a short address, i.e., the output length of pqringctx.Hash.
+const ShortAddressLen = pqringctx.HashOutputBytesLen
+
+const (
+	// shortAddressVersion is the version byte of the encoded short addresses,
+	// which is distinct from the CoinAddressTypes, so that EncodeAddress and EncodeShortAddress never produce the same payload.
+	shortAddressVersion = 0xff
+	// shortAddressDomain separates the Hash for the short addresses from the other uses of Hash.
+	shortAddressDomain = "pqringctx-short-address"
+)
+
+// AddressResolver fetches the full (coinAddress, coinValuePublicKey) pair of a short address, e.g., from a directory service.
+// The resolver is not trusted, as ResolveShortAddress checks the fetched pair against the short address.
+type AddressResolver interface {
+	ResolveAddress(shortAddress []byte) (coinAddress []byte, coinValuePublicKey []byte, err error)
+}
+
+// ShortAddressGen returns the short address of the input (coinAddress, coinValuePublicKey) pair,
+// namely Hash(domain || version || coinAddress || coinValuePublicKey), where version is the CoinAddressType of coinAddress.
+// The short address is a fixed-size (ShortAddressLen bytes) commitment to the full pair, which can be shared and confirmed out of band,
+// while the full pair is fetched by an AddressResolver.
+// As in EncodeAddress, the coinValuePublicKey is required for the coinAddress with RingCT-privacy, and must be nil/empty for the coinAddress with pseudonym-privacy.
+func ShortAddressGen(pp *PublicParameter, coinAddress []byte, coinValuePublicKey []byte) ([]byte, error) {
+	payload, err := addressPayload(pp, coinAddress, coinValuePublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("ShortAddressGen: %v", err)
+	}
+	data := make([]byte, 0, len(shortAddressDomain)+len(payload))
+	data = append(data, shortAddressDomain...)
+	data = append(data, payload...)
+	return pqringctx.Hash(data)
+}
+
+// ShortAddressVerify checks whether the input (coinAddress, coinValuePublicKey) pair matches the input shortAddress.
+// The error is not nil only if the input shortAddress or (coinAddress, coinValuePublicKey) is not well-form.
+func ShortAddressVerify(pp *PublicParameter, shortAddress []byte, coinAddress []byte, coinValuePublicKey []byte) (bool, error) {
+	if len(shortAddress) != ShortAddressLen {
+		return false, fmt.Errorf("ShortAddressVerify: the input shortAddress has length %d, but %d is expected", len(shortAddress), ShortAddressLen)
+	}
+	computed, err := ShortAddressGen(pp, coinAddress, coinValuePublicKey)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(computed, shortAddress), nil
+}
+
+// ResolveShortAddress fetches the full (coinAddress, coinValuePublicKey) pair of the input shortAddress by the input resolver,
+// and returns it only if it matches shortAddress.
+func ResolveShortAddress(pp *PublicParameter, resolver AddressResolver, shortAddress []byte) (coinAddress []byte, coinValuePublicKey []byte, err error) {
+	if len(shortAddress) != ShortAddressLen {
+		return nil, nil, fmt.Errorf("ResolveShortAddress: the input shortAddress has length %d, but %d is expected", len(shortAddress), ShortAddressLen)
+	}
+	coinAddress, coinValuePublicKey, err = resolver.ResolveAddress(shortAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ResolveShortAddress: fail to resolve the shortAddress: %v", err)
+	}
+	match, err := ShortAddressVerify(pp, shortAddress, coinAddress, coinValuePublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ResolveShortAddress: the resolved address is not well-form: %v", err)
+	}
+	if !match {
+		return nil, nil, fmt.Errorf("ResolveShortAddress: the resolved address does not match the shortAddress")
+	}
+	return coinAddress, coinValuePublicKey, nil
+}
+
+// EncodeShortAddress encodes the input shortAddress into a human-readable string for the input network, in the same format as EncodeAddress,
+// with the version byte 0xff.
+func EncodeShortAddress(network AddressNetwork, shortAddress []byte) (string, error) {
+	if len(shortAddress) != ShortAddressLen {
+		return "", fmt.Errorf("EncodeShortAddress: the input shortAddress has length %d, but %d is expected", len(shortAddress), ShortAddressLen)
+	}
+	payload := make([]byte, 0, 1+ShortAddressLen)
+	payload = append(payload, shortAddressVersion)
+	payload = append(payload, shortAddress...)
+	return encodeAddressData(network, payload)
+}
+
+// DecodeShortAddress decodes the input encodedShortAddress, which is generated by EncodeShortAddress for the input network.
+// As DecodeAddress, the returned error wraps ErrAddressWrongNetwork, ErrAddressBadChecksum, ErrAddressBadLength, or ErrAddressMalformed.
+func DecodeShortAddress(network AddressNetwork, encodedShortAddress string) ([]byte, error) {
+	payload, err := decodeAddressData(network, encodedShortAddress, func(version byte) (int, bool) {
+		return 1 + ShortAddressLen, version == shortAddressVersion
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DecodeShortAddress: %w", err)
+	}
+	shortAddress := make([]byte, ShortAddressLen)
+	copy(shortAddress, payload[1:])
+	return shortAddress, nil
+}
+
+//	Short Address	end
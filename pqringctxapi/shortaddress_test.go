@@ -0,0 +1,96 @@
+package pqringctxapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"testing"
+)
+
+// mapAddressResolver resolves the short addresses from a map, which may be modified to simulate a dishonest resolver.
+type mapAddressResolver struct {
+	addresses map[string][2][]byte
+}
+
+func (r *mapAddressResolver) ResolveAddress(shortAddress []byte) ([]byte, []byte, error) {
+	pair, ok := r.addresses[string(shortAddress)]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown short address")
+	}
+	return pair[0], pair[1], nil
+}
+
+func TestShortAddress(t *testing.T) {
+	pp := InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+
+	coinAddress, _, _, err := CoinAddressKeyForPKRingGen(pp,
+		randomBytesForTest(t, GetParamSeedBytesLen(pp)), randomBytesForTest(t, GetParamSeedBytesLen(pp)),
+		randomBytesForTest(t, GetParamMACKeyBytesLen(pp)), randomBytesForTest(t, GetParamKeyGenPublicRandBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinAddressKeyForPKRingGen: %v", err)
+	}
+	coinValuePublicKey, _, err := CoinValueKeyGen(pp, randomBytesForTest(t, GetParamSeedBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinValueKeyGen: %v", err)
+	}
+	otherCoinValuePublicKey, _, err := CoinValueKeyGen(pp, randomBytesForTest(t, GetParamSeedBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinValueKeyGen: %v", err)
+	}
+
+	shortAddress, err := ShortAddressGen(pp, coinAddress, coinValuePublicKey)
+	if err != nil {
+		t.Fatalf("ShortAddressGen: %v", err)
+	}
+	if len(shortAddress) != ShortAddressLen {
+		t.Fatalf("ShortAddressGen: the short address has length %d, want %d", len(shortAddress), ShortAddressLen)
+	}
+
+	if match, err := ShortAddressVerify(pp, shortAddress, coinAddress, coinValuePublicKey); err != nil || !match {
+		t.Errorf("ShortAddressVerify with the matching pair = (%v, %v), want (true, nil)", match, err)
+	}
+	if match, err := ShortAddressVerify(pp, shortAddress, coinAddress, otherCoinValuePublicKey); err != nil || match {
+		t.Errorf("ShortAddressVerify with another coinValuePublicKey = (%v, %v), want (false, nil)", match, err)
+	}
+
+	//	resolver
+	resolver := &mapAddressResolver{addresses: map[string][2][]byte{string(shortAddress): {coinAddress, coinValuePublicKey}}}
+	resolvedAddress, resolvedValuePublicKey, err := ResolveShortAddress(pp, resolver, shortAddress)
+	if err != nil {
+		t.Fatalf("ResolveShortAddress: %v", err)
+	}
+	if !bytes.Equal(resolvedAddress, coinAddress) || !bytes.Equal(resolvedValuePublicKey, coinValuePublicKey) {
+		t.Errorf("ResolveShortAddress: the resolved pair differs from the original one")
+	}
+	resolver.addresses[string(shortAddress)] = [2][]byte{coinAddress, otherCoinValuePublicKey}
+	if _, _, err = ResolveShortAddress(pp, resolver, shortAddress); err == nil {
+		t.Errorf("ResolveShortAddress should fail for a resolved pair that does not match")
+	}
+
+	//	encoding
+	encoded, err := EncodeShortAddress(AddressNetworkTestnet, shortAddress)
+	if err != nil {
+		t.Fatalf("EncodeShortAddress: %v", err)
+	}
+	decoded, err := DecodeShortAddress(AddressNetworkTestnet, encoded)
+	if err != nil {
+		t.Fatalf("DecodeShortAddress: %v", err)
+	}
+	if !bytes.Equal(decoded, shortAddress) {
+		t.Errorf("DecodeShortAddress: the decoded short address differs from the encoded one")
+	}
+	if _, err = DecodeShortAddress(AddressNetworkMainnet, encoded); !errors.Is(err, ErrAddressWrongNetwork) {
+		t.Errorf("DecodeShortAddress with the wrong network: error = %v, want %v", err, ErrAddressWrongNetwork)
+	}
+	if _, _, err = DecodeAddress(pp, AddressNetworkTestnet, encoded); !errors.Is(err, ErrAddressMalformed) {
+		t.Errorf("DecodeAddress with a short address: error = %v, want %v", err, ErrAddressMalformed)
+	}
+	encodedFull, err := EncodeAddress(pp, AddressNetworkTestnet, coinAddress, coinValuePublicKey)
+	if err != nil {
+		t.Fatalf("EncodeAddress: %v", err)
+	}
+	if _, err = DecodeShortAddress(AddressNetworkTestnet, encodedFull); !errors.Is(err, ErrAddressMalformed) {
+		t.Errorf("DecodeShortAddress with a full address: error = %v, want %v", err, ErrAddressMalformed)
+	}
+}
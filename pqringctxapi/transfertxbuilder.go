@@ -0,0 +1,179 @@
+package pqringctxapi
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// TransferTxBuilder collects the inputs and outputs of a TransferTxMLP in any order,
+// and generates the TransferTxMLP by TransferTxGen,
+// where the RingCT-privacy inputs/outputs are put at the first successive positions, as TransferTxGen requires.
+// The limits on the numbers of inputs/outputs are checked when they are added.
+// Note that the relative order among the RingCT-privacy (resp. pseudonym-privacy) items keeps unchanged.
+type TransferTxBuilder struct {
+	pp *PublicParameter
+
+	inputsForRing   []*TxInputDescMLP
+	inputsForSingle []*TxInputDescMLP
+	//	the distinct coinAddresses of inputsForSingle
+	inputCoinAddressesForSingle map[string]struct{}
+
+	outputs []*TxOutputDescMLP
+	//	outputIsForRing[i] denotes whether outputs[i] is a RingCT-privacy output
+	outputIsForRing []bool
+	outForRing      int
+	outForSingle    int
+
+	fee    uint64
+	txMemo []byte
+}
+
+// NewTransferTxBuilder creates an empty TransferTxBuilder.
+func NewTransferTxBuilder(pp *PublicParameter) *TransferTxBuilder {
+	return &TransferTxBuilder{
+		pp:                          pp,
+		inputCoinAddressesForSingle: make(map[string]struct{}),
+	}
+}
+
+// AddInput adds a coin to be consumed.
+// It returns an error if the coin-to-spend is not well-form, or adding it will exceed the allowed maximum number of inputs.
+func (b *TransferTxBuilder) AddInput(txInputDesc *TxInputDescMLP) error {
+	if txInputDesc == nil {
+		return fmt.Errorf("TransferTxBuilder.AddInput: the input txInputDesc is nil")
+	}
+	lgrTxo := txInputDesc.GetLgrTxoToSpend()
+	if lgrTxo == nil || lgrTxo.GetTxo() == nil {
+		return fmt.Errorf("TransferTxBuilder.AddInput: the input txInputDesc does not have a valid coin-to-spend")
+	}
+
+	coinAddressType := lgrTxo.GetTxo().CoinAddressType()
+	switch coinAddressType {
+	case CoinAddressTypePublicKeyForRingPre, CoinAddressTypePublicKeyForRing:
+		if len(b.inputsForRing) >= GetTxInputMaxNumForRing(b.pp) {
+			return fmt.Errorf("TransferTxBuilder.AddInput: the number of RingCT-privacy inputs will exceed the allowed maximum value (%d)", GetTxInputMaxNumForRing(b.pp))
+		}
+		b.inputsForRing = append(b.inputsForRing, txInputDesc)
+
+	case CoinAddressTypePublicKeyHashForSingle:
+		if len(b.inputsForSingle) >= GetTxInputMaxNumForSingle(b.pp) {
+			return fmt.Errorf("TransferTxBuilder.AddInput: the number of pseudonym-privacy inputs will exceed the allowed maximum value (%d)", GetTxInputMaxNumForSingle(b.pp))
+		}
+		coinAddress, err := GetCoinAddressFromTxo(b.pp, lgrTxo.GetTxo())
+		if err != nil {
+			return err
+		}
+		coinAddressString := hex.EncodeToString(coinAddress)
+		if _, exists := b.inputCoinAddressesForSingle[coinAddressString]; !exists {
+			if len(b.inputCoinAddressesForSingle) >= int(b.pp.GetTxInputMaxNumForSingleDistinct()) {
+				return fmt.Errorf("TransferTxBuilder.AddInput: the number of distinct coinAddresses of pseudonym-privacy inputs will exceed the allowed maximum value (%d)", b.pp.GetTxInputMaxNumForSingleDistinct())
+			}
+			b.inputCoinAddressesForSingle[coinAddressString] = struct{}{}
+		}
+		b.inputsForSingle = append(b.inputsForSingle, txInputDesc)
+
+	default:
+		return fmt.Errorf("TransferTxBuilder.AddInput: the coinAddressType (%d) of the coin-to-spend is not supported", coinAddressType)
+	}
+
+	return nil
+}
+
+// AddOutput adds an output, and returns its index in the caller's output order, i.e., the number of previously added outputs.
+// It returns an error if the coinAddress is not supported, or adding it will exceed the allowed maximum number of outputs.
+func (b *TransferTxBuilder) AddOutput(txOutputDesc *TxOutputDescMLP) (int, error) {
+	if txOutputDesc == nil {
+		return -1, fmt.Errorf("TransferTxBuilder.AddOutput: the input txOutputDesc is nil")
+	}
+
+	coinAddressType, err := ExtractCoinAddressTypeFromCoinAddress(b.pp, txOutputDesc.GetCoinAddress())
+	if err != nil {
+		return -1, err
+	}
+
+	isForRing := false
+	switch coinAddressType {
+	case CoinAddressTypePublicKeyForRingPre, CoinAddressTypePublicKeyForRing:
+		if b.outForRing >= GetTxOutputMaxNumForRing(b.pp) {
+			return -1, fmt.Errorf("TransferTxBuilder.AddOutput: the number of RingCT-privacy outputs will exceed the allowed maximum value (%d)", GetTxOutputMaxNumForRing(b.pp))
+		}
+		isForRing = true
+		b.outForRing++
+
+	case CoinAddressTypePublicKeyHashForSingle:
+		if b.outForSingle >= GetTxOutputMaxNumForSingle(b.pp) {
+			return -1, fmt.Errorf("TransferTxBuilder.AddOutput: the number of pseudonym-privacy outputs will exceed the allowed maximum value (%d)", GetTxOutputMaxNumForSingle(b.pp))
+		}
+		b.outForSingle++
+
+	default:
+		return -1, fmt.Errorf("TransferTxBuilder.AddOutput: the coinAddressType (%d) of the output is not supported", coinAddressType)
+	}
+
+	b.outputs = append(b.outputs, txOutputDesc)
+	b.outputIsForRing = append(b.outputIsForRing, isForRing)
+	return len(b.outputs) - 1, nil
+}
+
+// SetFee sets the transaction fee.
+func (b *TransferTxBuilder) SetFee(fee uint64) {
+	b.fee = fee
+}
+
+// SetMemo sets the transaction memo.
+func (b *TransferTxBuilder) SetMemo(txMemo []byte) {
+	b.txMemo = txMemo
+}
+
+// EstimateFee solves for the fee of the TransferTxMLP to be built from the collected inputs, outputs, and memo under the input FeePolicy,
+// and returns the fee together with the resulting serialize size (with witness).
+// Note that the fee is not set; the caller adjusts the outputs (e.g., the change) by the fee and then calls SetFee.
+func (b *TransferTxBuilder) EstimateFee(feePolicy FeePolicy) (fee uint64, txSize int, err error) {
+	txInputDescs := make([]*TxInputDescMLP, 0, len(b.inputsForRing)+len(b.inputsForSingle))
+	txInputDescs = append(txInputDescs, b.inputsForRing...)
+	txInputDescs = append(txInputDescs, b.inputsForSingle...)
+	desc, err := NewTransferTxSizeDesc(b.pp, txInputDescs, b.outputs, b.txMemo)
+	if err != nil {
+		return 0, 0, err
+	}
+	return TransferTxFeeEstimate(b.pp, desc, feePolicy)
+}
+
+// Build generates the TransferTxMLP from the collected inputs and outputs.
+// It also returns outputIndexes, where outputIndexes[i] is the index (in the generated TransferTxMLP's txos)
+// of the Txo for the i-th added output, so that the caller can locate its outputs (e.g., the change outputs).
+func (b *TransferTxBuilder) Build() (trTx *TransferTxMLP, outputIndexes []int, err error) {
+	if len(b.inputsForRing)+len(b.inputsForSingle) == 0 {
+		return nil, nil, fmt.Errorf("TransferTxBuilder.Build: there is no input")
+	}
+	if len(b.outputs) == 0 {
+		return nil, nil, fmt.Errorf("TransferTxBuilder.Build: there is no output")
+	}
+
+	txInputDescs := make([]*TxInputDescMLP, 0, len(b.inputsForRing)+len(b.inputsForSingle))
+	txInputDescs = append(txInputDescs, b.inputsForRing...)
+	txInputDescs = append(txInputDescs, b.inputsForSingle...)
+
+	//	RingCT-privacy outputs first, with their relative order unchanged
+	txOutputDescs := make([]*TxOutputDescMLP, len(b.outputs))
+	outputIndexes = make([]int, len(b.outputs))
+	ringPos := 0
+	singlePos := b.outForRing
+	for i := 0; i < len(b.outputs); i++ {
+		if b.outputIsForRing[i] {
+			outputIndexes[i] = ringPos
+			ringPos++
+		} else {
+			outputIndexes[i] = singlePos
+			singlePos++
+		}
+		txOutputDescs[outputIndexes[i]] = b.outputs[i]
+	}
+
+	trTx, err = TransferTxGen(b.pp, txInputDescs, txOutputDescs, b.fee, b.txMemo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return trTx, outputIndexes, nil
+}
@@ -0,0 +1,134 @@
+package pqringctxapi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"testing"
+)
+
+func randomBytesForTest(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+	return b
+}
+
+func TestTransferTxBuilder(t *testing.T) {
+	pp := InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+
+	//	keys for RingCT-privacy
+	detectorKeyForRing := randomBytesForTest(t, GetParamMACKeyBytesLen(pp))
+	coinAddressForRing, coinSpendSecretKeyForRing, coinSerialNumberSecretKeyForRing, err := CoinAddressKeyForPKRingGen(pp,
+		randomBytesForTest(t, GetParamSeedBytesLen(pp)), randomBytesForTest(t, GetParamSeedBytesLen(pp)),
+		detectorKeyForRing, randomBytesForTest(t, GetParamKeyGenPublicRandBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinAddressKeyForPKRingGen: %v", err)
+	}
+	coinValuePublicKey, coinValueSecretKey, err := CoinValueKeyGen(pp, randomBytesForTest(t, GetParamSeedBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinValueKeyGen: %v", err)
+	}
+
+	//	keys for pseudonym-privacy
+	detectorKeyForSingle := randomBytesForTest(t, GetParamMACKeyBytesLen(pp))
+	coinAddressForSingle, coinSpendSecretKeyForSingle, err := CoinAddressKeyForPKHSingleGen(pp,
+		randomBytesForTest(t, GetParamSeedBytesLen(pp)), detectorKeyForSingle, randomBytesForTest(t, GetParamKeyGenPublicRandBytesLen(pp)))
+	if err != nil {
+		t.Fatalf("CoinAddressKeyForPKHSingleGen: %v", err)
+	}
+
+	//	coins to spend
+	cbTx, err := CoinbaseTxGen(pp, 300, []*TxOutputDescMLP{
+		NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, 200),
+		NewTxOutputDescMLP(coinAddressForSingle, nil, 100),
+	}, nil)
+	if err != nil {
+		t.Fatalf("CoinbaseTxGen: %v", err)
+	}
+	txos := GetCbTxTxos(cbTx)
+	lgrTxoForRing := NewLgrTxo(txos[0], randomBytesForTest(t, 64))
+	lgrTxoForSingle := NewLgrTxo(txos[1], randomBytesForTest(t, 64))
+
+	builder := NewTransferTxBuilder(pp)
+	//	add the pseudonym-privacy input first
+	err = builder.AddInput(NewTxInputDescMLP([]*LgrTxoMLP{lgrTxoForSingle}, 0, coinSpendSecretKeyForSingle, nil, nil, nil, detectorKeyForSingle, 100))
+	if err != nil {
+		t.Fatalf("AddInput: %v", err)
+	}
+	err = builder.AddInput(NewTxInputDescMLP([]*LgrTxoMLP{lgrTxoForRing}, 0, coinSpendSecretKeyForRing, coinSerialNumberSecretKeyForRing, coinValuePublicKey, coinValueSecretKey, detectorKeyForRing, 200))
+	if err != nil {
+		t.Fatalf("AddInput: %v", err)
+	}
+
+	//	outputs in the order: single, ring, single, ring
+	outputs := []*TxOutputDescMLP{
+		NewTxOutputDescMLP(coinAddressForSingle, nil, 50),
+		NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, 100),
+		NewTxOutputDescMLP(coinAddressForSingle, nil, 40),
+		NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, 100),
+	}
+	for i, output := range outputs {
+		index, err := builder.AddOutput(output)
+		if err != nil {
+			t.Fatalf("AddOutput: %v", err)
+		}
+		if index != i {
+			t.Fatalf("AddOutput returns %d, want %d", index, i)
+		}
+	}
+	builder.SetMemo([]byte("memo"))
+	fee, txSize, err := builder.EstimateFee(PerInputFeePolicy{BaseFee: 6, FeePerInput: 2})
+	if err != nil {
+		t.Fatalf("EstimateFee: %v", err)
+	}
+	if fee != 10 {
+		t.Fatalf("EstimateFee returns %d, want 10", fee)
+	}
+	builder.SetFee(fee)
+
+	trTx, outputIndexes, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if err = TransferTxVerify(pp, trTx); err != nil {
+		t.Fatalf("TransferTxVerify: %v", err)
+	}
+	serializedTrTx, err := pp.SerializeTransferTxMLP(trTx, true)
+	if err != nil {
+		t.Fatalf("SerializeTransferTxMLP: %v", err)
+	}
+	if len(serializedTrTx) != txSize {
+		t.Fatalf("EstimateFee returns the size %d, while the actual size is %d", txSize, len(serializedTrTx))
+	}
+
+	wantOutputIndexes := []int{2, 0, 3, 1}
+	trTxos := GetTrTxTxos(trTx)
+	for i, output := range outputs {
+		if outputIndexes[i] != wantOutputIndexes[i] {
+			t.Fatalf("outputIndexes[%d] = %d, want %d", i, outputIndexes[i], wantOutputIndexes[i])
+		}
+		coinAddress, err := GetCoinAddressFromTxo(pp, trTxos[outputIndexes[i]])
+		if err != nil {
+			t.Fatalf("GetCoinAddressFromTxo: %v", err)
+		}
+		if !bytes.Equal(coinAddress, output.GetCoinAddress()) {
+			t.Fatalf("the %d-th output is not at index %d", i, outputIndexes[i])
+		}
+	}
+
+	//	limits are checked when adding
+	limitBuilder := NewTransferTxBuilder(pp)
+	for i := 0; i < GetTxOutputMaxNumForRing(pp); i++ {
+		if _, err = limitBuilder.AddOutput(NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, 1)); err != nil {
+			t.Fatalf("AddOutput: %v", err)
+		}
+	}
+	if _, err = limitBuilder.AddOutput(NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, 1)); err == nil {
+		t.Fatalf("AddOutput shall fail when exceeding the allowed maximum number of RingCT-privacy outputs")
+	}
+	if _, _, err = limitBuilder.Build(); err == nil {
+		t.Fatalf("Build shall fail when there is no input")
+	}
+}
@@ -0,0 +1,489 @@
+// Package pqringctxcoinselect implements the coin selection for multi-privacy-level wallets on top of pqringctxapi,
+// which chooses the owned coins to spend under the limits on the inputs, computes the fee by the witness-size model,
+// and produces the change output, so that the resulting TxInputDescMLP/TxOutputDescMLP lists are ready for TransferTxGen.
+package pqringctxcoinselect
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/pqabelian/pqringctx/pqringctxapi"
+	"math/bits"
+	"sort"
+)
+
+// Strategy specifies how CoinSelector chooses the coins to spend.
+type Strategy uint8
+
+const (
+	// StrategyBranchAndBound searches the subsets of the coins for the one with the minimum fee
+	// (on a tie, the one without change, then the one with fewer inputs), and falls back to StrategyLargestFirst if the search budget is exhausted without a result.
+	StrategyBranchAndBound Strategy = iota
+	// StrategyLargestFirst adds the coins in the descending order of value, until the payments and the fee are covered.
+	StrategyLargestFirst
+	// StrategyPrivacyPreserving does not mix the privacy-levels, namely, it spends either only RingCT-privacy coins or only pseudonym-privacy coins
+	// (RingCT-privacy first), in the descending order of value, and the change has the same privacy-level as the spent coins.
+	StrategyPrivacyPreserving
+)
+
+// DefaultMaxTries is the default search budget of StrategyBranchAndBound, i.e., the maximum number of visited subsets.
+const DefaultMaxTries = 100000
+
+// Request describes the payments to make.
+// The change goes to ChangeCoinAddressForRing (with ChangeCoinValuePublicKeyForRing) if any RingCT-privacy coin is spent,
+// and to ChangeCoinAddressForSingle otherwise; if the preferred one is not provided, the other one is used,
+// except for StrategyPrivacyPreserving. If neither is provided, the selection must be exact, and the excess goes to the fee.
+// A change smaller than MinChange is not produced, and goes to the fee.
+type Request struct {
+	Payments  []*pqringctxapi.TxOutputDescMLP
+	TxMemo    []byte
+	FeePolicy pqringctxapi.FeePolicy
+
+	ChangeCoinAddressForRing        []byte
+	ChangeCoinValuePublicKeyForRing []byte
+	ChangeCoinAddressForSingle      []byte
+	MinChange                       uint64
+}
+
+// Selection is the result of CoinSelector.Select.
+// TxInputDescs and TxOutputDescs are ready for TransferTxGen with Fee and the TxMemo of the Request,
+// where the RingCT-privacy ones are at the first successive positions.
+// PaymentIndexes[i] is the index (in TxOutputDescs) of Request.Payments[i], and ChangeIndex is that of the change output, or -1 if there is no change.
+// TxSize is the serialize size (with witness) of the resulting TransferTxMLP.
+type Selection struct {
+	TxInputDescs   []*pqringctxapi.TxInputDescMLP
+	TxOutputDescs  []*pqringctxapi.TxOutputDescMLP
+	PaymentIndexes []int
+	ChangeIndex    int
+	Fee            uint64
+	TxSize         int
+}
+
+// CoinSelector chooses the coins to spend by the configured Strategy.
+// MaxTries is used only by StrategyBranchAndBound, and DefaultMaxTries is used if it is not positive.
+type CoinSelector struct {
+	pp *pqringctxapi.PublicParameter
+
+	Strategy Strategy
+	MaxTries int
+}
+
+// NewCoinSelector creates a CoinSelector with the input Strategy.
+func NewCoinSelector(pp *pqringctxapi.PublicParameter, strategy Strategy) *CoinSelector {
+	return &CoinSelector{
+		pp:       pp,
+		Strategy: strategy,
+	}
+}
+
+// coin is a candidate coin with the information for the selection.
+type coin struct {
+	txInputDesc *pqringctxapi.TxInputDescMLP
+	value       uint64
+	forRing     bool
+	//	the coinAddress (in hex) of a pseudonym-privacy coin, for counting the distinct coinAddresses
+	coinAddressForSingle string
+}
+
+// selectionContext carries the checked Request during a selection.
+type selectionContext struct {
+	req          *Request
+	paymentTotal uint64
+	//	the numbers of RingCT-privacy and pseudonym-privacy payments
+	outForRing   int
+	outForSingle int
+	//	privacyPreserving forbids the change on a privacy-level different from the spent coins
+	privacyPreserving bool
+}
+
+// Select chooses the coins to spend from the input coins, which are the owned coins as TxInputDescMLPs ready for TransferTxGen,
+// e.g., with the rings constructed by pqringctxapi.RingSelector.
+func (s *CoinSelector) Select(coins []*pqringctxapi.TxInputDescMLP, req *Request) (*Selection, error) {
+	ctx, err := s.newSelectionContext(req)
+	if err != nil {
+		return nil, err
+	}
+
+	//	the total value of the coins must not overflow, so that the total value of any subset does not either
+	candidates := make([]*coin, 0, len(coins))
+	candidatesTotal := uint64(0)
+	for i, txInputDesc := range coins {
+		c, err := s.newCoin(txInputDesc)
+		if err != nil {
+			return nil, fmt.Errorf("CoinSelector.Select: the %d -th coin: %v", i, err)
+		}
+		var carry uint64
+		candidatesTotal, carry = bits.Add64(candidatesTotal, c.value, 0)
+		if carry != 0 {
+			return nil, fmt.Errorf("CoinSelector.Select: the total value of the first %d coins overflows uint64", i+1)
+		}
+		candidates = append(candidates, c)
+	}
+	//	descending order of value, and the relative order of the coins with the same value keeps unchanged
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].value > candidates[j].value
+	})
+
+	var selection *Selection
+	switch s.Strategy {
+	case StrategyBranchAndBound:
+		selection = s.selectBranchAndBound(ctx, candidates)
+		if selection == nil {
+			selection = s.selectLargestFirst(ctx, candidates)
+		}
+	case StrategyLargestFirst:
+		selection = s.selectLargestFirst(ctx, candidates)
+	case StrategyPrivacyPreserving:
+		ctx.privacyPreserving = true
+		candidatesForRing := make([]*coin, 0, len(candidates))
+		candidatesForSingle := make([]*coin, 0, len(candidates))
+		for _, c := range candidates {
+			if c.forRing {
+				candidatesForRing = append(candidatesForRing, c)
+			} else {
+				candidatesForSingle = append(candidatesForSingle, c)
+			}
+		}
+		selection = s.selectLargestFirst(ctx, candidatesForRing)
+		if selection == nil {
+			selection = s.selectLargestFirst(ctx, candidatesForSingle)
+		}
+	default:
+		return nil, fmt.Errorf("CoinSelector.Select: the Strategy (%d) is not supported", s.Strategy)
+	}
+
+	if selection == nil {
+		return nil, fmt.Errorf("CoinSelector.Select: there is no selection of the coins to cover the payments (%d) and the fee under the limits", ctx.paymentTotal)
+	}
+	return selection, nil
+}
+
+// newSelectionContext checks the input Request and prepares the selectionContext.
+func (s *CoinSelector) newSelectionContext(req *Request) (*selectionContext, error) {
+	if req == nil {
+		return nil, fmt.Errorf("CoinSelector.Select: the input req is nil")
+	}
+	if req.FeePolicy == nil {
+		return nil, fmt.Errorf("CoinSelector.Select: the FeePolicy of the input req is nil")
+	}
+	if len(req.Payments) == 0 {
+		return nil, fmt.Errorf("CoinSelector.Select: there is no payment in the input req")
+	}
+
+	ctx := &selectionContext{req: req}
+	for i, payment := range req.Payments {
+		if payment == nil {
+			return nil, fmt.Errorf("CoinSelector.Select: the %d -th payment is nil", i)
+		}
+		forRing, err := s.isCoinAddressForRing(payment.GetCoinAddress())
+		if err != nil {
+			return nil, fmt.Errorf("CoinSelector.Select: the %d -th payment: %v", i, err)
+		}
+		if forRing {
+			ctx.outForRing++
+		} else {
+			ctx.outForSingle++
+		}
+		var carry uint64
+		ctx.paymentTotal, carry = bits.Add64(ctx.paymentTotal, payment.GetValue(), 0)
+		if carry != 0 {
+			return nil, fmt.Errorf("CoinSelector.Select: the total value of the first %d payments overflows uint64", i+1)
+		}
+	}
+
+	if len(req.ChangeCoinAddressForRing) != 0 {
+		forRing, err := s.isCoinAddressForRing(req.ChangeCoinAddressForRing)
+		if err != nil || !forRing || len(req.ChangeCoinValuePublicKeyForRing) == 0 {
+			return nil, fmt.Errorf("CoinSelector.Select: the ChangeCoinAddressForRing is not for RingCT-privacy, or the ChangeCoinValuePublicKeyForRing is nil")
+		}
+	}
+	if len(req.ChangeCoinAddressForSingle) != 0 {
+		forRing, err := s.isCoinAddressForRing(req.ChangeCoinAddressForSingle)
+		if err != nil || forRing {
+			return nil, fmt.Errorf("CoinSelector.Select: the ChangeCoinAddressForSingle is not for Pseudonym-privacy")
+		}
+	}
+
+	return ctx, nil
+}
+
+// isCoinAddressForRing returns whether the input coinAddress is for RingCT-privacy.
+func (s *CoinSelector) isCoinAddressForRing(coinAddress []byte) (bool, error) {
+	coinAddressType, err := pqringctxapi.ExtractCoinAddressTypeFromCoinAddress(s.pp, coinAddress)
+	if err != nil {
+		return false, err
+	}
+	switch coinAddressType {
+	case pqringctxapi.CoinAddressTypePublicKeyForRingPre, pqringctxapi.CoinAddressTypePublicKeyForRing:
+		return true, nil
+	case pqringctxapi.CoinAddressTypePublicKeyHashForSingle:
+		return false, nil
+	default:
+		return false, fmt.Errorf("the coinAddressType (%d) is not supported", coinAddressType)
+	}
+}
+
+// newCoin prepares the candidate coin for the input TxInputDescMLP.
+func (s *CoinSelector) newCoin(txInputDesc *pqringctxapi.TxInputDescMLP) (*coin, error) {
+	if txInputDesc == nil {
+		return nil, fmt.Errorf("the TxInputDescMLP is nil")
+	}
+	lgrTxo := txInputDesc.GetLgrTxoToSpend()
+	if lgrTxo == nil || lgrTxo.GetTxo() == nil {
+		return nil, fmt.Errorf("the TxInputDescMLP does not have a valid coin-to-spend")
+	}
+
+	c := &coin{
+		txInputDesc: txInputDesc,
+		value:       txInputDesc.GetValue(),
+	}
+	coinAddressType := lgrTxo.GetTxo().CoinAddressType()
+	switch coinAddressType {
+	case pqringctxapi.CoinAddressTypePublicKeyForRingPre, pqringctxapi.CoinAddressTypePublicKeyForRing:
+		c.forRing = true
+	case pqringctxapi.CoinAddressTypePublicKeyHashForSingle:
+		coinAddress, err := pqringctxapi.GetCoinAddressFromTxo(s.pp, lgrTxo.GetTxo())
+		if err != nil {
+			return nil, err
+		}
+		c.coinAddressForSingle = hex.EncodeToString(coinAddress)
+	default:
+		return nil, fmt.Errorf("the coinAddressType (%d) of the coin-to-spend is not supported", coinAddressType)
+	}
+	return c, nil
+}
+
+// canAdd returns whether the input coin can be added to the selected coins under the limits on the inputs.
+func (s *CoinSelector) canAdd(selected []*coin, c *coin) bool {
+	inForRing := 0
+	inForSingle := 0
+	coinAddressesForSingle := make(map[string]struct{})
+	for _, sc := range selected {
+		if sc.forRing {
+			inForRing++
+		} else {
+			inForSingle++
+			coinAddressesForSingle[sc.coinAddressForSingle] = struct{}{}
+		}
+	}
+
+	if c.forRing {
+		return inForRing < pqringctxapi.GetTxInputMaxNumForRing(s.pp)
+	}
+	if inForSingle >= pqringctxapi.GetTxInputMaxNumForSingle(s.pp) {
+		return false
+	}
+	if _, exists := coinAddressesForSingle[c.coinAddressForSingle]; !exists {
+		return len(coinAddressesForSingle) < pqringctxapi.GetTxInputMaxNumForSingleDistinct(s.pp)
+	}
+	return true
+}
+
+// changeOutput returns the change output with the input value for the selected coins, or nil if the change is not allowed.
+func (s *CoinSelector) changeOutput(ctx *selectionContext, selected []*coin, value uint64) *pqringctxapi.TxOutputDescMLP {
+	spendRing := false
+	for _, c := range selected {
+		if c.forRing {
+			spendRing = true
+			break
+		}
+	}
+
+	req := ctx.req
+	changeForRing := spendRing
+	if !ctx.privacyPreserving {
+		if changeForRing && len(req.ChangeCoinAddressForRing) == 0 {
+			changeForRing = false
+		} else if !changeForRing && len(req.ChangeCoinAddressForSingle) == 0 {
+			changeForRing = true
+		}
+	}
+
+	if changeForRing {
+		if len(req.ChangeCoinAddressForRing) == 0 || ctx.outForRing >= pqringctxapi.GetTxOutputMaxNumForRing(s.pp) {
+			return nil
+		}
+		return pqringctxapi.NewTxOutputDescMLP(req.ChangeCoinAddressForRing, req.ChangeCoinValuePublicKeyForRing, value)
+	}
+	if len(req.ChangeCoinAddressForSingle) == 0 || ctx.outForSingle >= pqringctxapi.GetTxOutputMaxNumForSingle(s.pp) {
+		return nil
+	}
+	return pqringctxapi.NewTxOutputDescMLP(req.ChangeCoinAddressForSingle, nil, value)
+}
+
+// requiredFee returns the serialize size (with witness) of the TransferTxMLP with the input inputs, outputs, and fee,
+// and the required fee at that size.
+func (s *CoinSelector) requiredFee(ctx *selectionContext, txInputDescs []*pqringctxapi.TxInputDescMLP, txOutputDescs []*pqringctxapi.TxOutputDescMLP, fee uint64) (int, uint64, error) {
+	desc, err := pqringctxapi.NewTransferTxSizeDesc(s.pp, txInputDescs, txOutputDescs, ctx.req.TxMemo)
+	if err != nil {
+		return 0, 0, err
+	}
+	txSize, err := pqringctxapi.GetTransferTxSerializeSizeByDesc(s.pp, desc, fee, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	return txSize, ctx.req.FeePolicy.Fee(txSize, len(txInputDescs)), nil
+}
+
+// evaluate returns the Selection with the minimum fee for the selected coins, or nil if they cannot cover the payments and the fee.
+// Without change, the fee is the whole excess. With change, the fee is solved iteratively,
+// since the change value (and hence, for a pseudonym-privacy change, the public value in the balance proof) depends on the fee.
+func (s *CoinSelector) evaluate(ctx *selectionContext, selected []*coin) *Selection {
+	total := uint64(0)
+	txInputDescs := make([]*pqringctxapi.TxInputDescMLP, len(selected))
+	for i, c := range selected {
+		total += c.value
+		txInputDescs[i] = c.txInputDesc
+	}
+	if len(selected) == 0 || total < ctx.paymentTotal {
+		return nil
+	}
+	excess := total - ctx.paymentTotal
+
+	var best *Selection
+
+	//	without change
+	txSize, requiredFee, err := s.requiredFee(ctx, txInputDescs, ctx.req.Payments, excess)
+	if err == nil && requiredFee <= excess {
+		best = s.newSelection(selected, ctx.req.Payments, nil, excess, txSize)
+	}
+
+	//	with change
+	minChange := ctx.req.MinChange
+	if minChange == 0 {
+		minChange = 1
+	}
+	fee := uint64(0)
+	for excess >= minChange && fee <= excess-minChange {
+		change := s.changeOutput(ctx, selected, excess-fee)
+		if change == nil {
+			break
+		}
+		txOutputDescs := append(append(make([]*pqringctxapi.TxOutputDescMLP, 0, len(ctx.req.Payments)+1), ctx.req.Payments...), change)
+		txSize, requiredFee, err = s.requiredFee(ctx, txInputDescs, txOutputDescs, fee)
+		if err != nil {
+			break
+		}
+		if requiredFee <= fee {
+			if best == nil || fee < best.Fee {
+				best = s.newSelection(selected, ctx.req.Payments, change, fee, txSize)
+			}
+			break
+		}
+		fee = requiredFee
+	}
+
+	return best
+}
+
+// newSelection constructs the Selection, with the RingCT-privacy inputs/outputs at the first successive positions.
+func (s *CoinSelector) newSelection(selected []*coin, payments []*pqringctxapi.TxOutputDescMLP, change *pqringctxapi.TxOutputDescMLP, fee uint64, txSize int) *Selection {
+	selection := &Selection{
+		TxInputDescs:   make([]*pqringctxapi.TxInputDescMLP, 0, len(selected)),
+		TxOutputDescs:  make([]*pqringctxapi.TxOutputDescMLP, 0, len(payments)+1),
+		PaymentIndexes: make([]int, len(payments)),
+		ChangeIndex:    -1,
+		Fee:            fee,
+		TxSize:         txSize,
+	}
+
+	for _, forRing := range []bool{true, false} {
+		for _, c := range selected {
+			if c.forRing == forRing {
+				selection.TxInputDescs = append(selection.TxInputDescs, c.txInputDesc)
+			}
+		}
+		for i, payment := range payments {
+			//	the coinAddresses have been checked
+			paymentForRing, _ := s.isCoinAddressForRing(payment.GetCoinAddress())
+			if paymentForRing == forRing {
+				selection.PaymentIndexes[i] = len(selection.TxOutputDescs)
+				selection.TxOutputDescs = append(selection.TxOutputDescs, payment)
+			}
+		}
+		if change != nil {
+			changeForRing, _ := s.isCoinAddressForRing(change.GetCoinAddress())
+			if changeForRing == forRing {
+				selection.ChangeIndex = len(selection.TxOutputDescs)
+				selection.TxOutputDescs = append(selection.TxOutputDescs, change)
+			}
+		}
+	}
+
+	return selection
+}
+
+// isBetter returns whether the Selection a is better than b, namely, with a lower fee, without change, or with fewer inputs.
+func isBetter(a *Selection, b *Selection) bool {
+	if b == nil {
+		return true
+	}
+	if a.Fee != b.Fee {
+		return a.Fee < b.Fee
+	}
+	if (a.ChangeIndex < 0) != (b.ChangeIndex < 0) {
+		return a.ChangeIndex < 0
+	}
+	return len(a.TxInputDescs) < len(b.TxInputDescs)
+}
+
+// selectLargestFirst adds the input candidates, which are in the descending order of value, until they cover the payments and the fee.
+func (s *CoinSelector) selectLargestFirst(ctx *selectionContext, candidates []*coin) *Selection {
+	selected := make([]*coin, 0, len(candidates))
+	for _, c := range candidates {
+		if !s.canAdd(selected, c) {
+			continue
+		}
+		selected = append(selected, c)
+		if selection := s.evaluate(ctx, selected); selection != nil {
+			return selection
+		}
+	}
+	return nil
+}
+
+// selectBranchAndBound searches the subsets of the input candidates, which are in the descending order of value,
+// for the Selection with the minimum fee.
+// A branch stops once its coins cover the payments and the fee, since more inputs lead to a larger size,
+// and it is pruned if the remaining coins cannot cover the payments.
+func (s *CoinSelector) selectBranchAndBound(ctx *selectionContext, candidates []*coin) *Selection {
+	maxTries := s.MaxTries
+	if maxTries <= 0 {
+		maxTries = DefaultMaxTries
+	}
+
+	//	remaining[i] is the total value of candidates[i:]
+	remaining := make([]uint64, len(candidates)+1)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + candidates[i].value
+	}
+
+	var best *Selection
+	tries := 0
+	var search func(i int, selected []*coin, total uint64)
+	search = func(i int, selected []*coin, total uint64) {
+		if tries >= maxTries {
+			return
+		}
+		tries++
+
+		if total >= ctx.paymentTotal {
+			if selection := s.evaluate(ctx, selected); selection != nil {
+				if isBetter(selection, best) {
+					best = selection
+				}
+				return
+			}
+		}
+		if i == len(candidates) || total+remaining[i] < ctx.paymentTotal {
+			return
+		}
+
+		if s.canAdd(selected, candidates[i]) {
+			search(i+1, append(selected[:len(selected):len(selected)], candidates[i]), total+candidates[i].value)
+		}
+		search(i+1, selected, total)
+	}
+	search(0, nil, 0)
+
+	return best
+}
@@ -0,0 +1,176 @@
+package pqringctxcoinselect
+
+import (
+	"crypto/rand"
+	"github.com/pqabelian/pqringctx/pqringctxapi"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"math"
+	"strings"
+	"testing"
+)
+
+func randomBytesForTest(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestCoinSelector(t *testing.T) {
+	pp := pqringctxapi.InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+
+	masterSeed := randomBytesForTest(t, 64)
+	coinDetectorKey, err := pqringctxapi.CoinDetectorKeyDerive(pp, masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinValuePublicKey, coinValueSecretKey, err := pqringctxapi.CoinValueKeyDerive(pp, masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddressForRing, coinSpendSecretKeyForRing, coinSerialNumberSecretKeyForRing, err := pqringctxapi.CoinAddressKeyForPKRingDerive(pp, masterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeCoinAddressForRing, _, _, err := pqringctxapi.CoinAddressKeyForPKRingDerive(pp, masterSeed, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddressForSingle, coinSpendSecretKeyForSingle, err := pqringctxapi.CoinAddressKeyForPKHSingleDerive(pp, masterSeed, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changeCoinAddressForSingle, _, err := pqringctxapi.CoinAddressKeyForPKHSingleDerive(pp, masterSeed, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//	the owned coins
+	valuesForRing := []uint64{3000000, 10000000, 6000000}
+	valuesForSingle := []uint64{5000000, 2000000}
+	txOutputDescs := make([]*pqringctxapi.TxOutputDescMLP, 0, len(valuesForRing)+len(valuesForSingle))
+	vTotal := uint64(0)
+	for _, value := range valuesForRing {
+		txOutputDescs = append(txOutputDescs, pqringctxapi.NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, value))
+		vTotal += value
+	}
+	for _, value := range valuesForSingle {
+		txOutputDescs = append(txOutputDescs, pqringctxapi.NewTxOutputDescMLP(coinAddressForSingle, nil, value))
+		vTotal += value
+	}
+	cbTx, err := pqringctxapi.CoinbaseTxGen(pp, vTotal, txOutputDescs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coins := make([]*pqringctxapi.TxInputDescMLP, 0, len(txOutputDescs))
+	for i, txo := range pqringctxapi.GetCbTxTxos(cbTx) {
+		lgrTxo := pqringctxapi.NewLgrTxo(txo, randomBytesForTest(t, 64))
+		if i < len(valuesForRing) {
+			coins = append(coins, pqringctxapi.NewTxInputDescMLP([]*pqringctxapi.LgrTxoMLP{lgrTxo}, 0, coinSpendSecretKeyForRing, coinSerialNumberSecretKeyForRing,
+				coinValuePublicKey, coinValueSecretKey, coinDetectorKey, valuesForRing[i]))
+		} else {
+			coins = append(coins, pqringctxapi.NewTxInputDescMLP([]*pqringctxapi.LgrTxoMLP{lgrTxo}, 0, coinSpendSecretKeyForSingle, nil,
+				nil, nil, coinDetectorKey, valuesForSingle[i-len(valuesForRing)]))
+		}
+	}
+
+	payment := pqringctxapi.NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, 4000000)
+	req := &Request{
+		Payments:                        []*pqringctxapi.TxOutputDescMLP{payment},
+		TxMemo:                          []byte("memo"),
+		FeePolicy:                       pqringctxapi.PerByteFeePolicy{BaseFee: 1000, FeePerByte: 1},
+		ChangeCoinAddressForRing:        changeCoinAddressForRing,
+		ChangeCoinValuePublicKeyForRing: coinValuePublicKey,
+		ChangeCoinAddressForSingle:      changeCoinAddressForSingle,
+	}
+
+	selections := make(map[Strategy]*Selection)
+	for _, strategy := range []Strategy{StrategyBranchAndBound, StrategyLargestFirst, StrategyPrivacyPreserving} {
+		selection, err := NewCoinSelector(pp, strategy).Select(coins, req)
+		if err != nil {
+			t.Fatalf("Select with Strategy %d: %v", strategy, err)
+		}
+		selections[strategy] = selection
+
+		if selection.TxOutputDescs[selection.PaymentIndexes[0]] != payment {
+			t.Fatalf("Strategy %d: the payment is not at PaymentIndexes[0]", strategy)
+		}
+		vIn := uint64(0)
+		for _, txInputDesc := range selection.TxInputDescs {
+			vIn += txInputDesc.GetValue()
+		}
+		vOut := selection.Fee
+		for _, txOutputDesc := range selection.TxOutputDescs {
+			vOut += txOutputDesc.GetValue()
+		}
+		if vIn != vOut {
+			t.Fatalf("Strategy %d: the input value (%d) is different from the output value with fee (%d)", strategy, vIn, vOut)
+		}
+
+		trTx, err := pqringctxapi.TransferTxGen(pp, selection.TxInputDescs, selection.TxOutputDescs, selection.Fee, req.TxMemo)
+		if err != nil {
+			t.Fatalf("Strategy %d: TransferTxGen: %v", strategy, err)
+		}
+		if err = pqringctxapi.TransferTxVerify(pp, trTx); err != nil {
+			t.Fatalf("Strategy %d: TransferTxVerify: %v", strategy, err)
+		}
+		serializedTrTx, err := pp.SerializeTransferTxMLP(trTx, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(serializedTrTx) != selection.TxSize {
+			t.Fatalf("Strategy %d: the TxSize (%d) is different from the actual size (%d)", strategy, selection.TxSize, len(serializedTrTx))
+		}
+		if selection.Fee < req.FeePolicy.Fee(len(serializedTrTx), len(selection.TxInputDescs)) {
+			t.Fatalf("Strategy %d: the Fee (%d) does not cover the required fee", strategy, selection.Fee)
+		}
+	}
+
+	if selections[StrategyBranchAndBound].Fee > selections[StrategyLargestFirst].Fee {
+		t.Fatalf("StrategyBranchAndBound has a higher fee (%d) than StrategyLargestFirst (%d)", selections[StrategyBranchAndBound].Fee, selections[StrategyLargestFirst].Fee)
+	}
+	if len(selections[StrategyLargestFirst].TxInputDescs) != 1 || selections[StrategyLargestFirst].TxInputDescs[0] != coins[1] {
+		t.Fatalf("StrategyLargestFirst does not spend the largest coin only")
+	}
+
+	//	the privacy-levels are not mixed, even if the RingCT-privacy coins are not enough
+	req.Payments = []*pqringctxapi.TxOutputDescMLP{pqringctxapi.NewTxOutputDescMLP(coinAddressForSingle, nil, 6500000)}
+	req.ChangeCoinAddressForRing = nil
+	req.ChangeCoinValuePublicKeyForRing = nil
+	selection, err := NewCoinSelector(pp, StrategyPrivacyPreserving).Select(coins[len(valuesForRing)-1:], req)
+	if err != nil {
+		t.Fatalf("Select with StrategyPrivacyPreserving: %v", err)
+	}
+	for _, txInputDesc := range selection.TxInputDescs {
+		if txInputDesc.GetLgrTxoToSpend().GetTxo().CoinAddressType() != pqringctxapi.CoinAddressTypePublicKeyHashForSingle {
+			t.Fatalf("StrategyPrivacyPreserving mixes the privacy-levels")
+		}
+	}
+	if selection.ChangeIndex < 0 {
+		t.Fatalf("StrategyPrivacyPreserving does not produce the change")
+	}
+
+	//	insufficient
+	req.Payments = []*pqringctxapi.TxOutputDescMLP{pqringctxapi.NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, vTotal)}
+	if _, err = NewCoinSelector(pp, StrategyBranchAndBound).Select(coins, req); err == nil {
+		t.Fatalf("Select succeeds with insufficient coins")
+	}
+
+	//	the total value of the payments overflows
+	req.Payments = []*pqringctxapi.TxOutputDescMLP{
+		pqringctxapi.NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, math.MaxUint64),
+		pqringctxapi.NewTxOutputDescMLP(coinAddressForRing, coinValuePublicKey, 2),
+	}
+	if _, err = NewCoinSelector(pp, StrategyBranchAndBound).Select(coins, req); err == nil || !strings.Contains(err.Error(), "overflows") {
+		t.Fatalf("Select with the overflowing payments: err = %v, want an overflow error", err)
+	}
+
+	//	the total value of the coins overflows
+	req.Payments = []*pqringctxapi.TxOutputDescMLP{payment}
+	overflowingCoin := pqringctxapi.NewTxInputDescMLP([]*pqringctxapi.LgrTxoMLP{pqringctxapi.NewLgrTxo(pqringctxapi.GetCbTxTxos(cbTx)[0], randomBytesForTest(t, 64))}, 0, coinSpendSecretKeyForRing, coinSerialNumberSecretKeyForRing,
+		coinValuePublicKey, coinValueSecretKey, coinDetectorKey, math.MaxUint64)
+	if _, err = NewCoinSelector(pp, StrategyBranchAndBound).Select(append([]*pqringctxapi.TxInputDescMLP{overflowingCoin}, coins...), req); err == nil || !strings.Contains(err.Error(), "overflows") {
+		t.Fatalf("Select with the overflowing coins: err = %v, want an overflow error", err)
+	}
+}
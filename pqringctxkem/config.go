@@ -4,8 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"github.com/cryptosuite/kyber-go/kyber"
-	"github.com/pqabelian/pqringctx/pqringctxkem/pqringctOQSKem"
 	"github.com/pqabelian/pqringctx/pqringctxkem/pqringctkyber"
+	"github.com/pqabelian/pqringctx/pqringctxkem/pqringctmlkem"
+	"io"
 	"log"
 )
 
@@ -13,9 +14,17 @@ type VersionKEM uint32
 
 const (
 	KEM_KYBER VersionKEM = iota
+	// KEM_OQS_KYBER is implemented by liboqs (see pqringctOQSKem), and hence requires cgo.
+	// Without cgo, the functions return ErrOQSKyberRequiresCgo for KEM_OQS_KYBER.
 	KEM_OQS_KYBER
+	// KEM_MLKEM768 is ML-KEM-768 (FIPS 203), implemented in pure Go by pqringctmlkem.
+	// Note that ML-KEM-768 is not byte-compatible with KEM_OQS_KYBER (round-3 Kyber768).
+	KEM_MLKEM768
 )
 
+// ErrOQSKyberRequiresCgo is returned for KEM_OQS_KYBER, if the binary is built without cgo (and hence without liboqs).
+var ErrOQSKyberRequiresCgo = errors.New("pqringctxkem: KEM_OQS_KYBER requires liboqs, which is not available without cgo; use KEM_MLKEM768 instead")
+
 type ParamKem struct {
 	Version  VersionKEM
 	Kyber    *kyber.ParameterSet
@@ -52,7 +61,12 @@ func KeyGen(ppkem *ParamKem, seed []byte, seedLen int) ([]byte, []byte, error) {
 			recovery = true
 			seed = seed[:32]
 		}
-		originSerializedPK, originSerializedSK, err = pqringctOQSKem.KeyPair(ppkem.OQSKyber, seed, recovery)
+		originSerializedPK, originSerializedSK, err = oqsKeyPair(ppkem.OQSKyber, seed, recovery)
+		if err != nil {
+			return nil, nil, err
+		}
+	case KEM_MLKEM768:
+		originSerializedPK, originSerializedSK, err = pqringctmlkem.KeyPair(seed, seedLen)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -124,6 +138,13 @@ func VerifyKeyPair(ppkem *ParamKem, serializedPK []byte, serializedSK []byte) (v
 // 2. perform actual encapsulation distributed by kem version
 // todo(MLP): add the sanity-check on the input pk
 func Encaps(ppkem *ParamKem, pk []byte) ([]byte, []byte, error) {
+	return EncapsWithRand(ppkem, pk, nil)
+}
+
+// EncapsWithRand is the same as Encaps, except that the encapsulation randomness is read from randReader.
+// A nil randReader means the default randomness source of the underlying KEM.
+// Note that KEM_KYBER and KEM_OQS_KYBER do not support a non-nil randReader.
+func EncapsWithRand(ppkem *ParamKem, pk []byte, randReader io.Reader) ([]byte, []byte, error) {
 	var serializedC, kappa []byte
 	var err error
 
@@ -142,19 +163,37 @@ func Encaps(ppkem *ParamKem, pk []byte) ([]byte, []byte, error) {
 		if len(pk) != 4+ppkem.Kyber.CryptoPublicKeyBytes() {
 			return nil, nil, errors.New("invalid public key")
 		}
+		if randReader != nil {
+			return nil, nil, errors.New("the encapsulation with given randomness is not supported by KEM_KYBER")
+		}
 		serializedC, kappa, err = pqringctkyber.Encaps(ppkem.Kyber, pk[4:])
 		if err != nil {
 			return nil, nil, err
 		}
 	case KEM_OQS_KYBER:
-		expectPKLen, err := pqringctOQSKem.LengthPublicKey(ppkem.OQSKyber)
+		expectPKLen, err := oqsLengthPublicKey(ppkem.OQSKyber)
 		if err != nil {
 			return nil, nil, err
 		}
 		if len(pk) != 4+expectPKLen {
 			return nil, nil, errors.New("invalid public key")
 		}
-		serializedC, kappa, err = pqringctOQSKem.Encaps(ppkem.OQSKyber, pk[4:])
+		if randReader != nil {
+			return nil, nil, errors.New("the encapsulation with given randomness is not supported by KEM_OQS_KYBER")
+		}
+		serializedC, kappa, err = oqsEncaps(ppkem.OQSKyber, pk[4:])
+		if err != nil {
+			return nil, nil, err
+		}
+	case KEM_MLKEM768:
+		if len(pk) != 4+pqringctmlkem.PublicKeyBytes {
+			return nil, nil, errors.New("invalid public key")
+		}
+		if randReader == nil {
+			serializedC, kappa, err = pqringctmlkem.Encaps(pk[4:])
+		} else {
+			serializedC, kappa, err = pqringctmlkem.EncapsWithRand(pk[4:], randReader)
+		}
 		if err != nil {
 			return nil, nil, err
 		}
@@ -215,7 +254,7 @@ func Decaps(ppkem *ParamKem, serializedC []byte, sk []byte) ([]byte, error) {
 			return nil, err
 		}
 	case KEM_OQS_KYBER:
-		expectedSKLen, err := pqringctOQSKem.LengthSecretKey(ppkem.OQSKyber)
+		expectedSKLen, err := oqsLengthSecretKey(ppkem.OQSKyber)
 		if err != nil {
 			return nil, errors.New("invalid secret key")
 		}
@@ -223,7 +262,7 @@ func Decaps(ppkem *ParamKem, serializedC []byte, sk []byte) ([]byte, error) {
 			return nil, errors.New("invalid secret key")
 		}
 
-		expectedCipherTextLen, err := pqringctOQSKem.LengthCiphertext(ppkem.OQSKyber)
+		expectedCipherTextLen, err := oqsLengthCiphertext(ppkem.OQSKyber)
 		if err != nil {
 			return nil, errors.New("invalid cipher text")
 		}
@@ -231,7 +270,18 @@ func Decaps(ppkem *ParamKem, serializedC []byte, sk []byte) ([]byte, error) {
 			return nil, errors.New("invalid cipher text")
 		}
 
-		kappa, err = pqringctOQSKem.Decaps(ppkem.OQSKyber, serializedC[4:], sk[4:])
+		kappa, err = oqsDecaps(ppkem.OQSKyber, serializedC[4:], sk[4:])
+		if err != nil {
+			return nil, err
+		}
+	case KEM_MLKEM768:
+		if len(sk) != 4+pqringctmlkem.SecretKeyBytes {
+			return nil, errors.New("invalid secret key")
+		}
+		if len(serializedC) != 4+pqringctmlkem.CiphertextBytes {
+			return nil, errors.New("invalid cipher text")
+		}
+		kappa, err = pqringctmlkem.Decaps(serializedC[4:], sk[4:])
 		if err != nil {
 			return nil, err
 		}
@@ -246,11 +296,13 @@ func GetKemPublicKeyBytesLen(ppkem *ParamKem) int {
 	case KEM_KYBER:
 		return 4 + ppkem.Kyber.CryptoPublicKeyBytes()
 	case KEM_OQS_KYBER:
-		length, err := pqringctOQSKem.LengthPublicKey(ppkem.OQSKyber)
+		length, err := oqsLengthPublicKey(ppkem.OQSKyber)
 		if err != nil {
 			return -1
 		}
 		return 4 + length
+	case KEM_MLKEM768:
+		return 4 + pqringctmlkem.PublicKeyBytes
 	default:
 		log.Fatalln("Unsupported KEM version.")
 	}
@@ -262,11 +314,13 @@ func GetKemSecretKeyBytesLen(ppkem *ParamKem) int {
 	case KEM_KYBER:
 		return 4 + ppkem.Kyber.CryptoSecretKeyBytes()
 	case KEM_OQS_KYBER:
-		length, err := pqringctOQSKem.LengthSecretKey(ppkem.OQSKyber)
+		length, err := oqsLengthSecretKey(ppkem.OQSKyber)
 		if err != nil {
 			return -1
 		}
 		return 4 + length
+	case KEM_MLKEM768:
+		return 4 + pqringctmlkem.SecretKeyBytes
 	default:
 		log.Fatalln("Unsupported KEM version.")
 	}
@@ -281,11 +335,13 @@ func GetKemCiphertextBytesLen(ppkem *ParamKem) int {
 	case KEM_KYBER:
 		return 4 + ppkem.Kyber.CryptoCiphertextBytes()
 	case KEM_OQS_KYBER:
-		length, err := pqringctOQSKem.LengthCiphertext(ppkem.OQSKyber)
+		length, err := oqsLengthCiphertext(ppkem.OQSKyber)
 		if err != nil {
 			return -1
 		}
 		return 4 + length
+	case KEM_MLKEM768:
+		return 4 + pqringctmlkem.CiphertextBytes
 	default:
 		log.Fatalln("Unsupported KEM version.")
 	}
@@ -297,11 +353,13 @@ func GetKemSharedSecretBytesLen(ppkem *ParamKem) int {
 	case KEM_KYBER:
 		return 4 + ppkem.Kyber.CryptoCiphertextBytes()
 	case KEM_OQS_KYBER:
-		length, err := pqringctOQSKem.LengthSharedSecret(ppkem.OQSKyber)
+		length, err := oqsLengthSharedSecret(ppkem.OQSKyber)
 		if err != nil {
 			return -1
 		}
 		return 4 + length
+	case KEM_MLKEM768:
+		return 4 + pqringctmlkem.SharedSecretBytes
 	default:
 		log.Fatalln("Unsupported KEM version.")
 	}
@@ -320,6 +378,10 @@ func NewParamKem(version VersionKEM, kyber *kyber.ParameterSet, oqsKEM string) *
 			Kyber:    nil,
 			OQSKyber: oqsKEM,
 		}
+	case KEM_MLKEM768:
+		return &ParamKem{
+			Version: version,
+		}
 	default:
 		return nil
 	}
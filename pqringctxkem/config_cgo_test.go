@@ -0,0 +1,41 @@
+//go:build cgo
+
+package pqringctxkem
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/pqabelian/pqringctx/pqringctxkem/pqringctOQSKem"
+)
+
+func TestNewParamKem_OQSKyber(t *testing.T) {
+	paramKem := NewParamKem(KEM_OQS_KYBER, nil, pqringctOQSKem.OQSKYBER768)
+	seed := make([]byte, 32)
+	rand.Read(seed)
+
+	serializedPK, serializedSK, err := KeyGen(paramKem, seed, 32)
+	if err != nil {
+		t.Fatalf("error in keypair: %v", err)
+	}
+	if valid, hints := VerifyKeyPair(paramKem, serializedPK, serializedSK); !valid {
+		t.Fatalf("invalid keypair %v", hints)
+	}
+
+	sc, kappa, err := Encaps(paramKem, serializedPK)
+	if err != nil {
+		t.Fatalf("error in encaps: %v", err)
+	}
+	res, err := Decaps(paramKem, sc, serializedSK)
+	if err != nil {
+		t.Fatalf("error in decaps: %v", err)
+	}
+	if !bytes.Equal(kappa, res) {
+		t.Fatalf("error in matched")
+	}
+
+	if _, _, err = EncapsWithRand(paramKem, serializedPK, rand.Reader); err == nil {
+		t.Fatalf("EncapsWithRand accepts a non-nil randReader for KEM_OQS_KYBER")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !cgo
+
+package pqringctxkem
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewParamKem_OQSKyberWithoutCgo(t *testing.T) {
+	paramKem := NewParamKem(KEM_OQS_KYBER, nil, "Kyber768")
+	if _, _, err := KeyGen(paramKem, make([]byte, 32), 32); !errors.Is(err, ErrOQSKyberRequiresCgo) {
+		t.Fatalf("KeyGen() error = %v, want %v", err, ErrOQSKyberRequiresCgo)
+	}
+	if GetKemPublicKeyBytesLen(paramKem) != -1 {
+		t.Fatalf("GetKemPublicKeyBytesLen() = %d, want -1", GetKemPublicKeyBytesLen(paramKem))
+	}
+}
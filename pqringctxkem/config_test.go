@@ -5,7 +5,6 @@ import (
 	"crypto/rand"
 	"fmt"
 	"github.com/cryptosuite/kyber-go/kyber"
-	"github.com/pqabelian/pqringctx/pqringctxkem/pqringctOQSKem"
 	"testing"
 )
 
@@ -28,11 +27,11 @@ func TestNewParamKem(t *testing.T) {
 			},
 		},
 		{
-			name: "OQSKyber",
+			name: "MLKEM768",
 			args: args{
-				version: KEM_OQS_KYBER,
+				version: KEM_MLKEM768,
 				kyber:   nil,
-				oqsKEM:  pqringctOQSKem.OQSKYBER768,
+				oqsKEM:  "",
 			},
 		},
 	}
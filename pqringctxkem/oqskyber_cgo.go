@@ -0,0 +1,37 @@
+//go:build cgo
+
+package pqringctxkem
+
+import (
+	"github.com/pqabelian/pqringctx/pqringctxkem/pqringctOQSKem"
+)
+
+// The KEM_OQS_KYBER functions below are served by liboqs via pqringctOQSKem, see oqskyber_nocgo.go for the builds without cgo.
+
+func oqsKeyPair(kemName string, seed []byte, recovery bool) ([]byte, []byte, error) {
+	return pqringctOQSKem.KeyPair(kemName, seed, recovery)
+}
+
+func oqsEncaps(kemName string, pk []byte) ([]byte, []byte, error) {
+	return pqringctOQSKem.Encaps(kemName, pk)
+}
+
+func oqsDecaps(kemName string, cipher []byte, sk []byte) ([]byte, error) {
+	return pqringctOQSKem.Decaps(kemName, cipher, sk)
+}
+
+func oqsLengthPublicKey(kemName string) (int, error) {
+	return pqringctOQSKem.LengthPublicKey(kemName)
+}
+
+func oqsLengthSecretKey(kemName string) (int, error) {
+	return pqringctOQSKem.LengthSecretKey(kemName)
+}
+
+func oqsLengthCiphertext(kemName string) (int, error) {
+	return pqringctOQSKem.LengthCiphertext(kemName)
+}
+
+func oqsLengthSharedSecret(kemName string) (int, error) {
+	return pqringctOQSKem.LengthSharedSecret(kemName)
+}
@@ -0,0 +1,33 @@
+//go:build !cgo
+
+package pqringctxkem
+
+// Without cgo, liboqs is not available, so that the KEM_OQS_KYBER functions below return ErrOQSKyberRequiresCgo.
+
+func oqsKeyPair(kemName string, seed []byte, recovery bool) ([]byte, []byte, error) {
+	return nil, nil, ErrOQSKyberRequiresCgo
+}
+
+func oqsEncaps(kemName string, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, ErrOQSKyberRequiresCgo
+}
+
+func oqsDecaps(kemName string, cipher []byte, sk []byte) ([]byte, error) {
+	return nil, ErrOQSKyberRequiresCgo
+}
+
+func oqsLengthPublicKey(kemName string) (int, error) {
+	return 0, ErrOQSKyberRequiresCgo
+}
+
+func oqsLengthSecretKey(kemName string) (int, error) {
+	return 0, ErrOQSKyberRequiresCgo
+}
+
+func oqsLengthCiphertext(kemName string) (int, error) {
+	return 0, ErrOQSKyberRequiresCgo
+}
+
+func oqsLengthSharedSecret(kemName string) (int, error) {
+	return 0, ErrOQSKyberRequiresCgo
+}
@@ -1,3 +1,8 @@
+//go:build cgo
+
+// Package pqringctOQSKem wraps the KEMs of liboqs (via liboqs-go) for pqringctxkem.KEM_OQS_KYBER.
+// NOTE: It requires cgo and liboqs, and has no Go files to build without cgo, so that importing it in a build without cgo fails.
+// pqringctxkem does not import it without cgo, where pqringctxkem.KEM_OQS_KYBER returns pqringctxkem.ErrOQSKyberRequiresCgo.
 package pqringctOQSKem
 
 import (
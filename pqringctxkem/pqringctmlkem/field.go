@@ -0,0 +1,270 @@
+package pqringctmlkem
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// This file implements the arithmetic in R_q = Z_q[X]/(X^256+1) with q = 3329,
+// as specified by FIPS 203, Section 4.
+// The coefficients are always kept in [0, q), and the reductions avoid data-dependent branches.
+
+const (
+	n = 256
+	q = 3329
+
+	// barrettMultiplier = floor(2^24 / q)
+	barrettMultiplier = 5039
+	barrettShift      = 24
+
+	// invN128 = 128^{-1} mod q
+	invN128 = 3303
+)
+
+type fieldElement uint16
+
+// ringElement is a polynomial in R_q, either in the normal form or in the NTT form.
+type ringElement [n]fieldElement
+
+// zetas[i] = 17^{BitRev7(i)} mod q and gammas[i] = 17^{2*BitRev7(i)+1} mod q.
+var zetas, gammas [128]fieldElement
+
+func init() {
+	var pow [256]fieldElement
+	pow[0] = 1
+	for i := 1; i < 256; i++ {
+		pow[i] = fieldMul(pow[i-1], 17)
+	}
+	for i := 0; i < 128; i++ {
+		br := bitRev7(uint8(i))
+		zetas[i] = pow[br]
+		gammas[i] = pow[2*int(br)+1]
+	}
+}
+
+func bitRev7(x uint8) uint8 {
+	var r uint8
+	for i := 0; i < 7; i++ {
+		r = (r << 1) | (x & 1)
+		x >>= 1
+	}
+	return r
+}
+
+// fieldReduceOnce maps a in [0, 2q) to [0, q).
+func fieldReduceOnce(a uint16) fieldElement {
+	x := a - q
+	// if a < q, x underflows and its top bit is set
+	x += (x >> 15) * q
+	return fieldElement(x)
+}
+
+// fieldReduce maps a in [0, q^2] to [0, q), using Barrett reduction.
+func fieldReduce(a uint32) fieldElement {
+	quotient := uint32((uint64(a) * barrettMultiplier) >> barrettShift)
+	return fieldReduceOnce(uint16(a - quotient*q))
+}
+
+func fieldAdd(a, b fieldElement) fieldElement {
+	return fieldReduceOnce(uint16(a + b))
+}
+
+func fieldSub(a, b fieldElement) fieldElement {
+	return fieldReduceOnce(uint16(a - b + q))
+}
+
+func fieldMul(a, b fieldElement) fieldElement {
+	return fieldReduce(uint32(a) * uint32(b))
+}
+
+func polyAdd(a, b *ringElement) *ringElement {
+	var r ringElement
+	for i := 0; i < n; i++ {
+		r[i] = fieldAdd(a[i], b[i])
+	}
+	return &r
+}
+
+func polySub(a, b *ringElement) *ringElement {
+	var r ringElement
+	for i := 0; i < n; i++ {
+		r[i] = fieldSub(a[i], b[i])
+	}
+	return &r
+}
+
+// ntt implements Algorithm 9 of FIPS 203.
+func ntt(f *ringElement) *ringElement {
+	r := *f
+	k := 1
+	for length := 128; length >= 2; length /= 2 {
+		for start := 0; start < n; start += 2 * length {
+			zeta := zetas[k]
+			k++
+			for j := start; j < start+length; j++ {
+				t := fieldMul(zeta, r[j+length])
+				r[j+length] = fieldSub(r[j], t)
+				r[j] = fieldAdd(r[j], t)
+			}
+		}
+	}
+	return &r
+}
+
+// nttInverse implements Algorithm 10 of FIPS 203.
+func nttInverse(f *ringElement) *ringElement {
+	r := *f
+	k := 127
+	for length := 2; length <= 128; length *= 2 {
+		for start := 0; start < n; start += 2 * length {
+			zeta := zetas[k]
+			k--
+			for j := start; j < start+length; j++ {
+				t := r[j]
+				r[j] = fieldAdd(t, r[j+length])
+				r[j+length] = fieldMul(zeta, fieldSub(r[j+length], t))
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		r[i] = fieldMul(r[i], invN128)
+	}
+	return &r
+}
+
+// nttMul implements Algorithms 11 and 12 of FIPS 203.
+func nttMul(f, g *ringElement) *ringElement {
+	var h ringElement
+	for i := 0; i < 128; i++ {
+		a0, a1 := f[2*i], f[2*i+1]
+		b0, b1 := g[2*i], g[2*i+1]
+		h[2*i] = fieldAdd(fieldMul(a0, b0), fieldMul(fieldMul(a1, b1), gammas[i]))
+		h[2*i+1] = fieldAdd(fieldMul(a0, b1), fieldMul(a1, b0))
+	}
+	return &h
+}
+
+// sampleNTT implements Algorithm 7 of FIPS 203, i.e., it samples a uniform ringElement in NTT form
+// from SHAKE128(rho || j || i).
+func sampleNTT(rho []byte, j byte, i byte) *ringElement {
+	xof := sha3.NewShake128()
+	xof.Write(rho)
+	xof.Write([]byte{j, i})
+
+	var a ringElement
+	var buf [168]byte
+	cnt := 0
+	for cnt < n {
+		xof.Read(buf[:])
+		for off := 0; off+3 <= len(buf) && cnt < n; off += 3 {
+			d1 := uint16(buf[off]) | (uint16(buf[off+1])&0x0F)<<8
+			d2 := uint16(buf[off+1])>>4 | uint16(buf[off+2])<<4
+			if d1 < q {
+				a[cnt] = fieldElement(d1)
+				cnt++
+			}
+			if d2 < q && cnt < n {
+				a[cnt] = fieldElement(d2)
+				cnt++
+			}
+		}
+	}
+	return &a
+}
+
+// samplePolyCBD2 implements Algorithm 8 of FIPS 203 with eta = 2,
+// on input PRF_2(s, b) = SHAKE256(s || b, 128).
+func samplePolyCBD2(s []byte, b byte) *ringElement {
+	prf := sha3.NewShake256()
+	prf.Write(s)
+	prf.Write([]byte{b})
+	var buf [64 * 2]byte
+	prf.Read(buf[:])
+
+	var f ringElement
+	for i := 0; i < n; i += 2 {
+		bt := buf[i/2]
+		x0 := (bt & 1) + ((bt >> 1) & 1)
+		y0 := ((bt >> 2) & 1) + ((bt >> 3) & 1)
+		x1 := ((bt >> 4) & 1) + ((bt >> 5) & 1)
+		y1 := ((bt >> 6) & 1) + ((bt >> 7) & 1)
+		f[i] = fieldSub(fieldElement(x0), fieldElement(y0))
+		f[i+1] = fieldSub(fieldElement(x1), fieldElement(y1))
+	}
+	return &f
+}
+
+// compress implements Compress_d in FIPS 203, i.e., round((2^d/q) * x) mod 2^d.
+func compress(x fieldElement, d uint) uint16 {
+	return uint16(((uint32(x)<<d)+q/2)/q) & (1<<d - 1)
+}
+
+// decompress implements Decompress_d in FIPS 203, i.e., round((q/2^d) * y).
+func decompress(y uint16, d uint) fieldElement {
+	return fieldElement((uint32(y)*q + 1<<(d-1)) >> d)
+}
+
+// byteEncode12 implements ByteEncode_12 in FIPS 203.
+func byteEncode12(b []byte, f *ringElement) []byte {
+	for i := 0; i < n; i += 2 {
+		x := uint32(f[i]) | uint32(f[i+1])<<12
+		b = append(b, byte(x), byte(x>>8), byte(x>>16))
+	}
+	return b
+}
+
+// byteDecode12 implements ByteDecode_12 in FIPS 203.
+// It returns false if some decoded coefficient is not in [0, q),
+// which serves as the modulus check on the encapsulation key.
+func byteDecode12(b []byte) (*ringElement, bool) {
+	var f ringElement
+	ok := true
+	for i := 0; i < n; i += 2 {
+		x := uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+		c0 := uint16(x & 0x0FFF)
+		c1 := uint16(x >> 12)
+		if c0 >= q || c1 >= q {
+			ok = false
+		}
+		f[i] = fieldElement(c0 % q)
+		f[i+1] = fieldElement(c1 % q)
+		b = b[3:]
+	}
+	return &f, ok
+}
+
+// byteEncodeCompressed compresses each coefficient to d bits and packs them little-endian,
+// i.e., ByteEncode_d(Compress_d(f)) in FIPS 203.
+func byteEncodeCompressed(b []byte, f *ringElement, d uint) []byte {
+	var acc uint32
+	var accBits uint
+	for i := 0; i < n; i++ {
+		acc |= uint32(compress(f[i], d)) << accBits
+		accBits += d
+		for accBits >= 8 {
+			b = append(b, byte(acc))
+			acc >>= 8
+			accBits -= 8
+		}
+	}
+	return b
+}
+
+// byteDecodeDecompressed is the inverse of byteEncodeCompressed,
+// i.e., Decompress_d(ByteDecode_d(b)) in FIPS 203.
+func byteDecodeDecompressed(b []byte, d uint) *ringElement {
+	var f ringElement
+	var acc uint32
+	var accBits uint
+	idx := 0
+	for i := 0; i < n; i++ {
+		for accBits < d {
+			acc |= uint32(b[idx]) << accBits
+			idx++
+			accBits += 8
+		}
+		f[i] = decompress(uint16(acc&(1<<d-1)), d)
+		acc >>= d
+		accBits -= d
+	}
+	return &f
+}
@@ -0,0 +1,96 @@
+package pqringctmlkem
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// kyber768KATFile holds the known-answer vectors of the "Kyber768" of liboqs,
+// which are generated by TestKyber768AgainstOQS with -update-kat in a build with cgo and liboqs.
+const kyber768KATFile = "testdata/kyber768_kat.json"
+
+// kyber768KATVector is a known-answer vector in kyber768KATFile, whose fields are hex-encoded.
+// (pk, sk) is generated by liboqs from seed with recovery, (ct, ss) is generated by liboqs' encapsulation on pk,
+// and ssImplicitRejection is decapsulated by liboqs with sk from ct with its first byte flipped.
+type kyber768KATVector struct {
+	Seed                string `json:"seed"`
+	PK                  string `json:"pk"`
+	SK                  string `json:"sk"`
+	CT                  string `json:"ct"`
+	SS                  string `json:"ss"`
+	SSImplicitRejection string `json:"ssImplicitRejection"`
+}
+
+type kyber768KAT struct {
+	Description string               `json:"description"`
+	Vectors     []*kyber768KATVector `json:"vectors"`
+}
+
+// readKyber768KAT reads the vectors in kyber768KATFile.
+func readKyber768KAT(t *testing.T) []*kyber768KATVector {
+	serializedKAT, err := os.ReadFile(kyber768KATFile)
+	if err != nil {
+		t.Fatalf("failed to read %s, which shall be generated from liboqs by \"CGO_ENABLED=1 go test -run TestKyber768AgainstOQS -update-kat\": %v", kyber768KATFile, err)
+	}
+	var kat kyber768KAT
+	if err = json.Unmarshal(serializedKAT, &kat); err != nil {
+		t.Fatalf("failed to parse %s: %v", kyber768KATFile, err)
+	}
+	if len(kat.Vectors) == 0 {
+		t.Fatalf("%s has no vectors", kyber768KATFile)
+	}
+	return kat.Vectors
+}
+
+// TestKyber768KAT checks the round-3 Kyber768 against the vectors generated by liboqs, without cgo.
+// The key pair is re-generated from the seed as d and the z in the liboqs secret key,
+// and is compared with the liboqs key pair byte-for-byte.
+func TestKyber768KAT(t *testing.T) {
+	for i, v := range readKyber768KAT(t) {
+		decode := func(name string, s string) []byte {
+			b, err := hex.DecodeString(s)
+			if err != nil {
+				t.Fatalf("vector %d: failed to decode %s: %v", i, name, err)
+			}
+			return b
+		}
+		seed := decode("seed", v.Seed)
+		wantPK := decode("pk", v.PK)
+		wantSK := decode("sk", v.SK)
+		ct := decode("ct", v.CT)
+		wantSS := decode("ss", v.SS)
+		wantSSImplicitRejection := decode("ssImplicitRejection", v.SSImplicitRejection)
+		if len(seed) < sym || len(wantSK) != SecretKeyBytes {
+			t.Fatalf("vector %d: the seed or sk has an incorrect length", i)
+		}
+
+		pk, sk := keyPairInternal(seed[:sym], wantSK[SecretKeyBytes-sym:], variantKyberR3)
+		if !bytes.Equal(pk, wantPK) {
+			t.Errorf("vector %d: the public key does not match liboqs", i)
+		}
+		if !bytes.Equal(sk, wantSK) {
+			t.Errorf("vector %d: the secret key does not match liboqs", i)
+		}
+
+		ss, err := Kyber768Decaps(ct, wantSK)
+		if err != nil {
+			t.Fatalf("vector %d: Kyber768Decaps: %v", i, err)
+		}
+		if !bytes.Equal(ss, wantSS) {
+			t.Errorf("vector %d: Kyber768Decaps does not match liboqs", i)
+		}
+
+		invalidCT := append([]byte{}, ct...)
+		invalidCT[0] ^= 0x01
+		ss, err = Kyber768Decaps(invalidCT, wantSK)
+		if err != nil {
+			t.Fatalf("vector %d: Kyber768Decaps: %v", i, err)
+		}
+		if !bytes.Equal(ss, wantSSImplicitRejection) {
+			t.Errorf("vector %d: the implicit rejection of Kyber768Decaps does not match liboqs", i)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+//go:build cgo
+
+package pqringctmlkem
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pqabelian/pqringctx/pqringctxkem/pqringctOQSKem"
+)
+
+var updateKAT = flag.Bool("update-kat", false, "re-generate "+kyber768KATFile+" from liboqs")
+
+// kyber768KATSeedNum is the number of the seeds in kyber768KATFile.
+const kyber768KATSeedNum = 8
+
+// TestKyber768AgainstOQS checks the round-3 Kyber768 against liboqs for the same seeds,
+// and that liboqs still reproduces the checked-in known-answer vectors.
+// With -update-kat, it re-generates kyber768KATFile from liboqs instead.
+func TestKyber768AgainstOQS(t *testing.T) {
+	if *updateKAT {
+		writeKyber768KAT(t)
+	}
+
+	for i, v := range readKyber768KAT(t) {
+		seed, _ := hex.DecodeString(v.Seed)
+		oqsPK, oqsSK, err := pqringctOQSKem.KeyPair(pqringctOQSKem.OQSKYBER768, seed, true)
+		if err != nil {
+			t.Fatalf("KeyPair: %v", err)
+		}
+		if hex.EncodeToString(oqsPK) != v.PK || hex.EncodeToString(oqsSK) != v.SK {
+			t.Errorf("vector %d: liboqs does not reproduce the key pair", i)
+		}
+		ct, _ := hex.DecodeString(v.CT)
+		ss, err := pqringctOQSKem.Decaps(pqringctOQSKem.OQSKYBER768, ct, oqsSK)
+		if err != nil {
+			t.Fatalf("Decaps: %v", err)
+		}
+		if hex.EncodeToString(ss) != v.SS {
+			t.Errorf("vector %d: liboqs does not reproduce the shared secret", i)
+		}
+
+		// pure-Go encapsulation, liboqs decapsulation
+		ct, ss, err = Kyber768Encaps(oqsPK)
+		if err != nil {
+			t.Fatalf("Kyber768Encaps: %v", err)
+		}
+		oqsSS, err := pqringctOQSKem.Decaps(pqringctOQSKem.OQSKYBER768, ct, oqsSK)
+		if err != nil {
+			t.Fatalf("Decaps: %v", err)
+		}
+		if !bytes.Equal(oqsSS, ss) {
+			t.Errorf("vector %d: liboqs Decaps on the pure-Go ciphertext does not match", i)
+		}
+
+		// liboqs encapsulation, pure-Go decapsulation, including the implicit rejection
+		ct, oqsSS, err = pqringctOQSKem.Encaps(pqringctOQSKem.OQSKYBER768, oqsPK)
+		if err != nil {
+			t.Fatalf("Encaps: %v", err)
+		}
+		ss, err = Kyber768Decaps(ct, oqsSK)
+		if err != nil {
+			t.Fatalf("Kyber768Decaps: %v", err)
+		}
+		if !bytes.Equal(ss, oqsSS) {
+			t.Errorf("vector %d: Kyber768Decaps on the liboqs ciphertext does not match", i)
+		}
+		ct[0] ^= 0x01
+		oqsSS, err = pqringctOQSKem.Decaps(pqringctOQSKem.OQSKYBER768, ct, oqsSK)
+		if err != nil {
+			t.Fatalf("Decaps: %v", err)
+		}
+		ss, err = Kyber768Decaps(ct, oqsSK)
+		if err != nil {
+			t.Fatalf("Kyber768Decaps: %v", err)
+		}
+		if !bytes.Equal(ss, oqsSS) {
+			t.Errorf("vector %d: the implicit rejection of Kyber768Decaps does not match liboqs", i)
+		}
+	}
+}
+
+// writeKyber768KAT generates kyber768KATFile from liboqs.
+func writeKyber768KAT(t *testing.T) {
+	kat := &kyber768KAT{
+		Description: "Known-answer vectors of the round-3 Kyber768 of liboqs (\"Kyber768\", with OQS_KEM_keypair_with_recovery), generated by TestKyber768AgainstOQS with -update-kat. " +
+			"For each seed, (pk, sk) is generated by liboqs from the seed with recovery, (ct, ss) by liboqs' encapsulation on pk, " +
+			"and ssImplicitRejection by liboqs' decapsulation with sk of ct with its first byte flipped.",
+	}
+	for i := 0; i < kyber768KATSeedNum; i++ {
+		seed := make([]byte, sym)
+		for j := 0; j < sym; j++ {
+			seed[j] = byte(i*sym + j)
+		}
+		pk, sk, err := pqringctOQSKem.KeyPair(pqringctOQSKem.OQSKYBER768, append([]byte{}, seed...), true)
+		if err != nil {
+			t.Fatalf("KeyPair: %v", err)
+		}
+		ct, ss, err := pqringctOQSKem.Encaps(pqringctOQSKem.OQSKYBER768, pk)
+		if err != nil {
+			t.Fatalf("Encaps: %v", err)
+		}
+		invalidCT := append([]byte{}, ct...)
+		invalidCT[0] ^= 0x01
+		ssImplicitRejection, err := pqringctOQSKem.Decaps(pqringctOQSKem.OQSKYBER768, invalidCT, sk)
+		if err != nil {
+			t.Fatalf("Decaps: %v", err)
+		}
+		kat.Vectors = append(kat.Vectors, &kyber768KATVector{
+			Seed:                hex.EncodeToString(seed),
+			PK:                  hex.EncodeToString(pk),
+			SK:                  hex.EncodeToString(sk),
+			CT:                  hex.EncodeToString(ct),
+			SS:                  hex.EncodeToString(ss),
+			SSImplicitRejection: hex.EncodeToString(ssImplicitRejection),
+		})
+	}
+	serializedKAT, err := json.MarshalIndent(kat, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode the vectors: %v", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(kyber768KATFile), 0755); err != nil {
+		t.Fatalf("failed to create the directory of %s: %v", kyber768KATFile, err)
+	}
+	if err = os.WriteFile(kyber768KATFile, append(serializedKAT, '\n'), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", kyber768KATFile, err)
+	}
+}
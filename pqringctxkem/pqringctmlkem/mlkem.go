@@ -0,0 +1,349 @@
+// Package pqringctmlkem is a pure-Go implementation of the module-lattice KEM with the parameter set of security category 3 (k = 3).
+// It provides two KEMs which share the same K-PKE:
+// (1) ML-KEM-768 as specified by FIPS 203, used by pqringctxkem.KEM_MLKEM768, and
+// (2) Kyber768 as specified by the round-3 submission, whose encapsulation and decapsulation are byte-compatible with the "Kyber768" of liboqs,
+// so that the ciphertexts and the secret keys of pqringctxkem.KEM_OQS_KYBER can be processed in pure Go.
+// Note that pqringctxkem.KEM_OQS_KYBER is served by liboqs only. The round-3 Kyber768 here is checked against liboqs
+// by the known-answer vectors in testdata/kyber768_kat.json, which are generated from liboqs (see kyber768_oqs_test.go).
+package pqringctmlkem
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"golang.org/x/crypto/sha3"
+	"io"
+)
+
+const (
+	k   = 3
+	du  = 10
+	dv  = 4
+	sym = 32
+
+	encodedPolyBytes = 384
+	compressedUBytes = n * du / 8
+	compressedVBytes = n * dv / 8
+
+	pkePublicKeyBytes = k*encodedPolyBytes + sym
+	pkeSecretKeyBytes = k * encodedPolyBytes
+
+	// PublicKeyBytes is the length of the (encapsulation) public key.
+	PublicKeyBytes = pkePublicKeyBytes
+	// SecretKeyBytes is the length of the (decapsulation) secret key.
+	SecretKeyBytes = pkeSecretKeyBytes + pkePublicKeyBytes + 2*sym
+	// CiphertextBytes is the length of the ciphertext.
+	CiphertextBytes = k*compressedUBytes + compressedVBytes
+	// SharedSecretBytes is the length of the shared secret.
+	SharedSecretBytes = sym
+	// SeedBytes is the length of the seed (d || z) from which a key pair is deterministically generated.
+	SeedBytes = 2 * sym
+)
+
+// variant distinguishes the hash/domain-separation differences between ML-KEM-768 and round-3 Kyber768.
+type variant int
+
+const (
+	variantMLKEM variant = iota
+	variantKyberR3
+)
+
+//	K-PKE	begin
+
+// pkeKeyGen implements K-PKE.KeyGen (Algorithm 13 of FIPS 203).
+// For round-3 Kyber768, (rho, sigma) = G(d), rather than G(d || k).
+func pkeKeyGen(d []byte, v variant) (ek []byte, dk []byte) {
+	g := sha3.New512()
+	g.Write(d)
+	if v == variantMLKEM {
+		g.Write([]byte{k})
+	}
+	rhoSigma := g.Sum(nil)
+	rho, sigma := rhoSigma[:sym], rhoSigma[sym:]
+
+	var sHat, eHat [k]*ringElement
+	var nonce byte
+	for i := 0; i < k; i++ {
+		sHat[i] = ntt(samplePolyCBD2(sigma, nonce))
+		nonce++
+	}
+	for i := 0; i < k; i++ {
+		eHat[i] = ntt(samplePolyCBD2(sigma, nonce))
+		nonce++
+	}
+
+	ek = make([]byte, 0, pkePublicKeyBytes)
+	for i := 0; i < k; i++ {
+		tHat := eHat[i]
+		for j := 0; j < k; j++ {
+			tHat = polyAdd(tHat, nttMul(sampleNTT(rho, byte(j), byte(i)), sHat[j]))
+		}
+		ek = byteEncode12(ek, tHat)
+	}
+	ek = append(ek, rho...)
+
+	dk = make([]byte, 0, pkeSecretKeyBytes)
+	for i := 0; i < k; i++ {
+		dk = byteEncode12(dk, sHat[i])
+	}
+	return ek, dk
+}
+
+// pkeEncrypt implements K-PKE.Encrypt (Algorithm 14 of FIPS 203).
+// It returns an error if the input ek does not pass the modulus check.
+func pkeEncrypt(ek []byte, m []byte, r []byte) ([]byte, error) {
+	var tHat [k]*ringElement
+	for i := 0; i < k; i++ {
+		var ok bool
+		tHat[i], ok = byteDecode12(ek[i*encodedPolyBytes : (i+1)*encodedPolyBytes])
+		if !ok {
+			return nil, errors.New("pkeEncrypt: the input public key is not well-form")
+		}
+	}
+	rho := ek[k*encodedPolyBytes:]
+
+	var yHat [k]*ringElement
+	var nonce byte
+	for i := 0; i < k; i++ {
+		yHat[i] = ntt(samplePolyCBD2(r, nonce))
+		nonce++
+	}
+	var e1 [k]*ringElement
+	for i := 0; i < k; i++ {
+		e1[i] = samplePolyCBD2(r, nonce)
+		nonce++
+	}
+	e2 := samplePolyCBD2(r, nonce)
+
+	c := make([]byte, 0, CiphertextBytes)
+	for i := 0; i < k; i++ {
+		// u[i] = NTT^{-1}( sum_j A_hat[j][i] * y_hat[j] ) + e1[i]
+		var acc ringElement
+		uHat := &acc
+		for j := 0; j < k; j++ {
+			uHat = polyAdd(uHat, nttMul(sampleNTT(rho, byte(i), byte(j)), yHat[j]))
+		}
+		u := polyAdd(nttInverse(uHat), e1[i])
+		c = byteEncodeCompressed(c, u, du)
+	}
+
+	var acc ringElement
+	vHat := &acc
+	for i := 0; i < k; i++ {
+		vHat = polyAdd(vHat, nttMul(tHat[i], yHat[i]))
+	}
+	mu := byteDecodeDecompressed(m, 1)
+	v := polyAdd(polyAdd(nttInverse(vHat), e2), mu)
+	c = byteEncodeCompressed(c, v, dv)
+
+	return c, nil
+}
+
+// pkeDecrypt implements K-PKE.Decrypt (Algorithm 15 of FIPS 203).
+func pkeDecrypt(dk []byte, c []byte) []byte {
+	var acc ringElement
+	wHat := &acc
+	for i := 0; i < k; i++ {
+		u := byteDecodeDecompressed(c[i*compressedUBytes:(i+1)*compressedUBytes], du)
+		sHat, _ := byteDecode12(dk[i*encodedPolyBytes : (i+1)*encodedPolyBytes])
+		wHat = polyAdd(wHat, nttMul(sHat, ntt(u)))
+	}
+	v := byteDecodeDecompressed(c[k*compressedUBytes:], dv)
+	w := polySub(v, nttInverse(wHat))
+	return byteEncodeCompressed(make([]byte, 0, sym), w, 1)
+}
+
+//	K-PKE	end
+
+//	KEM	begin
+
+// keyPairInternal generates the key pair deterministically from (d, z).
+// The secret key is dk_pke || ek || H(ek) || z for both variants.
+func keyPairInternal(d []byte, z []byte, v variant) ([]byte, []byte) {
+	ek, dkPKE := pkeKeyGen(d, v)
+	h := sha3.Sum256(ek)
+
+	dk := make([]byte, 0, SecretKeyBytes)
+	dk = append(dk, dkPKE...)
+	dk = append(dk, ek...)
+	dk = append(dk, h[:]...)
+	dk = append(dk, z...)
+	return ek, dk
+}
+
+// encapsInternal encapsulates with the randomness m.
+// For ML-KEM-768 it implements Algorithm 17 of FIPS 203.
+// For round-3 Kyber768, m is first hashed by H, and the shared secret is KDF(K || H(c)).
+func encapsInternal(ek []byte, m []byte, v variant) ([]byte, []byte, error) {
+	h := sha3.Sum256(ek)
+	if v == variantKyberR3 {
+		hm := sha3.Sum256(m)
+		m = hm[:]
+	}
+
+	g := sha3.New512()
+	g.Write(m)
+	g.Write(h[:])
+	kr := g.Sum(nil)
+
+	c, err := pkeEncrypt(ek, m, kr[sym:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if v == variantKyberR3 {
+		return c, kyberR3KDF(kr[:sym], c), nil
+	}
+	ss := make([]byte, SharedSecretBytes)
+	copy(ss, kr[:sym])
+	return c, ss, nil
+}
+
+// decapsInternal implements Algorithm 18 of FIPS 203 for ML-KEM-768,
+// and the corresponding Kyber.CCAKEM.Dec for round-3 Kyber768.
+// The implicit rejection is done in constant time.
+func decapsInternal(dk []byte, c []byte, v variant) ([]byte, error) {
+	dkPKE := dk[:pkeSecretKeyBytes]
+	ek := dk[pkeSecretKeyBytes : pkeSecretKeyBytes+pkePublicKeyBytes]
+	h := dk[pkeSecretKeyBytes+pkePublicKeyBytes : pkeSecretKeyBytes+pkePublicKeyBytes+sym]
+	z := dk[pkeSecretKeyBytes+pkePublicKeyBytes+sym:]
+
+	m := pkeDecrypt(dkPKE, c)
+
+	g := sha3.New512()
+	g.Write(m)
+	g.Write(h)
+	kr := g.Sum(nil)
+
+	cPrime, err := pkeEncrypt(ek, m, kr[sym:])
+	if err != nil {
+		return nil, err
+	}
+	equal := subtle.ConstantTimeCompare(c, cPrime)
+
+	if v == variantKyberR3 {
+		kBar := make([]byte, sym)
+		copy(kBar, kr[:sym])
+		subtle.ConstantTimeCopy(1-equal, kBar, z)
+		return kyberR3KDF(kBar, c), nil
+	}
+
+	kBar := make([]byte, SharedSecretBytes)
+	j := sha3.NewShake256()
+	j.Write(z)
+	j.Write(c)
+	j.Read(kBar)
+	subtle.ConstantTimeCopy(equal, kBar, kr[:sym])
+	return kBar, nil
+}
+
+// kyberR3KDF computes the round-3 Kyber shared secret SHAKE256(kBar || H(c)).
+func kyberR3KDF(kBar []byte, c []byte) []byte {
+	hc := sha3.Sum256(c)
+	kdf := sha3.NewShake256()
+	kdf.Write(kBar)
+	kdf.Write(hc[:])
+	ss := make([]byte, SharedSecretBytes)
+	kdf.Read(ss)
+	return ss
+}
+
+func checkSecretKey(sk []byte) error {
+	if len(sk) != SecretKeyBytes {
+		return errors.New("invalid secret key")
+	}
+	// the hash of the public key embedded in the secret key must match
+	h := sha3.Sum256(sk[pkeSecretKeyBytes : pkeSecretKeyBytes+pkePublicKeyBytes])
+	if subtle.ConstantTimeCompare(h[:], sk[pkeSecretKeyBytes+pkePublicKeyBytes:pkeSecretKeyBytes+pkePublicKeyBytes+sym]) != 1 {
+		return errors.New("invalid secret key")
+	}
+	return nil
+}
+
+//	KEM	end
+
+//	ML-KEM-768	begin
+
+// KeyPair generates an ML-KEM-768 key pair.
+// If seed is nil, the key pair is generated with fresh randomness.
+// Otherwise, len(seed) must be seedLen (at least 32), and (d || z) is derived by SHAKE256(seed),
+// so that the key pair can be recovered from the seed.
+func KeyPair(seed []byte, seedLen int) ([]byte, []byte, error) {
+	dz := make([]byte, SeedBytes)
+	if seed == nil {
+		if _, err := io.ReadFull(rand.Reader, dz); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if len(seed) != seedLen || seedLen < sym {
+			return nil, nil, errors.New("the length of seed is invalid")
+		}
+		shake256 := sha3.NewShake256()
+		shake256.Write(seed)
+		shake256.Read(dz)
+	}
+	pk, sk := keyPairInternal(dz[:sym], dz[sym:], variantMLKEM)
+	return pk, sk, nil
+}
+
+// Encaps generates a ciphertext and the shared secret for the input ML-KEM-768 public key.
+func Encaps(pk []byte) ([]byte, []byte, error) {
+	return EncapsWithRand(pk, rand.Reader)
+}
+
+// EncapsWithRand is the same as Encaps, except that the encapsulation randomness is read from randReader.
+func EncapsWithRand(pk []byte, randReader io.Reader) ([]byte, []byte, error) {
+	if len(pk) != PublicKeyBytes {
+		return nil, nil, errors.New("invalid public key")
+	}
+	m := make([]byte, sym)
+	if _, err := io.ReadFull(randReader, m); err != nil {
+		return nil, nil, err
+	}
+	return encapsInternal(pk, m, variantMLKEM)
+}
+
+// Decaps recovers the shared secret from the input ciphertext, using the input ML-KEM-768 secret key.
+func Decaps(cipher []byte, sk []byte) ([]byte, error) {
+	if len(cipher) != CiphertextBytes {
+		return nil, errors.New("invalid cipher text")
+	}
+	if err := checkSecretKey(sk); err != nil {
+		return nil, err
+	}
+	return decapsInternal(sk, cipher, variantMLKEM)
+}
+
+//	ML-KEM-768	end
+
+//	Kyber768 (round 3)	begin
+
+// Kyber768Encaps generates a ciphertext and the shared secret for the input round-3 Kyber768 public key.
+func Kyber768Encaps(pk []byte) ([]byte, []byte, error) {
+	return Kyber768EncapsWithRand(pk, rand.Reader)
+}
+
+// Kyber768EncapsWithRand is the same as Kyber768Encaps, except that the encapsulation randomness is read from randReader.
+func Kyber768EncapsWithRand(pk []byte, randReader io.Reader) ([]byte, []byte, error) {
+	if len(pk) != PublicKeyBytes {
+		return nil, nil, errors.New("invalid public key")
+	}
+	m := make([]byte, sym)
+	if _, err := io.ReadFull(randReader, m); err != nil {
+		return nil, nil, err
+	}
+	return encapsInternal(pk, m, variantKyberR3)
+}
+
+// Kyber768Decaps recovers the shared secret from the input ciphertext, using the input round-3 Kyber768 secret key.
+func Kyber768Decaps(cipher []byte, sk []byte) ([]byte, error) {
+	if len(cipher) != CiphertextBytes {
+		return nil, errors.New("invalid cipher text")
+	}
+	if err := checkSecretKey(sk); err != nil {
+		return nil, err
+	}
+	return decapsInternal(sk, cipher, variantKyberR3)
+}
+
+//	Kyber768 (round 3)	end
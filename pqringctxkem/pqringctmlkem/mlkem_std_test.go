@@ -0,0 +1,65 @@
+//go:build go1.24
+
+package pqringctmlkem
+
+import (
+	"bytes"
+	"crypto/mlkem"
+	"testing"
+)
+
+// TestMLKEM768AgainstStd checks the ML-KEM-768 implementation against crypto/mlkem of the standard library,
+// including the key generation from (d || z), and the encapsulation/decapsulation in both directions.
+func TestMLKEM768AgainstStd(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		dz := make([]byte, SeedBytes)
+		for j := 0; j < SeedBytes; j++ {
+			dz[j] = byte(i*SeedBytes + j)
+		}
+
+		pk, sk := keyPairInternal(dz[:sym], dz[sym:], variantMLKEM)
+
+		stdDK, err := mlkem.NewDecapsulationKey768(dz)
+		if err != nil {
+			t.Fatalf("NewDecapsulationKey768: %v", err)
+		}
+		if !bytes.Equal(pk, stdDK.EncapsulationKey().Bytes()) {
+			t.Fatalf("the public key for seed %d does not match crypto/mlkem", i)
+		}
+
+		stdSS, stdC := stdDK.EncapsulationKey().Encapsulate()
+		ss, err := Decaps(stdC, sk)
+		if err != nil {
+			t.Fatalf("Decaps: %v", err)
+		}
+		if !bytes.Equal(ss, stdSS) {
+			t.Fatalf("Decaps on the ciphertext from crypto/mlkem does not match for seed %d", i)
+		}
+
+		c, ss, err := Encaps(pk)
+		if err != nil {
+			t.Fatalf("Encaps: %v", err)
+		}
+		stdSS, err = stdDK.Decapsulate(c)
+		if err != nil {
+			t.Fatalf("Decapsulate: %v", err)
+		}
+		if !bytes.Equal(ss, stdSS) {
+			t.Fatalf("crypto/mlkem Decapsulate on the ciphertext from Encaps does not match for seed %d", i)
+		}
+
+		// implicit rejection
+		c[0] ^= 0x01
+		ss, err = Decaps(c, sk)
+		if err != nil {
+			t.Fatalf("Decaps: %v", err)
+		}
+		stdSS, err = stdDK.Decapsulate(c)
+		if err != nil {
+			t.Fatalf("Decapsulate: %v", err)
+		}
+		if !bytes.Equal(ss, stdSS) {
+			t.Fatalf("the implicit rejection does not match crypto/mlkem for seed %d", i)
+		}
+	}
+}
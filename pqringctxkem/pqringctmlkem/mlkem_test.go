@@ -0,0 +1,82 @@
+package pqringctmlkem
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKeyPair_Encaps_Decaps(t *testing.T) {
+	seedLen := 64
+	seed := make([]byte, seedLen)
+	for i := 0; i < seedLen; i++ {
+		seed[i] = byte(i)
+	}
+	pk, sk, err := KeyPair(seed, seedLen)
+	if err != nil {
+		t.Fatalf("error in keypair: %v", err)
+	}
+	if len(pk) != PublicKeyBytes || len(sk) != SecretKeyBytes {
+		t.Fatalf("invalid key length")
+	}
+
+	pkRecovered, skRecovered, err := KeyPair(seed, seedLen)
+	if err != nil {
+		t.Fatalf("error in keypair: %v", err)
+	}
+	if !bytes.Equal(pk, pkRecovered) || !bytes.Equal(sk, skRecovered) {
+		t.Fatalf("the key pair can not be recovered from the seed")
+	}
+
+	c, kappa, err := Encaps(pk)
+	if err != nil {
+		t.Fatalf("error in encaps: %v", err)
+	}
+	res, err := Decaps(c, sk)
+	if err != nil {
+		t.Fatalf("error in decaps: %v", err)
+	}
+	if !bytes.Equal(kappa, res) {
+		t.Fatalf("error in matched")
+	}
+
+	c[0] ^= 0x01
+	res, err = Decaps(c, sk)
+	if err != nil {
+		t.Fatalf("error in decaps: %v", err)
+	}
+	if bytes.Equal(kappa, res) {
+		t.Fatalf("the modified ciphertext is not implicitly rejected")
+	}
+
+	if _, _, err = KeyPair(seed[:31], 31); err == nil {
+		t.Fatalf("a short seed is accepted")
+	}
+}
+
+func TestKyber768_Encaps_Decaps(t *testing.T) {
+	d := make([]byte, sym)
+	z := make([]byte, sym)
+	for i := 0; i < sym; i++ {
+		d[i] = byte(i)
+		z[i] = byte(sym + i)
+	}
+	pk, sk := keyPairInternal(d, z, variantKyberR3)
+
+	c, kappa, err := Kyber768Encaps(pk)
+	if err != nil {
+		t.Fatalf("error in encaps: %v", err)
+	}
+	res, err := Kyber768Decaps(c, sk)
+	if err != nil {
+		t.Fatalf("error in decaps: %v", err)
+	}
+	if !bytes.Equal(kappa, res) {
+		t.Fatalf("error in matched")
+	}
+
+	// the two variants share the K-PKE but differ in the hashing
+	pkMLKEM, _ := keyPairInternal(d, z, variantMLKEM)
+	if bytes.Equal(pk, pkMLKEM) {
+		t.Fatalf("Kyber768 and ML-KEM-768 keys shall differ for the same seed")
+	}
+}
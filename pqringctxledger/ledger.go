@@ -0,0 +1,396 @@
+// Package pqringctxledger implements a standalone ledger state engine on top of pqringctxapi,
+// which assigns the LgrTxo ids, checks the ring members and the serial numbers of the transfer transactions,
+// and supports the rollback of blocks.
+// It serves as the reference of the ledger rules, e.g., for testnets and integration tests without a full node.
+package pqringctxledger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/pqabelian/pqringctx/pqringctxapi"
+	"golang.org/x/crypto/sha3"
+	"io"
+	"sync"
+)
+
+const lgrTxoIdDomainSeparationString = "PQRINGCTX-LGRTXOID"
+
+// LgrTxoIdGen computes the id of the txoIndex-th Txo of a transaction, from the transaction's serialization without witness,
+// namely, id = SHA3-512("PQRINGCTX-LGRTXOID" || serializedTxWithoutWitness || txoIndex (4 bytes, little-endian)).
+// Note that the serialization without witness determines the Txos, and the witness is malleable.
+func LgrTxoIdGen(serializedTxWithoutWitness []byte, txoIndex int) []byte {
+	h := sha3.New512()
+	h.Write([]byte(lgrTxoIdDomainSeparationString))
+	h.Write(serializedTxWithoutWitness)
+	var index [4]byte
+	binary.LittleEndian.PutUint32(index[:], uint32(txoIndex))
+	h.Write(index[:])
+	return h.Sum(nil)
+}
+
+// Block is the unit to connect to (and disconnect from) a Ledger.
+// CoinbaseTx could be nil, e.g., for a test block that contains only transfer transactions.
+type Block struct {
+	CoinbaseTx  *pqringctxapi.CoinbaseTxMLP
+	TransferTxs []*pqringctxapi.TransferTxMLP
+}
+
+// BlockLgrTxos are the LgrTxos created by a connected Block,
+// where TransferLgrTxos[i] are the LgrTxos of Block.TransferTxs[i].
+type BlockLgrTxos struct {
+	CoinbaseLgrTxos []*pqringctxapi.LgrTxoMLP
+	TransferLgrTxos [][]*pqringctxapi.LgrTxoMLP
+}
+
+// blockUndo records the changes of a connected block, for the rollback.
+// It is persisted in the Store, keyed by the height of the block, in the format of serializeBlockUndo.
+type blockUndo struct {
+	lgrTxoIds     [][]byte
+	serialNumbers [][]byte
+}
+
+// serializeBlockUndo serializes the input blockUndo as
+// uvarint(len(lgrTxoIds)) || (uvarint(len(id)) || id)* || uvarint(len(serialNumbers)) || (uvarint(len(serialNumber)) || serialNumber)*.
+func serializeBlockUndo(undo *blockUndo) []byte {
+	var w bytes.Buffer
+	var buf [binary.MaxVarintLen64]byte
+	writeList := func(list [][]byte) {
+		w.Write(buf[:binary.PutUvarint(buf[:], uint64(len(list)))])
+		for _, b := range list {
+			w.Write(buf[:binary.PutUvarint(buf[:], uint64(len(b)))])
+			w.Write(b)
+		}
+	}
+	writeList(undo.lgrTxoIds)
+	writeList(undo.serialNumbers)
+	return w.Bytes()
+}
+
+// deserializeBlockUndo deserializes the input serializedUndo, which is generated by serializeBlockUndo.
+func deserializeBlockUndo(serializedUndo []byte) (*blockUndo, error) {
+	r := bytes.NewReader(serializedUndo)
+	readList := func() ([][]byte, error) {
+		count, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if count > uint64(r.Len()) {
+			return nil, fmt.Errorf("the count (%d) exceeds the remaining length (%d)", count, r.Len())
+		}
+		list := make([][]byte, count)
+		for i := range list {
+			length, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, err
+			}
+			if length > uint64(r.Len()) {
+				return nil, fmt.Errorf("the length (%d) exceeds the remaining length (%d)", length, r.Len())
+			}
+			list[i] = make([]byte, length)
+			if _, err = io.ReadFull(r, list[i]); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	}
+
+	lgrTxoIds, err := readList()
+	if err != nil {
+		return nil, fmt.Errorf("deserializeBlockUndo: the lgrTxoIds: %v", err)
+	}
+	serialNumbers, err := readList()
+	if err != nil {
+		return nil, fmt.Errorf("deserializeBlockUndo: the serialNumbers: %v", err)
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("deserializeBlockUndo: there are %d redundant bytes", r.Len())
+	}
+	return &blockUndo{
+		lgrTxoIds:     lgrTxoIds,
+		serialNumbers: serialNumbers,
+	}, nil
+}
+
+// Ledger maintains the ledger state, i.e., the LgrTxos and the spent serial numbers, in a Store.
+// A block is connected only if
+// (1) every transaction is valid,
+// (2) every ring member of every input exists on the ledger (before the block), with the same Txo, and
+// (3) every serial number is neither spent on the ledger nor repeated in the block.
+// The undo record of each connected block is persisted in the Store, keyed by the block height,
+// so that the blocks can be rolled back by another Ledger instance on the same Store, e.g., after a restart.
+type Ledger struct {
+	pp    *pqringctxapi.PublicParameter
+	store Store
+
+	mu sync.Mutex
+}
+
+// NewLedger creates a Ledger on the input Store. If store is nil, a MemStore is used.
+func NewLedger(pp *pqringctxapi.PublicParameter, store Store) *Ledger {
+	if store == nil {
+		store = NewMemStore()
+	}
+	return &Ledger{
+		pp:    pp,
+		store: store,
+	}
+}
+
+// Height returns the number of blocks connected to the ledger and not disconnected yet.
+func (l *Ledger) Height() (uint64, error) {
+	return l.store.GetHeight()
+}
+
+// GetLgrTxo returns the LgrTxo with the input id, or nil if it does not exist.
+func (l *Ledger) GetLgrTxo(id []byte) (*pqringctxapi.LgrTxoMLP, error) {
+	serializedTxo, exists, err := l.store.GetTxo(id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	txo, err := pqringctxapi.DeserializeTxo(l.pp, serializedTxo)
+	if err != nil {
+		return nil, err
+	}
+	return pqringctxapi.NewLgrTxo(txo, id), nil
+}
+
+// IsSerialNumberSpent returns whether the input serial number has been spent on the ledger.
+func (l *Ledger) IsSerialNumberSpent(serialNumber []byte) (bool, error) {
+	return l.store.HasSerialNumber(serialNumber)
+}
+
+// checkTransferTxInputs checks the ring members and the serial numbers of the input TransferTxMLP,
+// where serialNumbersInBlock collects the serial numbers of the previous transactions in the same block.
+func (l *Ledger) checkTransferTxInputs(trTx *pqringctxapi.TransferTxMLP, serialNumbersInBlock map[string]struct{}) error {
+	for i, txInput := range pqringctxapi.GetTrTxTxInputs(trTx) {
+		if txInput == nil {
+			return fmt.Errorf("the %d -th input is nil", i)
+		}
+
+		serialNumber := pqringctxapi.GetTxInputSerialNumber(txInput)
+		if _, exists := serialNumbersInBlock[string(serialNumber)]; exists {
+			return fmt.Errorf("the %d -th input double-spends a serial number in the block", i)
+		}
+		spent, err := l.store.HasSerialNumber(serialNumber)
+		if err != nil {
+			return err
+		}
+		if spent {
+			return fmt.Errorf("the %d -th input double-spends a serial number on the ledger", i)
+		}
+		serialNumbersInBlock[string(serialNumber)] = struct{}{}
+
+		for j, lgrTxo := range pqringctxapi.GetTxInputLgrTxoList(txInput) {
+			if lgrTxo == nil || lgrTxo.GetTxo() == nil {
+				return fmt.Errorf("the %d -th ring member of the %d -th input is nil", j, i)
+			}
+			storedTxo, exists, err := l.store.GetTxo(lgrTxo.GetId())
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("the %d -th ring member of the %d -th input does not exist on the ledger", j, i)
+			}
+			serializedTxo, err := pqringctxapi.SerializeTxo(l.pp, lgrTxo.GetTxo())
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(serializedTxo, storedTxo) {
+				return fmt.Errorf("the %d -th ring member of the %d -th input does not match the Txo on the ledger", j, i)
+			}
+		}
+	}
+	return nil
+}
+
+// assignLgrTxos assigns the ids to the input txos of a transaction,
+// where lgrTxoIdsInBlock collects the ids of the previous transactions in the same block.
+func (l *Ledger) assignLgrTxos(serializedTxWithoutWitness []byte, txos []pqringctxapi.TxoMLP, lgrTxoIdsInBlock map[string]struct{}) ([]*pqringctxapi.LgrTxoMLP, error) {
+	lgrTxos := make([]*pqringctxapi.LgrTxoMLP, len(txos))
+	for i, txo := range txos {
+		id := LgrTxoIdGen(serializedTxWithoutWitness, i)
+		if _, exists := lgrTxoIdsInBlock[string(id)]; exists {
+			return nil, fmt.Errorf("the %d -th txo is repeated in the block", i)
+		}
+		_, exists, err := l.store.GetTxo(id)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, fmt.Errorf("the %d -th txo already exists on the ledger", i)
+		}
+		lgrTxoIdsInBlock[string(id)] = struct{}{}
+		lgrTxos[i] = pqringctxapi.NewLgrTxo(txo, id)
+	}
+	return lgrTxos, nil
+}
+
+// ConnectBlock validates the input Block against the ledger state, and if valid, applies it.
+// It returns the LgrTxos created by the block, which can be used as the ring members of the later transactions.
+// Note that the inputs of a transaction can only use the LgrTxos connected before the block.
+// If writing the block to the Store fails, the partially applied block is rolled back, and the errors of both are returned.
+// If the rollback fails as well, the undo record of the block is kept, so that DisconnectBlock can remove it once the Store recovers.
+func (l *Ledger) ConnectBlock(block *Block) (*BlockLgrTxos, error) {
+	if block == nil {
+		return nil, fmt.Errorf("ConnectBlock: the input block is nil")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	//	check the inputs, before the expensive verification
+	serialNumbersInBlock := make(map[string]struct{})
+	for i, trTx := range block.TransferTxs {
+		if trTx == nil {
+			return nil, fmt.Errorf("ConnectBlock: the %d -th TransferTx is nil", i)
+		}
+		if err := l.checkTransferTxInputs(trTx, serialNumbersInBlock); err != nil {
+			return nil, fmt.Errorf("ConnectBlock: the %d -th TransferTx: %v", i, err)
+		}
+	}
+
+	//	verify the transactions
+	if block.CoinbaseTx != nil {
+		if err := pqringctxapi.CoinbaseTxVerify(l.pp, block.CoinbaseTx); err != nil {
+			return nil, fmt.Errorf("ConnectBlock: the CoinbaseTx is invalid: %v", err)
+		}
+	}
+	for i, err := range pqringctxapi.TransferTxVerifyBatch(l.pp, block.TransferTxs) {
+		if err != nil {
+			return nil, fmt.Errorf("ConnectBlock: the %d -th TransferTx is invalid: %v", i, err)
+		}
+	}
+
+	//	assign the ids
+	blockLgrTxos := &BlockLgrTxos{
+		TransferLgrTxos: make([][]*pqringctxapi.LgrTxoMLP, len(block.TransferTxs)),
+	}
+	lgrTxoIdsInBlock := make(map[string]struct{})
+	if block.CoinbaseTx != nil {
+		serializedCbTx, err := l.pp.SerializeCoinbaseTxMLP(block.CoinbaseTx, false)
+		if err != nil {
+			return nil, err
+		}
+		blockLgrTxos.CoinbaseLgrTxos, err = l.assignLgrTxos(serializedCbTx, pqringctxapi.GetCbTxTxos(block.CoinbaseTx), lgrTxoIdsInBlock)
+		if err != nil {
+			return nil, fmt.Errorf("ConnectBlock: the CoinbaseTx: %v", err)
+		}
+	}
+	for i, trTx := range block.TransferTxs {
+		serializedTrTx, err := l.pp.SerializeTransferTxMLP(trTx, false)
+		if err != nil {
+			return nil, err
+		}
+		blockLgrTxos.TransferLgrTxos[i], err = l.assignLgrTxos(serializedTrTx, pqringctxapi.GetTrTxTxos(trTx), lgrTxoIdsInBlock)
+		if err != nil {
+			return nil, fmt.Errorf("ConnectBlock: the %d -th TransferTx: %v", i, err)
+		}
+	}
+
+	//	persist the undo record before applying the block, so that a partially applied block can be rolled back
+	height, err := l.store.GetHeight()
+	if err != nil {
+		return nil, err
+	}
+	undo := &blockUndo{}
+	var serializedTxos [][]byte
+	lgrTxosList := append([][]*pqringctxapi.LgrTxoMLP{blockLgrTxos.CoinbaseLgrTxos}, blockLgrTxos.TransferLgrTxos...)
+	for _, lgrTxos := range lgrTxosList {
+		for _, lgrTxo := range lgrTxos {
+			serializedTxo, err := pqringctxapi.SerializeTxo(l.pp, lgrTxo.GetTxo())
+			if err != nil {
+				return nil, err
+			}
+			serializedTxos = append(serializedTxos, serializedTxo)
+			undo.lgrTxoIds = append(undo.lgrTxoIds, lgrTxo.GetId())
+		}
+	}
+	for _, trTx := range block.TransferTxs {
+		for _, txInput := range pqringctxapi.GetTrTxTxInputs(trTx) {
+			undo.serialNumbers = append(undo.serialNumbers, pqringctxapi.GetTxInputSerialNumber(txInput))
+		}
+	}
+	if err = l.store.PutUndo(height+1, serializeBlockUndo(undo)); err != nil {
+		return nil, fmt.Errorf("ConnectBlock: failed to store the undo record: %v", err)
+	}
+
+	//	apply the block
+	if err = l.apply(undo, serializedTxos); err != nil {
+		if revertErr := l.revert(undo); revertErr != nil {
+			return nil, fmt.Errorf("ConnectBlock: %v; and failed to roll back the partially applied block: %v", err, revertErr)
+		}
+		if deleteErr := l.store.DeleteUndo(height + 1); deleteErr != nil {
+			return nil, fmt.Errorf("ConnectBlock: %v; and failed to delete the undo record: %v", err, deleteErr)
+		}
+		return nil, fmt.Errorf("ConnectBlock: %v", err)
+	}
+
+	return blockLgrTxos, nil
+}
+
+// apply writes the LgrTxos and the serial numbers recorded in the input blockUndo to the Store,
+// where serializedTxos[i] is the serialized Txo of the LgrTxo with id undo.lgrTxoIds[i].
+func (l *Ledger) apply(undo *blockUndo, serializedTxos [][]byte) error {
+	for i, id := range undo.lgrTxoIds {
+		if err := l.store.PutTxo(id, serializedTxos[i]); err != nil {
+			return err
+		}
+	}
+	for _, serialNumber := range undo.serialNumbers {
+		if err := l.store.PutSerialNumber(serialNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DisconnectBlock rolls back the latest connected block, using its undo record in the Store.
+func (l *Ledger) DisconnectBlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	height, err := l.store.GetHeight()
+	if err != nil {
+		return fmt.Errorf("DisconnectBlock: %v", err)
+	}
+	if height == 0 {
+		return fmt.Errorf("DisconnectBlock: there is no block to disconnect")
+	}
+	serializedUndo, exists, err := l.store.GetUndo(height)
+	if err != nil {
+		return fmt.Errorf("DisconnectBlock: %v", err)
+	}
+	if !exists {
+		return fmt.Errorf("DisconnectBlock: the undo record of the block at height %d does not exist", height)
+	}
+	undo, err := deserializeBlockUndo(serializedUndo)
+	if err != nil {
+		return fmt.Errorf("DisconnectBlock: %v", err)
+	}
+	if err = l.revert(undo); err != nil {
+		return fmt.Errorf("DisconnectBlock: %v", err)
+	}
+	if err = l.store.DeleteUndo(height); err != nil {
+		return fmt.Errorf("DisconnectBlock: %v", err)
+	}
+	return nil
+}
+
+// revert removes the changes recorded in the input blockUndo from the Store.
+func (l *Ledger) revert(undo *blockUndo) error {
+	for _, serialNumber := range undo.serialNumbers {
+		if err := l.store.DeleteSerialNumber(serialNumber); err != nil {
+			return err
+		}
+	}
+	for _, id := range undo.lgrTxoIds {
+		if err := l.store.DeleteTxo(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
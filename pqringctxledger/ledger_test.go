@@ -0,0 +1,240 @@
+package pqringctxledger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"github.com/pqabelian/pqringctx/pqringctxapi"
+	"github.com/pqabelian/pqringctx/pqringctxkem"
+	"strings"
+	"testing"
+)
+
+func TestLedger(t *testing.T) {
+	pp := pqringctxapi.InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+
+	masterSeed := make([]byte, 64)
+	if _, err := rand.Read(masterSeed); err != nil {
+		t.Fatal(err)
+	}
+	coinDetectorKey, err := pqringctxapi.CoinDetectorKeyDerive(pp, masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinValuePublicKey, coinValueSecretKey, err := pqringctxapi.CoinValueKeyDerive(pp, masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddress0, coinSpendSecretKey0, coinSerialNumberSecretKey0, err := pqringctxapi.CoinAddressKeyForPKRingDerive(pp, masterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddress1, _, _, err := pqringctxapi.CoinAddressKeyForPKRingDerive(pp, masterSeed, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewMemStore()
+	ledger := NewLedger(pp, store)
+
+	//	block 1: a coinbase
+	cbTx, err := pqringctxapi.CoinbaseTxGen(pp, 100, []*pqringctxapi.TxOutputDescMLP{pqringctxapi.NewTxOutputDescMLP(coinAddress0, coinValuePublicKey, 100)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block1LgrTxos, err := ledger.ConnectBlock(&Block{CoinbaseTx: cbTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(block1LgrTxos.CoinbaseLgrTxos) != 1 {
+		t.Fatalf("the number of LgrTxos of the coinbase (%d) is not 1", len(block1LgrTxos.CoinbaseLgrTxos))
+	}
+	lgrTxo := block1LgrTxos.CoinbaseLgrTxos[0]
+	storedLgrTxo, err := ledger.GetLgrTxo(lgrTxo.GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storedLgrTxo == nil {
+		t.Fatalf("the LgrTxo of the coinbase is not on the ledger")
+	}
+
+	//	the coinbase cannot be connected twice
+	if _, err = ledger.ConnectBlock(&Block{CoinbaseTx: cbTx}); err == nil {
+		t.Fatalf("a repeated coinbase is accepted")
+	}
+
+	//	block 2: a transfer spending the coinbase
+	txInputDesc := pqringctxapi.NewTxInputDescMLP([]*pqringctxapi.LgrTxoMLP{lgrTxo}, 0, coinSpendSecretKey0, coinSerialNumberSecretKey0,
+		coinValuePublicKey, coinValueSecretKey, coinDetectorKey, 100)
+	trTx, err := pqringctxapi.TransferTxGen(pp, []*pqringctxapi.TxInputDescMLP{txInputDesc},
+		[]*pqringctxapi.TxOutputDescMLP{pqringctxapi.NewTxOutputDescMLP(coinAddress1, coinValuePublicKey, 90)}, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialNumber := pqringctxapi.GetTxInputSerialNumber(pqringctxapi.GetTrTxTxInputs(trTx)[0])
+
+	block2LgrTxos, err := ledger.ConnectBlock(&Block{TransferTxs: []*pqringctxapi.TransferTxMLP{trTx}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	height, err := ledger.Height()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 2 {
+		t.Fatalf("the height (%d) is not 2", height)
+	}
+	spent, err := ledger.IsSerialNumberSpent(serialNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !spent {
+		t.Fatalf("the serial number is not spent after the transfer")
+	}
+
+	//	double spend
+	if _, err = ledger.ConnectBlock(&Block{TransferTxs: []*pqringctxapi.TransferTxMLP{trTx}}); err == nil {
+		t.Fatalf("a double spend is accepted")
+	}
+
+	//	the ring member must be on the ledger
+	fakeLgrTxo := pqringctxapi.NewLgrTxo(lgrTxo.GetTxo(), bytes.Repeat([]byte{1}, len(lgrTxo.GetId())))
+	fakeTrTx := pqringctxapi.NewTransferTxMLP([]*pqringctxapi.TxInputMLP{pqringctxapi.NewTxInputMLP([]*pqringctxapi.LgrTxoMLP{fakeLgrTxo}, bytes.Repeat([]byte{2}, len(serialNumber)))},
+		pqringctxapi.GetTrTxTxos(trTx), 10, nil, pqringctxapi.GetTrTxWitness(trTx))
+	if _, err = ledger.ConnectBlock(&Block{TransferTxs: []*pqringctxapi.TransferTxMLP{fakeTrTx}}); err == nil {
+		t.Fatalf("a transfer with a ring member not on the ledger is accepted")
+	}
+
+	//	rollback block 2, by another Ledger on the same Store (e.g., after a restart), as the undo records are in the Store
+	if err = NewLedger(pp, store).DisconnectBlock(); err != nil {
+		t.Fatal(err)
+	}
+	spent, err = ledger.IsSerialNumberSpent(serialNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spent {
+		t.Fatalf("the serial number is spent after the rollback")
+	}
+	rolledBackLgrTxo, err := ledger.GetLgrTxo(block2LgrTxos.TransferLgrTxos[0][0].GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rolledBackLgrTxo != nil {
+		t.Fatalf("the LgrTxo of the transfer is on the ledger after the rollback")
+	}
+
+	//	the transfer can be connected again
+	if _, err = ledger.ConnectBlock(&Block{TransferTxs: []*pqringctxapi.TransferTxMLP{trTx}}); err != nil {
+		t.Fatal(err)
+	}
+
+	//	rollback all
+	if err = ledger.DisconnectBlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err = ledger.DisconnectBlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err = ledger.DisconnectBlock(); err == nil {
+		t.Fatalf("disconnecting from an empty ledger is accepted")
+	}
+}
+
+// failingStore is a Store whose PutSerialNumber and DeleteTxo fail.
+type failingStore struct {
+	*MemStore
+}
+
+func (s *failingStore) PutSerialNumber(serialNumber []byte) error {
+	return errors.New("PutSerialNumber fails")
+}
+
+func (s *failingStore) DeleteTxo(id []byte) error {
+	return errors.New("DeleteTxo fails")
+}
+
+// TestLedger_ConnectBlock_RevertError checks that the errors of rolling back a partially applied block are returned.
+func TestLedger_ConnectBlock_RevertError(t *testing.T) {
+	pp := pqringctxapi.InitializePQRingCTXWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+
+	masterSeed := make([]byte, 64)
+	coinDetectorKey, err := pqringctxapi.CoinDetectorKeyDerive(pp, masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinValuePublicKey, coinValueSecretKey, err := pqringctxapi.CoinValueKeyDerive(pp, masterSeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coinAddress, coinSpendSecretKey, coinSerialNumberSecretKey, err := pqringctxapi.CoinAddressKeyForPKRingDerive(pp, masterSeed, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &failingStore{NewMemStore()}
+	ledger := NewLedger(pp, store)
+	cbTx, err := pqringctxapi.CoinbaseTxGen(pp, 100, []*pqringctxapi.TxOutputDescMLP{pqringctxapi.NewTxOutputDescMLP(coinAddress, coinValuePublicKey, 100)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockLgrTxos, err := ledger.ConnectBlock(&Block{CoinbaseTx: cbTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txInputDesc := pqringctxapi.NewTxInputDescMLP(blockLgrTxos.CoinbaseLgrTxos, 0, coinSpendSecretKey, coinSerialNumberSecretKey,
+		coinValuePublicKey, coinValueSecretKey, coinDetectorKey, 100)
+	trTx, err := pqringctxapi.TransferTxGen(pp, []*pqringctxapi.TxInputDescMLP{txInputDesc},
+		[]*pqringctxapi.TxOutputDescMLP{pqringctxapi.NewTxOutputDescMLP(coinAddress, coinValuePublicKey, 90)}, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ledger.ConnectBlock(&Block{TransferTxs: []*pqringctxapi.TransferTxMLP{trTx}})
+	if err == nil {
+		t.Fatalf("ConnectBlock succeeds while the Store fails")
+	}
+	if !strings.Contains(err.Error(), "PutSerialNumber fails") || !strings.Contains(err.Error(), "DeleteTxo fails") {
+		t.Fatalf("ConnectBlock error = %v, which does not contain both the apply and the rollback errors", err)
+	}
+
+	//	the undo record of the partially applied block is kept, so that it can be rolled back once the Store recovers
+	height, err := ledger.Height()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 2 {
+		t.Fatalf("the height (%d) is not 2", height)
+	}
+	if err = NewLedger(pp, store.MemStore).DisconnectBlock(); err != nil {
+		t.Fatal(err)
+	}
+	lgrTxo, err := ledger.GetLgrTxo(blockLgrTxos.CoinbaseLgrTxos[0].GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lgrTxo == nil {
+		t.Fatalf("the LgrTxo of the previous block is removed by the rollback")
+	}
+}
+
+func TestBlockUndo_Serialize(t *testing.T) {
+	undo := &blockUndo{
+		lgrTxoIds:     [][]byte{bytes.Repeat([]byte{1}, 64), bytes.Repeat([]byte{2}, 64)},
+		serialNumbers: [][]byte{bytes.Repeat([]byte{3}, 32)},
+	}
+	serializedUndo := serializeBlockUndo(undo)
+	deserializedUndo, err := deserializeBlockUndo(serializedUndo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(serializeBlockUndo(deserializedUndo), serializedUndo) {
+		t.Fatalf("the deserialized blockUndo is different from the original one")
+	}
+	if _, err = deserializeBlockUndo(serializedUndo[:len(serializedUndo)-1]); err == nil {
+		t.Fatalf("deserializeBlockUndo accepts a truncated input")
+	}
+	if _, err = deserializeBlockUndo(append(serializedUndo, 0)); err == nil {
+		t.Fatalf("deserializeBlockUndo accepts redundant bytes")
+	}
+}
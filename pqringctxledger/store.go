@@ -0,0 +1,140 @@
+package pqringctxledger
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Store is the storage of the ledger state, namely the LgrTxos (keyed by id) and the spent serial numbers.
+// A Store implementation can be backed by a database, and it must be safe for concurrent use.
+// Note that the Ledger validates a block before writing it to the Store,
+// so the Store only needs to persist what it is told.
+// DeleteTxo and DeleteSerialNumber must succeed for the ids and serial numbers that do not exist,
+// since the rollback of a partially applied block may remove the entries that were not written.
+type Store interface {
+	// GetTxo returns the serialized Txo of the LgrTxo with the input id, and whether it exists.
+	GetTxo(id []byte) (serializedTxo []byte, exists bool, err error)
+	// PutTxo stores the serialized Txo of the LgrTxo with the input id.
+	PutTxo(id []byte, serializedTxo []byte) error
+	// DeleteTxo removes the LgrTxo with the input id.
+	DeleteTxo(id []byte) error
+
+	// HasSerialNumber returns whether the input serial number has been spent.
+	HasSerialNumber(serialNumber []byte) (bool, error)
+	// PutSerialNumber marks the input serial number as spent.
+	PutSerialNumber(serialNumber []byte) error
+	// DeleteSerialNumber marks the input serial number as unspent.
+	DeleteSerialNumber(serialNumber []byte) error
+
+	// GetHeight returns the number of the stored undo records, i.e., the height of the latest connected block (0 for an empty ledger).
+	GetHeight() (height uint64, err error)
+	// GetUndo returns the serialized undo record of the block at the input height (starting from 1), and whether it exists.
+	GetUndo(height uint64) (serializedUndo []byte, exists bool, err error)
+	// PutUndo stores the serialized undo record of the block at the input height, which is always GetHeight() + 1.
+	PutUndo(height uint64, serializedUndo []byte) error
+	// DeleteUndo removes the undo record of the block at the input height, which is always GetHeight().
+	DeleteUndo(height uint64) error
+}
+
+// MemStore is an in-memory Store.
+type MemStore struct {
+	mu            sync.RWMutex
+	txos          map[string][]byte
+	serialNumbers map[string]struct{}
+	undos         [][]byte // undos[i] is the undo record of the block at height i+1
+}
+
+// NewMemStore creates an empty MemStore.
+func NewMemStore() *MemStore {
+	return &MemStore{
+		txos:          make(map[string][]byte),
+		serialNumbers: make(map[string]struct{}),
+	}
+}
+
+// GetTxo implements Store.
+func (s *MemStore) GetTxo(id []byte) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	serializedTxo, exists := s.txos[string(id)]
+	return serializedTxo, exists, nil
+}
+
+// PutTxo implements Store.
+func (s *MemStore) PutTxo(id []byte, serializedTxo []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txos[string(id)] = serializedTxo
+	return nil
+}
+
+// DeleteTxo implements Store.
+func (s *MemStore) DeleteTxo(id []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.txos, string(id))
+	return nil
+}
+
+// HasSerialNumber implements Store.
+func (s *MemStore) HasSerialNumber(serialNumber []byte) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, exists := s.serialNumbers[string(serialNumber)]
+	return exists, nil
+}
+
+// PutSerialNumber implements Store.
+func (s *MemStore) PutSerialNumber(serialNumber []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serialNumbers[string(serialNumber)] = struct{}{}
+	return nil
+}
+
+// DeleteSerialNumber implements Store.
+func (s *MemStore) DeleteSerialNumber(serialNumber []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.serialNumbers, string(serialNumber))
+	return nil
+}
+
+// GetHeight implements Store.
+func (s *MemStore) GetHeight() (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return uint64(len(s.undos)), nil
+}
+
+// GetUndo implements Store.
+func (s *MemStore) GetUndo(height uint64) ([]byte, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if height == 0 || height > uint64(len(s.undos)) {
+		return nil, false, nil
+	}
+	return s.undos[height-1], true, nil
+}
+
+// PutUndo implements Store.
+func (s *MemStore) PutUndo(height uint64, serializedUndo []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if height != uint64(len(s.undos))+1 {
+		return fmt.Errorf("MemStore.PutUndo: the height (%d) is not the next height (%d)", height, len(s.undos)+1)
+	}
+	s.undos = append(s.undos, serializedUndo)
+	return nil
+}
+
+// DeleteUndo implements Store.
+func (s *MemStore) DeleteUndo(height uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if height == 0 || height != uint64(len(s.undos)) {
+		return fmt.Errorf("MemStore.DeleteUndo: the height (%d) is not the latest height (%d)", height, len(s.undos))
+	}
+	s.undos = s.undos[:len(s.undos)-1]
+	return nil
+}
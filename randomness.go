@@ -3,7 +3,9 @@ package pqringctx
 import (
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"golang.org/x/crypto/sha3"
+	"io"
 )
 
 var ErrLength = errors.New("invalid length")
@@ -93,16 +95,36 @@ func RandomBytes(length int) []byte {
 	return res
 }
 
+// randomBytes returns a byte array with given length from the randomness source of pp.
+// If pp does not have a randomness source, i.e., by default, crypto/rand.Reader is used via RandomBytes.
+// All the samplers that need fresh randomness should use randomBytes, rather than RandomBytes,
+// so that the generation algorithms can be made deterministic by WithRandReader.
+func (pp *PublicParameter) randomBytes(length int) ([]byte, error) {
+	if pp.randReader == nil {
+		return RandomBytes(length), nil
+	}
+
+	res := make([]byte, length)
+	_, err := io.ReadFull(pp.randReader, res)
+	if err != nil {
+		return nil, fmt.Errorf("randomBytes: failed to read %d bytes from the randomness source: %v", length, err)
+	}
+	return res, nil
+}
+
 // 523987 = 0111_1111_1110_1101_0011
 // randomPolyAForResponseA() returns a PolyA, where each coefficient lies in [-(eta_a - beta_a), (eta_a - beta_a)],
 // where eta_a = 2^{19}-1 and beta=120
 // reviewed by Alice, 2024.06.20
 func (pp *PublicParameter) randomPolyAForResponseA() (*PolyA, error) {
-	seed := RandomBytes(RandSeedBytesLen)
+	seed, err := pp.randomBytes(RandSeedBytesLen)
+	if err != nil {
+		return nil, err
+	}
 
 	xof := sha3.NewShake128()
 	xof.Reset()
-	_, err := xof.Write(seed)
+	_, err = xof.Write(seed)
 	if err != nil {
 		return nil, err
 	}
@@ -180,11 +202,14 @@ func (pp *PublicParameter) randomPolyAForResponseA() (*PolyA, error) {
 // reviewed by Alice, 2024.06.20
 func (pp *PublicParameter) randomPolyAinEtaA() (*PolyA, error) {
 
-	seed := RandomBytes(RandSeedBytesLen)
+	seed, err := pp.randomBytes(RandSeedBytesLen)
+	if err != nil {
+		return nil, err
+	}
 
 	xof := sha3.NewShake128()
 	xof.Reset()
-	_, err := xof.Write(seed)
+	_, err = xof.Write(seed)
 	if err != nil {
 		return nil, err
 	}
@@ -264,11 +289,14 @@ func (pp *PublicParameter) randomPolyAinEtaA() (*PolyA, error) {
 // where eta_c = 2^{24}-1 and beta_c=128
 // reviewed by Alice, 2024.06.20
 func (pp *PublicParameter) randomPolyCForResponseC() (*PolyC, error) {
-	seed := RandomBytes(RandSeedBytesLen)
+	seed, err := pp.randomBytes(RandSeedBytesLen)
+	if err != nil {
+		return nil, err
+	}
 
 	xof := sha3.NewShake128()
 	xof.Reset()
-	_, err := xof.Write(seed)
+	_, err = xof.Write(seed)
 	if err != nil {
 		return nil, err
 	}
@@ -349,11 +377,14 @@ func (pp *PublicParameter) randomPolyCForResponseC() (*PolyC, error) {
 // reviewed by Alice, 2024.06.20
 func (pp *PublicParameter) randomPolyCinEtaC() (*PolyC, error) {
 
-	seed := RandomBytes(RandSeedBytesLen)
+	seed, err := pp.randomBytes(RandSeedBytesLen)
+	if err != nil {
+		return nil, err
+	}
 
 	xof := sha3.NewShake128()
 	xof.Reset()
-	_, err := xof.Write(seed)
+	_, err = xof.Write(seed)
 	if err != nil {
 		return nil, err
 	}
@@ -436,7 +467,11 @@ func (pp *PublicParameter) randomPolyAinGammaA2(seed []byte) (*PolyA, error) {
 
 	var seedUsed []byte
 	if len(seed) == 0 {
-		seedUsed = RandomBytes(RandSeedBytesLen)
+		randSeed, err := pp.randomBytes(RandSeedBytesLen)
+		if err != nil {
+			return nil, err
+		}
+		seedUsed = randSeed
 	} else {
 		seedUsed = make([]byte, len(seed))
 		copy(seedUsed, seed)
@@ -507,6 +542,33 @@ func (pp *PublicParameter) expandValuePadRandomness(seed []byte) ([]byte, error)
 	return buf, nil
 }
 
+// expandProposalOpeningsPad() returns length bytes,
+// which will be used to encrypt the value openings in a TransferTxMLPProposal.
+// To be self-completed, this function append 'PO' before seed to form the real used seed.
+// As expandValuePadRandomness, the seed (KEM-generated key) is used only once.
+func (pp *PublicParameter) expandProposalOpeningsPad(seed []byte, length int) ([]byte, error) {
+	if len(seed) == 0 {
+		//	for such an expand function, the seed should not be empty.
+		return nil, errors.New("expandProposalOpeningsPad: the seed is empty")
+	}
+
+	buf := make([]byte, length)
+	realSeed := append([]byte{'P', 'O'}, seed...)
+	defer wipeBytes(realSeed)
+
+	XOF := sha3.NewShake256()
+	XOF.Reset()
+	_, err := XOF.Write(realSeed)
+	if err != nil {
+		return nil, err
+	}
+	_, err = XOF.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
 // expandAddressSKsp() expand s \in (S_{\gamma_a})^{L_a} from input seed.
 // To be self-completed, this function append 'ASKSP' before seed to form the real used seed.
 // vector length PublicParameter.paramLA
@@ -518,9 +580,11 @@ func (pp *PublicParameter) expandAddressSKsp(seed []byte) (*PolyAVec, error) {
 	}
 
 	realSeed := append([]byte{'A', 'S', 'K', 'S', 'P'}, seed...) // AskSp
+	defer wipeBytes(realSeed)
 
 	tmpSeedLen := len(realSeed) + 1
 	tmpSeed := make([]byte, tmpSeedLen) // 1 byte for index i \in [0, paramLA -1], where paramLA is assumed to be smaller than 127
+	defer wipeBytes(tmpSeed)
 
 	var err error
 	polyAs := make([]*PolyA, pp.paramLA)
@@ -562,6 +626,7 @@ func (pp *PublicParameter) expandAddressSKsn(seed []byte) (*PolyANTT, error) {
 	}
 
 	realSeed := append([]byte{'A', 'S', 'K', 'S', 'N'}, seed...) // AskSn
+	defer wipeBytes(realSeed)
 
 	coeffs, err := pp.randomDaIntegersInQa(realSeed)
 	if err != nil {
@@ -623,7 +688,11 @@ func (pp *PublicParameter) randomPolyCinDistributionChi(seed []byte) (*PolyC, er
 
 	var seedUsed []byte
 	if len(seed) == 0 {
-		seedUsed = RandomBytes(RandSeedBytesLen)
+		randSeed, err := pp.randomBytes(RandSeedBytesLen)
+		if err != nil {
+			return nil, err
+		}
+		seedUsed = randSeed
 	} else {
 		seedUsed = make([]byte, len(seed))
 		copy(seedUsed, seed)
@@ -766,7 +835,11 @@ func (pp *PublicParameter) sampleResponseC() (*PolyCVec, error) {
 func (pp *PublicParameter) randomDcIntegersInQc(seed []byte) ([]int64, error) {
 	var tmpSeed []byte
 	if len(seed) == 0 {
-		tmpSeed = RandomBytes(RandSeedBytesLen)
+		randSeed, err := pp.randomBytes(RandSeedBytesLen)
+		if err != nil {
+			return nil, err
+		}
+		tmpSeed = randSeed
 	} else {
 		tmpSeed = make([]byte, len(seed))
 		copy(tmpSeed, seed)
@@ -847,11 +920,14 @@ func (pp *PublicParameter) randomDcIntegersInQc(seed []byte) ([]int64, error) {
 // reviewed by Alice, 2024.06.20
 func (pp *PublicParameter) randomDcIntegersInQcEtaF() ([]int64, error) {
 
-	seed := RandomBytes(RandSeedBytesLen)
+	seed, err := pp.randomBytes(RandSeedBytesLen)
+	if err != nil {
+		return nil, err
+	}
 
 	xof := sha3.NewShake128()
 	xof.Reset()
-	_, err := xof.Write(seed)
+	_, err = xof.Write(seed)
 	if err != nil {
 		return nil, err
 	}
@@ -923,7 +999,11 @@ func (pp *PublicParameter) randomDcIntegersInQcEtaF() ([]int64, error) {
 func (pp *PublicParameter) randomDaIntegersInQa(seed []byte) ([]int64, error) {
 	var tmpSeed []byte
 	if len(seed) == 0 {
-		tmpSeed = RandomBytes(RandSeedBytesLen)
+		randSeed, err := pp.randomBytes(RandSeedBytesLen)
+		if err != nil {
+			return nil, err
+		}
+		tmpSeed = randSeed
 	} else {
 		tmpSeed = make([]byte, len(seed))
 		copy(tmpSeed, seed)
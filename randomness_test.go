@@ -74,7 +74,7 @@ func TestNaive_randomBytes(t *testing.T) {
 }
 
 func TestNaive_randomnessPolyAForResponseA(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -155,7 +155,7 @@ func TestNaive_randomnessPolyAForResponseA(t *testing.T) {
 }
 
 func TestNaive_randomnessPolyCForResponseC(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -237,7 +237,7 @@ func TestNaive_randomnessPolyCForResponseC(t *testing.T) {
 }
 
 func TestNaive_randomPolyCinEtaC(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -320,7 +320,7 @@ func TestNaive_randomPolyCinEtaC(t *testing.T) {
 }
 
 func TestNaive_randomPolyAinEtaA(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -402,7 +402,7 @@ func TestNaive_randomPolyAinEtaA(t *testing.T) {
 
 // retest done 0413
 func TestNaive_randomPolyAinGammaA2(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	count := make([]int, 5)
 	for i := 0; i < 5; i++ {
 		count[i] = 0
@@ -434,7 +434,7 @@ func TestNaive_randomPolyAinGammaA2(t *testing.T) {
 }
 
 func TestPublicParameter_randomPolyCinDistributionChi(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -513,7 +513,7 @@ func TestPublicParameter_randomPolyCinDistributionChi(t *testing.T) {
 }
 
 func TestNaive_randomDcIntegersInQc(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -594,7 +594,7 @@ func TestNaive_randomDcIntegersInQc(t *testing.T) {
 }
 
 func TestNaive_randomDcIntegersInQcEtaF(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -675,7 +675,7 @@ func TestNaive_randomDcIntegersInQcEtaF(t *testing.T) {
 }
 
 func TestNaive_expandChallengeA(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -762,7 +762,7 @@ func TestNaive_expandChallengeA(t *testing.T) {
 }
 
 func TestNaive_expandChallengeC(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -850,7 +850,7 @@ func TestNaive_expandChallengeC(t *testing.T) {
 }
 
 func TestNaive_samplePloyCWithLowZeros(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
@@ -937,7 +937,7 @@ func TestNaive_samplePloyCWithLowZeros(t *testing.T) {
 }
 
 func TestExpandBinaryMatrix(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	for i := 0; i < 10; i++ {
 		seed := RandomBytes(RandSeedBytesLen)
@@ -968,7 +968,7 @@ func TestExpandBinaryMatrix(t *testing.T) {
 }
 
 func TestExpandAddressSKsp(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	count := make([]int, 5)
 	for time := 0; time < 100; time++ {
 		polyAVec, err := pp.expandAddressSKsp(RandomBytes(RandSeedBytesLen))
@@ -999,7 +999,7 @@ func TestExpandAddressSKsp(t *testing.T) {
 }
 
 func TestNaive_randomDaIntegersInQa(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	tests := []struct {
 		name      string
 		times     int
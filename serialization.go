@@ -74,7 +74,7 @@ func (pp *PublicParameter) readPolyANTT(r io.Reader) (*PolyANTT, error) {
 
 	retPolyANTT := pp.NewPolyANTT()
 	for i := 0; i < pp.paramDA; i++ {
-		_, err := r.Read(tmp)
+		_, err := io.ReadFull(r, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -87,7 +87,7 @@ func (pp *PublicParameter) readPolyANTT(r io.Reader) (*PolyANTT, error) {
 	}
 
 	signalBytes := make([]byte, pp.paramDA/8)
-	_, err := r.Read(signalBytes)
+	_, err := io.ReadFull(r, signalBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -222,7 +222,7 @@ func (pp *PublicParameter) readPolyAEta(r io.Reader) (*PolyA, error) {
 	var tmpLow, tmpHigh byte
 
 	for i := 0; i < pp.paramDA; i = i + 2 {
-		_, err = r.Read(tmp)
+		_, err = io.ReadFull(r, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -384,7 +384,7 @@ func (pp *PublicParameter) readPolyAGamma(r io.Reader) (*PolyA, error) {
 	polyA := pp.NewPolyA()
 
 	serialized := make([]byte, pp.paramDA/4)
-	_, err := r.Read(serialized)
+	_, err := io.ReadFull(r, serialized)
 	if err != nil {
 		return nil, err
 	}
@@ -403,7 +403,7 @@ func (pp *PublicParameter) readPolyAGamma(r io.Reader) (*PolyA, error) {
 	}
 
 	signalBytes := make([]byte, pp.paramDA/8)
-	_, err = r.Read(signalBytes)
+	_, err = io.ReadFull(r, signalBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -507,7 +507,7 @@ func (pp *PublicParameter) readPolyCNTT(r io.Reader) (*PolyCNTT, error) {
 	tmp := make([]byte, 7)
 
 	for i := 0; i < pp.paramDC; i++ {
-		_, err := r.Read(tmp)
+		_, err := io.ReadFull(r, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -678,7 +678,7 @@ func (pp *PublicParameter) readPolyCEta(r io.Reader) (*PolyC, error) {
 	var coeff int64
 
 	for i := 0; i < pp.paramDC; i++ {
-		_, err = r.Read(tmp)
+		_, err = io.ReadFull(r, tmp)
 		if err != nil {
 			return nil, err
 		}
@@ -691,7 +691,7 @@ func (pp *PublicParameter) readPolyCEta(r io.Reader) (*PolyC, error) {
 	}
 
 	signalBytes := make([]byte, pp.paramDC/8)
-	_, err = r.Read(signalBytes)
+	_, err = io.ReadFull(r, signalBytes)
 	if err != nil {
 		return nil, err
 	}
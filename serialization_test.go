@@ -17,7 +17,7 @@ func Test_writePolyANTT_readPolyANTT(t *testing.T) {
 	var polyANTT *PolyANTT
 	manualCheck := true
 
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	for t := 0; t < 1000; t++ {
 		coeffs, err := pp.randomDaIntegersInQa(nil)
@@ -72,7 +72,7 @@ func Test_writePolyANTT_readPolyANTT(t *testing.T) {
 }
 
 func Test_writePolyANTTVec_readPolyANTTVec(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	polyANTTs := make([]*PolyANTT, pp.paramKA)
 	for i := 0; i < pp.paramKA; i++ {
@@ -135,7 +135,7 @@ func Test_writePolyANTTVec_readPolyANTTVec(t *testing.T) {
 }
 
 func Test_writePolyAEta_readPolyAEta(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	testBound := true
 	//testBound := true
@@ -194,7 +194,7 @@ func Test_writePolyAEta_readPolyAEta(t *testing.T) {
 }
 
 func Test_writePolyANTTVecEta_readPolyANTTVecEta(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	var err error
 
@@ -255,7 +255,7 @@ func Test_writePolyANTTVecEta_readPolyANTTVecEta(t *testing.T) {
 }
 
 func Test_writePolyAGamma_readPolyAGamma(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	testBound := true
 	//testBound := true
@@ -320,7 +320,7 @@ func Test_writePolyCNTT_readPolyCNTT(t *testing.T) {
 	var polyCNTT *PolyCNTT
 	manualCheck := true
 
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	for t := 0; t < 1000; t++ {
 		coeffs, err := pp.randomDcIntegersInQc(nil)
@@ -376,7 +376,7 @@ func Test_writePolyCNTT_readPolyCNTT(t *testing.T) {
 }
 
 func Test_writePolyCNTTVec_readPolyCNTTVec(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	polyCNTTs := make([]*PolyCNTT, pp.paramKC)
 	for i := 0; i < pp.paramKC; i++ {
@@ -446,7 +446,7 @@ func Test_writePolyCEta_readPolyCEta(t *testing.T) {
 	var polyCEta *PolyC
 	manualCheck := true
 
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	var err error
 	for t := 0; t < 1000; t++ {
@@ -502,7 +502,7 @@ func Test_writePolyCEta_readPolyCEta(t *testing.T) {
 }
 
 func Test_writePolyCVecEta_readPolyCVecEta(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	var err error
 	polyCs := make([]*PolyC, pp.paramKC)
@@ -565,7 +565,7 @@ func Test_writePolyCVecEta_readPolyCVecEta(t *testing.T) {
 }
 
 func TestAddressPubicKeySerialize(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	testAbnormal := false
 
@@ -641,7 +641,7 @@ func TestAddressPubicKeySerialize(t *testing.T) {
 }
 
 func TestSerializeAddressSecretKeySp(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	testAbnormal := false
 
@@ -695,7 +695,7 @@ func TestSerializeAddressSecretKeySp(t *testing.T) {
 }
 
 func TestSerializeAddressSecretKeySn(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	coeffs, err := pp.randomDaIntegersInQa(nil)
 	if err != nil {
 		log.Fatal(err)
@@ -731,7 +731,7 @@ func TestSerializeAddressSecretKeySn(t *testing.T) {
 }
 
 func TestSerializeValueCommitment(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	b := pp.NewPolyCNTTVec(pp.paramKC)
 	for i := 0; i < len(b.polyCNTTs); i++ {
@@ -776,7 +776,7 @@ func TestSerializeValueCommitment(t *testing.T) {
 }
 
 func TestEncodeTxoValueToBytes(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	testAbnormal := false
 
@@ -885,7 +885,7 @@ func TestEncodeTxoValueToBytes(t *testing.T) {
 
 func TestSerializeTxo(t *testing.T) {
 
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	apk, _, err := pp.addressKeyGen(nil)
 	if err != nil {
@@ -963,7 +963,7 @@ func TestSerializeTxo(t *testing.T) {
 }
 
 func TestSerializeLgrTxo(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	apk, _, err := pp.addressKeyGen(nil)
 	if err != nil {
@@ -1025,7 +1025,7 @@ func TestSerializeLgrTxo(t *testing.T) {
 }
 
 func TestSerializeRpulpProof(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	n := 10
 	var seed []byte
 	// c_waves []*PolyCNTT
@@ -1132,7 +1132,7 @@ func TestSerializeRpulpProof(t *testing.T) {
 }
 
 func TestSerializeCbTxWitnessJ1(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	var cbTxJ1 = &CbTxWitnessJ1{}
 	cbTxJ1.chseed = make([]byte, HashOutputBytesLen)
@@ -1173,7 +1173,7 @@ func TestSerializeCbTxWitnessJ1(t *testing.T) {
 }
 
 func TestWriteCarryVectorRProof(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	u_p, err := pp.randomDcIntegersInQcEtaF()
 	if err != nil {
@@ -1208,7 +1208,7 @@ func TestWriteCarryVectorRProof(t *testing.T) {
 }
 
 func TestSerializeCoinbaseTx(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	seed1 := RandomBytes(pp.paramKeyGenSeedBytesLen)
 	apk1, _, _ := pp.addressKeyGen(seed1)
 	serializedVPk1, _, _ := pp.valueKeyGen(seed1)
@@ -1390,7 +1390,7 @@ func TestSerializeCoinbaseTx(t *testing.T) {
 }
 
 func TestSerializeTransferTx(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 
 	seed1 := RandomBytes(pp.paramKeyGenSeedBytesLen)
 	apk1, ask1, _ := pp.addressKeyGen(seed1)
@@ -2290,7 +2290,7 @@ func TestSerializeTransferTx(t *testing.T) {
 //}
 
 //func TestPublicParameter_writePolyAVecGamma_readPolyAVecGamma(t *testing.T) {
-//	pp :=  Initialize(nil)
+//	pp :=  initializeForTest()
 //	as := pp.NewPolyAVec(pp.paramLA)
 //	for i := 0; i < pp.paramLA; i++ {
 //		seed := RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -2323,7 +2323,7 @@ func TestSerializeTransferTx(t *testing.T) {
 //}
 
 func TestPublicParameter_writePolyCVecEta_readPolyCVecEta(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	var err error
 	as := pp.NewPolyCVec(pp.paramLC)
 	for i := 0; i < pp.paramLC; i++ {
@@ -2356,7 +2356,7 @@ func TestPublicParameter_writePolyCVecEta_readPolyCVecEta(t *testing.T) {
 }
 
 func TestPublicParameter_SerializeAddressSecretSpAndSnKey_DeserializeAddressSecretSpAndSnKey(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	ts := pp.NewPolyAVec(pp.paramLA)
 	for i := 0; i < pp.paramLA; i++ {
 		seed := RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -2409,7 +2409,7 @@ func TestPublicParameter_SerializeAddressSecretSpAndSnKey_DeserializeAddressSecr
 }
 
 func TestPublicParameter_SerializeValueCommitment_DeserializeValueCommitment(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	b := pp.NewPolyCNTTVec(pp.paramKC)
 	for i := 0; i < pp.paramKC; i++ {
 		seed := RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -2456,7 +2456,7 @@ func TestPublicParameter_SerializeValueCommitment_DeserializeValueCommitment(t *
 }
 
 func TestPublicParameter_SerializeAddressPublicKey(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	ts := pp.NewPolyANTTVec(pp.paramKA)
 	for i := 0; i < pp.paramKA; i++ {
 		seed := RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -2503,7 +2503,7 @@ func TestPublicParameter_SerializeAddressPublicKey(t *testing.T) {
 
 func TestPublicParameter_SerializeTxo_DeserializeTxo(t *testing.T) {
 	var seed []byte
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	ts := pp.NewPolyANTTVec(pp.paramKA)
 	for i := 0; i < pp.paramKA; i++ {
 		seed = RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -2580,7 +2580,7 @@ func TestPublicParameter_SerializeTxo_DeserializeTxo(t *testing.T) {
 
 func TestPublicParameter_SerializeLgrTxo_DeserializeLgrTxo(t *testing.T) {
 	var seed []byte
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	ts := pp.NewPolyANTTVec(pp.paramKA)
 	for i := 0; i < pp.paramKA; i++ {
 		seed = RandomBytes(pp.paramKeyGenSeedBytesLen)
@@ -2657,7 +2657,7 @@ func TestPublicParameter_SerializeLgrTxo_DeserializeLgrTxo(t *testing.T) {
 }
 
 func TestPublicParameter_SerializeRpulpProof_DeserializeRpulpProof(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	J := 2
 	var seed []byte
 	// c_waves []*PolyCNTT
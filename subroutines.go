@@ -2,7 +2,6 @@ package pqringctx
 
 import (
 	"errors"
-	"math/big"
 )
 
 // RpUlpType is the type for difference transaction
@@ -591,64 +590,48 @@ func (pp *PublicParameter) genUlpPolyCNTTs(rpulpType RpUlpType, binMatrixB [][]b
 
 // intVecInnerProductWithReductionQc
 // reviewed by Alice, 2024.06.20
+// The arithmetic is in constant time, since the inputs may be derived from secrets, e.g., the values in the balance proofs.
 func (pp *PublicParameter) intVecInnerProductWithReductionQc(a []int64, b []int64, vecLen int) (r int64) {
+	modQC := pp.paramQCModulus
 
-	tmp1 := big.NewInt(1)
-	tmp2 := big.NewInt(1)
-	bigQc := big.NewInt(pp.paramQC)
-
-	rst := big.NewInt(0)
+	rst := int64(0)
 	for i := 0; i < vecLen; i++ {
-		tmp1.SetInt64(a[i])
-		tmp2.SetInt64(b[i])
-		tmp1.Mul(tmp1, tmp2)
-		tmp1.Mod(tmp1, bigQc)
-
-		rst.Add(rst, tmp1)
-		rst.Mod(rst, bigQc)
+		rst = modQC.add(rst, modQC.mul(modQC.reduce(a[i]), modQC.reduce(b[i])))
 	}
 
-	return reduceInt64(rst.Int64(), pp.paramQC)
+	return rst
 }
 
 // intMatrixInnerProductWithReductionQc
 // reviewed by Alice, 2024.06.20
 func (pp *PublicParameter) intMatrixInnerProductWithReductionQc(a [][]int64, b [][]int64, rowNum int, colNum int) (r int64) {
-	tmp1 := big.NewInt(1)
-	tmp2 := big.NewInt(1)
-	bigQc := big.NewInt(pp.paramQC)
+	modQC := pp.paramQCModulus
 
-	rst := big.NewInt(0)
+	rst := int64(0)
 	for i := 0; i < rowNum; i++ {
 		for j := 0; j < colNum; j++ {
-			tmp1.SetInt64(a[i][j])
-			tmp2.SetInt64(b[i][j])
-			tmp1.Mul(tmp1, tmp2)
-			tmp1.Mod(tmp1, bigQc)
-
-			rst.Add(rst, tmp1)
-			rst.Mod(rst, bigQc)
+			rst = modQC.add(rst, modQC.mul(modQC.reduce(a[i][j]), modQC.reduce(b[i][j])))
 		}
 	}
 
-	return reduceInt64(rst.Int64(), pp.paramQC)
+	return rst
 }
 
 // reduceInt64
 // q is assumed to be an odd number
 // applied to q_a and q_c
 // reviewed by Alice, 2024.06.20
+// The adjustment to the centered scope is branch-free, but the operator % is not constant-time on all platforms,
+// so that the code paths on secrets use the constant-time ctModulus (pp.paramQAModulus and pp.paramQCModulus) instead.
 func reduceInt64(a int64, q int64) int64 {
 	r := a % q
 
 	m := (q - 1) >> 1
 
 	//	make sure the result in the scope [-(q-1)/2, (q-1)/2]
-	if r < (-m) {
-		r = r + q
-	} else if r > m {
-		r = r - q
-	}
+	//	r + m < 0 iff r < -m, and m - r < 0 iff r > m, where |r| < q < 2^62.
+	r += q & ((r + m) >> 63)
+	r -= q & ((m - r) >> 63)
 
 	return r
 }
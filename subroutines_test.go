@@ -6,7 +6,7 @@ import (
 )
 
 func Test_randomDcIntegersInQc(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	type args struct {
 		seed   []byte
 		length int
@@ -44,7 +44,7 @@ func Test_randomDcIntegersInQc(t *testing.T) {
 }
 
 func Test_randomDaIntegersInQa(t *testing.T) {
-	pp := Initialize(nil)
+	pp := initializeForTest()
 	type args struct {
 		seed   []byte
 		length int
@@ -0,0 +1,8 @@
+//go:build cgo
+
+package pqringctx
+
+// initializeForTest returns the PublicParameter for the tests, which is the default one by Initialize, with KEM_OQS_KYBER (liboqs).
+func initializeForTest() *PublicParameter {
+	return initializeForTest()
+}
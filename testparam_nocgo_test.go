@@ -0,0 +1,11 @@
+//go:build !cgo
+
+package pqringctx
+
+import "github.com/pqabelian/pqringctx/pqringctxkem"
+
+// initializeForTest returns the PublicParameter for the tests.
+// Without cgo, KEM_OQS_KYBER (liboqs) of Initialize is not available, so that KEM_MLKEM768 is used instead.
+func initializeForTest() *PublicParameter {
+	return InitializeWithParamKem(nil, pqringctxkem.NewParamKem(pqringctxkem.KEM_MLKEM768, nil, ""))
+}